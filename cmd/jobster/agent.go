@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/plugins"
+	"github.com/spf13/cobra"
+)
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Work with hook agents",
+	Long: `Work with the agents jobs run as hooks.
+
+Subcommands:
+  list - Show the agents discovery finds and where
+  test - Run one agent once with synthetic hook parameters
+
+Examples:
+  jobster agent list --config jobster.yaml
+  jobster agent test notify --with channel=#ops --config jobster.yaml`,
+}
+
+var agentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the agents discovery finds and where",
+	Long: `List every agent found in the agent search paths with the file it
+resolves to, followed by the built-in agents.
+
+Paths are searched in order and the first agent of a name wins; same-named
+agents in later paths are listed as shadowed, along with the file that runs
+instead. The paths are --paths when given, else the config's agents_paths
+when --config is given, else the default search paths.
+
+Examples:
+  jobster agent list
+  jobster agent list --config jobster.yaml
+  jobster agent list --paths ./agents,/usr/local/lib/jobster/agents --json`,
+	Args: cobra.NoArgs,
+	RunE: runAgentList,
+}
+
+var agentTestCmd = &cobra.Command{
+	Use:   "test <agent-name>",
+	Short: "Run one agent once with synthetic hook parameters",
+	Long: `Run a single agent once, outside of any job, and print what it did:
+its stdout, stderr, exit code, parsed JSON output, and duration.
+
+Agents are discovered in the config's agents_paths when --config is given,
+and in the default search paths otherwise; the config's agent_checksums and
+agent_timeout_sec also apply. Each --with key=value pair becomes a field of
+the agent's CONFIG_JSON, as if set under with: in a hook. Values are read as
+JSON when they parse (numbers, true/false, quoted strings, objects) and as
+plain strings otherwise.
+
+The command fails if the agent cannot run or exits non-zero.
+
+Examples:
+  jobster agent test notify
+  jobster agent test notify --hook on_error --job-id backup
+  jobster agent test notify --with channel=#ops --with retries=3 --config jobster.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAgentTest,
+}
+
+func init() {
+	agentTestCmd.Flags().StringP("config", "c", "", "Path to configuration file (default: use the default agent search paths)")
+	agentTestCmd.Flags().String("hook", string(plugins.PostRun), "Hook to run the agent as (pre_run, post_run, on_success, on_error, on_circuit_open)")
+	agentTestCmd.Flags().String("job-id", "agent-test", "Job ID passed to the agent")
+	agentTestCmd.Flags().StringArray("with", nil, "Agent option as key=value (repeatable)")
+
+	agentListCmd.Flags().StringP("config", "c", "", "Path to configuration file (default: use the default agent search paths)")
+	agentListCmd.Flags().StringSlice("paths", nil, "Agent search paths, in priority order (overrides the config)")
+	agentListCmd.Flags().Bool("json", false, "Print agents as JSON")
+
+	agentCmd.AddCommand(agentListCmd)
+	agentCmd.AddCommand(agentTestCmd)
+}
+
+// listedAgent is one row of "jobster agent list".
+type listedAgent struct {
+	plugins.AgentFile
+	Builtin bool `json:"builtin,omitempty"`
+}
+
+func runAgentList(cmd *cobra.Command, _ []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	paths, _ := cmd.Flags().GetStringSlice("paths")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	if len(paths) == 0 && configPath != "" {
+		cfg, err := config.LoadConfig(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		paths = cfg.AgentsPaths
+	}
+
+	var agents []listedAgent
+	for _, file := range plugins.ScanAgents(paths) {
+		agents = append(agents, listedAgent{AgentFile: file})
+	}
+	// By name; same-named agents stay in search order, winner first
+	sort.SliceStable(agents, func(i, j int) bool { return agents[i].Name < agents[j].Name })
+	for _, name := range plugins.BuiltinAgentNames() {
+		agents = append(agents, listedAgent{AgentFile: plugins.AgentFile{Name: name}, Builtin: true})
+	}
+
+	out := cmd.OutOrStdout()
+	if asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(agents)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPATH\tNOTE")
+	fmt.Fprintln(w, "────\t────\t────")
+	for _, agent := range agents {
+		path, note := agent.Path, ""
+		switch {
+		case agent.Builtin:
+			path = "(built-in)"
+		case agent.ShadowedBy != "":
+			note = "shadowed by " + agent.ShadowedBy
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", agent.Name, path, note)
+	}
+	return w.Flush()
+}
+
+func runAgentTest(cmd *cobra.Command, args []string) error {
+	agentName := args[0]
+	configPath, _ := cmd.Flags().GetString("config")
+	hook, _ := cmd.Flags().GetString("hook")
+	jobID, _ := cmd.Flags().GetString("job-id")
+	withPairs, _ := cmd.Flags().GetStringArray("with")
+
+	switch plugins.HookType(hook) {
+	case plugins.PreRun, plugins.PostRun, plugins.OnSuccess, plugins.OnError, plugins.OnCircuitOpen:
+	default:
+		return fmt.Errorf("invalid --hook %q (must be pre_run, post_run, on_success, on_error, or on_circuit_open)", hook)
+	}
+
+	with, err := parseAgentOptions(withPairs)
+	if err != nil {
+		return err
+	}
+	configJSON, err := json.Marshal(with)
+	if err != nil {
+		return fmt.Errorf("failed to encode --with options: %w", err)
+	}
+
+	cfg := &config.Config{Defaults: config.Defaults{AgentTimeoutSec: 10}}
+	if configPath != "" {
+		if cfg, err = config.LoadConfig(configPath); err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+	}
+
+	pluginMgr, err := newPluginManager(cfg, logger)
+	if err != nil {
+		return err
+	}
+	if _, ok := pluginMgr.GetAgents()[agentName]; !ok && !plugins.IsBuiltinAgent(agentName) {
+		return fmt.Errorf("agent not found: %s", agentName)
+	}
+
+	stateDir, err := os.MkdirTemp("", "jobster-agent-test-")
+	if err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	defer os.RemoveAll(stateDir)
+
+	now := time.Now()
+	result, err := pluginMgr.Execute(context.Background(), agentName, plugins.AgentParams{
+		JobID:      jobID,
+		Hook:       hook,
+		RunID:      "agent-test",
+		Attempt:    1,
+		StartTS:    now,
+		EndTS:      now,
+		ConfigJSON: string(configJSON),
+		StateDir:   stateDir,
+		TimeoutSec: cfg.Defaults.AgentTimeoutSec,
+	})
+	if err != nil {
+		return fmt.Errorf("agent %s failed: %w", agentName, err)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Agent:      %s\n", agentName)
+	fmt.Fprintf(out, "Hook:       %s\n", hook)
+	fmt.Fprintf(out, "Exit code:  %d\n", result.ExitCode)
+	fmt.Fprintf(out, "Duration:   %s\n", result.Duration.Round(time.Millisecond))
+	printAgentStream(out, "Stdout", result.Stdout)
+	printAgentStream(out, "Stderr", result.Stderr)
+	if result.JSONOutput != nil {
+		data, err := json.MarshalIndent(result.JSONOutput, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		fmt.Fprintf(out, "\nJSON output:\n%s\n", data)
+	}
+
+	if result.ExitCode != 0 {
+		return fmt.Errorf("agent %s exited with code %d", agentName, result.ExitCode)
+	}
+	return nil
+}
+
+// parseAgentOptions turns --with key=value pairs into an agent's with:
+// options. A value that parses as JSON keeps its JSON type; anything else is
+// taken as a string.
+func parseAgentOptions(pairs []string) (map[string]interface{}, error) {
+	with := make(map[string]interface{}, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --with %q (must be key=value)", pair)
+		}
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value), &parsed); err == nil {
+			with[key] = parsed
+		} else {
+			with[key] = value
+		}
+	}
+	return with, nil
+}
+
+// printAgentStream prints one of the agent's output streams under a heading.
+func printAgentStream(out io.Writer, name, data string) {
+	if data == "" {
+		fmt.Fprintf(out, "\n%s: (empty)\n", name)
+		return
+	}
+	fmt.Fprintf(out, "\n%s:\n%s", name, data)
+	if !strings.HasSuffix(data, "\n") {
+		fmt.Fprintln(out)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runAgentCmd executes "jobster agent" with args and returns its output.
+func runAgentCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return executeRootCmd(t, []*cobra.Command{agentTestCmd, agentListCmd}, append([]string{"agent"}, args...)...)
+}
+
+// writeAgentConfig writes a config whose agents_paths holds one agent
+// script with the given body, and returns the config path.
+func writeAgentConfig(t *testing.T, name, script string) string {
+	t.Helper()
+	dir := t.TempDir()
+	agentsDir := filepath.Join(dir, "agents")
+	require.NoError(t, os.MkdirAll(agentsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(agentsDir, name), []byte(script), 0o755))
+
+	configPath := filepath.Join(dir, "jobster.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`agents_paths: ["`+agentsDir+`"]
+jobs:
+  - id: "placeholder"
+    schedule: "@daily"
+    command: "true"
+`), 0o644))
+	return configPath
+}
+
+func TestAgentTest_ReportsResult(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("agent scripts require a POSIX shell")
+	}
+	configPath := writeAgentConfig(t, "echo-config", `#!/bin/sh
+echo "hook=$HOOK job=$JOB_ID" >&2
+echo "$CONFIG_JSON"
+`)
+
+	out, err := runAgentCmd(t, "test", "echo-config",
+		"--config", configPath,
+		"--hook", "on_error",
+		"--job-id", "backup",
+		"--with", "channel=#ops",
+		"--with", "retries=3")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "Exit code:  0")
+	assert.Contains(t, out, "hook=on_error job=backup")
+	assert.Contains(t, out, "JSON output:")
+	assert.Contains(t, out, `"channel": "#ops"`)
+	assert.Contains(t, out, `"retries": 3`)
+	assert.Contains(t, out, "Duration:")
+}
+
+func TestAgentTest_NonZeroExit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("agent scripts require a POSIX shell")
+	}
+	configPath := writeAgentConfig(t, "broken", `#!/bin/sh
+echo "something went wrong" >&2
+exit 3
+`)
+
+	out, err := runAgentCmd(t, "test", "broken", "--config", configPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exited with code 3")
+	assert.Contains(t, out, "Exit code:  3")
+	assert.Contains(t, out, "something went wrong")
+}
+
+func TestAgentTest_Errors(t *testing.T) {
+	configPath := writeAgentConfig(t, "noop", "#!/bin/sh\n")
+
+	_, err := runAgentCmd(t, "test", "missing", "--config", configPath)
+	assert.ErrorContains(t, err, "agent not found: missing")
+
+	_, err = runAgentCmd(t, "test", "noop", "--config", configPath, "--hook", "sometimes")
+	assert.ErrorContains(t, err, "invalid --hook")
+
+	_, err = runAgentCmd(t, "test", "noop", "--config", configPath, "--with", "novalue")
+	assert.ErrorContains(t, err, "must be key=value")
+}
+
+func TestParseAgentOptions(t *testing.T) {
+	with, err := parseAgentOptions([]string{"n=3", "flag=true", "name=ops", "quoted=\"7\"", "url=http://x/?a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"n":      float64(3),
+		"flag":   true,
+		"name":   "ops",
+		"quoted": "7",
+		"url":    "http://x/?a=b",
+	}, with)
+}
+
+func TestAgentList_FirstPathWins(t *testing.T) {
+	dir1, dir2 := t.TempDir(), t.TempDir()
+	for _, path := range []string{
+		filepath.Join(dir1, "notify"),
+		filepath.Join(dir2, "notify"),
+		filepath.Join(dir2, "page"),
+	} {
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755))
+	}
+	paths := dir1 + "," + dir2
+
+	out, err := runAgentCmd(t, "list", "--paths", paths, "--json")
+	require.NoError(t, err)
+
+	var agents []listedAgent
+	require.NoError(t, json.Unmarshal([]byte(out), &agents))
+	require.Len(t, agents, 4)
+	assert.Equal(t, "notify", agents[0].Name)
+	assert.Equal(t, filepath.Join(dir1, "notify"), agents[0].Path)
+	assert.Empty(t, agents[0].ShadowedBy)
+	assert.Equal(t, filepath.Join(dir2, "notify"), agents[1].Path)
+	assert.Equal(t, filepath.Join(dir1, "notify"), agents[1].ShadowedBy)
+	assert.Equal(t, "page", agents[2].Name)
+	assert.Equal(t, "@webhook", agents[3].Name)
+	assert.True(t, agents[3].Builtin)
+
+	out, err = runAgentCmd(t, "list", "--paths", paths)
+	require.NoError(t, err)
+	assert.Contains(t, out, "shadowed by "+filepath.Join(dir1, "notify"))
+	assert.Contains(t, out, "(built-in)")
+}
+
+func TestAgentList_UsesConfigPaths(t *testing.T) {
+	configPath := writeAgentConfig(t, "from-config", "#!/bin/sh\n")
+
+	out, err := runAgentCmd(t, "list", "--config", configPath)
+	require.NoError(t, err)
+	assert.Contains(t, out, "from-config")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caevv/jobster/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_AuthToken(t *testing.T) {
+	srv := server.New(":0", nil, nil, "", nil)
+	srv.SetAuthToken("s3cret")
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		setAuth    func(*http.Request)
+		wantStatus int
+	}{
+		{
+			name:       "missing token",
+			path:       "/api/jobs",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong token",
+			path:       "/api/jobs",
+			setAuth:    func(r *http.Request) { r.Header.Set("Authorization", "Bearer nope") },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "correct token",
+			path:       "/api/jobs",
+			setAuth:    func(r *http.Request) { r.Header.Set("Authorization", "Bearer s3cret") },
+			wantStatus: http.StatusServiceUnavailable, // authorized; no scheduler configured
+		},
+		{
+			name:       "basic auth password",
+			path:       "/api/jobs",
+			setAuth:    func(r *http.Request) { r.SetBasicAuth("admin", "s3cret") },
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "dashboard requires token",
+			path:       "/",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "health is open",
+			path:       "/api/health",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ts.URL+tt.path, nil)
+			require.NoError(t, err)
+			if tt.setAuth != nil {
+				tt.setAuth(req)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.NotEmpty(t, resp.Header.Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestServe_AuthDisabledWithEmptyToken(t *testing.T) {
+	srv := server.New(":0", nil, nil, "", nil)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/jobs")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.NotEqual(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Work with jobster configuration files",
+	Long: `Work with jobster configuration files.
+
+Subcommands:
+  generate - Write a commented starter configuration
+
+Examples:
+  jobster config generate
+  jobster config generate --output /etc/jobster/jobster.yaml`,
+}
+
+var generateConfigCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Write a commented starter configuration",
+	Long: `Write a starter jobster.yaml with the default settings, a json run
+history store, a sample job, and a commented-out hooks example.
+
+An existing file is left untouched unless --force is given.
+
+Examples:
+  jobster config generate
+  jobster config generate --output ./config/jobster.yaml --force`,
+	Args: cobra.NoArgs,
+	RunE: runGenerateConfig,
+}
+
+func init() {
+	configCmd.AddCommand(generateConfigCmd)
+
+	generateConfigCmd.Flags().StringP("output", "o", "jobster.yaml", "Path to write the configuration to")
+	generateConfigCmd.Flags().Bool("force", false, "Overwrite the file if it already exists")
+}
+
+func runGenerateConfig(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("output")
+	force, _ := cmd.Flags().GetBool("force")
+
+	if !force {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to check %s: %w", path, err)
+		}
+	}
+
+	if err := config.GenerateConfig(path); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote starter configuration to %s\n", path)
+	fmt.Fprintf(cmd.OutOrStdout(), "Check it with: jobster validate --config %s\n", path)
+	return nil
+}
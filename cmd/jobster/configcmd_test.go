@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runGenerateCmd executes "jobster config generate" with args and returns its output.
+func runGenerateCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return executeRootCmd(t, []*cobra.Command{generateConfigCmd}, append([]string{"config", "generate"}, args...)...)
+}
+
+func TestConfigGenerate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conf", "jobster.yaml")
+
+	out, err := runGenerateCmd(t, "--output", path)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Wrote starter configuration to "+path)
+
+	cfg, err := config.LoadConfig(path)
+	require.NoError(t, err, "generated config loads cleanly")
+	require.Len(t, cfg.Jobs, 1)
+	assert.Equal(t, "hello", cfg.Jobs[0].ID)
+	assert.Equal(t, "json", cfg.Store.Driver)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# hooks:", "commented hooks example")
+
+	// Refuses to overwrite without --force
+	require.NoError(t, os.WriteFile(path, []byte("# mine\n"), 0o644))
+	_, err = runGenerateCmd(t, "--output", path)
+	assert.ErrorContains(t, err, "already exists")
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# mine\n", string(data))
+
+	_, err = runGenerateCmd(t, "--output", path, "--force")
+	require.NoError(t, err)
+	_, err = config.LoadConfig(path)
+	require.NoError(t, err)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caevv/jobster/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_CORS(t *testing.T) {
+	srv := server.New(":0", nil, nil, "", nil)
+	srv.SetAuthToken("s3cret")
+	srv.SetCORSOrigins([]string{"https://ops.example.com"})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	send := func(method, path, origin string, headers map[string]string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, ts.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	preflight := map[string]string{
+		"Access-Control-Request-Method":  "POST",
+		"Access-Control-Request-Headers": "authorization",
+	}
+
+	// Preflights are answered without credentials
+	resp := send(http.MethodOptions, "/api/jobs/backup/run", "https://ops.example.com", preflight)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://ops.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, resp.Header.Get("Access-Control-Allow-Methods"), "POST")
+	assert.Equal(t, "authorization", resp.Header.Get("Access-Control-Allow-Headers"))
+	assert.NotEmpty(t, resp.Header.Get("Access-Control-Max-Age"))
+
+	// The actual request still needs the token, and carries the CORS headers
+	resp = send(http.MethodGet, "/api/health", "https://ops.example.com", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "https://ops.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, resp.Header.Get("Access-Control-Expose-Headers"), "X-Total-Count")
+
+	resp = send(http.MethodGet, "/api/jobs", "https://ops.example.com", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "https://ops.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+
+	// Other origins get no CORS headers, so the browser blocks them
+	resp = send(http.MethodOptions, "/api/jobs/backup/run", "https://evil.example.com", preflight)
+	assert.NotEqual(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+
+	resp = send(http.MethodGet, "/api/health", "https://evil.example.com", nil)
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+
+	// Routes outside /api/ are same-origin only
+	resp = send(http.MethodGet, "/metrics", "https://ops.example.com", map[string]string{"Authorization": "Bearer s3cret"})
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestServe_CORSDisabledByDefault(t *testing.T) {
+	srv := server.New(":0", nil, nil, "", nil)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodOptions, ts.URL+"/api/jobs", nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://ops.example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/server"
+	"github.com/caevv/jobster/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_DashboardSortFilterMarkup(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	sched := scheduler.New(context.Background(), runner.logger)
+	for _, id := range []string{"alpha", "beta"} {
+		require.NoError(t, sched.AddJob(&config.Job{
+			ID:         id,
+			Schedule:   "@every 1h",
+			Command:    config.NewCommandSpec("true"),
+			TimeoutSec: 5,
+		}, runner))
+	}
+
+	// alpha has one success and one failure; beta has never run
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	for i, ok := range []bool{true, false} {
+		begin := start.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, st.SaveRun(context.Background(), &store.JobRun{
+			RunID:     "run-" + strconv.Itoa(i),
+			JobID:     "alpha",
+			StartTime: begin,
+			EndTime:   begin.Add(time.Second),
+			ExitCode:  map[bool]int{true: 0, false: 1}[ok],
+			Success:   ok,
+		}))
+	}
+
+	require.NoError(t, sched.Start())
+	defer sched.Stop()
+
+	schedAdapter := server.NewSchedulerAdapter(sched)
+	schedAdapter.SetStore(st)
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), schedAdapter, "", nil)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	html := string(body)
+
+	// Controls
+	assert.Contains(t, html, `id="job-filter"`)
+	assert.Contains(t, html, `id="failures-only"`)
+	assert.Contains(t, html, `data-sort="last-run"`)
+	assert.Contains(t, html, `data-sort="next-run"`)
+	assert.Contains(t, html, `data-sort="success-rate"`)
+
+	// Job rows carry their sort keys; beta has no runs, so its last run and
+	// success rate are empty
+	lastRun := strconv.FormatInt(start.Add(time.Minute).UnixMilli(), 10)
+	assert.Contains(t, html, `data-job-id="alpha" data-last-run="`+lastRun+`"`)
+	assert.Contains(t, html, `data-success-rate="0.5000"`)
+	assert.Regexp(t, `data-job-id="beta" data-last-run="" data-next-run="\d+" data-success-rate=""`, html)
+
+	// Run rows carry their status for the failures-only toggle
+	assert.Contains(t, html, `data-status="success"`)
+	assert.Contains(t, html, `data-status="failure"`)
+}
+
+func TestServe_RunDetailPage(t *testing.T) {
+	dir := t.TempDir()
+	_, st := newTestRunner(t, dir, config.Defaults{})
+
+	start := time.Now().Add(-time.Minute)
+	require.NoError(t, st.SaveRun(context.Background(), &store.JobRun{
+		RunID:      "run-detail-1",
+		JobID:      "report",
+		StartTime:  start,
+		EndTime:    start.Add(3 * time.Second),
+		ExitCode:   2,
+		StdoutTail: "generated 12 rows\n",
+		StderrTail: "warning: <slow> query\n",
+		Trigger:    scheduler.TriggerManual,
+		Metadata:   map[string]interface{}{"attempts": 2},
+	}))
+
+	srv := server.New(":0", server.NewStoreAdapter(st, nil), nil, filepath.Join(dir, "history"), nil)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/runs/run-detail-1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	html := string(body)
+
+	assert.Contains(t, html, "generated 12 rows")
+	assert.Contains(t, html, "warning: &lt;slow&gt; query", "stderr is HTML-escaped")
+	assert.Contains(t, html, `href="/jobs/report"`)
+	assert.Contains(t, html, `href="/api/runs/run-detail-1/logs?stream=stderr"`)
+	assert.Contains(t, html, "attempts")
+	assert.Contains(t, html, "manual")
+
+	resp, err = http.Get(ts.URL + "/runs/missing")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServe_DashboardMetaRefresh(t *testing.T) {
+	fetch := func(srv *server.Server) string {
+		t.Helper()
+		ts := httptest.NewServer(srv.Handler())
+		defer ts.Close()
+
+		resp, err := http.Get(ts.URL + "/")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	srv := server.New(":0", nil, nil, "", nil)
+	srv.SetDashboardRefresh(30 * time.Second)
+	assert.Contains(t, fetch(srv), `<meta http-equiv="refresh" content="30">`)
+
+	srv = server.New(":0", nil, nil, "", nil)
+	assert.NotContains(t, fetch(srv), `http-equiv="refresh"`)
+}
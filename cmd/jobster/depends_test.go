@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_DependsOnChain(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	jobs := []config.Job{
+		{ID: "extract", Schedule: "@every 1h", Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+		{ID: "load", DependsOn: []string{"extract"}, Command: config.NewCommandSpec("/bin/echo loaded"), TimeoutSec: 5},
+		{ID: "broken", Schedule: "@every 1h", Command: config.NewCommandSpec("/bin/false"), TimeoutSec: 5},
+		{ID: "after-broken", DependsOn: []string{"broken"}, Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+	}
+
+	sched := scheduler.New(context.Background(), nil)
+	for i := range jobs {
+		require.NoError(t, sched.AddJob(&jobs[i], runner))
+	}
+	runner.SetDependents(jobs, sched)
+	require.NoError(t, sched.Start())
+
+	stats, ok := sched.GetJobStats("load")
+	require.True(t, ok)
+	assert.True(t, stats.NextRun.IsZero(), "dependent jobs are not on the cron schedule")
+
+	_, err := sched.RunJobNow("broken")
+	require.NoError(t, err)
+	_, err = sched.RunJobNow("extract")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		runs, err := st.GetJobRuns(context.Background(), "load", 10)
+		return err == nil && len(runs) == 1 && !runs[0].EndTime.IsZero()
+	}, 5*time.Second, 20*time.Millisecond, "load should run after extract succeeds")
+
+	require.NoError(t, sched.Stop())
+
+	runs, err := st.GetJobRuns(context.Background(), "load", 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.True(t, runs[0].Success)
+	assert.Equal(t, "loaded\n", runs[0].StdoutTail)
+
+	runs, err = st.GetJobRuns(context.Background(), "after-broken", 10)
+	require.NoError(t, err)
+	assert.Empty(t, runs, "a failed upstream run does not trigger dependents")
+}
+
+func TestRunner_DependsOnSkipsPausedJob(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	jobs := []config.Job{
+		{ID: "upstream", Schedule: "@every 1h", Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+		{ID: "downstream", DependsOn: []string{"upstream"}, Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+	}
+
+	sched := scheduler.New(context.Background(), nil)
+	for i := range jobs {
+		require.NoError(t, sched.AddJob(&jobs[i], runner))
+	}
+	runner.SetDependents(jobs, sched)
+	require.NoError(t, sched.PauseJob("downstream"))
+
+	require.NoError(t, runner.RunJob(context.Background(), &jobs[0]))
+	require.NoError(t, sched.Stop())
+
+	runs, err := st.GetJobRuns(context.Background(), "downstream", 10)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+}
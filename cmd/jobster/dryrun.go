@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/plugins"
+	"github.com/caevv/jobster/internal/scheduler"
+)
+
+// dryRunFireTimes is how many upcoming fire times --dry-run shows per job.
+const dryRunFireTimes = 3
+
+// printDryRun describes what `jobster run` would do with cfg, starting at
+// now, without executing anything. Problems resolving a job's command are
+// printed in place and reported together in the returned error.
+func printDryRun(w io.Writer, cfg *config.Config, now time.Time) error {
+	loc, err := resolveLocation(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Dry run: %d job(s), nothing will be executed\n", len(cfg.Jobs))
+
+	problems := 0
+	for i := range cfg.Jobs {
+		job := &cfg.Jobs[i]
+		fmt.Fprintf(w, "\n%s\n", job.ID)
+
+		if !job.IsEnabled() {
+			fmt.Fprintf(w, "  schedule:  disabled (enabled: false)\n")
+		} else if len(job.DependsOn) > 0 {
+			fmt.Fprintf(w, "  schedule:  after %s succeeds\n", strings.Join(job.DependsOn, " or "))
+		} else {
+			jobLoc := loc
+			if job.Timezone != "" {
+				// Validated at config load
+				jobLoc, _ = config.LoadLocation(job.Timezone)
+			}
+			fmt.Fprintf(w, "  schedule:  %s (%s)\n", job.Schedule, jobLoc)
+
+			times, err := scheduler.NextRunTimes(job, loc, now, dryRunFireTimes)
+			if err != nil {
+				fmt.Fprintf(w, "  next runs: error: %v\n", err)
+				problems++
+			}
+			for j, t := range times {
+				label := ""
+				if j == 0 {
+					label = "next runs:"
+				}
+				fmt.Fprintf(w, "  %-10s %s\n", label, t.In(jobLoc).Format("2006-01-02 15:04:05 MST"))
+			}
+		}
+
+		argv, _, _, err := resolveCommand(job, previewRun())
+		if err != nil {
+			fmt.Fprintf(w, "  command:   error: %v\n", err)
+			problems++
+		} else {
+			quoted := make([]string, len(argv))
+			for j, arg := range argv {
+				quoted[j] = strconv.Quote(arg)
+			}
+			fmt.Fprintf(w, "  command:   [%s]\n", strings.Join(quoted, " "))
+		}
+
+		workdir := job.Workdir
+		if workdir == "" {
+			workdir = "(current directory)"
+		}
+		fmt.Fprintf(w, "  workdir:   %s\n", workdir)
+		fmt.Fprintf(w, "  timeout:   %ds\n", job.TimeoutSec)
+
+		for _, hookType := range []plugins.HookType{plugins.PreRun, plugins.OnSuccess, plugins.OnError, plugins.PostRun, plugins.OnCircuitOpen} {
+			hooks := plugins.GetHooksByType(job.Hooks, hookType)
+			if len(hooks) == 0 {
+				continue
+			}
+			names := make([]string, len(hooks))
+			for j, hook := range hooks {
+				names[j] = hook.Agent
+			}
+			fmt.Fprintf(w, "  %-10s %s\n", hookType.String()+":", strings.Join(names, ", "))
+		}
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("dry run found %d problem(s)", problems)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintDryRun(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "jobster.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+defaults:
+  timezone: "UTC"
+
+jobs:
+  - id: "backup"
+    schedule: "30 2 * * *"
+    command: ["/usr/local/bin/backup.sh", "--target", "$TARGET"]
+    env:
+      TARGET: "/srv/backups"
+    workdir: "/opt/backup"
+    timeout_sec: 3600
+    hooks:
+      on_error:
+        - agent: "@webhook"
+          with: {url: "http://localhost/hook"}
+      post_run:
+        - agent: "notify.sh"
+        - agent: "cleanup.sh"
+  - id: "report"
+    depends_on: ["backup"]
+    command: "echo done | mail ops"
+    shell: true
+  - id: "tick"
+    schedule: "@every 90s"
+    command: "/bin/echo $JOBSTER_TEST_SURELY_UNDEFINED"
+`), 0o644))
+
+	cfg, err := config.LoadConfig(configPath)
+	require.NoError(t, err)
+
+	var out strings.Builder
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	err = printDryRun(&out, cfg, now)
+	assert.EqualError(t, err, "dry run found 1 problem(s)")
+
+	lines := strings.Split(out.String(), "\n")
+	assert.Equal(t, []string{
+		"Dry run: 3 job(s), nothing will be executed",
+		"",
+		"backup",
+		"  schedule:  30 2 * * * (UTC)",
+		"  next runs: 2025-03-11 02:30:00 UTC",
+		"             2025-03-12 02:30:00 UTC",
+		"             2025-03-13 02:30:00 UTC",
+		`  command:   ["/usr/local/bin/backup.sh" "--target" "/srv/backups"]`,
+		"  workdir:   /opt/backup",
+		"  timeout:   3600s",
+		"  on_error:  @webhook",
+		"  post_run:  notify.sh, cleanup.sh",
+		"",
+		"report",
+		"  schedule:  after backup succeeds",
+		`  command:   ["/bin/sh" "-c" "echo done | mail ops"]`,
+	}, lines[:16])
+
+	assert.Contains(t, out.String(), "  next runs: 2025-03-10 12:01:30 UTC\n")
+	assert.Contains(t, out.String(), "  command:   error: interpolate command:")
+}
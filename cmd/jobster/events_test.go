@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/events"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseEvent is one parsed text/event-stream message.
+type sseEvent struct {
+	id    string
+	event string
+	data  events.Event
+}
+
+// readSSE parses events from an SSE stream onto a channel until it ends.
+func readSSE(t *testing.T, body *bufio.Reader) <-chan sseEvent {
+	t.Helper()
+	out := make(chan sseEvent, 16)
+	go func() {
+		defer close(out)
+		var cur sseEvent
+		for {
+			line, err := body.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case line == "":
+				if cur.event != "" {
+					out <- cur
+				}
+				cur = sseEvent{}
+			case strings.HasPrefix(line, "id: "):
+				cur.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				cur.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &cur.data)
+			}
+		}
+	}()
+	return out
+}
+
+// nextEvent waits for the next SSE event or fails the test.
+func nextEvent(t *testing.T, ch <-chan sseEvent) sseEvent {
+	t.Helper()
+	select {
+	case e, ok := <-ch:
+		require.True(t, ok, "event stream closed")
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return sseEvent{}
+	}
+}
+
+// openEventStream connects to /api/events, optionally resuming after lastID.
+func openEventStream(t *testing.T, ctx context.Context, baseURL, lastID string) <-chan sseEvent {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/events", nil)
+	require.NoError(t, err)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return readSSE(t, bufio.NewReader(resp.Body))
+}
+
+func TestServe_EventStreamReportsTriggeredRun(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	bus := events.NewBus()
+	runner.SetEventBus(bus)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sched := scheduler.New(ctx, runner.logger)
+	job := &config.Job{
+		ID:         "sse-job",
+		Schedule:   "@every 1h",
+		Command:    config.NewCommandSpec("echo hello"),
+		TimeoutSec: 5,
+	}
+	require.NoError(t, sched.AddJob(job, runner))
+	require.NoError(t, sched.Start())
+	defer sched.Stop()
+
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), server.NewSchedulerAdapter(sched), "", runner.logger)
+	srv.SetEventSource(bus)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	// Streams must be closed before ts.Close, which waits for open connections
+	streamCtx, closeStreams := context.WithCancel(ctx)
+	defer closeStreams()
+
+	stream := openEventStream(t, streamCtx, ts.URL, "")
+
+	resp, err := http.Post(ts.URL+"/api/jobs/sse-job/run", "application/json", nil)
+	require.NoError(t, err)
+	var trigger server.TriggerResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&trigger))
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	started := nextEvent(t, stream)
+	assert.Equal(t, events.TypeRunStarted, started.event)
+	assert.Equal(t, trigger.RunID, started.data.RunID)
+	assert.Equal(t, "sse-job", started.data.JobID)
+	assert.Equal(t, "running", started.data.Status)
+
+	finished := nextEvent(t, stream)
+	assert.Equal(t, events.TypeRunFinished, finished.event)
+	assert.Equal(t, trigger.RunID, finished.data.RunID)
+	assert.Equal(t, "success", finished.data.Status)
+	assert.Equal(t, 0, finished.data.ExitCode)
+
+	// A reconnecting client resumes after the last event it saw
+	resumed := openEventStream(t, streamCtx, ts.URL, started.id)
+	replayed := nextEvent(t, resumed)
+	assert.Equal(t, finished.id, replayed.id)
+	assert.Equal(t, events.TypeRunFinished, replayed.event)
+}
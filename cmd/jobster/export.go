@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export run history",
+	Long: `Export every run in the run history store, oldest first, as
+newline-delimited JSON (one run per line) or CSV.
+
+--since and --until filter on the run's start time and accept RFC 3339
+timestamps or dates (YYYY-MM-DD, local time); --since is inclusive and
+--until exclusive.
+
+Examples:
+  jobster export --config jobster.yaml > runs.ndjson
+  jobster export --config jobster.yaml --format csv --output runs.csv
+  jobster export --config jobster.yaml --job backup --since 2025-01-01`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringP("config", "c", "jobster.yaml", "Path to configuration file")
+	exportCmd.Flags().String("format", "json", "Output format: json (newline-delimited) or csv")
+	exportCmd.Flags().StringP("output", "o", "", "Write to this file instead of stdout")
+	exportCmd.Flags().String("job", "", "Only export runs of this job")
+	exportCmd.Flags().String("since", "", "Only export runs started at or after this time")
+	exportCmd.Flags().String("until", "", "Only export runs started before this time")
+	exportCmd.MarkFlagRequired("config")
+}
+
+// exportCSVHeader lists the CSV columns written by writeRunsCSV.
+var exportCSVHeader = []string{
+	"run_id", "job_id", "start_time", "end_time", "duration_ms",
+	"exit_code", "success", "stdout_tail", "stderr_tail", "metadata", "trigger",
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	format, _ := cmd.Flags().GetString("format")
+	output, _ := cmd.Flags().GetString("output")
+	jobID, _ := cmd.Flags().GetString("job")
+	sinceStr, _ := cmd.Flags().GetString("since")
+	untilStr, _ := cmd.Flags().GetString("until")
+
+	if format != "json" && format != "csv" {
+		return fmt.Errorf("--format must be json or csv")
+	}
+	since, err := parseExportTime(sinceStr)
+	if err != nil {
+		return fmt.Errorf("--since: %w", err)
+	}
+	until, err := parseExportTime(untilStr)
+	if err != nil {
+		return fmt.Errorf("--until: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+	defer st.Close()
+
+	runs, err := exportRuns(context.Background(), st, jobID, since, until)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if output != "" {
+		// Run output can contain sensitive data, so keep the file private
+		f, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if format == "csv" {
+		err = writeRunsCSV(out, runs)
+	} else {
+		err = writeRunsNDJSON(out, runs)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	if output != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d run(s) to %s\n", len(runs), output)
+	}
+	return nil
+}
+
+// parseExportTime parses an RFC 3339 timestamp or a YYYY-MM-DD date in local
+// time. An empty string yields the zero time, meaning no bound.
+func parseExportTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use RFC 3339 or YYYY-MM-DD)", s)
+}
+
+// exportRuns returns every stored run, or every run of jobID if set, that
+// started within [since, until), oldest first. Zero bounds are open.
+func exportRuns(ctx context.Context, st store.Store, jobID string, since, until time.Time) ([]*store.JobRun, error) {
+	// The store only lists the newest N runs; ask for as many as it holds
+	stats, err := st.GetStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run count: %w", err)
+	}
+	if stats.TotalRuns == 0 {
+		return nil, nil
+	}
+
+	var runs []*store.JobRun
+	if jobID != "" {
+		runs, err = st.GetJobRuns(ctx, jobID, stats.TotalRuns)
+	} else {
+		runs, err = st.GetAllRuns(ctx, stats.TotalRuns)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get runs: %w", err)
+	}
+
+	runs = slices.DeleteFunc(runs, func(run *store.JobRun) bool {
+		return (!since.IsZero() && run.StartTime.Before(since)) ||
+			(!until.IsZero() && !run.StartTime.Before(until))
+	})
+	slices.Reverse(runs)
+	return runs, nil
+}
+
+// writeRunsNDJSON writes one JSON-encoded run per line.
+func writeRunsNDJSON(w io.Writer, runs []*store.JobRun) error {
+	enc := json.NewEncoder(w)
+	for _, run := range runs {
+		if err := enc.Encode(run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRunsCSV writes runs as CSV with a header row. Times are RFC 3339 (end
+// time empty while a run is in progress) and metadata is a JSON object.
+func writeRunsCSV(w io.Writer, runs []*store.JobRun) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportCSVHeader); err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		endTime, duration := "", ""
+		if !run.EndTime.IsZero() {
+			endTime = run.EndTime.Format(time.RFC3339Nano)
+			duration = strconv.FormatInt(run.Duration().Milliseconds(), 10)
+		}
+		metadata := ""
+		if len(run.Metadata) > 0 {
+			b, err := json.Marshal(run.Metadata)
+			if err != nil {
+				return fmt.Errorf("run %s metadata: %w", run.RunID, err)
+			}
+			metadata = string(b)
+		}
+
+		if err := cw.Write([]string{
+			run.RunID,
+			run.JobID,
+			run.StartTime.Format(time.RFC3339Nano),
+			endTime,
+			duration,
+			strconv.Itoa(run.ExitCode),
+			strconv.FormatBool(run.Success),
+			run.StdoutTail,
+			run.StderrTail,
+			metadata,
+			run.Trigger,
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
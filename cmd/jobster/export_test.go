@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/store"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runExportCmd executes "jobster export" with args and returns its stdout.
+func runExportCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return executeRootCmd(t, []*cobra.Command{exportCmd}, append([]string{"export"}, args...)...)
+}
+
+// seedExportStore writes a config using a JSON store holding four runs:
+// backup-1 and report-1 on March 1, backup-2 on March 2, and a still-running
+// backup-3 on March 3.
+func seedExportStore(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	storePath := filepath.Join(dir, "runs.json")
+	configPath := filepath.Join(dir, "jobster.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(fmt.Sprintf(`
+store:
+  driver: "json"
+  path: %q
+jobs:
+  - id: "backup"
+    schedule: "@daily"
+    command: "/bin/true"
+`, storePath)), 0o644))
+
+	st, err := store.NewStore("json", storePath)
+	require.NoError(t, err)
+	day := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC) }
+	for _, run := range []*store.JobRun{
+		{RunID: "backup-1", JobID: "backup", StartTime: day(1, 2), EndTime: day(1, 2).Add(1500 * time.Millisecond), Success: true,
+			StdoutTail: "saved 3 files\n", Metadata: map[string]interface{}{"attempt": 1}},
+		{RunID: "report-1", JobID: "report", StartTime: day(1, 6), EndTime: day(1, 6).Add(time.Second), Success: true},
+		{RunID: "backup-2", JobID: "backup", StartTime: day(2, 2), EndTime: day(2, 2).Add(time.Second), ExitCode: 2,
+			StderrTail: "disk full, \"quota\" exceeded\n"},
+		{RunID: "backup-3", JobID: "backup", StartTime: day(3, 2)},
+	} {
+		require.NoError(t, st.SaveRun(context.Background(), run))
+	}
+	require.NoError(t, st.Close())
+	return configPath
+}
+
+func TestExportCommand_JSON(t *testing.T) {
+	configPath := seedExportStore(t)
+
+	out, err := runExportCmd(t, "--config", configPath)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 4)
+	var ids []string
+	for _, line := range lines {
+		var run store.JobRun
+		require.NoError(t, json.Unmarshal([]byte(line), &run))
+		ids = append(ids, run.RunID)
+	}
+	assert.Equal(t, []string{"backup-1", "report-1", "backup-2", "backup-3"}, ids, "oldest first")
+
+	var first store.JobRun
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "saved 3 files\n", first.StdoutTail)
+	assert.Equal(t, float64(1), first.Metadata["attempt"])
+
+	out, err = runExportCmd(t, "--config", configPath, "--job", "backup", "--since", "2026-03-01T03:00:00Z", "--until", "2026-03-03")
+	require.NoError(t, err)
+	lines = strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], `"run_id":"backup-2"`)
+
+	_, err = runExportCmd(t, "--config", configPath, "--since", "yesterday")
+	assert.ErrorContains(t, err, `--since: invalid time "yesterday"`)
+}
+
+func TestExportCommand_CSV(t *testing.T) {
+	configPath := seedExportStore(t)
+	outputPath := filepath.Join(t.TempDir(), "runs.csv")
+
+	stdout, err := runExportCmd(t, "--config", configPath, "--format", "csv", "--output", outputPath, "--job", "backup")
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+
+	f, err := os.Open(outputPath)
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+
+	require.Len(t, records, 4)
+	assert.Equal(t, exportCSVHeader, records[0])
+	assert.Equal(t, []string{
+		"backup-1", "backup", "2026-03-01T02:00:00Z", "2026-03-01T02:00:01.5Z", "1500",
+		"0", "true", "saved 3 files\n", "", `{"attempt":1}`, "",
+	}, records[1])
+	assert.Equal(t, "2", records[2][5])
+	assert.Equal(t, "disk full, \"quota\" exceeded\n", records[2][8], "quotes and commas round-trip")
+	assert.Equal(t, []string{"backup-3", "", ""}, []string{records[3][0], records[3][3], records[3][4]},
+		"running runs have no end time or duration")
+
+	info, err := os.Stat(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	_, err = runExportCmd(t, "--config", configPath, "--format", "xml")
+	assert.ErrorContains(t, err, "--format must be json or csv")
+}
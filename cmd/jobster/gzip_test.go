@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/events"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/server"
+	"github.com/caevv/jobster/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_Gzip(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	sched := scheduler.New(context.Background(), runner.logger)
+	require.NoError(t, sched.AddJob(&config.Job{
+		ID:         "gzip-job",
+		Schedule:   "@every 1h",
+		Command:    config.NewCommandSpec("echo hello"),
+		TimeoutSec: 5,
+	}, runner))
+	for i := 0; i < 20; i++ {
+		require.NoError(t, st.SaveRun(context.Background(), &store.JobRun{
+			RunID:     fmt.Sprintf("run-%02d", i),
+			JobID:     "gzip-job",
+			StartTime: time.Now().Add(-time.Duration(i) * time.Minute),
+			EndTime:   time.Now().Add(-time.Duration(i)*time.Minute + time.Second),
+			Success:   true,
+		}))
+	}
+
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), server.NewSchedulerAdapter(sched), "", runner.logger)
+	srv.SetEventSource(events.NewBus())
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	// Setting Accept-Encoding ourselves stops the transport from
+	// transparently decompressing, so the raw encoding can be checked
+	get := func(ctx context.Context, path, acceptEncoding string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := get(context.Background(), "/", "gzip, deflate")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+	assert.Contains(t, resp.Header.Values("Vary"), "Accept-Encoding")
+	zr, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "gzip-job")
+	assert.Contains(t, string(body), "</html>")
+
+	// JSON run lists are compressed too
+	resp = get(context.Background(), "/api/runs", "gzip")
+	resp.Body.Close()
+	assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+
+	// Clients that do not accept gzip, and tiny bodies, are sent as is
+	resp = get(context.Background(), "/", "identity")
+	body, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	assert.Contains(t, string(body), "</html>")
+
+	resp = get(context.Background(), "/api/health", "gzip")
+	body, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	assert.Contains(t, string(body), "status")
+
+	// The event stream is never compressed, so events arrive as they happen
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp = get(ctx, "/api/events", "gzip")
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(line, ":") || strings.HasPrefix(line, "retry"), "plain SSE, got %q", line)
+	cancel()
+}
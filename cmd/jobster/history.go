@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [job-id]",
+	Short: "Show recent runs of a job",
+	Long: `Show the most recent runs of a job from the run history store, newest
+first. Use --all instead of a job ID to list runs across every job.
+
+Examples:
+  jobster history backup --config jobster.yaml
+  jobster history --all --limit 50 --config jobster.yaml
+  jobster history backup --json --config jobster.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().StringP("config", "c", "jobster.yaml", "Path to configuration file")
+	historyCmd.Flags().IntP("limit", "n", 20, "Maximum number of runs to show")
+	historyCmd.Flags().Bool("all", false, "Show runs of all jobs")
+	historyCmd.Flags().Bool("json", false, "Print runs as JSON")
+	historyCmd.MarkFlagRequired("config")
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	limit, _ := cmd.Flags().GetInt("limit")
+	all, _ := cmd.Flags().GetBool("all")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	if all == (len(args) == 1) {
+		return fmt.Errorf("specify either a job ID or --all")
+	}
+	if limit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	var runs []*store.JobRun
+	if all {
+		runs, err = st.GetAllRuns(ctx, limit)
+	} else {
+		runs, err = st.GetJobRuns(ctx, args[0], limit)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get runs: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if asJSON {
+		if runs == nil {
+			runs = []*store.JobRun{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(runs)
+	}
+
+	if len(runs) == 0 {
+		fmt.Fprintln(out, "No runs recorded")
+		return nil
+	}
+	printHistoryTable(out, runs, all)
+	return nil
+}
+
+// printHistoryTable writes runs as a table, with a JOB column when runs can
+// belong to different jobs.
+func printHistoryTable(out io.Writer, runs []*store.JobRun, withJob bool) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if withJob {
+		fmt.Fprintln(w, "RUN ID\tJOB\tSTARTED\tDURATION\tEXIT\tSTATUS")
+		fmt.Fprintln(w, "──────\t───\t───────\t────────\t────\t──────")
+	} else {
+		fmt.Fprintln(w, "RUN ID\tSTARTED\tDURATION\tEXIT\tSTATUS")
+		fmt.Fprintln(w, "──────\t───────\t────────\t────\t──────")
+	}
+
+	for _, run := range runs {
+		status, duration, exit := "running", "-", "-"
+		if !run.IsRunning() {
+			status = "success"
+			if !run.Success {
+				status = "failed"
+			}
+			duration = run.Duration().Round(time.Millisecond).String()
+			exit = fmt.Sprintf("%d", run.ExitCode)
+		}
+
+		started := run.StartTime.Local().Format("2006-01-02 15:04:05")
+		if withJob {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", run.RunID, run.JobID, started, duration, exit, status)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", run.RunID, started, duration, exit, status)
+		}
+	}
+
+	w.Flush()
+	fmt.Fprintf(out, "\nShowing %d run(s)\n", len(runs))
+}
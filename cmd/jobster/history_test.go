@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/store"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runHistoryCmd executes "jobster history" with args and returns its output.
+func runHistoryCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return executeRootCmd(t, []*cobra.Command{historyCmd}, append([]string{"history"}, args...)...)
+}
+
+func TestHistoryCommand(t *testing.T) {
+	dir := t.TempDir()
+	storePath := filepath.Join(dir, "runs.json")
+	configPath := filepath.Join(dir, "jobster.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(fmt.Sprintf(`
+store:
+  driver: "json"
+  path: %q
+jobs:
+  - id: "backup"
+    schedule: "@daily"
+    command: "/bin/true"
+`, storePath)), 0o644))
+
+	st, err := store.NewStore("json", storePath)
+	require.NoError(t, err)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, run := range []*store.JobRun{
+		{RunID: "backup-1", JobID: "backup", Success: true},
+		{RunID: "backup-2", JobID: "backup", ExitCode: 2},
+		{RunID: "report-1", JobID: "report", Success: true},
+	} {
+		run.StartTime = base.Add(time.Duration(i) * time.Hour)
+		run.EndTime = run.StartTime.Add(1500 * time.Millisecond)
+		require.NoError(t, st.SaveRun(context.Background(), run))
+	}
+	require.NoError(t, st.Close())
+
+	out, err := runHistoryCmd(t, "backup", "--config", configPath, "--limit", "20", "--all=false", "--json=false")
+	require.NoError(t, err)
+	assert.Contains(t, out, "RUN ID")
+	assert.NotContains(t, out, "JOB")
+	assert.Regexp(t, `backup-2\s+\S+ \S+\s+1.5s\s+2\s+failed`, out)
+	assert.Regexp(t, `backup-1\s+\S+ \S+\s+1.5s\s+0\s+success`, out)
+	assert.NotContains(t, out, "report-1")
+	assert.Less(t, strings.Index(out, "backup-2"), strings.Index(out, "backup-1"), "newest first")
+
+	out, err = runHistoryCmd(t, "--all", "--config", configPath, "--limit", "2", "--json=false")
+	require.NoError(t, err)
+	assert.Contains(t, out, "JOB")
+	assert.Contains(t, out, "report-1")
+	assert.Contains(t, out, "backup-2")
+	assert.NotContains(t, out, "backup-1", "limited to two runs")
+
+	out, err = runHistoryCmd(t, "backup", "--config", configPath, "--limit", "20", "--all=false", "--json")
+	require.NoError(t, err)
+	var runs []store.JobRun
+	require.NoError(t, json.Unmarshal([]byte(out), &runs))
+	require.Len(t, runs, 2)
+	assert.Equal(t, "backup-2", runs[0].RunID)
+	assert.Equal(t, 2, runs[0].ExitCode)
+
+	_, err = runHistoryCmd(t, "--config", configPath, "--all=false", "--json=false")
+	assert.ErrorContains(t, err, "either a job ID or --all")
+}
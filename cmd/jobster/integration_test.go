@@ -77,7 +77,7 @@ func TestIntegration_JobExecution(t *testing.T) {
 	}
 
 	// Verify runs were recorded
-	runs, err := st.GetJobRuns("test-job", 10)
+	runs, err := st.GetJobRuns(context.Background(), "test-job", 10)
 	if err != nil {
 		t.Fatalf("Failed to get job runs: %v", err)
 	}
@@ -156,7 +156,7 @@ func TestIntegration_FailingJob(t *testing.T) {
 	}
 
 	// Verify failure was recorded
-	runs, err := st.GetJobRuns("failing-job", 10)
+	runs, err := st.GetJobRuns(context.Background(), "failing-job", 10)
 	if err != nil {
 		t.Fatalf("Failed to get job runs: %v", err)
 	}
@@ -247,7 +247,7 @@ func TestIntegration_MultipleJobs(t *testing.T) {
 
 	// Verify all jobs ran
 	for _, job := range cfg.Jobs {
-		runs, err := st.GetJobRuns(job.ID, 10)
+		runs, err := st.GetJobRuns(context.Background(), job.ID, 10)
 		if err != nil {
 			t.Fatalf("Failed to get runs for %s: %v", job.ID, err)
 		}
@@ -258,7 +258,7 @@ func TestIntegration_MultipleJobs(t *testing.T) {
 	}
 
 	// Verify GetAllRuns works
-	allRuns, err := st.GetAllRuns(100)
+	allRuns, err := st.GetAllRuns(context.Background(), 100)
 	if err != nil {
 		t.Fatalf("Failed to get all runs: %v", err)
 	}
@@ -360,7 +360,7 @@ exit 0
 	// safe (Stop's wg.Wait establishes the happens-before edge).
 	deadline := time.Now().Add(5 * time.Second)
 	for time.Now().Before(deadline) {
-		runs, err := st.GetJobRuns("hook-job", 10)
+		runs, err := st.GetJobRuns(context.Background(), "hook-job", 10)
 		if err != nil {
 			t.Fatalf("Failed to get job runs: %v", err)
 		}
@@ -378,7 +378,7 @@ exit 0
 	}
 
 	// Verify job ran
-	runs, err := st.GetJobRuns("hook-job", 10)
+	runs, err := st.GetJobRuns(context.Background(), "hook-job", 10)
 	if err != nil {
 		t.Fatalf("Failed to get job runs: %v", err)
 	}
@@ -453,7 +453,7 @@ func TestIntegration_GracefulShutdown(t *testing.T) {
 	}
 
 	// Verify at least one run was recorded
-	runs, err := st.GetJobRuns("long-job", 10)
+	runs, err := st.GetJobRuns(context.Background(), "long-job", 10)
 	if err != nil {
 		t.Fatalf("Failed to get job runs: %v", err)
 	}
@@ -523,7 +523,7 @@ func TestIntegration_JobWithEnvironment(t *testing.T) {
 	}
 
 	// Verify job ran and captured environment variable
-	runs, err := st.GetJobRuns("env-job", 10)
+	runs, err := st.GetJobRuns(context.Background(), "env-job", 10)
 	if err != nil {
 		t.Fatalf("Failed to get job runs: %v", err)
 	}
@@ -576,12 +576,12 @@ func TestIntegration_StoreFactoryCreation(t *testing.T) {
 				Success:   true,
 			}
 
-			err = st.SaveRun(run)
+			err = st.SaveRun(context.Background(), run)
 			if err != nil {
 				t.Fatalf("SaveRun() error = %v", err)
 			}
 
-			got, err := st.GetRun("test-run")
+			got, err := st.GetRun(context.Background(), "test-run")
 			if err != nil {
 				t.Fatalf("GetRun() error = %v", err)
 			}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+)
+
+// expandJobEnv interpolates $VAR and ${VAR} in job env values. A value may
+// reference the process environment (via lookup) and other job env entries;
+// a variable referencing itself (PATH: "$PATH:/opt/bin") sees the process
+// value. Undefined variables and reference cycles are errors; "$$" is a
+// literal dollar sign.
+func expandJobEnv(env map[string]string, lookup func(string) (string, bool)) (map[string]string, error) {
+	expanded := make(map[string]string, len(env))
+	visiting := make(map[string]bool)
+
+	var resolve func(key string) (string, error)
+	resolve = func(key string) (string, error) {
+		if v, ok := expanded[key]; ok {
+			return v, nil
+		}
+		if visiting[key] {
+			return "", fmt.Errorf("env %s: reference cycle", key)
+		}
+		visiting[key] = true
+		defer delete(visiting, key)
+
+		v, err := expandVars(env[key], func(name string) (string, bool, error) {
+			if _, ok := env[name]; ok && name != key {
+				v, err := resolve(name)
+				return v, true, err
+			}
+			v, ok := lookup(name)
+			return v, ok, nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("env %s: %w", key, err)
+		}
+		expanded[key] = v
+		return v, nil
+	}
+
+	// Resolve in a fixed order so the reported error is deterministic
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, err := resolve(k); err != nil {
+			return nil, err
+		}
+	}
+
+	return expanded, nil
+}
+
+// expandArgs interpolates $VAR and ${VAR} in each already-tokenized command
+// argument against vars, so an expanded value never splits into more
+// arguments. Undefined variables are errors; "$$" is a literal dollar sign.
+func expandArgs(args []string, vars map[string]string) ([]string, error) {
+	out := make([]string, len(args))
+	for i, arg := range args {
+		v, err := expandVars(arg, func(name string) (string, bool, error) {
+			v, ok := vars[name]
+			return v, ok, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("command argument %q: %w", arg, err)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// expandVars wraps os.Expand, failing on the first variable lookup reports
+// as undefined. ${VAR:-default} yields default when VAR is unset or empty.
+func expandVars(s string, lookup func(name string) (string, bool, error)) (string, error) {
+	var firstErr error
+	out := os.Expand(s, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		name, def, hasDefault := strings.Cut(name, ":-")
+		v, ok, err := lookup(name)
+		if hasDefault && err == nil && (!ok || v == "") {
+			return def
+		}
+		if firstErr == nil {
+			switch {
+			case err != nil:
+				firstErr = err
+			case !ok:
+				firstErr = fmt.Errorf("undefined variable $%s (set it, or use no_interpolate to pass $ literally)", name)
+			}
+		}
+		return v
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return out, nil
+}
+
+// environMap parses os.Environ-style "KEY=VALUE" entries into a map.
+func environMap(environ []string) map[string]string {
+	m := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// commandRun is what the arguments of a templated_command job are expanded
+// against, e.g. {{.RunID}} or {{.Now.Format "2006-01-02"}}.
+type commandRun struct {
+	Now     time.Time
+	RunID   string
+	JobID   string
+	Attempt int
+	Env     map[string]string // process environment, env_file and job env
+}
+
+// previewRun is the commandRun used to show or check a command outside a
+// run, as dry runs and validate --strict do.
+func previewRun() commandRun {
+	return commandRun{Now: time.Now(), RunID: "preview", Attempt: 1}
+}
+
+// expandArgTemplates expands each already-tokenized command argument as a
+// template against run.
+func expandArgTemplates(args []string, run commandRun) ([]string, error) {
+	out := make([]string, len(args))
+	for i, arg := range args {
+		v, err := expandArgTemplate(arg, run)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// expandArgTemplate expands one templated_command argument against run.
+func expandArgTemplate(arg string, run commandRun) (string, error) {
+	tmpl, err := config.ParseArgTemplate(arg)
+	if err != nil {
+		return "", fmt.Errorf("templated_command: %w", err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, run); err != nil {
+		return "", fmt.Errorf("templated_command argument %q: %w", arg, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lookupIn(m map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := m[name]
+		return v, ok
+	}
+}
+
+func TestExpandJobEnv(t *testing.T) {
+	process := lookupIn(map[string]string{"HOME": "/home/ops", "PATH": "/usr/bin"})
+
+	got, err := expandJobEnv(map[string]string{
+		"BACKUP_DIR": "${HOME}/backups",
+		"TARGET":     "$BACKUP_DIR/daily",
+		"PATH":       "$PATH:/opt/bin",
+		"PRICE":      "$$5",
+	}, process)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/home/ops/backups", got["BACKUP_DIR"])
+	assert.Equal(t, "/home/ops/backups/daily", got["TARGET"], "references other job env entries")
+	assert.Equal(t, "/usr/bin:/opt/bin", got["PATH"], "self reference sees the process value")
+	assert.Equal(t, "$5", got["PRICE"])
+}
+
+func TestExpandJobEnv_Errors(t *testing.T) {
+	process := lookupIn(map[string]string{})
+
+	_, err := expandJobEnv(map[string]string{"A": "$MISSING"}, process)
+	assert.ErrorContains(t, err, "undefined variable $MISSING")
+
+	_, err = expandJobEnv(map[string]string{"A": "$B", "B": "$A"}, process)
+	assert.ErrorContains(t, err, "reference cycle")
+}
+
+func TestExpandJobEnv_Defaults(t *testing.T) {
+	process := lookupIn(map[string]string{"REGION": "eu-west-1", "EMPTY": ""})
+
+	got, err := expandJobEnv(map[string]string{
+		"SET":     "${REGION:-us-east-1}",
+		"UNSET":   "${API_URL:-http://localhost}",
+		"BLANK":   "${EMPTY:-fallback}",
+		"NO_DEFL": "${MISSING:-}",
+	}, process)
+	require.NoError(t, err)
+
+	assert.Equal(t, "eu-west-1", got["SET"])
+	assert.Equal(t, "http://localhost", got["UNSET"])
+	assert.Equal(t, "fallback", got["BLANK"], "empty values take the default")
+	assert.Equal(t, "", got["NO_DEFL"])
+}
+
+func TestExpandArgs(t *testing.T) {
+	vars := map[string]string{"DIR": "/data/my files", "EMPTY": ""}
+
+	got, err := expandArgs([]string{"backup.sh", "$DIR", "--tag=${EMPTY}x", "$$HOME"}, vars)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backup.sh", "/data/my files", "--tag=x", "$HOME"}, got,
+		"values with spaces stay a single argument")
+
+	_, err = expandArgs([]string{"echo", "$UNDEFINED"}, vars)
+	assert.ErrorContains(t, err, "undefined variable $UNDEFINED")
+}
+
+func TestRunner_InterpolatesCommandAndEnv(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	t.Setenv("JOBSTER_TEST_BASE", "/srv")
+
+	job := &config.Job{
+		ID:         "interp-job",
+		Schedule:   "@every 1s",
+		Command:    config.NewCommandSpec(`/bin/echo "$TARGET" ${JOBSTER_TEST_BASE}`),
+		Env:        map[string]string{"TARGET": "$JOBSTER_TEST_BASE/backups"},
+		TimeoutSec: 5,
+	}
+
+	require.NoError(t, runner.RunJob(context.Background(), job))
+
+	runs, err := st.GetJobRuns(context.Background(), "interp-job", 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "/srv/backups /srv", strings.TrimSpace(runs[0].StdoutTail))
+}
+
+func TestRunner_UndefinedVariableFailsRun(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	job := &config.Job{
+		ID:         "undefined-job",
+		Schedule:   "@every 1s",
+		Command:    config.NewCommandSpec(`/bin/echo $JOBSTER_TEST_SURELY_UNDEFINED`),
+		TimeoutSec: 5,
+	}
+
+	assert.ErrorContains(t, runner.RunJob(context.Background(), job), "undefined variable")
+
+	runs, err := st.GetJobRuns(context.Background(), "undefined-job", 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.False(t, runs[0].Success)
+}
+
+func TestRunner_NoInterpolate(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	job := &config.Job{
+		ID:            "literal-job",
+		Schedule:      "@every 1s",
+		Command:       config.NewCommandSpec(`/bin/echo $1 ${HOME}`),
+		Env:           map[string]string{"LITERAL": "$HOME"},
+		NoInterpolate: true,
+		TimeoutSec:    5,
+	}
+
+	require.NoError(t, runner.RunJob(context.Background(), job))
+
+	runs, err := st.GetJobRuns(context.Background(), "literal-job", 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "$1 ${HOME}", strings.TrimSpace(runs[0].StdoutTail))
+}
+
+func TestRunner_EnvFile(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "job.env"),
+		[]byte("FROM_FILE=file\nOVERRIDDEN=file\nSECRET=pa$$word\n"), 0o600))
+
+	job := &config.Job{
+		ID:       "env-file-job",
+		Schedule: "@every 1s",
+		Command:  config.NewCommandSpec(`echo "$FROM_FILE $OVERRIDDEN $SECRET $DERIVED"`),
+		Shell:    true,
+		Workdir:  dir,
+		EnvFile:  "job.env", // relative to workdir
+		Env: map[string]string{
+			"OVERRIDDEN": "env",
+			"DERIVED":    "${FROM_FILE}-derived",
+		},
+		TimeoutSec: 5,
+	}
+
+	require.NoError(t, runner.RunJob(context.Background(), job))
+
+	runs, err := st.GetJobRuns(context.Background(), "env-file-job", 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "file env pa$$word file-derived", strings.TrimSpace(runs[0].StdoutTail),
+		"env entries win over the file, file values stay literal")
+}
+
+func TestExpandArgTemplates(t *testing.T) {
+	run := commandRun{
+		Now:     time.Date(2025, 10, 8, 2, 30, 0, 0, time.UTC),
+		RunID:   "run-42",
+		JobID:   "report",
+		Attempt: 2,
+		Env:     map[string]string{"REGION": "eu west"},
+	}
+
+	got, err := expandArgTemplates([]string{
+		"report.sh",
+		`--date={{.Now.Format "2006-01-02"}}`,
+		"--run-id={{.RunID}}",
+		"{{.JobID}}#{{.Attempt}}",
+		"{{.Env.REGION}}",
+	}, run)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"report.sh", "--date=2025-10-08", "--run-id=run-42", "report#2", "eu west"}, got,
+		"values with spaces stay a single argument")
+
+	_, err = expandArgTemplates([]string{"{{.Env.JOBSTER_TEST_UNSET}}"}, run)
+	assert.ErrorContains(t, err, "JOBSTER_TEST_UNSET")
+	_, err = expandArgTemplates([]string{"{{.Bogus}}"}, run)
+	assert.Error(t, err)
+}
+
+func TestRunner_TemplatedCommand(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	t.Setenv("JOBSTER_TEST_BASE", "/srv")
+
+	job := &config.Job{
+		ID:               "templated-job",
+		Schedule:         "@every 1s",
+		Command:          config.NewCommandSpec(`/bin/echo '--date={{.Now.Format "2006-01-02"}}' --run-id={{.RunID}} {{.Env.JOBSTER_TEST_BASE}} $JOBSTER_TEST_BASE`),
+		TemplatedCommand: true,
+		TimeoutSec:       5,
+	}
+
+	ctx := scheduler.ContextWithRunID(context.Background(), "run-templated")
+	require.NoError(t, runner.RunJob(ctx, job))
+
+	runs, err := st.GetJobRuns(context.Background(), "templated-job", 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	want := "--date=" + runs[0].StartTime.Format("2006-01-02") + " --run-id=run-templated /srv $JOBSTER_TEST_BASE"
+	assert.Equal(t, want, strings.TrimSpace(runs[0].StdoutTail))
+}
+
+func TestRunner_TemplatedCommandOutputNotInterpolated(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	job := &config.Job{
+		ID:               "templated-dollar",
+		Schedule:         "@every 1s",
+		Command:          config.NewCommandSpec("/bin/echo {{.Env.PASSWORD}}"),
+		Env:              map[string]string{"PASSWORD": "pa$$word-$$JOBSTER_TEST_UNSET"},
+		TemplatedCommand: true,
+		TimeoutSec:       5,
+	}
+
+	require.NoError(t, runner.RunJob(context.Background(), job))
+
+	runs, err := st.GetJobRuns(context.Background(), "templated-dollar", 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.True(t, runs[0].Success, runs[0].Metadata["error"])
+	// The env value is interpolated once ($$ -> $); the template output is not
+	assert.Equal(t, "pa$word-$JOBSTER_TEST_UNSET", strings.TrimSpace(runs[0].StdoutTail))
+}
+
+func TestRunner_TemplatedCommandOffByDefault(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	job := &config.Job{
+		ID:         "braces-job",
+		Schedule:   "@every 1s",
+		Command:    config.NewCommandSpec(`/bin/echo {{.RunID}} '{"a":1}'`),
+		TimeoutSec: 5,
+	}
+
+	require.NoError(t, runner.RunJob(context.Background(), job))
+
+	runs, err := st.GetJobRuns(context.Background(), "braces-job", 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, `{{.RunID}} {"a":1}`, strings.TrimSpace(runs[0].StdoutTail))
+}
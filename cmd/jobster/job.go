@@ -3,12 +3,16 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
 	"github.com/spf13/cobra"
 )
 
@@ -20,10 +24,15 @@ var jobCmd = &cobra.Command{
 Subcommands:
   add     - Add a new job to the configuration
   list    - List all jobs in the configuration
+  update  - Change fields of an existing job
+  enable  - Schedule a disabled job again
+  disable - Stop scheduling a job without removing it
   remove  - Remove a job from the configuration
 
 Examples:
   jobster job add backup --schedule "@daily" --command "/usr/bin/backup.sh"
+  jobster job update backup --schedule "0 3 * * *"
+  jobster job disable backup
   jobster job list --config jobster.yaml
   jobster job remove backup --config jobster.yaml`,
 }
@@ -58,13 +67,68 @@ var listJobsCmd = &cobra.Command{
 	Short: "List all jobs in the configuration",
 	Long: `List all configured cron jobs from the Jobster configuration file.
 
-Displays job ID, schedule, and command in a table format.
+Displays job ID, schedule, and command in a table format. With --next N,
+also shows each job's next N fire times. With --label key=value
+(repeatable), only jobs carrying every given label are listed.
 
-Example:
-  jobster job list --config jobster.yaml`,
+Examples:
+  jobster job list --config jobster.yaml
+  jobster job list --config jobster.yaml --next 3
+  jobster job list --config jobster.yaml --label team=payments`,
 	RunE: runListJobs,
 }
 
+var updateJobCmd = &cobra.Command{
+	Use:   "update [job-id]",
+	Short: "Change fields of an existing job",
+	Long: `Change fields of an existing job in the Jobster configuration file.
+
+Only the fields given as flags are changed; everything else about the job is
+kept. --env entries are added to the job's environment, replacing entries
+with the same key.
+
+If --interactive flag is used, the command prompts for each field with its
+current value as the default.
+
+Examples:
+  # Move a job to 3 AM
+  jobster job update daily-backup --schedule "0 3 * * *"
+
+  # Change the command and timeout
+  jobster job update api-check --command "curl -f http://api/health" --timeout 10
+
+  # Interactive mode
+  jobster job update api-check --interactive`,
+	RunE: runUpdateJob,
+	Args: cobra.MaximumNArgs(1),
+}
+
+var enableJobCmd = &cobra.Command{
+	Use:   "enable [job-id]",
+	Short: "Schedule a disabled job again",
+	Long: `Enable a job disabled with "jobster job disable", so it is scheduled
+again. A running scheduler picks the change up on reload (SIGHUP).
+
+Example:
+  jobster job enable daily-backup --config jobster.yaml`,
+	RunE: runSetJobEnabled(true),
+	Args: cobra.ExactArgs(1),
+}
+
+var disableJobCmd = &cobra.Command{
+	Use:   "disable [job-id]",
+	Short: "Stop scheduling a job without removing it",
+	Long: `Disable a job: it stays in the configuration, with enabled: false, but
+is not scheduled and is not started by jobs it depends on. It can still be
+run with "jobster trigger". A running scheduler picks the change up on
+reload (SIGHUP).
+
+Example:
+  jobster job disable daily-backup --config jobster.yaml`,
+	RunE: runSetJobEnabled(false),
+	Args: cobra.ExactArgs(1),
+}
+
 var removeJobCmd = &cobra.Command{
 	Use:   "remove [job-id]",
 	Short: "Remove a job from the configuration",
@@ -80,6 +144,9 @@ func init() {
 	// Add subcommands
 	jobCmd.AddCommand(addJobCmd)
 	jobCmd.AddCommand(listJobsCmd)
+	jobCmd.AddCommand(updateJobCmd)
+	jobCmd.AddCommand(enableJobCmd)
+	jobCmd.AddCommand(disableJobCmd)
 	jobCmd.AddCommand(removeJobCmd)
 
 	// Common flags
@@ -92,6 +159,18 @@ func init() {
 	addJobCmd.Flags().Int("timeout", 600, "Timeout in seconds")
 	addJobCmd.Flags().StringSlice("env", []string{}, "Environment variables (KEY=VALUE, repeatable)")
 	addJobCmd.Flags().BoolP("interactive", "i", false, "Interactive mode with prompts")
+
+	// Update command flags
+	updateJobCmd.Flags().String("schedule", "", "New cron expression or @-notation")
+	updateJobCmd.Flags().String("command", "", "New command to execute")
+	updateJobCmd.Flags().String("workdir", "", "New working directory")
+	updateJobCmd.Flags().Int("timeout", 0, "New timeout in seconds")
+	updateJobCmd.Flags().StringSlice("env", []string{}, "Environment variables to set (KEY=VALUE, repeatable)")
+	updateJobCmd.Flags().BoolP("interactive", "i", false, "Interactive mode with prompts")
+
+	// List command flags
+	listJobsCmd.Flags().Int("next", 0, "Show each job's next N scheduled run times")
+	listJobsCmd.Flags().StringArray("label", nil, "Only list jobs with this label (key=value, repeatable)")
 }
 
 func runAddJob(cmd *cobra.Command, args []string) error {
@@ -128,19 +207,20 @@ func runAddJob(cmd *cobra.Command, args []string) error {
 		}
 
 		// Parse environment variables
-		env := make(map[string]string)
-		for _, envVar := range envVars {
-			parts := strings.SplitN(envVar, "=", 2)
-			if len(parts) != 2 {
-				return fmt.Errorf("invalid environment variable format: %s (expected KEY=VALUE)", envVar)
-			}
-			env[parts[0]] = parts[1]
+		env, err := parseEnvFlags(envVars)
+		if err != nil {
+			return err
+		}
+
+		cmdSpec, err := config.ParseCommandSpec(command)
+		if err != nil {
+			return fmt.Errorf("invalid --command: %w", err)
 		}
 
 		job = config.Job{
 			ID:         jobID,
 			Schedule:   schedule,
-			Command:    config.NewCommandSpec(command),
+			Command:    cmdSpec,
 			Workdir:    workdir,
 			TimeoutSec: timeout,
 			Env:        env,
@@ -167,6 +247,14 @@ func runAddJob(cmd *cobra.Command, args []string) error {
 
 func runListJobs(cmd *cobra.Command, args []string) error {
 	configPath, _ := cmd.Flags().GetString("config")
+	next, _ := cmd.Flags().GetInt("next")
+	if next < 0 {
+		return fmt.Errorf("--next must be non-negative")
+	}
+	selector, err := labelSelectorFlag(cmd)
+	if err != nil {
+		return fmt.Errorf("--label: %w", err)
+	}
 
 	// Check if config exists
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
@@ -179,37 +267,181 @@ func runListJobs(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	out := cmd.OutOrStdout()
+	if len(cfg.Jobs) == 0 {
+		fmt.Fprintln(out, "No jobs configured")
+		return nil
+	}
+	cfg.Jobs = selectJobs(cfg.Jobs, selector)
 	if len(cfg.Jobs) == 0 {
-		fmt.Println("No jobs configured")
+		fmt.Fprintf(out, "No jobs match labels %s\n", selector)
 		return nil
 	}
 
+	loc, err := resolveLocation(cfg)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
 	// Print jobs in table format
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tSCHEDULE\tCOMMAND\tWORKDIR\tTIMEOUT")
-	fmt.Fprintln(w, "──\t────────\t───────\t───────\t───────")
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if next > 0 {
+		fmt.Fprintln(w, "ID\tENABLED\tSCHEDULE\tCOMMAND\tWORKDIR\tTIMEOUT\tNEXT RUNS")
+		fmt.Fprintln(w, "──\t───────\t────────\t───────\t───────\t───────\t─────────")
+	} else {
+		fmt.Fprintln(w, "ID\tENABLED\tSCHEDULE\tCOMMAND\tWORKDIR\tTIMEOUT")
+		fmt.Fprintln(w, "──\t───────\t────────\t───────\t───────\t───────")
+	}
 
-	for _, job := range cfg.Jobs {
+	for i := range cfg.Jobs {
+		job := &cfg.Jobs[i]
 		workdir := job.Workdir
 		if workdir == "" {
 			workdir = "."
 		}
+		schedule := job.Schedule
+		if len(job.DependsOn) > 0 {
+			schedule = "after " + strings.Join(job.DependsOn, ", ")
+		}
+		enabled := "yes"
+		if !job.IsEnabled() {
+			enabled = "no"
+		}
 		fmt.Fprintf(
-			w, "%s\t%s\t%s\t%s\t%ds\n",
+			w, "%s\t%s\t%s\t%s\t%s\t%ds",
 			job.ID,
-			job.Schedule,
+			enabled,
+			schedule,
 			truncate(job.Command.String(), 40),
 			workdir,
 			job.TimeoutSec,
 		)
+		if next == 0 {
+			fmt.Fprintln(w)
+			continue
+		}
+
+		times, err := scheduler.NextRunTimes(job, loc, now, next)
+		if err != nil {
+			return fmt.Errorf("job %s: %w", job.ID, err)
+		}
+		if !job.IsEnabled() {
+			times = nil
+		}
+		if len(times) == 0 {
+			fmt.Fprintln(w, "\t-")
+		}
+		for j, t := range times {
+			if j > 0 {
+				fmt.Fprint(w, "\t\t\t\t\t")
+			}
+			fmt.Fprintf(w, "\t%s\n", t.Format("2006-01-02 15:04:05 MST"))
+		}
 	}
 
 	w.Flush()
-	fmt.Printf("\nTotal jobs: %d\n", len(cfg.Jobs))
+	fmt.Fprintf(out, "\nTotal jobs: %d\n", len(cfg.Jobs))
 
 	return nil
 }
 
+func runUpdateJob(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	interactive, _ := cmd.Flags().GetBool("interactive")
+	out := cmd.OutOrStdout()
+
+	var jobID string
+	if len(args) > 0 {
+		jobID = args[0]
+	}
+
+	var reader *bufio.Reader
+	if interactive {
+		reader = bufio.NewReader(cmd.InOrStdin())
+		if jobID == "" {
+			fmt.Fprint(out, "Job ID: ")
+			id, err := reader.ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("failed to get job details: %w", err)
+			}
+			jobID = strings.TrimSpace(id)
+		}
+	}
+	if jobID == "" {
+		return fmt.Errorf("job ID is required (or use --interactive flag)")
+	}
+
+	existing, err := config.GetJob(configPath, jobID)
+	if err != nil {
+		return err
+	}
+	job := *existing
+	job.Env = make(map[string]string, len(existing.Env))
+	for k, v := range existing.Env {
+		job.Env[k] = v
+	}
+
+	if interactive {
+		if err := promptForJobUpdate(reader, out, &job); err != nil {
+			return fmt.Errorf("failed to get job details: %w", err)
+		}
+	} else if err := applyJobFlags(cmd, &job); err != nil {
+		return err
+	}
+
+	// Validate schedule
+	if err := config.ValidateSchedule(job.Schedule); err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+
+	changes := jobChanges(existing, &job)
+	if len(changes) == 0 {
+		fmt.Fprintf(out, "No changes to job '%s'\n", jobID)
+		return nil
+	}
+
+	if err := config.UpdateJob(configPath, job); err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+
+	fmt.Fprintf(out, "✓ Job '%s' updated in %s\n", jobID, configPath)
+	for _, change := range changes {
+		fmt.Fprintf(out, "  %s\n", change)
+	}
+
+	return nil
+}
+
+// runSetJobEnabled returns the RunE of `job enable` or `job disable`.
+func runSetJobEnabled(enabled bool) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		configPath, _ := cmd.Flags().GetString("config")
+		jobID := args[0]
+		state := "disabled"
+		if enabled {
+			state = "enabled"
+		}
+
+		job, err := config.GetJob(configPath, jobID)
+		if err != nil {
+			return err
+		}
+		if job.IsEnabled() == enabled {
+			fmt.Fprintf(cmd.OutOrStdout(), "Job '%s' is already %s\n", jobID, state)
+			return nil
+		}
+
+		job.SetEnabled(enabled)
+		if err := config.UpdateJob(configPath, *job); err != nil {
+			return fmt.Errorf("failed to update job: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Job '%s' %s in %s\n", jobID, state, configPath)
+		return nil
+	}
+}
+
 func runRemoveJob(cmd *cobra.Command, args []string) error {
 	configPath, _ := cmd.Flags().GetString("config")
 	jobID := args[0]
@@ -255,7 +487,10 @@ func promptForJob() (config.Job, error) {
 	if err != nil {
 		return job, err
 	}
-	job.Command.Set(strings.TrimSpace(command))
+	job.Command, err = config.ParseCommandSpec(strings.TrimSpace(command))
+	if err != nil {
+		return job, fmt.Errorf("invalid command: %w", err)
+	}
 
 	// Working directory (optional)
 	fmt.Print("Working directory (optional, press Enter to skip): ")
@@ -330,6 +565,160 @@ func promptForJob() (config.Job, error) {
 	return job, nil
 }
 
+// applyJobFlags sets the fields of job whose update flags were given.
+func applyJobFlags(cmd *cobra.Command, job *config.Job) error {
+	flags := cmd.Flags()
+	if flags.Changed("schedule") {
+		job.Schedule, _ = flags.GetString("schedule")
+	}
+	if flags.Changed("command") {
+		command, _ := flags.GetString("command")
+		cmdSpec, err := config.ParseCommandSpec(command)
+		if err != nil {
+			return fmt.Errorf("invalid --command: %w", err)
+		}
+		job.Command = cmdSpec
+	}
+	if flags.Changed("workdir") {
+		job.Workdir, _ = flags.GetString("workdir")
+	}
+	if flags.Changed("timeout") {
+		timeout, _ := flags.GetInt("timeout")
+		if timeout <= 0 {
+			return fmt.Errorf("--timeout must be positive")
+		}
+		job.TimeoutSec = timeout
+	}
+	if flags.Changed("env") {
+		envVars, _ := flags.GetStringSlice("env")
+		env, err := parseEnvFlags(envVars)
+		if err != nil {
+			return err
+		}
+		for k, v := range env {
+			job.Env[k] = v
+		}
+	}
+	return nil
+}
+
+// parseEnvFlags parses KEY=VALUE --env values.
+func parseEnvFlags(envVars []string) (map[string]string, error) {
+	env := make(map[string]string)
+	for _, envVar := range envVars {
+		parts := strings.SplitN(envVar, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid environment variable format: %s (expected KEY=VALUE)", envVar)
+		}
+		env[parts[0]] = parts[1]
+	}
+	return env, nil
+}
+
+// promptForJobUpdate asks for each editable field of job, keeping the
+// current value when the answer is empty.
+func promptForJobUpdate(reader *bufio.Reader, out io.Writer, job *config.Job) error {
+	prompt := func(label, current string) (string, error) {
+		fmt.Fprintf(out, "%s [%s]: ", label, current)
+		answer, err := reader.ReadString('\n')
+		if err != nil && answer == "" {
+			return "", err
+		}
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			return current, nil
+		}
+		return answer, nil
+	}
+
+	schedule, err := prompt("Schedule", job.Schedule)
+	if err != nil {
+		return err
+	}
+	job.Schedule = schedule
+
+	command, err := prompt("Command", job.Command.String())
+	if err != nil {
+		return err
+	}
+	if command != job.Command.String() {
+		if job.Command, err = config.ParseCommandSpec(command); err != nil {
+			return fmt.Errorf("invalid command: %w", err)
+		}
+	}
+
+	workdir, err := prompt("Working directory", job.Workdir)
+	if err != nil {
+		return err
+	}
+	job.Workdir = workdir
+
+	timeoutStr, err := prompt("Timeout in seconds", strconv.Itoa(job.TimeoutSec))
+	if err != nil {
+		return err
+	}
+	timeout, err := strconv.Atoi(timeoutStr)
+	if err != nil || timeout <= 0 {
+		return fmt.Errorf("invalid timeout: %s", timeoutStr)
+	}
+	job.TimeoutSec = timeout
+
+	fmt.Fprintln(out, "Environment variables to set (KEY=VALUE, one per line, empty line to finish):")
+	for {
+		fmt.Fprint(out, "  ")
+		envVar, err := reader.ReadString('\n')
+		envVar = strings.TrimSpace(envVar)
+		if envVar == "" {
+			break
+		}
+		parts := strings.SplitN(envVar, "=", 2)
+		if len(parts) != 2 {
+			fmt.Fprintln(out, "  Invalid format, use KEY=VALUE")
+		} else {
+			job.Env[parts[0]] = parts[1]
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+// jobChanges describes, one line per field, how updated differs from old.
+func jobChanges(old, updated *config.Job) []string {
+	var changes []string
+	if old.Schedule != updated.Schedule {
+		changes = append(changes, fmt.Sprintf("Schedule: %s → %s", old.Schedule, updated.Schedule))
+	}
+	if old.Command.String() != updated.Command.String() {
+		changes = append(changes, fmt.Sprintf("Command:  %s → %s", old.Command.String(), updated.Command.String()))
+	}
+	if old.Workdir != updated.Workdir {
+		changes = append(changes, fmt.Sprintf("Workdir:  %q → %q", old.Workdir, updated.Workdir))
+	}
+	if old.TimeoutSec != updated.TimeoutSec {
+		changes = append(changes, fmt.Sprintf("Timeout:  %ds → %ds", old.TimeoutSec, updated.TimeoutSec))
+	}
+
+	keys := make([]string, 0, len(updated.Env))
+	for k := range updated.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		oldValue, ok := old.Env[k]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("Env:      %s=%s (added)", k, updated.Env[k]))
+		case oldValue != updated.Env[k]:
+			changes = append(changes, fmt.Sprintf("Env:      %s=%s → %s", k, oldValue, updated.Env[k]))
+		}
+	}
+
+	return changes
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runJobCmd executes "jobster job" with args and returns its output.
+func runJobCmd(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	rootCmd.SetIn(strings.NewReader(stdin))
+	return executeRootCmd(t, jobCmd.Commands(), append([]string{"job"}, args...)...)
+}
+
+func writeJobConfig(t *testing.T) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "jobster.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+defaults:
+  timezone: "UTC"
+
+jobs:
+  - id: "backup"
+    schedule: "@daily"
+    command: "/usr/bin/backup.sh"
+    timeout_sec: 120
+    env:
+      TARGET: "/srv"
+  - id: "report"
+    schedule: "@weekly"
+    command: "/usr/bin/report.sh"
+`), 0o644))
+	return configPath
+}
+
+func TestJobUpdateCommand(t *testing.T) {
+	configPath := writeJobConfig(t)
+
+	out, err := runJobCmd(t, "", "update", "backup", "--config", configPath,
+		"--schedule", "0 3 * * *", "--env", "LEVEL=9")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Schedule: @daily → 0 3 * * *")
+	assert.Contains(t, out, "LEVEL=9 (added)")
+	assert.NotContains(t, out, "Command:")
+
+	job, err := config.GetJob(configPath, "backup")
+	require.NoError(t, err)
+	assert.Equal(t, "0 3 * * *", job.Schedule)
+	assert.Equal(t, "/usr/bin/backup.sh", job.Command.String(), "unchanged fields are kept")
+	assert.Equal(t, 120, job.TimeoutSec)
+	assert.Equal(t, map[string]string{"TARGET": "/srv", "LEVEL": "9"}, job.Env)
+
+	other, err := config.GetJob(configPath, "report")
+	require.NoError(t, err)
+	assert.Equal(t, "@weekly", other.Schedule)
+}
+
+func TestJobUpdateCommand_Errors(t *testing.T) {
+	configPath := writeJobConfig(t)
+
+	_, err := runJobCmd(t, "", "update", "backup", "--config", configPath, "--schedule", "not a schedule")
+	assert.ErrorContains(t, err, "invalid schedule")
+
+	_, err = runJobCmd(t, "", "update", "missing", "--config", configPath, "--schedule", "@hourly")
+	assert.ErrorContains(t, err, "not found")
+
+	out, err := runJobCmd(t, "", "update", "backup", "--config", configPath)
+	require.NoError(t, err)
+	assert.Contains(t, out, "No changes to job 'backup'")
+
+	job, err := config.GetJob(configPath, "backup")
+	require.NoError(t, err)
+	assert.Equal(t, "@daily", job.Schedule)
+}
+
+func TestJobUpdateCommand_Interactive(t *testing.T) {
+	configPath := writeJobConfig(t)
+
+	// Keep the schedule and workdir, change the command and timeout
+	stdin := "\n/usr/bin/backup.sh --full\n\n300\nMODE=full\n\n"
+	out, err := runJobCmd(t, stdin, "update", "backup", "--config", configPath, "--interactive")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Schedule [@daily]:")
+	assert.Contains(t, out, "Timeout:  120s → 300s")
+
+	job, err := config.GetJob(configPath, "backup")
+	require.NoError(t, err)
+	assert.Equal(t, "@daily", job.Schedule)
+	assert.Equal(t, "/usr/bin/backup.sh --full", job.Command.String())
+	assert.Equal(t, 300, job.TimeoutSec)
+	assert.Equal(t, "full", job.Env["MODE"])
+}
+
+func TestJobEnableDisableCommands(t *testing.T) {
+	configPath := writeJobConfig(t)
+
+	out, err := runJobCmd(t, "", "disable", "backup", "--config", configPath)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Job 'backup' disabled")
+
+	job, err := config.GetJob(configPath, "backup")
+	require.NoError(t, err)
+	assert.False(t, job.IsEnabled())
+	assert.Equal(t, "@daily", job.Schedule, "other fields are kept")
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Regexp(t, `(?m)^\s+enabled: false`, string(data))
+
+	out, err = runJobCmd(t, "", "list", "--config", configPath, "--next", "1")
+	require.NoError(t, err)
+	lines := strings.Split(out, "\n")
+	require.GreaterOrEqual(t, len(lines), 4)
+	assert.Contains(t, lines[0], "ENABLED")
+	assert.Regexp(t, `^backup\s+no\s+@daily\s.*-$`, strings.TrimSpace(lines[2]))
+	assert.Regexp(t, `^report\s+yes\s+@weekly`, lines[3])
+
+	out, err = runJobCmd(t, "", "disable", "backup", "--config", configPath)
+	require.NoError(t, err)
+	assert.Contains(t, out, "already disabled")
+
+	_, err = runJobCmd(t, "", "enable", "backup", "--config", configPath)
+	require.NoError(t, err)
+	job, err = config.GetJob(configPath, "backup")
+	require.NoError(t, err)
+	assert.True(t, job.IsEnabled())
+	data, err = os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.NotRegexp(t, `(?m)^\s+enabled: false`, string(data))
+
+	_, err = runJobCmd(t, "", "enable", "missing", "--config", configPath)
+	assert.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/server"
+	"github.com/caevv/jobster/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_JobStats(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	sched := scheduler.New(context.Background(), runner.logger)
+	require.NoError(t, sched.AddJob(&config.Job{
+		ID:         "stats-job",
+		Schedule:   "@every 1h",
+		Command:    config.NewCommandSpec("echo hello"),
+		TimeoutSec: 5,
+	}, runner))
+
+	// Durations of 2s and 4s succeed, 6s fails
+	start := time.Now().Add(-time.Hour)
+	for i, d := range []time.Duration{2 * time.Second, 4 * time.Second, 6 * time.Second} {
+		begin := start.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, st.SaveRun(context.Background(), &store.JobRun{
+			RunID:     "run-" + d.String(),
+			JobID:     "stats-job",
+			StartTime: begin,
+			EndTime:   begin.Add(d),
+			Success:   i < 2,
+		}))
+	}
+
+	schedAdapter := server.NewSchedulerAdapter(sched)
+	schedAdapter.SetStore(st)
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), schedAdapter, "", nil)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	getJSON := func(path string, v any) {
+		t.Helper()
+		resp, err := http.Get(ts.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+
+	var stats server.JobStatsResponse
+	getJSON("/api/jobs/stats-job/stats", &stats)
+	assert.Equal(t, 3, stats.TotalRuns)
+	assert.Equal(t, 2, stats.SuccessCount)
+	assert.Equal(t, 1, stats.FailureCount)
+	assert.InDelta(t, 2.0/3.0, stats.SuccessRate, 1e-9)
+	assert.Equal(t, 4000.0, stats.AvgDurationMs)
+	require.NotNil(t, stats.LastRunTime)
+	assert.WithinDuration(t, start.Add(2*time.Minute), *stats.LastRunTime, time.Millisecond)
+
+	var jobs []server.JobSummary
+	getJSON("/api/jobs", &jobs)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 2, jobs[0].SuccessCount)
+	assert.Equal(t, 1, jobs[0].FailureCount)
+
+	var overall server.StatsResponse
+	getJSON("/api/stats", &overall)
+	assert.InDelta(t, 2.0/3.0, overall.SuccessRate, 1e-9)
+}
+
+func TestServe_JobSummaryFromHistory(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	sched := scheduler.New(context.Background(), runner.logger)
+	for _, id := range []string{"summary-job", "idle-job"} {
+		require.NoError(t, sched.AddJob(&config.Job{
+			ID:         id,
+			Schedule:   "@every 1h",
+			Command:    config.NewCommandSpec("echo hello"),
+			TimeoutSec: 5,
+		}, runner))
+	}
+
+	// The newest run failed; the scheduler itself has never run the job
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i, success := range []bool{true, true, false} {
+		begin := start.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, st.SaveRun(context.Background(), &store.JobRun{
+			RunID:     fmt.Sprintf("run-%d", i),
+			JobID:     "summary-job",
+			StartTime: begin,
+			EndTime:   begin.Add(time.Second),
+			Success:   success,
+		}))
+	}
+
+	schedAdapter := server.NewSchedulerAdapter(sched)
+	schedAdapter.SetStore(st)
+
+	summary, err := schedAdapter.GetJob(context.Background(), "summary-job")
+	require.NoError(t, err)
+	require.NotNil(t, summary.LastRunID)
+	assert.Equal(t, "run-2", *summary.LastRunID)
+	require.NotNil(t, summary.LastStatus)
+	assert.Equal(t, "failure", *summary.LastStatus)
+	require.NotNil(t, summary.LastRunTime)
+	assert.True(t, start.Add(2*time.Minute).Equal(*summary.LastRunTime))
+	assert.Equal(t, 2, summary.SuccessCount)
+	assert.Equal(t, 1, summary.FailureCount)
+
+	jobs, err := schedAdapter.GetJobs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	for _, job := range jobs {
+		if job.ID == "idle-job" {
+			assert.Nil(t, job.LastRunID)
+			assert.Nil(t, job.LastStatus)
+			assert.Zero(t, job.SuccessCount+job.FailureCount)
+		}
+	}
+}
+
+func TestServe_StatsActiveJobs(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	sched := scheduler.New(context.Background(), runner.logger)
+	for _, id := range []string{"busy-job", "other-job", "idle-job"} {
+		require.NoError(t, sched.AddJob(&config.Job{
+			ID:         id,
+			Schedule:   "@every 1h",
+			Command:    config.NewCommandSpec("echo hello"),
+			TimeoutSec: 5,
+		}, runner))
+	}
+
+	// busy-job has a scheduled and a manual run in progress, other-job one,
+	// and idle-job only a finished run
+	now := time.Now()
+	runs := []*store.JobRun{
+		{RunID: "busy-1", JobID: "busy-job", StartTime: now.Add(-time.Minute)},
+		{RunID: "busy-2", JobID: "busy-job", StartTime: now},
+		{RunID: "other-1", JobID: "other-job", StartTime: now},
+		{RunID: "idle-1", JobID: "idle-job", StartTime: now.Add(-time.Hour), EndTime: now.Add(-time.Hour + time.Second), Success: true},
+	}
+	for _, run := range runs {
+		require.NoError(t, st.SaveRun(context.Background(), run))
+	}
+
+	stats, err := server.NewStoreAdapter(st, sched).GetStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.TotalJobs)
+	assert.Equal(t, 4, stats.TotalRuns)
+	assert.Equal(t, 2, stats.ActiveJobs)
+
+	// Finishing other-job's run leaves only busy-job active
+	runs[2].EndTime = now.Add(time.Second)
+	require.NoError(t, st.SaveRun(context.Background(), runs[2]))
+	stats, err = server.NewStoreAdapter(st, sched).GetStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.ActiveJobs)
+}
+
+func TestServe_SchedulerStats(t *testing.T) {
+	dir := t.TempDir()
+	runner, _ := newTestRunner(t, dir, config.Defaults{})
+
+	sched := scheduler.New(context.Background(), runner.logger)
+	require.NoError(t, sched.AddJob(&config.Job{
+		ID:         "sched-stats-job",
+		Schedule:   "@every 1h",
+		Command:    config.NewCommandSpec("echo hello"),
+		TimeoutSec: 5,
+	}, runner))
+	require.NoError(t, sched.Start())
+	defer sched.Stop()
+
+	srv := server.New(":0", nil, server.NewSchedulerAdapter(sched), "", nil)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	get := func(path string) (int, scheduler.JobStats) {
+		t.Helper()
+		resp, err := http.Get(ts.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var body scheduler.JobStats
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		}
+		return resp.StatusCode, body
+	}
+
+	status, stats := get("/api/jobs/sched-stats-job/scheduler-stats")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "sched-stats-job", stats.JobID)
+	assert.Zero(t, stats.RunCount)
+	assert.True(t, stats.LastRun.IsZero())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), stats.NextRun, time.Minute)
+
+	before := time.Now()
+	for range 2 {
+		resp, err := http.Post(ts.URL+"/api/jobs/sched-stats-job/run", "", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	}
+
+	require.Eventually(t, func() bool {
+		_, stats = get("/api/jobs/sched-stats-job/scheduler-stats")
+		return stats.RunCount == 2
+	}, 5*time.Second, 10*time.Millisecond, "run count after two triggered runs")
+	assert.False(t, stats.LastRun.Before(before), "LastRun = %v, want after %v", stats.LastRun, before)
+
+	status, _ = get("/api/jobs/missing/scheduler-stats")
+	assert.Equal(t, http.StatusNotFound, status)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/caevv/jobster/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// labelSelectorFlag parses the command's repeatable --label key=value flag.
+func labelSelectorFlag(cmd *cobra.Command) (config.LabelSelector, error) {
+	terms, _ := cmd.Flags().GetStringArray("label")
+	return config.ParseLabelSelector(terms)
+}
+
+// selectJobs returns the jobs whose labels match selector, in order. An
+// empty selector returns jobs unchanged.
+func selectJobs(jobs []config.Job, selector config.LabelSelector) []config.Job {
+	if len(selector) == 0 {
+		return jobs
+	}
+	var selected []config.Job
+	for _, job := range jobs {
+		if selector.Matches(job.Labels) {
+			selected = append(selected, job)
+		}
+	}
+	return selected
+}
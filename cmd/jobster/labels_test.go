@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func labeledJobs() []config.Job {
+	return []config.Job{
+		{ID: "charge", Schedule: "@hourly", Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5,
+			Labels: map[string]string{"team": "payments", "tier": "critical"}},
+		{ID: "refund", Schedule: "@daily", Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5,
+			Labels: map[string]string{"team": "payments"}},
+		{ID: "reindex", Schedule: "@daily", Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5,
+			Labels: map[string]string{"team": "search", "tier": "critical"}},
+		{ID: "cleanup", Schedule: "@weekly", Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+	}
+}
+
+func jobIDs(jobs []config.Job) []string {
+	ids := make([]string, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+	return ids
+}
+
+func TestSelectJobs(t *testing.T) {
+	jobs := labeledJobs()
+
+	assert.Equal(t, jobIDs(jobs), jobIDs(selectJobs(jobs, nil)))
+	assert.Equal(t, []string{"charge", "refund"},
+		jobIDs(selectJobs(jobs, config.LabelSelector{"team": "payments"})))
+	assert.Equal(t, []string{"charge"},
+		jobIDs(selectJobs(jobs, config.LabelSelector{"team": "payments", "tier": "critical"})))
+	assert.Empty(t, selectJobs(jobs, config.LabelSelector{"team": "growth"}))
+}
+
+func TestRunJobsOnce_UnselectedUpstream(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	// The upstream job was filtered out by --label
+	jobs := []config.Job{
+		{ID: "after", DependsOn: []string{"upstream"}, Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+	}
+
+	results := runJobsOnce(context.Background(), runner, st, jobs, 1)
+	require.Len(t, results, 1)
+	assert.Nil(t, results[0].Run)
+	assert.Equal(t, "no upstream job succeeded", results[0].Skipped)
+}
+
+func TestServe_ListJobsByLabel(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	sched := scheduler.New(context.Background(), runner.logger)
+	jobs := labeledJobs()
+	for i := range jobs {
+		require.NoError(t, sched.AddJob(&jobs[i], runner))
+	}
+
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), server.NewSchedulerAdapter(sched), "", runner.logger)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	list := func(labels ...string) (int, []string) {
+		t.Helper()
+		query := url.Values{"label": labels}
+		resp, err := http.Get(ts.URL + "/api/jobs?" + query.Encode())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, nil
+		}
+		var summaries []server.JobSummary
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&summaries))
+		var ids []string
+		for _, s := range summaries {
+			ids = append(ids, s.ID)
+		}
+		return resp.StatusCode, ids
+	}
+
+	_, ids := list()
+	assert.ElementsMatch(t, []string{"charge", "refund", "reindex", "cleanup"}, ids)
+
+	_, ids = list("team=payments")
+	assert.ElementsMatch(t, []string{"charge", "refund"}, ids)
+
+	_, ids = list("team=payments", "tier=critical")
+	assert.Equal(t, []string{"charge"}, ids)
+
+	_, ids = list("team=growth")
+	assert.Empty(t, ids)
+
+	status, _ := list("team")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	// Labels are part of the summary
+	resp, err := http.Get(ts.URL + "/api/jobs/charge")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var summary server.JobSummary
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&summary))
+	assert.Equal(t, map[string]string{"team": "payments", "tier": "critical"}, summary.Labels)
+}
+
+func TestJobListCommand_Label(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "jobster.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+jobs:
+  - id: "charge"
+    schedule: "@hourly"
+    command: "/bin/charge"
+    labels:
+      team: payments
+  - id: "reindex"
+    schedule: "@daily"
+    command: "/bin/reindex"
+    labels:
+      team: search
+`), 0o644))
+
+	out, err := runJobCmd(t, "", "list", "--config", configPath, "--label", "team=payments")
+	require.NoError(t, err)
+	assert.Contains(t, out, "charge")
+	assert.NotContains(t, out, "reindex")
+	assert.Contains(t, out, "Total jobs: 1")
+
+	out, err = runJobCmd(t, "", "list", "--config", configPath, "--label", "team=growth")
+	require.NoError(t, err)
+	assert.Contains(t, out, "No jobs match labels team=growth")
+
+	_, err = runJobCmd(t, "", "list", "--config", configPath, "--label", "team")
+	assert.ErrorContains(t, err, "want key=value")
+}
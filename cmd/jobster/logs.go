@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs <run-id>",
+	Short: "Print the output of a run",
+	Long: `Print the full stdout and stderr logs saved for a run.
+
+The run is looked up in the configured store to find its job, and the logs
+are read from ~/.jobster/history. The run's stdout log is written to stdout
+and its stderr log to stderr. If the full log files are gone, the output
+tail kept in run history is shown instead.
+
+Examples:
+  jobster logs 3f2a9c1e-... --config jobster.yaml
+  jobster logs 3f2a9c1e-... --config jobster.yaml --stderr
+  jobster logs 3f2a9c1e-... --config jobster.yaml --follow`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLogs,
+}
+
+func init() {
+	logsCmd.Flags().StringP("config", "c", "jobster.yaml", "Path to configuration file")
+	logsCmd.Flags().Bool("stdout", false, "Print only the stdout log")
+	logsCmd.Flags().Bool("stderr", false, "Print only the stderr log")
+	logsCmd.Flags().BoolP("follow", "f", false, "Keep printing new output until the run finishes")
+	logsCmd.MarkFlagRequired("config")
+	logsCmd.MarkFlagsMutuallyExclusive("stdout", "stderr")
+}
+
+// logFollowInterval is how often --follow checks for new output.
+const logFollowInterval = 500 * time.Millisecond
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	onlyStdout, _ := cmd.Flags().GetBool("stdout")
+	onlyStderr, _ := cmd.Flags().GetBool("stderr")
+	follow, _ := cmd.Flags().GetBool("follow")
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+	defer st.Close()
+
+	var streams []*logStream
+	if !onlyStderr {
+		streams = append(streams, &logStream{name: "stdout", w: os.Stdout})
+	}
+	if !onlyStdout {
+		streams = append(streams, &logStream{name: "stderr", w: os.Stderr})
+	}
+
+	return printRunLogs(setupSignalHandler(), st, defaultHistoryDir(), args[0], streams, follow, logFollowInterval)
+}
+
+// logStream is one of a run's output logs and where to copy it.
+type logStream struct {
+	name   string // "stdout" or "stderr"
+	w      io.Writer
+	offset int64 // bytes of the log file already copied
+	found  bool  // the log file has been seen
+}
+
+// printRunLogs copies the saved logs of runID to their writers. With follow
+// set it keeps polling the files every interval until the run has finished or
+// ctx is cancelled. A stream whose log file does not exist falls back to the
+// output tail stored with the run.
+func printRunLogs(ctx context.Context, st store.Store, historyDir, runID string, streams []*logStream, follow bool, interval time.Duration) error {
+	run, err := st.GetRun(ctx, runID)
+	if errors.Is(err, store.ErrRunNotFound) {
+		return fmt.Errorf("run %s not found", runID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get run: %w", err)
+	}
+
+	for {
+		// Check for completion before copying, so output written just before
+		// the run finished is not missed
+		finished := !run.IsRunning()
+
+		for _, s := range streams {
+			if err := s.copyNew(runLogPath(historyDir, run.JobID, run.RunID, s.name)); err != nil {
+				return err
+			}
+		}
+
+		if finished || !follow {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+
+		if run, err = st.GetRun(ctx, runID); err != nil {
+			return fmt.Errorf("failed to get run: %w", err)
+		}
+	}
+
+	if run.IsRunning() {
+		fmt.Fprintf(os.Stderr, "run %s is still in progress; use --follow to wait for its output\n", runID)
+		return nil
+	}
+
+	for _, s := range streams {
+		if s.found {
+			continue
+		}
+		tail := run.StdoutTail
+		if s.name == "stderr" {
+			tail = run.StderrTail
+		}
+		if tail != "" {
+			fmt.Fprintf(os.Stderr, "full %s log not found; showing the tail kept in run history\n", s.name)
+			io.WriteString(s.w, tail)
+		}
+	}
+	return nil
+}
+
+// copyNew writes whatever was appended to the log at path since the last
+// call. A missing file is not an error: the run may have produced no output
+// or not have saved it yet.
+func (s *logStream) copyNew(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open %s log: %w", s.name, err)
+	}
+	defer f.Close()
+	s.found = true
+
+	if _, err := f.Seek(s.offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read %s log: %w", s.name, err)
+	}
+	n, err := io.Copy(s.w, f)
+	s.offset += n
+	if err != nil {
+		return fmt.Errorf("failed to read %s log: %w", s.name, err)
+	}
+	return nil
+}
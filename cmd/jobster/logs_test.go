@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/server"
+	"github.com/caevv/jobster/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_RunLogs(t *testing.T) {
+	dir := t.TempDir()
+	_, st := newTestRunner(t, dir, config.Defaults{})
+
+	run := &store.JobRun{
+		RunID:     "run-1",
+		JobID:     "log-job",
+		StartTime: time.Now().Add(-time.Second),
+		EndTime:   time.Now(),
+		Success:   true,
+	}
+	require.NoError(t, st.SaveRun(context.Background(), run))
+
+	// Write a fake log where the runner would have saved it
+	historyDir := filepath.Join(dir, "history")
+	require.NoError(t, os.MkdirAll(filepath.Join(historyDir, "log-job"), 0o755))
+	full := "line 1\nline 2\nline 3\nline 4\nline 5\n"
+	require.NoError(t, os.WriteFile(filepath.Join(historyDir, "log-job", "run-1.stdout.log"), []byte(full), 0o644))
+
+	// A log larger than one tail read chunk, without a trailing newline
+	var big strings.Builder
+	for i := 1; i <= 20000; i++ {
+		fmt.Fprintf(&big, "stderr line %d\n", i)
+	}
+	bigLog := strings.TrimSuffix(big.String(), "\n")
+	require.NoError(t, os.WriteFile(filepath.Join(historyDir, "log-job", "run-1.stderr.log"), []byte(bigLog), 0o644))
+
+	sched := scheduler.New(context.Background(), nil)
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), nil, historyDir, nil)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	get := func(path string) (int, string) {
+		t.Helper()
+		resp, err := http.Get(ts.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := get("/api/runs/run-1/logs")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, full, body)
+
+	status, body = get("/api/runs/run-1/logs?stream=stdout&tail=2")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "line 4\nline 5\n", body)
+
+	status, body = get("/api/runs/run-1/logs?tail=100")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, full, body)
+
+	status, body = get("/api/runs/run-1/logs?stream=stderr&tail=3")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "stderr line 19998\nstderr line 19999\nstderr line 20000", body)
+
+	status, body = get("/api/runs/run-1/logs?stream=stderr&tail=5000")
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, strings.HasPrefix(body, "stderr line 15001\n"), "tail spanning several read chunks")
+	assert.Equal(t, 4999, strings.Count(body, "\n"))
+
+	require.NoError(t, os.Remove(filepath.Join(historyDir, "log-job", "run-1.stderr.log")))
+	status, _ = get("/api/runs/run-1/logs?stream=stderr")
+	assert.Equal(t, http.StatusNotFound, status, "no stderr log on disk")
+
+	status, _ = get("/api/runs/missing/logs")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = get("/api/runs/run-1/logs?stream=both")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = get("/api/runs/run-1/logs?tail=-1")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestServe_RunLogsOutlastWriteTimeout(t *testing.T) {
+	dir := t.TempDir()
+	_, st := newTestRunner(t, dir, config.Defaults{})
+	require.NoError(t, st.SaveRun(context.Background(), &store.JobRun{
+		RunID:     "big-run",
+		JobID:     "log-job",
+		StartTime: time.Now().Add(-time.Second),
+		EndTime:   time.Now(),
+		Success:   true,
+	}))
+
+	// Far more than the socket buffers hold, so the server keeps writing for
+	// as long as the client takes to read
+	historyDir := filepath.Join(dir, "history")
+	require.NoError(t, os.MkdirAll(filepath.Join(historyDir, "log-job"), 0o755))
+	var big strings.Builder
+	for i := 0; big.Len() < 32<<20; i++ {
+		fmt.Fprintf(&big, "output line %d of a long-running job\n", i)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(historyDir, "log-job", "big-run.stdout.log"), []byte(big.String()), 0o644))
+
+	sched := scheduler.New(context.Background(), nil)
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), nil, historyDir, nil)
+
+	// Stand in for the server's 15s WriteTimeout with a short one, and read
+	// slower than it allows for the whole body
+	const serverWriteTimeout = 300 * time.Millisecond
+	ts := httptest.NewUnstartedServer(srv.Handler())
+	ts.Config.WriteTimeout = serverWriteTimeout
+	ts.Start()
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/api/runs/big-run/logs", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "identity") // no gzip, so all 32 MiB go over the wire
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	start := time.Now()
+	var body strings.Builder
+	buf := make([]byte, 256<<10)
+	for {
+		n, err := resp.Body.Read(buf)
+		body.Write(buf[:n])
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	assert.Greater(t, time.Since(start), 2*serverWriteTimeout)
+	assert.Equal(t, big.Len(), body.Len(), "download was truncated")
+	assert.True(t, body.String() == big.String(), "download content differs")
+}
+
+func TestPrintRunLogs(t *testing.T) {
+	dir := t.TempDir()
+	_, st := newTestRunner(t, dir, config.Defaults{})
+	ctx := context.Background()
+	historyDir := filepath.Join(dir, "history")
+
+	saved := &store.JobRun{
+		RunID: "saved", JobID: "job", StartTime: time.Now(), EndTime: time.Now(),
+		StdoutTail: "tail\n",
+	}
+	cleaned := &store.JobRun{
+		RunID: "cleaned", JobID: "job", StartTime: time.Now(), EndTime: time.Now(),
+		StdoutTail: "kept tail\n",
+	}
+	require.NoError(t, st.SaveRun(ctx, saved))
+	require.NoError(t, st.SaveRun(ctx, cleaned))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(historyDir, "job"), 0o755))
+	require.NoError(t, os.WriteFile(runLogPath(historyDir, "job", "saved", "stdout"), []byte("full out\n"), 0o644))
+	require.NoError(t, os.WriteFile(runLogPath(historyDir, "job", "saved", "stderr"), []byte("full err\n"), 0o644))
+
+	var stdout, stderr strings.Builder
+	streams := []*logStream{{name: "stdout", w: &stdout}, {name: "stderr", w: &stderr}}
+	require.NoError(t, printRunLogs(ctx, st, historyDir, "saved", streams, false, time.Millisecond))
+	assert.Equal(t, "full out\n", stdout.String())
+	assert.Equal(t, "full err\n", stderr.String())
+
+	stdout.Reset()
+	streams = []*logStream{{name: "stdout", w: &stdout}}
+	require.NoError(t, printRunLogs(ctx, st, historyDir, "cleaned", streams, false, time.Millisecond))
+	assert.Equal(t, "kept tail\n", stdout.String(), "falls back to the stored tail")
+
+	err := printRunLogs(ctx, st, historyDir, "missing", streams, false, time.Millisecond)
+	assert.ErrorContains(t, err, "run missing not found")
+}
+
+func TestPrintRunLogs_Follow(t *testing.T) {
+	dir := t.TempDir()
+	_, st := newTestRunner(t, dir, config.Defaults{})
+	ctx := context.Background()
+	historyDir := filepath.Join(dir, "history")
+
+	run := &store.JobRun{RunID: "live", JobID: "job", StartTime: time.Now()}
+	require.NoError(t, st.SaveRun(ctx, run))
+	logPath := runLogPath(historyDir, "job", "live", "stdout")
+	require.NoError(t, os.MkdirAll(filepath.Dir(logPath), 0o755))
+
+	// Append output over a few polls, then finish the run
+	go func() {
+		f, err := os.Create(logPath)
+		if err != nil {
+			return
+		}
+		defer f.Close()
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(f, "line %d\n", i)
+			time.Sleep(20 * time.Millisecond)
+		}
+		run.EndTime = time.Now()
+		run.Success = true
+		st.SaveRun(ctx, run)
+	}()
+
+	var stdout strings.Builder
+	streams := []*logStream{{name: "stdout", w: &stdout}}
+	require.NoError(t, printRunLogs(ctx, st, historyDir, "live", streams, true, 5*time.Millisecond))
+	assert.Equal(t, "line 1\nline 2\nline 3\n", stdout.String())
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -11,9 +12,63 @@ import (
 	_ "time/tzdata" // embed the IANA tz database so configured timezones resolve on any host
 
 	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/eventsink"
+	"github.com/caevv/jobster/internal/logging"
+	"github.com/caevv/jobster/internal/plugins"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/store"
+	"github.com/caevv/jobster/internal/telemetry"
 	"github.com/spf13/cobra"
 )
 
+// loggingOptions returns the logger settings from cfg's logging section
+// beyond format, level and output. Shared by the run, serve, and tui commands.
+func loggingOptions(cfg *config.Config) logging.Options {
+	return logging.Options{
+		RedactPatterns: cfg.Logging.RedactPatterns,
+		MaxSizeMB:      cfg.Logging.MaxSizeMB,
+		MaxBackups:     cfg.Logging.MaxBackups,
+		MaxAgeDays:     cfg.Logging.MaxAgeDays,
+	}
+}
+
+// newRunner creates the job runner with the output redaction and per-job
+// log settings from cfg's logging section, and the event sink and audit
+// log, if configured.
+func newRunner(cfg *config.Config, st store.Store, pluginMgr *plugins.AgentExecutor) (*Runner, error) {
+	runner := NewRunner(st, pluginMgr, cfg.Defaults, logger)
+
+	valueRedactor, err := logging.NewValueRedactor(cfg.Logging.RedactValues)
+	if err != nil {
+		return nil, fmt.Errorf("logging.redact_values: %w", err)
+	}
+	runner.SetValueRedactor(valueRedactor)
+
+	if cfg.Logging.PerJobDir != "" {
+		jobLoggers, err := logging.NewJobLoggers(cfg.Logging.PerJobDir, cfg.Logging.Format, cfg.Logging.Level, loggingOptions(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize per-job logs: %w", err)
+		}
+		runner.SetJobLoggers(jobLoggers)
+	}
+
+	sink, err := eventsink.New(cfg.Events)
+	if err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+	runner.SetEventSink(sink)
+
+	if cfg.Audit.Path != "" {
+		audit, err := logging.NewAuditLog(cfg.Audit.Path)
+		if err != nil {
+			return nil, err
+		}
+		runner.SetAuditLog(audit)
+	}
+
+	return runner, nil
+}
+
 // resolveLocation resolves the configured timezone into a *time.Location for the
 // scheduler. Shared by the run, serve, and tui commands.
 func resolveLocation(cfg *config.Config) (*time.Location, error) {
@@ -24,6 +79,89 @@ func resolveLocation(cfg *config.Config) (*time.Location, error) {
 	return loc, nil
 }
 
+// openStore opens the run history store described by the config. Shared by
+// the run, serve, and tui commands.
+func openStore(cfg *config.Config) (store.Store, error) {
+	var opts []store.Option
+	if cfg.Store.FlushInterval != "" {
+		// Validated at config load
+		d, _ := time.ParseDuration(cfg.Store.FlushInterval)
+		opts = append(opts, store.WithJSONFlushInterval(d))
+	}
+	return store.NewStore(cfg.Store.Driver, cfg.Store.Path, opts...)
+}
+
+// newPluginManager creates the agent executor, discovers agents in the
+// configured search paths, and applies the config's security settings.
+// Shared by the run, serve, tui, and trigger commands.
+func newPluginManager(cfg *config.Config, logger *slog.Logger) (*plugins.AgentExecutor, error) {
+	for _, dir := range cfg.AgentsPaths {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			logger.Warn("agents path is not a directory", "path", dir)
+		}
+	}
+
+	pluginMgr := plugins.New(logger)
+	if err := pluginMgr.Discover(cfg.AgentsPaths); err != nil {
+		return nil, err
+	}
+	pluginMgr.SetChecksums(cfg.Security.AgentChecksums)
+	return pluginMgr, nil
+}
+
+// lastRunLookup adapts the run history store to the scheduler's catch-up lookup.
+func lastRunLookup(st store.Store) scheduler.LastRunFunc {
+	return func(jobID string) (time.Time, bool, error) {
+		runs, err := st.GetJobRuns(context.Background(), jobID, 1)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		if len(runs) == 0 {
+			return time.Time{}, false, nil
+		}
+		return runs[0].StartTime, true, nil
+	}
+}
+
+// tracingFlushTimeout bounds how long exiting waits to export pending spans.
+const tracingFlushTimeout = 5 * time.Second
+
+// setupTracing starts exporting trace spans when telemetry.otlp_endpoint is
+// set. The returned function flushes pending spans; defer it.
+func setupTracing(cfg *config.Config) (func(), error) {
+	shutdown, err := telemetry.Setup(context.Background(), cfg.Telemetry, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+	if cfg.Telemetry.OTLPEndpoint != "" {
+		logger.Info("tracing enabled", "otlp_endpoint", cfg.Telemetry.OTLPEndpoint)
+	}
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			logger.Error("failed to flush trace spans", "error", err)
+		}
+	}, nil
+}
+
+// schedulerOptions returns the scheduler options every command that runs
+// jobs derives from the config: timezone, catch-up lookup, the concurrency
+// limit, and the default jitter.
+func schedulerOptions(cfg *config.Config, loc *time.Location, st store.Store) []scheduler.Option {
+	// Validated at config load, so the error is always nil here.
+	jitter, _ := config.ParseJitter(cfg.Defaults.Jitter)
+	cooldown, _ := config.ParseCircuitCooldown(cfg.Defaults.CircuitCooldown)
+	return []scheduler.Option{
+		scheduler.WithLocation(loc),
+		scheduler.WithLastRunLookup(lastRunLookup(st)),
+		scheduler.WithMaxConcurrentJobs(cfg.Defaults.MaxConcurrentJobs, cfg.Defaults.ConcurrencyPolicy),
+		scheduler.WithDefaultJitter(jitter),
+		scheduler.WithCircuitBreaker(cfg.Defaults.FailureThreshold, cooldown),
+		scheduler.WithShutdownGracePeriod(time.Duration(cfg.Defaults.ShutdownTimeoutSec) * time.Second),
+	}
+}
+
 var (
 	// Version information (set via ldflags at build time)
 	version   = "dev"
@@ -44,11 +182,25 @@ func main() {
 
 	// Execute root command
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		logger.Error("command failed", "error", err)
 		os.Exit(1)
 	}
 }
 
+// exitCodeError makes main exit with code without logging an error. Commands
+// that forward a job's exit status return it after reporting the failure.
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "jobster",
 	Short: "A lightweight, plugin-based cron job runner",
@@ -87,6 +239,14 @@ func init() {
 	rootCmd.AddCommand(tuiCmd)
 	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(jobCmd)
+	rootCmd.AddCommand(triggerCmd)
+	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(storeCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(scheduleCmd)
+	rootCmd.AddCommand(agentCmd)
 }
 
 // setupSignalHandler creates a context that cancels on SIGINT or SIGTERM
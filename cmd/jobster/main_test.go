@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeRootCmd runs rootCmd with args and returns what it wrote to stdout;
+// stderr and the package logger are discarded. The flags of rootCmd and of
+// cmds, the subcommands under test, are reset before and after, so no test
+// sees flags another left set.
+func executeRootCmd(t *testing.T, cmds []*cobra.Command, args ...string) (string, error) {
+	t.Helper()
+	reset := func() {
+		resetFlags(rootCmd)
+		for _, cmd := range cmds {
+			resetFlags(cmd)
+		}
+	}
+	reset()
+
+	prevLogger := logger
+	logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	var out bytes.Buffer
+	rootCmd.SetArgs(args)
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(io.Discard)
+	t.Cleanup(func() {
+		logger = prevLogger
+		rootCmd.SetArgs(nil)
+		rootCmd.SetIn(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		reset()
+	})
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+// resetFlags restores cmd's flags to their defaults and clears Changed, so a
+// later Execute in the same test binary doesn't see them as given.
+func resetFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			sv.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+func TestNewPluginManager_AgentsPaths(t *testing.T) {
+	dir := t.TempDir()
+	agentsDir := filepath.Join(dir, "custom-agents")
+	require.NoError(t, os.Mkdir(agentsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(agentsDir, "notify.sh"), []byte("#!/bin/sh\nexit 0\n"), 0o755))
+
+	configPath := filepath.Join(dir, "jobster.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+agents_paths:
+  - "`+agentsDir+`"
+  - "`+filepath.Join(dir, "missing")+`"
+
+jobs:
+  - id: "notify-job"
+    schedule: "@daily"
+    command: "/bin/true"
+    hooks:
+      on_success:
+        - agent: "notify.sh"
+`), 0o644))
+
+	cfg, err := config.LoadConfig(configPath)
+	require.NoError(t, err)
+
+	pluginMgr, err := newPluginManager(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(agentsDir, "notify.sh"), pluginMgr.GetAgents()["notify.sh"])
+	assert.NoError(t, pluginMgr.ValidateAgent("notify.sh", nil))
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/metrics"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_MetricsEndpoint(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	m := metrics.New()
+	runner.SetMetrics(m)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sched := scheduler.New(ctx, runner.logger, scheduler.WithJobCountObserver(m.SetSchedulerJobs))
+	job := &config.Job{
+		ID:         "metrics-job",
+		Schedule:   "@every 1h",
+		Command:    config.NewCommandSpec("echo hello"),
+		TimeoutSec: 5,
+	}
+	require.NoError(t, sched.AddJob(job, runner))
+	require.NoError(t, runner.RunJob(ctx, job))
+
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), server.NewSchedulerAdapter(sched), "", runner.logger)
+	srv.SetMetricsHandler(m.Handler())
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	text := string(body)
+
+	assert.Contains(t, text, `jobster_runs_total{job="metrics-job",status="success"} 1`)
+	assert.Contains(t, text, `jobster_run_duration_seconds_count{job="metrics-job"} 1`)
+	assert.Contains(t, text, `jobster_job_last_success_timestamp{job="metrics-job"}`)
+	assert.Contains(t, text, `jobster_scheduler_jobs 1`)
+}
+
+func TestServe_MetricsDisabled(t *testing.T) {
+	srv := server.New(":0", nil, nil, "", nil)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/store"
+)
+
+// onceResult is the outcome of one job under `jobster run --once`.
+type onceResult struct {
+	JobID   string
+	Run     *store.JobRun // nil if the job did not run
+	Skipped string        // why the job did not run
+	Err     error         // the run's record could not be read
+}
+
+// failed reports whether the job counts against the exit code: it ran and
+// failed, or it was cut short by shutdown.
+func (r onceResult) failed() bool {
+	if r.Run != nil {
+		return !r.Run.Success
+	}
+	return r.Err != nil || r.Skipped == onceInterrupted
+}
+
+// onceInterrupted is the skip reason of jobs not started before shutdown.
+const onceInterrupted = "interrupted"
+
+// runJobsOnce runs every enabled job once through runner, at most parallel
+// at a time, and returns their results in config order. A job with
+// depends_on waits for its upstream jobs and runs only if one of them
+// succeeded, as it would under the scheduler. Disabled jobs are skipped,
+// restart policies are ignored, and once ctx is cancelled no further jobs
+// start.
+func runJobsOnce(ctx context.Context, runner *Runner, st store.Store, jobs []config.Job, parallel int) []onceResult {
+	results := make([]onceResult, len(jobs))
+	index := make(map[string]int, len(jobs))
+	done := make([]chan struct{}, len(jobs))
+	for i, job := range jobs {
+		index[job.ID] = i
+		done[i] = make(chan struct{})
+		results[i].JobID = job.ID
+	}
+
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i := range jobs {
+		wg.Add(1)
+		go func(job *config.Job, res *onceResult) {
+			defer wg.Done()
+			defer close(done[index[job.ID]])
+
+			if !job.IsEnabled() {
+				res.Skipped = "disabled"
+				return
+			}
+			if len(job.DependsOn) > 0 {
+				upstreamOK := false
+				for _, dep := range job.DependsOn {
+					j, ok := index[dep]
+					if !ok {
+						// Not selected for this run
+						continue
+					}
+					<-done[j]
+					if up := results[j]; up.Run != nil && up.Run.Success {
+						upstreamOK = true
+					}
+				}
+				if !upstreamOK {
+					res.Skipped = "no upstream job succeeded"
+					return
+				}
+			}
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				res.Skipped = onceInterrupted
+				return
+			}
+			if ctx.Err() != nil {
+				res.Skipped = onceInterrupted
+				return
+			}
+
+			// Each job runs exactly once, so restart policies do not apply
+			oneShot := *job
+			oneShot.Restart = ""
+
+			runID := scheduler.GenerateRunID()
+			// Failures are recorded on the run, which is read back below
+			_ = runner.RunJob(scheduler.ContextWithRunID(ctx, runID), &oneShot)
+			run, err := st.GetRun(context.Background(), runID)
+			if err != nil {
+				res.Err = fmt.Errorf("failed to read run result: %w", err)
+				return
+			}
+			res.Run = run
+		}(&jobs[i], &results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+// printOnceSummary writes one line per job of a --once pass to w and
+// returns how many jobs failed.
+func printOnceSummary(w io.Writer, results []onceResult) int {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "JOB\tSTATUS\tEXIT\tDURATION\tRUN ID")
+	failed := 0
+	for _, res := range results {
+		if res.failed() {
+			failed++
+		}
+		switch {
+		case res.Run != nil:
+			status := "success"
+			if res.Run.TimedOut() {
+				status = store.StatusTimeout
+			} else if missing, _ := res.Run.MissingPath(); missing != "" {
+				status = missing
+			} else if !res.Run.Success {
+				status = "failed"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", res.JobID, status, res.Run.ExitCode,
+				res.Run.Duration().Round(time.Millisecond), res.Run.RunID)
+		case res.Err != nil:
+			fmt.Fprintf(tw, "%s\terror: %v\t-\t-\t-\n", res.JobID, res.Err)
+		default:
+			fmt.Fprintf(tw, "%s\tskipped (%s)\t-\t-\t-\n", res.JobID, res.Skipped)
+		}
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\n%d job(s), %d failed\n", len(results), failed)
+	return failed
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunJobsOnce(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	disabled := false
+
+	jobs := []config.Job{
+		{ID: "pass", Schedule: "@daily", Command: config.NewCommandSpec("/bin/echo ok"), TimeoutSec: 5},
+		{ID: "fail", Schedule: "@daily", Command: config.NewCommandSpec("exit 2"), Shell: true, TimeoutSec: 5},
+		{ID: "after-pass", DependsOn: []string{"pass"}, Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+		{ID: "after-fail", DependsOn: []string{"fail"}, Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+		{ID: "off", Schedule: "@daily", Command: config.NewCommandSpec("/bin/true"), Enabled: &disabled},
+	}
+
+	results := runJobsOnce(context.Background(), runner, st, jobs, 2)
+	require.Len(t, results, 5)
+
+	require.NotNil(t, results[0].Run)
+	assert.True(t, results[0].Run.Success)
+	require.NotNil(t, results[1].Run)
+	assert.False(t, results[1].Run.Success)
+	assert.Equal(t, 2, results[1].Run.ExitCode)
+	require.NotNil(t, results[2].Run, "dependent of a passing job runs")
+	assert.True(t, results[2].Run.Success)
+	assert.Nil(t, results[3].Run)
+	assert.Equal(t, "no upstream job succeeded", results[3].Skipped)
+	assert.Equal(t, "disabled", results[4].Skipped)
+
+	var out bytes.Buffer
+	assert.Equal(t, 1, printOnceSummary(&out, results), "only the job that ran and failed counts")
+	assert.Contains(t, out.String(), "5 job(s), 1 failed")
+	assert.Contains(t, out.String(), "skipped (disabled)")
+}
+
+func TestRunJobsOnce_Parallel(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	var jobs []config.Job
+	for _, id := range []string{"a", "b", "c"} {
+		jobs = append(jobs, config.Job{ID: id, Schedule: "@daily", Command: config.NewCommandSpec("/bin/sleep 0.5"), TimeoutSec: 5})
+	}
+
+	start := time.Now()
+	results := runJobsOnce(context.Background(), runner, st, jobs, 3)
+	assert.Less(t, time.Since(start), 1400*time.Millisecond, "three jobs at once take about one job's time")
+	for _, res := range results {
+		require.NotNil(t, res.Run)
+		assert.True(t, res.Run.Success)
+	}
+}
+
+func TestRunCmd_OnceExitCode(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig := func(failing bool) string {
+		content := `store:
+  driver: json
+  path: ` + filepath.Join(dir, "runs.json") + `
+jobs:
+  - id: passing
+    schedule: "@daily"
+    command: /bin/true
+`
+		if failing {
+			content += `  - id: failing
+    schedule: "@daily"
+    command: /bin/false
+`
+		}
+		path := filepath.Join(dir, "jobster.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	run := func(args ...string) (string, error) {
+		return executeRootCmd(t, []*cobra.Command{runCmd}, append([]string{"run"}, args...)...)
+	}
+
+	out, err := run("--config", writeConfig(false), "--once")
+	require.NoError(t, err)
+	assert.Contains(t, out, "1 job(s), 0 failed")
+
+	out, err = run("--config", writeConfig(true), "--once", "--parallel", "2")
+	var exitErr *exitCodeError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.code)
+	assert.Contains(t, out, "2 job(s), 1 failed")
+
+	_, err = run("--config", writeConfig(false), "--parallel", "2")
+	assert.ErrorContains(t, err, "--parallel requires --once")
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingStore is a server.Store whose Ping always fails.
+type failingStore struct {
+	server.Store
+}
+
+func (failingStore) Ping(context.Context) error {
+	return errors.New("database is locked")
+}
+
+func TestServe_Probes(t *testing.T) {
+	dir := t.TempDir()
+	_, st := newTestRunner(t, dir, config.Defaults{})
+
+	sched := scheduler.New(context.Background(), nil)
+	storeAdapter := server.NewStoreAdapter(st, sched)
+	schedAdapter := server.NewSchedulerAdapter(sched)
+
+	probe := func(srv *server.Server, path string) (int, server.ReadyResponse) {
+		t.Helper()
+		srv.SetAuthToken("s3cret") // probes must not need it
+		ts := httptest.NewServer(srv.Handler())
+		defer ts.Close()
+
+		resp, err := http.Get(ts.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var body server.ReadyResponse
+		if path == "/api/readyz" {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		}
+		return resp.StatusCode, body
+	}
+
+	// Before the scheduler starts the server is alive but not ready
+	status, body := probe(server.New(":0", storeAdapter, schedAdapter, "", nil), "/api/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "not running", body.Checks["scheduler"])
+	assert.Equal(t, "ok", body.Checks["store"])
+
+	require.NoError(t, sched.Start())
+	defer sched.Stop()
+
+	status, body = probe(server.New(":0", storeAdapter, schedAdapter, "", nil), "/api/readyz")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", body.Status)
+
+	// A broken store fails readiness but not liveness
+	broken := server.New(":0", failingStore{storeAdapter}, schedAdapter, "", nil)
+	status, body = probe(broken, "/api/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "unavailable", body.Status)
+	assert.Equal(t, "database is locked", body.Checks["store"])
+	assert.Equal(t, "ok", body.Checks["scheduler"])
+
+	status, _ = probe(broken, "/api/livez")
+	assert.Equal(t, http.StatusOK, status)
+	status, _ = probe(broken, "/api/health")
+	assert.Equal(t, http.StatusOK, status)
+}
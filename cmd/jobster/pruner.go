@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/store"
+)
+
+// pruneInterval is how often the background pruner applies the retention policy.
+const pruneInterval = time.Hour
+
+// runPruner applies the store retention policy once immediately and then every
+// pruneInterval until ctx is cancelled. It returns at once if no retention is
+// configured. The config is validated at load, so MaxAge always parses here.
+func runPruner(ctx context.Context, st store.Store, retention config.Retention, logger *slog.Logger) {
+	if !retention.Enabled() {
+		return
+	}
+
+	maxAge, _ := retention.MaxAgeDuration()
+
+	prune := func() {
+		var olderThan time.Time
+		if maxAge > 0 {
+			olderThan = time.Now().Add(-maxAge)
+		}
+
+		deleted, err := st.PruneRuns(ctx, olderThan, retention.MaxRunsPerJob)
+		if err != nil {
+			logger.Error("failed to prune run history", "error", err)
+			return
+		}
+		if deleted > 0 {
+			logger.Info("pruned run history", "deleted", deleted)
+		}
+	}
+
+	logger.Info("run history retention enabled",
+		"max_age", retention.MaxAge,
+		"max_runs_per_job", retention.MaxRunsPerJob,
+		"interval", pruneInterval)
+
+	prune()
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			prune()
+		}
+	}
+}
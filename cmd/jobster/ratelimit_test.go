@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_RateLimitBurst(t *testing.T) {
+	srv := server.New(":0", nil, nil, "", nil)
+	srv.SetRateLimit(1, 3)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	var limited int
+	for range 10 {
+		resp, err := http.Get(ts.URL + "/api/jobs")
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			limited++
+			retry, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, retry, 1)
+		} else {
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode) // no scheduler configured
+		}
+	}
+	assert.GreaterOrEqual(t, limited, 6, "requests beyond the burst should be limited")
+
+	// The dashboard and health probes are exempt
+	for _, path := range []string{"/", "/api/health"} {
+		resp, err := http.Get(ts.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+	}
+}
+
+func TestServe_RateLimitSlowClient(t *testing.T) {
+	srv := server.New(":0", nil, nil, "", nil)
+	srv.SetRateLimit(20, 1)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	// One request every 100ms stays well under 20 per second
+	for range 5 {
+		resp, err := http.Get(ts.URL + "/api/jobs")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.NotEqual(t, http.StatusTooManyRequests, resp.StatusCode)
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestServe_RateLimitDisabled(t *testing.T) {
+	srv := server.New(":0", nil, nil, "", nil)
+	srv.SetRateLimit(0, 0)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	for range 20 {
+		resp, err := http.Get(ts.URL + "/api/jobs")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.NotEqual(t, http.StatusTooManyRequests, resp.StatusCode)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+)
+
+// watchReload reloads the job definitions from configPath into sched each
+// time the process receives SIGHUP, until ctx is cancelled. Only jobs
+// matching selector are kept. The signal is registered before watchReload
+// returns.
+func watchReload(ctx context.Context, configPath string, selector config.LabelSelector, sched *scheduler.Scheduler, runner *Runner) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sigChan)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigChan:
+				runner.logger.Info("received SIGHUP, reloading configuration", "config", configPath)
+				if err := reloadConfig(configPath, selector, sched, runner); err != nil {
+					runner.logger.Error("config reload failed; keeping the running configuration", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// reloadConfig loads and validates configPath, then applies its jobs that
+// match selector to sched. Nothing is changed unless the whole config is
+// valid. Settings other than jobs (store, server, defaults) only take effect
+// on restart.
+func reloadConfig(configPath string, selector config.LabelSelector, sched *scheduler.Scheduler, runner *Runner) error {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	added, removed, updated, err := applyJobs(sched, runner, selectJobs(cfg.Jobs, selector))
+	if err != nil {
+		return err
+	}
+
+	runner.logger.Info("configuration reloaded",
+		"added", added,
+		"removed", removed,
+		"updated", updated)
+	return nil
+}
+
+// applyJobs makes the scheduler's job set match jobs: new IDs are added,
+// missing ones removed, and jobs whose definition changed are re-registered
+// so a new schedule takes effect. A changed job that was paused stays paused
+// (unless it is now disabled). Every job is validated before the scheduler is
+// touched.
+func applyJobs(sched *scheduler.Scheduler, runner *Runner, jobs []config.Job) (added, removed, updated []string, err error) {
+	for i := range jobs {
+		if err := scheduler.ValidateJob(&jobs[i]); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	current := make(map[string]*config.Job)
+	for _, job := range sched.ListJobs() {
+		current[job.ID] = job
+	}
+	wanted := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		wanted[job.ID] = true
+	}
+
+	for id := range current {
+		if !wanted[id] {
+			if err := sched.RemoveJob(id); err != nil {
+				return added, removed, updated, err
+			}
+			removed = append(removed, id)
+		}
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		old, exists := current[job.ID]
+		switch {
+		case !exists:
+			if err := sched.AddJob(job, runner); err != nil {
+				return added, removed, updated, err
+			}
+			added = append(added, job.ID)
+		case !reflect.DeepEqual(old, job):
+			stats, _ := sched.GetJobStats(job.ID)
+			if err := sched.RemoveJob(job.ID); err != nil {
+				return added, removed, updated, err
+			}
+			if err := sched.AddJob(job, runner); err != nil {
+				return added, removed, updated, err
+			}
+			if stats != nil && stats.Paused && job.IsEnabled() {
+				if err := sched.PauseJob(job.ID); err != nil {
+					return added, removed, updated, err
+				}
+			}
+			updated = append(updated, job.ID)
+		}
+	}
+
+	runner.SetDependents(jobs, sched)
+	return added, removed, updated, nil
+}
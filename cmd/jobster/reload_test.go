@@ -0,0 +1,120 @@
+//go:build unix
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reloadBaseConfig = `
+jobs:
+  - id: "keep"
+    schedule: "@every 1h"
+    command: "/bin/true"
+  - id: "drop"
+    schedule: "@every 1h"
+    command: "/bin/true"
+  - id: "change"
+    schedule: "@every 1h"
+    command: "/bin/true"
+`
+
+func startReloadScheduler(t *testing.T, configPath string) (*scheduler.Scheduler, *Runner) {
+	t.Helper()
+	runner, _ := newTestRunner(t, t.TempDir(), config.Defaults{})
+
+	cfg, err := config.LoadConfig(configPath)
+	require.NoError(t, err)
+
+	sched := scheduler.New(context.Background(), nil)
+	for i := range cfg.Jobs {
+		require.NoError(t, sched.AddJob(&cfg.Jobs[i], runner))
+	}
+	runner.SetDependents(cfg.Jobs, sched)
+	require.NoError(t, sched.Start())
+	t.Cleanup(func() { sched.Stop() })
+
+	return sched, runner
+}
+
+func TestReload_SIGHUPAppliesJobChanges(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "jobster.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(reloadBaseConfig), 0o644))
+
+	sched, runner := startReloadScheduler(t, configPath)
+	require.NoError(t, sched.PauseJob("change"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchReload(ctx, configPath, nil, sched, runner)
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+jobs:
+  - id: "keep"
+    schedule: "@every 1h"
+    command: "/bin/true"
+  - id: "change"
+    schedule: "@every 2h"
+    command: "/bin/true"
+  - id: "new"
+    schedule: "@every 1h"
+    command: "/bin/echo new"
+`), 0o644))
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	require.Eventually(t, func() bool {
+		_, ok := sched.GetJob("new")
+		return ok
+	}, 5*time.Second, 20*time.Millisecond, "new job should be scheduled after SIGHUP")
+
+	_, ok := sched.GetJob("drop")
+	assert.False(t, ok, "job removed from the config is unscheduled")
+
+	changed, ok := sched.GetJob("change")
+	require.True(t, ok)
+	assert.Equal(t, "@every 2h", changed.Schedule)
+	stats, _ := sched.GetJobStats("change")
+	assert.True(t, stats.Paused, "a paused job stays paused when its definition changes")
+
+	stats, _ = sched.GetJobStats("new")
+	assert.False(t, stats.NextRun.IsZero())
+}
+
+func TestReload_InvalidConfigKeepsRunningJobs(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "jobster.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(reloadBaseConfig), 0o644))
+
+	sched, runner := startReloadScheduler(t, configPath)
+
+	// The second job's schedule passes config validation but not the cron
+	// parser; nothing may be applied, not even the valid first job
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+jobs:
+  - id: "added"
+    schedule: "@every 1h"
+    command: "/bin/true"
+  - id: "bad"
+    schedule: "99 * * * *"
+    command: "/bin/true"
+`), 0o644))
+	assert.Error(t, reloadConfig(configPath, nil, sched, runner))
+
+	require.NoError(t, os.WriteFile(configPath, []byte("jobs: [}"), 0o644))
+	assert.Error(t, reloadConfig(configPath, nil, sched, runner))
+
+	var ids []string
+	for _, job := range sched.ListJobs() {
+		ids = append(ids, job.ID)
+	}
+	assert.ElementsMatch(t, []string{"keep", "drop", "change"}, ids)
+}
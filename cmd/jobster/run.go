@@ -3,12 +3,11 @@ package main
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/caevv/jobster/internal/config"
 	"github.com/caevv/jobster/internal/logging"
-	"github.com/caevv/jobster/internal/plugins"
 	"github.com/caevv/jobster/internal/scheduler"
-	"github.com/caevv/jobster/internal/store"
 	"github.com/spf13/cobra"
 )
 
@@ -19,30 +18,72 @@ var runCmd = &cobra.Command{
 
 This command loads the configuration file, initializes the scheduler,
 and starts all configured jobs. It runs continuously until interrupted
-by SIGINT or SIGTERM.
-
-Example:
-  jobster run --config ./jobster.yaml`,
+by SIGINT or SIGTERM. Send SIGHUP to reload job definitions without
+restarting.
+
+With --dry-run, it instead prints each job's schedule, next fire times,
+resolved command, working directory, and hooks, then exits without running
+anything.
+
+With --once, it runs every enabled job once right away instead of
+scheduling, up to --parallel at a time, prints a summary, and exits non-zero
+if any job failed. Runs are recorded, with their timeouts, retries and hooks,
+as they would be under the scheduler; restart policies are ignored. A job
+with depends_on runs after its upstream jobs, and only if one of them
+succeeded. Disabled jobs are skipped.
+
+With --label key=value (repeatable), only jobs carrying every given label
+are scheduled, dry-run or run once; the rest of the config is ignored,
+including on SIGHUP reloads.
+
+Examples:
+  jobster run --config ./jobster.yaml
+  jobster run --config ./jobster.yaml --dry-run
+  jobster run --config ./jobster.yaml --once --parallel 4
+  jobster run --config ./jobster.yaml --label team=payments`,
 	RunE: runScheduler,
 }
 
 func init() {
 	runCmd.Flags().StringP("config", "c", "jobster.yaml", "Path to configuration file")
 	runCmd.MarkFlagRequired("config")
+	runCmd.Flags().Bool("dry-run", false, "Print what would run and exit without starting the scheduler")
+	runCmd.Flags().Bool("once", false, "Run every job once now and exit instead of scheduling")
+	runCmd.Flags().Int("parallel", 1, "With --once, how many jobs may run at the same time")
+	runCmd.Flags().StringArray("label", nil, "Only run jobs with this label (key=value, repeatable)")
+	runCmd.MarkFlagsMutuallyExclusive("dry-run", "once")
 }
 
 func runScheduler(cmd *cobra.Command, args []string) error {
 	configPath, _ := cmd.Flags().GetString("config")
+	once, _ := cmd.Flags().GetBool("once")
+	parallel, _ := cmd.Flags().GetInt("parallel")
+	if parallel < 1 {
+		return fmt.Errorf("--parallel must be at least 1")
+	}
+	if cmd.Flags().Changed("parallel") && !once {
+		return fmt.Errorf("--parallel requires --once")
+	}
+	selector, err := labelSelectorFlag(cmd)
+	if err != nil {
+		return fmt.Errorf("--label: %w", err)
+	}
 
 	// Load configuration
 	cfg, err := config.LoadConfig(configPath)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	cfg.Jobs = selectJobs(cfg.Jobs, selector)
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		cmd.SilenceUsage = true
+		return printDryRun(cmd.OutOrStdout(), cfg, time.Now())
+	}
 
 	// Apply logging config from YAML if provided
-	if cfg.Logging.Output != "" || cfg.Logging.Level != "" || cfg.Logging.Format != "" {
-		runLogger, err := logging.NewFromConfig(cfg.Logging.Format, cfg.Logging.Level, cfg.Logging.Output)
+	if cfg.Logging.Output != "" || cfg.Logging.Level != "" || cfg.Logging.Format != "" || len(cfg.Logging.RedactPatterns) > 0 {
+		runLogger, err := logging.NewFromConfigWithOptions(cfg.Logging.Format, cfg.Logging.Level, cfg.Logging.Output, loggingOptions(cfg))
 		if err != nil {
 			return fmt.Errorf("failed to initialize logger: %w", err)
 		}
@@ -56,8 +97,14 @@ func runScheduler(cmd *cobra.Command, args []string) error {
 		"timezone", cfg.Defaults.Timezone,
 		"store_driver", cfg.Store.Driver)
 
+	stopTracing, err := setupTracing(cfg)
+	if err != nil {
+		return err
+	}
+	defer stopTracing()
+
 	// Initialize store for run history
-	st, err := store.NewStore(cfg.Store.Driver, cfg.Store.Path)
+	st, err := openStore(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize store: %w", err)
 	}
@@ -70,7 +117,10 @@ func runScheduler(cmd *cobra.Command, args []string) error {
 	logger.Info("store initialized", "driver", cfg.Store.Driver, "path", cfg.Store.Path)
 
 	// Initialize plugin manager
-	pluginMgr := plugins.New(logger)
+	pluginMgr, err := newPluginManager(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize plugin manager: %w", err)
+	}
 
 	logger.Info("plugin manager initialized",
 		"timeout_sec", cfg.Defaults.AgentTimeoutSec,
@@ -78,11 +128,26 @@ func runScheduler(cmd *cobra.Command, args []string) error {
 		"allowed_agents", cfg.Security.AllowedAgents)
 
 	// Create job runner
-	runner := NewRunner(st, pluginMgr, cfg.Defaults, logger)
+	runner, err := newRunner(cfg, st, pluginMgr)
+	if err != nil {
+		return err
+	}
 
 	// Setup signal handling for graceful shutdown
 	ctx := setupSignalHandler()
 
+	if once {
+		logger.Info("running all jobs once", "jobs", len(cfg.Jobs), "parallel", parallel)
+		results := runJobsOnce(ctx, runner, st, cfg.Jobs, parallel)
+		if failed := printOnceSummary(cmd.OutOrStdout(), results); failed > 0 {
+			// The summary already names the failed jobs
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			return &exitCodeError{code: 1}
+		}
+		return nil
+	}
+
 	// Resolve the configured timezone for cron schedules
 	loc, err := resolveLocation(cfg)
 	if err != nil {
@@ -90,7 +155,7 @@ func runScheduler(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize scheduler
-	sched := scheduler.New(ctx, logger, scheduler.WithLocation(loc))
+	sched := scheduler.New(ctx, logger, schedulerOptions(cfg, loc, st)...)
 
 	// Add jobs to scheduler
 	for i := range cfg.Jobs {
@@ -98,6 +163,7 @@ func runScheduler(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to add job %s: %w", cfg.Jobs[i].ID, err)
 		}
 	}
+	runner.SetDependents(cfg.Jobs, sched)
 
 	// Start scheduler
 	if err := sched.Start(); err != nil {
@@ -107,6 +173,16 @@ func runScheduler(cmd *cobra.Command, args []string) error {
 	logger.Info("scheduler started successfully",
 		"scheduled_jobs", len(cfg.Jobs))
 
+	// Reload job definitions on SIGHUP
+	watchReload(ctx, configPath, selector, sched, runner)
+
+	// Prune run history in the background; waited on before the store closes
+	pruneDone := make(chan struct{})
+	go func() {
+		defer close(pruneDone)
+		runPruner(ctx, st, cfg.Store.Retention, logger)
+	}()
+
 	// Wait for shutdown signal
 	<-ctx.Done()
 
@@ -117,6 +193,7 @@ func runScheduler(cmd *cobra.Command, args []string) error {
 		logger.Error("error during scheduler shutdown", "error", err)
 		return err
 	}
+	<-pruneDone
 
 	logger.Info("jobster stopped")
 	return nil
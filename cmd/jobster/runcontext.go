@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/store"
+)
+
+// runContext is the document written to $HISTORY_FILE for on_error,
+// on_success and post_run agents. The schema is documented in AGENTS.md;
+// add fields rather than renaming them, agents depend on it.
+type runContext struct {
+	Job runContextJob `json:"job"`
+	Run runContextRun `json:"run"`
+}
+
+type runContextJob struct {
+	ID         string   `json:"id"`
+	Command    string   `json:"command"`
+	Schedule   string   `json:"schedule,omitempty"`
+	Timezone   string   `json:"timezone,omitempty"`
+	Workdir    string   `json:"workdir,omitempty"`
+	Shell      bool     `json:"shell"`
+	TimeoutSec int      `json:"timeout_sec"`
+	DependsOn  []string `json:"depends_on,omitempty"`
+}
+
+type runContextRun struct {
+	RunID       string    `json:"run_id"`
+	Status      string    `json:"status"`
+	Success     bool      `json:"success"`
+	ExitCode    int       `json:"exit_code"`
+	Error       string    `json:"error,omitempty"`
+	Attempt     int       `json:"attempt"`
+	MaxAttempts int       `json:"max_attempts"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	DurationMs  int64     `json:"duration_ms"`
+	StdoutTail  string    `json:"stdout_tail"`
+	StderrTail  string    `json:"stderr_tail"`
+}
+
+// writeRunContext writes the finished run's context to a private temp file
+// and returns its path. The caller removes the file once the hooks are done.
+func writeRunContext(job *config.Job, run *store.JobRun) (string, error) {
+	doc := runContext{
+		Job: runContextJob{
+			ID:         job.ID,
+			Command:    job.Command.String(),
+			Schedule:   job.Schedule,
+			Timezone:   job.Timezone,
+			Workdir:    job.Workdir,
+			Shell:      job.Shell,
+			TimeoutSec: job.TimeoutSec,
+			DependsOn:  job.DependsOn,
+		},
+		Run: runContextRun{
+			RunID:      run.RunID,
+			Success:    run.Success,
+			ExitCode:   run.ExitCode,
+			StartTime:  run.StartTime,
+			EndTime:    run.EndTime,
+			DurationMs: run.Duration().Milliseconds(),
+			StdoutTail: run.StdoutTail,
+			StderrTail: run.StderrTail,
+		},
+	}
+	doc.Run.Status, _ = run.Metadata["status"].(string)
+	doc.Run.Error, _ = run.Metadata["error"].(string)
+	doc.Run.Attempt, _ = run.Metadata["attempt"].(int)
+	doc.Run.MaxAttempts, _ = run.Metadata["max_attempts"].(int)
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode run context: %w", err)
+	}
+
+	// CreateTemp makes the file readable only by the jobster user
+	f, err := os.CreateTemp("", "jobster-run-*.json")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/caevv/jobster/internal/logging"
+)
+
+// runLogWriter streams one output stream of a run to its log file in the
+// history directory, so `jobster logs --follow` and the API see output while
+// the command runs. The file is created on the first write, so a stream that
+// produces no output has no file.
+//
+// With a value redactor set, output is written a line at a time so each
+// complete line can be redacted; a secret split across lines is not caught.
+// A line longer than maxPendingLine is redacted and written in pieces, so
+// output without newlines cannot grow the buffer without bound.
+//
+// Write never fails: a log file problem must not cut the command's output
+// off from the in-memory capture it is teed with. The first error is kept
+// and returned by Close.
+type runLogWriter struct {
+	path     string
+	stream   string
+	header   string // written before the first output, e.g. a retry marker
+	redactor *logging.ValueRedactor
+
+	f       *os.File
+	pending []byte // incomplete last line, when redacting
+	err     error
+}
+
+// maxPendingLine caps the incomplete line a redacting runLogWriter holds.
+const maxPendingLine = 64 << 10
+
+// newRunLogWriter returns the writer for one stream of an attempt of a run.
+// Later attempts append to the same file after a marker line.
+func (r *Runner) newRunLogWriter(jobID, runID, stream string, attempt int) *runLogWriter {
+	w := &runLogWriter{
+		path:     runLogPath(r.historyDir, jobID, runID, stream),
+		stream:   stream,
+		redactor: r.redactor,
+	}
+	if attempt > 1 {
+		w.header = fmt.Sprintf("[jobster: attempt %d]\n", attempt)
+	}
+	return w
+}
+
+// Write appends p to the log file.
+func (w *runLogWriter) Write(p []byte) (int, error) {
+	if w.redactor == nil {
+		w.write(p)
+		return len(p), nil
+	}
+
+	w.pending = append(w.pending, p...)
+	if i := bytes.LastIndexByte(w.pending, '\n'); i >= 0 {
+		w.write([]byte(w.redactor.Redact(string(w.pending[:i+1]))))
+		w.pending = append(w.pending[:0], w.pending[i+1:]...)
+	}
+	if len(w.pending) >= maxPendingLine {
+		// Force a break; a secret straddling it is missed, as at a newline
+		w.write([]byte(w.redactor.Redact(string(w.pending))))
+		w.pending = w.pending[:0]
+	}
+	return len(p), nil
+}
+
+// write appends p to the file, opening it first if needed.
+func (w *runLogWriter) write(p []byte) {
+	if w.err != nil || len(p) == 0 {
+		return
+	}
+	if w.f == nil {
+		if err := os.MkdirAll(filepath.Dir(w.path), 0o755); err != nil {
+			w.err = err
+			return
+		}
+		f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			w.err = err
+			return
+		}
+		w.f = f
+		if w.header != "" {
+			if _, err := f.WriteString(w.header); err != nil {
+				w.err = err
+				return
+			}
+		}
+	}
+	if _, err := w.f.Write(p); err != nil {
+		w.err = err
+	}
+}
+
+// Close writes any incomplete last line and closes the file.
+func (w *runLogWriter) Close() error {
+	if len(w.pending) > 0 {
+		w.write([]byte(w.redactor.Redact(string(w.pending))))
+		w.pending = nil
+	}
+	if w.f != nil {
+		if err := w.f.Close(); err != nil && w.err == nil {
+			w.err = err
+		}
+		w.f = nil
+	}
+	return w.err
+}
@@ -1,19 +1,33 @@
 package main
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/events"
+	"github.com/caevv/jobster/internal/eventsink"
+	"github.com/caevv/jobster/internal/logging"
+	"github.com/caevv/jobster/internal/metrics"
 	"github.com/caevv/jobster/internal/plugins"
+	"github.com/caevv/jobster/internal/scheduler"
 	"github.com/caevv/jobster/internal/store"
+	"github.com/caevv/jobster/internal/telemetry"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Runner orchestrates job execution with plugin hooks and history tracking
@@ -24,6 +38,24 @@ type Runner struct {
 	stateDir   string
 	historyDir string
 	logger     *slog.Logger
+	events     *events.Bus
+	sink       eventsink.Sink
+	metrics    *metrics.Metrics
+	redactor   *logging.ValueRedactor
+	jobLoggers *logging.JobLoggers
+	audit      *logging.AuditLog
+
+	// dependents maps a job ID to the jobs whose depends_on lists it. It is
+	// replaced on config reload, so access goes through depMu.
+	depMu      sync.RWMutex
+	dependents map[string][]string
+	trigger    DependentTrigger
+}
+
+// DependentTrigger starts a job because a job it depends on succeeded.
+// *scheduler.Scheduler implements it.
+type DependentTrigger interface {
+	RunDependentJob(jobID string) (string, error)
 }
 
 // NewRunner creates a new job runner
@@ -32,12 +64,8 @@ func NewRunner(st store.Store, pluginMgr *plugins.AgentExecutor, defaults config
 		logger = slog.Default()
 	}
 	// Create state directory for agent data
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		homeDir = "."
-	}
-	stateDir := filepath.Join(homeDir, ".jobster", "state")
-	historyDir := filepath.Join(homeDir, ".jobster", "history")
+	stateDir := filepath.Join(jobsterDir(), "state")
+	historyDir := defaultHistoryDir()
 
 	// Ensure directories exist
 	os.MkdirAll(stateDir, 0o755)
@@ -50,17 +78,176 @@ func NewRunner(st store.Store, pluginMgr *plugins.AgentExecutor, defaults config
 		stateDir:   stateDir,
 		historyDir: historyDir,
 		logger:     logger,
+		sink:       eventsink.Nop{},
+	}
+}
+
+// jobsterDir returns ~/.jobster, where agent state and run logs are kept, or
+// ./.jobster if the home directory cannot be determined.
+func jobsterDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		homeDir = "."
 	}
+	return filepath.Join(homeDir, ".jobster")
 }
 
-// RunJob implements the JobRunner interface from scheduler
+// defaultHistoryDir returns the directory the Runner saves full run logs to.
+func defaultHistoryDir() string {
+	return filepath.Join(jobsterDir(), "history")
+}
+
+// runLogPath returns where a run's full stdout or stderr log is saved.
+// stream is "stdout" or "stderr".
+func runLogPath(historyDir, jobID, runID, stream string) string {
+	return filepath.Join(historyDir, jobID, fmt.Sprintf("%s.%s.log", runID, stream))
+}
+
+// SetEventBus makes the runner publish run_started and run_finished events
+// to bus. A nil bus disables publishing.
+func (r *Runner) SetEventBus(bus *events.Bus) {
+	r.events = bus
+}
+
+// SetEventSink makes the runner also publish run_started and run_finished
+// events to an external sink, from a background queue so the sink never
+// holds up a run. A nil sink disables publishing.
+func (r *Runner) SetEventSink(sink eventsink.Sink) {
+	if sink == nil {
+		r.sink = eventsink.Nop{}
+		return
+	}
+	r.sink = eventsink.NewAsync(sink, eventSinkQueueSize, eventSinkTimeout, func(e eventsink.RunEvent, err error) {
+		r.jobLogger(e.JobID, e.RunID).Warn("failed to publish run event", "type", e.Type, "error", err)
+	})
+}
+
+// SetMetrics makes the runner record completed runs in m. A nil m disables
+// recording.
+func (r *Runner) SetMetrics(m *metrics.Metrics) {
+	r.metrics = m
+}
+
+// SetValueRedactor makes the runner mask secrets in job output before it is
+// stored, written to run logs, or passed to agents. A nil redactor disables
+// masking.
+func (r *Runner) SetValueRedactor(redactor *logging.ValueRedactor) {
+	r.redactor = redactor
+}
+
+// SetJobLoggers makes the runner also write each job's execution log lines
+// to that job's own file. A nil jobLoggers disables per-job files.
+func (r *Runner) SetJobLoggers(jobLoggers *logging.JobLoggers) {
+	r.jobLoggers = jobLoggers
+}
+
+// SetAuditLog makes the runner append a record of every completed run to
+// audit. A nil audit log disables it.
+func (r *Runner) SetAuditLog(audit *logging.AuditLog) {
+	r.audit = audit
+}
+
+// jobLogger returns the logger for one execution of a job: the runner's
+// logger with job_id and run_id fields, teed to the job's own file when
+// per-job logs are on. The scheduler logs under the same run ID, so every
+// line about an execution can be correlated.
+func (r *Runner) jobLogger(jobID, runID string) *slog.Logger {
+	logger := r.logger
+	if r.jobLoggers != nil {
+		fileLogger, err := r.jobLoggers.Logger(jobID)
+		if err != nil {
+			r.logger.Warn("per-job log unavailable", "job_id", jobID, "error", err)
+		} else {
+			logger = logging.Tee(r.logger, fileLogger)
+		}
+	}
+	return logging.WithFields(logger, map[string]any{"job_id": jobID, "run_id": runID})
+}
+
+// SetDependents builds the depends_on graph for jobs so that after each
+// successful run the runner starts, through trigger, every job that depends
+// on the one that just finished.
+// It may be called again, e.g. after a config reload.
+func (r *Runner) SetDependents(jobs []config.Job, trigger DependentTrigger) {
+	dependents := make(map[string][]string)
+	for _, job := range jobs {
+		for _, dep := range job.DependsOn {
+			dependents[dep] = append(dependents[dep], job.ID)
+		}
+	}
+
+	r.depMu.Lock()
+	r.dependents = dependents
+	r.trigger = trigger
+	r.depMu.Unlock()
+}
+
+// RunJob implements the JobRunner interface from scheduler. Jobs with a
+// restart policy are re-launched here, each time as a new run, until the
+// policy is satisfied, max_restarts is reached, ctx is cancelled, or the
+// scheduler pauses, disables, removes or replaces the job; the job keeps its
+// scheduler slot throughout, so scheduled runs do not overlap.
 func (r *Runner) RunJob(ctx context.Context, job *config.Job) error {
-	runID := uuid.New().String()
+	// Use the run ID the scheduler assigned (so callers such as a manual
+	// trigger can report it up front); fall back to a fresh one otherwise.
+	runID := scheduler.RunIDFromContext(ctx)
+	if runID == "" {
+		runID = uuid.New().String()
+	}
+	// Runs started other than by the scheduler, e.g. `jobster trigger`, are manual.
+	trigger := scheduler.TriggerFromContext(ctx)
+	if trigger == "" {
+		trigger = scheduler.TriggerManual
+	}
+
+	success, err := r.runOnce(ctx, job, runID, trigger, 0)
+	backoff, _ := config.ParseRestartBackoff(job.RestartBackoff)
+	limit := job.RestartLimit()
+	for restart := 1; job.ShouldRestart(success) && ctx.Err() == nil; restart++ {
+		log := r.jobLogger(job.ID, runID)
+		if restart > limit {
+			log.Warn("restart limit reached; waiting for the next scheduled run",
+				"restart_policy", job.Restart, "max_restarts", limit)
+			break
+		}
+
+		delay := restartDelay(backoff, restart)
+		log.Info("restarting job", "restart_policy", job.Restart,
+			"restart", restart, "max_restarts", limit, "delay", delay.String())
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		if !scheduler.JobActiveFromContext(ctx) {
+			log.Info("job paused or removed from the scheduler; not restarting")
+			break
+		}
+
+		runID = uuid.New().String()
+		success, err = r.runOnce(ctx, job, runID, scheduler.TriggerRestart, restart)
+	}
+	return err
+}
+
+// restartDelay returns the wait before the given 1-based restart: backoff,
+// doubled for each restart after the first, capped at maxBackoff.
+func restartDelay(backoff time.Duration, restart int) time.Duration {
+	d := backoff
+	for i := 1; i < restart && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
+
+// runOnce executes one run of job, with its hooks and retries, and records
+// it under runID. restart is how many restarts preceded it (0 for the run
+// the scheduler started). It reports whether the run succeeded.
+func (r *Runner) runOnce(ctx context.Context, job *config.Job, runID, trigger string, restart int) (bool, error) {
 	startTime := time.Now()
+	log := r.jobLogger(job.ID, runID)
 
-	r.logger.Info("starting job execution",
-		"job_id", job.ID,
-		"run_id", runID,
+	log.Info("starting job execution",
 		"schedule", job.Schedule,
 		"command", job.Command.String())
 
@@ -70,12 +257,36 @@ func (r *Runner) RunJob(ctx context.Context, job *config.Job) error {
 		JobID:     job.ID,
 		StartTime: startTime,
 		Metadata:  map[string]interface{}{"status": "running", "attempt": 1},
+		Trigger:   trigger,
 	}
+	if restart > 0 {
+		run.Metadata["restart"] = restart
+	} else if jitter := scheduler.JitterFromContext(ctx); jitter > 0 {
+		run.Metadata["jitter"] = jitter.String()
+	}
+
+	// Trace the run; hooks and agents started below join this span
+	ctx, span := telemetry.Tracer().Start(ctx, "job.run", trace.WithAttributes(
+		attribute.String("job.id", job.ID),
+		attribute.String("job.run_id", runID),
+	))
+	defer endRunSpan(span, run)
+
+	// Record writes must not be abandoned when shutdown cancels ctx, or a
+	// killed run would be left looking like it is still running.
+	storeCtx := context.WithoutCancel(ctx)
 
 	// Save initial run state
-	if err := r.store.SaveRun(run); err != nil {
-		r.logger.Error("failed to save run", "run_id", runID, "error", err)
+	if err := r.store.SaveRun(storeCtx, run); err != nil {
+		log.Error("failed to save run", "error", err)
 	}
+	r.publish(events.Event{
+		Type:   events.TypeRunStarted,
+		JobID:  job.ID,
+		RunID:  runID,
+		Time:   startTime,
+		Status: "running",
+	}, trigger)
 
 	// Create job-specific state directory
 	jobStateDir := filepath.Join(r.stateDir, job.ID)
@@ -95,23 +306,26 @@ func (r *Runner) RunJob(ctx context.Context, job *config.Job) error {
 
 	// Execute pre_run hooks
 	if len(job.Hooks.PreRun) > 0 {
-		r.logger.Debug("executing pre_run hooks", "job_id", job.ID, "run_id", runID, "count", len(job.Hooks.PreRun))
+		log.Debug("executing pre_run hooks", "count", len(job.Hooks.PreRun))
 		hookParams.Hook = "pre_run"
-		if err := plugins.ExecuteHooks(ctx, r.pluginMgr, job.Hooks.PreRun, hookParams, r.defaults.FailOnAgentError); err != nil {
-			r.logger.Error("pre_run hook failed", "job_id", job.ID, "run_id", runID, "error", err)
+		if err := r.executeHooks(ctx, run, job.Hooks.PreRun, hookParams); err != nil {
+			log.Error("pre_run hook failed", "error", err)
 			if r.defaults.FailOnAgentError {
 				run.EndTime = time.Now()
 				run.Success = false
 				run.Metadata["status"] = "failed"
 				run.Metadata["error"] = fmt.Sprintf("pre_run hook failed: %v", err)
-				r.store.SaveRun(run)
-				return err
+				r.store.SaveRun(storeCtx, run)
+				r.reportFinished(run)
+				return false, err
 			}
 		}
 	}
 
 	// Execute job command, retrying on failure per the configured policy.
-	exitCode, stdout, stderr, attempts, execErr := r.executeWithRetries(ctx, job, runID)
+	exitCode, stdout, stderr, attempts, execErr := r.executeWithRetries(ctx, job, run)
+	stdout = r.redactor.Redact(stdout)
+	stderr = r.redactor.Redact(stderr)
 
 	endTime := time.Now()
 	duration := endTime.Sub(startTime)
@@ -119,8 +333,8 @@ func (r *Runner) RunJob(ctx context.Context, job *config.Job) error {
 	// Update run record
 	run.EndTime = endTime
 	run.ExitCode = exitCode
-	run.StdoutTail = r.tailOutput(stdout, 10000)
-	run.StderrTail = r.tailOutput(stderr, 10000)
+	run.StdoutTail = r.tailOutput(stdout, r.outputTailBytes())
+	run.StderrTail = r.tailOutput(stderr, r.outputTailBytes())
 	run.Metadata["duration"] = duration.String()
 	run.Metadata["attempt"] = attempts
 	run.Metadata["max_attempts"] = r.defaults.JobRetries + 1
@@ -128,14 +342,15 @@ func (r *Runner) RunJob(ctx context.Context, job *config.Job) error {
 	// Reflect the final attempt count in hook environment variables.
 	hookParams.Attempt = attempts
 
-	// Save full logs to history directory
-	r.saveFullLogs(runID, job.ID, stdout, stderr)
-
 	// Update hook params with execution results
 	hookParams.EndTS = endTime
 	hookParams.ExitCode = exitCode
 
-	// Determine status and execute appropriate hooks
+	// post_run and on_error agents that set receive_stdin get the job's
+	// output on stdin
+	jobOutput := stdout + stderr
+
+	// Determine status
 	if execErr != nil || exitCode != 0 {
 		run.Success = false
 		errorMsg := ""
@@ -146,62 +361,253 @@ func (r *Runner) RunJob(ctx context.Context, job *config.Job) error {
 		}
 		run.Metadata["status"] = "failed"
 		run.Metadata["error"] = errorMsg
+		var missing *missingPathError
+		if errors.Is(execErr, errCommandTimeout) {
+			run.Metadata["status"] = store.StatusTimeout
+			run.Metadata["timed_out"] = true
+		} else if errors.As(execErr, &missing) {
+			run.Metadata["status"] = missing.status
+			run.Metadata["missing_path"] = missing.path
+		}
 
-		r.logger.Error("job execution failed",
-			"job_id", job.ID,
-			"run_id", runID,
+		log.Error("job execution failed",
 			"exit_code", exitCode,
 			"duration", duration,
 			"error", errorMsg)
-
-		// Execute on_error hooks
-		if len(job.Hooks.OnError) > 0 {
-			r.logger.Debug("executing on_error hooks", "job_id", job.ID, "run_id", runID, "count", len(job.Hooks.OnError))
-			hookParams.Hook = "on_error"
-			if err := plugins.ExecuteHooks(ctx, r.pluginMgr, job.Hooks.OnError, hookParams, r.defaults.FailOnAgentError); err != nil {
-				r.logger.Error("on_error hook failed", "job_id", job.ID, "run_id", runID, "error", err)
-			}
-		}
 	} else {
 		run.Success = true
 		run.Metadata["status"] = "success"
 
-		r.logger.Info("job execution succeeded",
-			"job_id", job.ID,
-			"run_id", runID,
-			"duration", duration)
-
-		// Execute on_success hooks
-		if len(job.Hooks.OnSuccess) > 0 {
-			r.logger.Debug("executing on_success hooks", "job_id", job.ID, "run_id", runID, "count", len(job.Hooks.OnSuccess))
-			hookParams.Hook = "on_success"
-			if err := plugins.ExecuteHooks(ctx, r.pluginMgr, job.Hooks.OnSuccess, hookParams, r.defaults.FailOnAgentError); err != nil {
-				r.logger.Error("on_success hook failed", "job_id", job.ID, "run_id", runID, "error", err)
-			}
+		log.Info("job execution succeeded", "duration", duration)
+	}
+
+	// Give the remaining hooks the full run context in $HISTORY_FILE
+	if len(job.Hooks.OnError) > 0 || len(job.Hooks.OnSuccess) > 0 || len(job.Hooks.PostRun) > 0 {
+		path, err := writeRunContext(job, run)
+		if err != nil {
+			log.Error("failed to write run context file", "error", err)
+		} else {
+			defer os.Remove(path)
+			hookParams.HistoryFile = path
+		}
+	}
+
+	// Execute on_error or on_success hooks
+	if !run.Success && len(job.Hooks.OnError) > 0 {
+		log.Debug("executing on_error hooks", "count", len(job.Hooks.OnError))
+		hookParams.Hook = "on_error"
+		hookParams.Stdin = jobOutput
+		if err := r.executeHooks(ctx, run, job.Hooks.OnError, hookParams); err != nil {
+			log.Error("on_error hook failed", "error", err)
+		}
+	}
+	if run.Success && len(job.Hooks.OnSuccess) > 0 {
+		log.Debug("executing on_success hooks", "count", len(job.Hooks.OnSuccess))
+		hookParams.Hook = "on_success"
+		hookParams.Stdin = ""
+		if err := r.executeHooks(ctx, run, job.Hooks.OnSuccess, hookParams); err != nil {
+			log.Error("on_success hook failed", "error", err)
 		}
 	}
 
 	// Execute post_run hooks (always run, regardless of job status)
 	if len(job.Hooks.PostRun) > 0 {
-		r.logger.Debug("executing post_run hooks", "job_id", job.ID, "run_id", runID, "count", len(job.Hooks.PostRun))
+		log.Debug("executing post_run hooks", "count", len(job.Hooks.PostRun))
 		hookParams.Hook = "post_run"
-		if err := plugins.ExecuteHooks(ctx, r.pluginMgr, job.Hooks.PostRun, hookParams, r.defaults.FailOnAgentError); err != nil {
-			r.logger.Error("post_run hook failed", "job_id", job.ID, "run_id", runID, "error", err)
+		hookParams.Stdin = jobOutput
+		if err := r.executeHooks(ctx, run, job.Hooks.PostRun, hookParams); err != nil {
+			log.Error("post_run hook failed", "error", err)
 		}
 	}
 
 	// Save final run state
-	if err := r.store.SaveRun(run); err != nil {
-		r.logger.Error("failed to save run", "run_id", runID, "error", err)
+	if err := r.store.SaveRun(storeCtx, run); err != nil {
+		log.Error("failed to save run", "error", err)
+	}
+	r.reportFinished(run)
+
+	if run.Success {
+		r.triggerDependents(job.ID, runID)
 	}
 
 	if execErr != nil {
-		return execErr
+		return false, execErr
 	}
 
-	return nil
+	return run.Success, nil
 }
 
+// endRunSpan records the outcome of run on its job.run span and ends it.
+func endRunSpan(span trace.Span, run *store.JobRun) {
+	span.SetAttributes(
+		attribute.Int("job.exit_code", run.ExitCode),
+		attribute.Int64("job.duration_ms", run.Duration().Milliseconds()),
+	)
+	if attempts, ok := run.Metadata["attempt"].(int); ok {
+		span.SetAttributes(attribute.Int("job.attempts", attempts))
+	}
+	if !run.Success {
+		msg, _ := run.Metadata["error"].(string)
+		span.SetStatus(codes.Error, msg)
+	}
+	span.End()
+}
+
+// executeHooks runs one hook list and records any JSON the agents printed
+// under run.Metadata["hooks"][hook][agent], so agents can attach structured
+// results (a deploy URL, a ticket ID) to the run.
+func (r *Runner) executeHooks(ctx context.Context, run *store.JobRun, hooks []config.Agent, params plugins.AgentParams) error {
+	outputs, err := plugins.ExecuteHooks(ctx, r.pluginMgr, hooks, params, plugins.HookOptions{
+		FailOnError: r.defaults.FailOnAgentError,
+		Concurrency: r.defaults.HookConcurrency,
+	})
+	if len(outputs) > 0 {
+		all, _ := run.Metadata["hooks"].(map[string]interface{})
+		if all == nil {
+			all = make(map[string]interface{})
+			run.Metadata["hooks"] = all
+		}
+		byAgent := make(map[string]interface{}, len(outputs))
+		for agent, output := range outputs {
+			byAgent[agent] = output
+		}
+		all[params.Hook] = byAgent
+	}
+	return err
+}
+
+// CircuitOpened runs the job's on_circuit_open hooks after the scheduler's
+// circuit breaker paused it. runID is the failed run that tripped it, and
+// the agents get the failure count in $CONSECUTIVE_FAILURES.
+func (r *Runner) CircuitOpened(ctx context.Context, job *config.Job, runID string, failures int) {
+	if len(job.Hooks.OnCircuitOpen) == 0 {
+		return
+	}
+
+	log := r.jobLogger(job.ID, runID)
+	log.Debug("executing on_circuit_open hooks", "count", len(job.Hooks.OnCircuitOpen))
+	params := plugins.AgentParams{
+		JobID:       job.ID,
+		JobCommand:  job.Command.String(),
+		JobSchedule: job.Schedule,
+		Hook:        string(plugins.OnCircuitOpen),
+		RunID:       runID,
+		Attempt:     1,
+		StartTS:     time.Now(),
+		StateDir:    filepath.Join(r.stateDir, job.ID),
+		ExtraEnv:    map[string]string{"CONSECUTIVE_FAILURES": strconv.Itoa(failures)},
+		TimeoutSec:  r.defaults.AgentTimeoutSec,
+	}
+	_, err := plugins.ExecuteHooks(ctx, r.pluginMgr, job.Hooks.OnCircuitOpen, params, plugins.HookOptions{
+		FailOnError: r.defaults.FailOnAgentError,
+		Concurrency: r.defaults.HookConcurrency,
+	})
+	if err != nil {
+		log.Error("on_circuit_open hook failed", "error", err)
+	}
+}
+
+// reportFinished records a completed run in metrics and the audit log and
+// announces it on the event bus. Status uses the same "success"/"failure"
+// values as the HTTP API.
+func (r *Runner) reportFinished(run *store.JobRun) {
+	status := "success"
+	if !run.Success {
+		status = "failure"
+	}
+	r.metrics.ObserveRun(run.JobID, status, run.Duration(), run.EndTime)
+
+	errMsg, _ := run.Metadata["error"].(string)
+	r.publish(events.Event{
+		Type:       events.TypeRunFinished,
+		JobID:      run.JobID,
+		RunID:      run.RunID,
+		Time:       run.EndTime,
+		Status:     status,
+		ExitCode:   run.ExitCode,
+		DurationMs: float64(run.Duration().Milliseconds()),
+		Error:      errMsg,
+	}, run.Trigger)
+
+	err := r.audit.Append(logging.AuditRecord{
+		JobID:       run.JobID,
+		RunID:       run.RunID,
+		StartTime:   run.StartTime,
+		EndTime:     run.EndTime,
+		ExitCode:    run.ExitCode,
+		Success:     run.Success,
+		TriggeredBy: run.Trigger,
+	})
+	if err != nil {
+		r.jobLogger(run.JobID, run.RunID).Error("failed to write audit record", "error", err)
+	}
+}
+
+const (
+	// eventSinkTimeout bounds how long publishing one run event to the
+	// event sink may take.
+	eventSinkTimeout = 5 * time.Second
+
+	// eventSinkQueueSize is how many run events may wait for a slow event
+	// sink before more are dropped.
+	eventSinkQueueSize = 256
+)
+
+// publish announces e on the event bus and queues it for the event sink.
+// Queueing never blocks: when the sink has fallen eventSinkQueueSize events
+// behind, e is dropped and logged. Sink failures are logged by the queue.
+func (r *Runner) publish(e events.Event, trigger string) {
+	r.events.Publish(e)
+
+	err := r.sink.Publish(context.Background(), eventsink.RunEvent{
+		Type:       e.Type,
+		JobID:      e.JobID,
+		RunID:      e.RunID,
+		Time:       e.Time,
+		Status:     e.Status,
+		Trigger:    trigger,
+		ExitCode:   e.ExitCode,
+		DurationMs: e.DurationMs,
+		Error:      e.Error,
+	})
+	if err != nil {
+		r.jobLogger(e.JobID, e.RunID).Warn("dropped run event", "type", e.Type, "error", err)
+	}
+}
+
+// triggerDependents starts every job that depends on jobID. Paused or
+// disabled dependents and triggers refused during shutdown are logged and
+// skipped.
+func (r *Runner) triggerDependents(jobID, runID string) {
+	r.depMu.RLock()
+	dependents, trigger := r.dependents[jobID], r.trigger
+	r.depMu.RUnlock()
+	if trigger == nil {
+		return
+	}
+
+	for _, dependent := range dependents {
+		depRunID, err := trigger.RunDependentJob(dependent)
+		if err != nil {
+			r.logger.Warn("dependent job not started",
+				"job_id", dependent,
+				"upstream_job_id", jobID,
+				"upstream_run_id", runID,
+				"error", err)
+			continue
+		}
+		r.logger.Info("dependent job started",
+			"job_id", dependent,
+			"run_id", depRunID,
+			"upstream_job_id", jobID,
+			"upstream_run_id", runID)
+	}
+}
+
+// processWaitDelay bounds how long a killed command's output pipes may stay
+// open before Wait gives up on them.
+const processWaitDelay = 5 * time.Second
+
 // Backoff bounds for retries between job attempts.
 const (
 	baseBackoff = 1 * time.Second
@@ -217,7 +623,13 @@ const (
 // The per-attempt timeout is enforced by executeCommand, so each retry gets the
 // full job.TimeoutSec budget. If the context is cancelled during a backoff wait
 // (e.g. graceful shutdown), retrying stops and the last failure is returned.
-func (r *Runner) executeWithRetries(ctx context.Context, job *config.Job, runID string) (exitCode int, stdout, stderr string, attempts int, execErr error) {
+//
+// Before each retry the in-progress attempt number is written to
+// run.Metadata["attempt"] and persisted, so the dashboard and TUI can show which
+// attempt a still-running job is on.
+func (r *Runner) executeWithRetries(ctx context.Context, job *config.Job, run *store.JobRun) (exitCode int, stdout, stderr string, attempts int, execErr error) {
+	runID := run.RunID
+	log := r.jobLogger(job.ID, runID)
 	maxAttempts := r.defaults.JobRetries + 1
 	if maxAttempts < 1 {
 		maxAttempts = 1
@@ -225,7 +637,13 @@ func (r *Runner) executeWithRetries(ctx context.Context, job *config.Job, runID
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		attempts = attempt
-		exitCode, stdout, stderr, execErr = r.executeCommand(ctx, job)
+		if attempt > 1 {
+			run.Metadata["attempt"] = attempt
+			if err := r.store.SaveRun(context.WithoutCancel(ctx), run); err != nil {
+				log.Error("failed to save run", "error", err)
+			}
+		}
+		exitCode, stdout, stderr, execErr = r.executeCommand(ctx, job, runID, attempt)
 
 		// Success: stop retrying.
 		if execErr == nil && exitCode == 0 {
@@ -238,9 +656,7 @@ func (r *Runner) executeWithRetries(ctx context.Context, job *config.Job, runID
 		}
 
 		delay := backoffDuration(r.defaults.JobBackoffStrategy, attempt)
-		r.logger.Warn("job attempt failed; retrying after backoff",
-			"job_id", job.ID,
-			"run_id", runID,
+		log.Warn("job attempt failed; retrying after backoff",
 			"attempt", attempt,
 			"max_attempts", maxAttempts,
 			"exit_code", exitCode,
@@ -250,10 +666,7 @@ func (r *Runner) executeWithRetries(ctx context.Context, job *config.Job, runID
 		case <-time.After(delay):
 			// proceed to the next attempt
 		case <-ctx.Done():
-			r.logger.Warn("retry backoff aborted by context cancellation",
-				"job_id", job.ID,
-				"run_id", runID,
-				"attempt", attempt)
+			log.Warn("retry backoff aborted by context cancellation", "attempt", attempt)
 			return exitCode, stdout, stderr, attempts, execErr
 		}
 	}
@@ -287,8 +700,121 @@ func backoffDuration(strategy string, attempt int) time.Duration {
 	return d
 }
 
-// executeCommand runs the job command and captures output
-func (r *Runner) executeCommand(ctx context.Context, job *config.Job) (int, string, string, error) {
+// resolveCommand returns the argv a job runs with, after env_file loading,
+// templated_command expansion against run, and $VAR interpolation, along
+// with the env_file entries and the interpolated job env to add to the
+// process environment.
+func resolveCommand(job *config.Job, run commandRun) (argv []string, fileEnv, env map[string]string, err error) {
+	// Get command parts (preserves array structure from YAML)
+	parts := job.Command.Parts()
+	if len(parts) == 0 {
+		return nil, nil, nil, fmt.Errorf("empty command")
+	}
+
+	// Load env_file entries; they are taken literally (secrets often contain
+	// '$') and explicit env entries take precedence over them.
+	if path := job.EnvFilePath(); path != "" {
+		if fileEnv, err = config.ParseEnvFile(path); err != nil {
+			return nil, nil, nil, fmt.Errorf("load env_file: %w", err)
+		}
+	}
+	lookup := func(name string) (string, bool) {
+		if v, ok := fileEnv[name]; ok {
+			return v, true
+		}
+		return os.LookupEnv(name)
+	}
+
+	// Interpolate $VAR references in env values
+	env = job.Env
+	if !job.NoInterpolate {
+		if env, err = expandJobEnv(job.Env, lookup); err != nil {
+			return nil, nil, nil, fmt.Errorf("interpolate env: %w", err)
+		}
+	}
+	vars := environMap(os.Environ())
+	maps.Copy(vars, fileEnv)
+	maps.Copy(vars, env)
+
+	// Expand templated_command arguments, each on its own so a value never
+	// splits into more arguments
+	script := job.Command.String()
+	if job.TemplatedCommand {
+		run.JobID = job.ID
+		run.Env = vars
+		if job.Shell {
+			script, err = expandArgTemplate(script, run)
+		} else {
+			parts, err = expandArgTemplates(parts, run)
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if job.Shell {
+		// Shell mode: let sh interpret the command string as written.
+		return []string{"/bin/sh", "-c", script}, fileEnv, env, nil
+	}
+
+	// Interpolate $VAR references in each argument after tokenization (in
+	// shell mode sh does its own expansion). Templated arguments are final:
+	// a $ in a template's output, such as an {{.Env.NAME}} value, is kept.
+	if !job.NoInterpolate && !job.TemplatedCommand {
+		if parts, err = expandArgs(parts, vars); err != nil {
+			return nil, nil, nil, fmt.Errorf("interpolate command: %w", err)
+		}
+	}
+	return parts, fileEnv, env, nil
+}
+
+// errCommandTimeout is wrapped by executeCommand's error when the command was
+// killed for running longer than the job's timeout.
+var errCommandTimeout = errors.New("command timed out")
+
+// missingPathError is executeCommand's error when the command could not
+// start because its executable or working directory does not exist, as
+// opposed to a command that ran and failed.
+type missingPathError struct {
+	status string // store.StatusCommandNotFound or store.StatusWorkdirNotFound
+	path   string
+	err    error
+}
+
+func (e *missingPathError) Error() string {
+	if e.status == store.StatusWorkdirNotFound {
+		return fmt.Sprintf("working directory not found: %s", e.path)
+	}
+	return fmt.Sprintf("command not found: %s", e.path)
+}
+
+func (e *missingPathError) Unwrap() error { return e.err }
+
+// classifyStartError wraps err in a missingPathError if cmd failed to start
+// because a path it needs does not exist, and returns it unchanged
+// otherwise.
+func classifyStartError(cmd *exec.Cmd, err error) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		// Not found in $PATH
+		return &missingPathError{status: store.StatusCommandNotFound, path: cmd.Args[0], err: err}
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	// The child's chdir fails with the same ENOENT as a missing executable,
+	// reported against the executable's path, so check the workdir first
+	if cmd.Dir != "" {
+		if _, statErr := os.Stat(cmd.Dir); errors.Is(statErr, fs.ErrNotExist) {
+			return &missingPathError{status: store.StatusWorkdirNotFound, path: cmd.Dir, err: err}
+		}
+	}
+	return &missingPathError{status: store.StatusCommandNotFound, path: cmd.Path, err: err}
+}
+
+// executeCommand runs one attempt of the job command. Output is appended to
+// the run's log files in the history directory as it is produced, and the
+// last max_output_bytes of each stream are returned.
+func (r *Runner) executeCommand(ctx context.Context, job *config.Job, runID string, attempt int) (int, string, string, error) {
 	// Create command with timeout
 	timeout := time.Duration(job.TimeoutSec) * time.Second
 	if timeout == 0 {
@@ -298,13 +824,14 @@ func (r *Runner) executeCommand(ctx context.Context, job *config.Job) (int, stri
 	cmdCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Get command parts (preserves array structure from YAML)
-	parts := job.Command.Parts()
-	if len(parts) == 0 {
-		return -1, "", "", fmt.Errorf("empty command")
+	argv, fileEnv, env, err := resolveCommand(job, commandRun{Now: time.Now(), RunID: runID, Attempt: attempt})
+	if err != nil {
+		return -1, "", "", err
 	}
+	cmd := exec.CommandContext(cmdCtx, argv[0], argv[1:]...)
 
-	cmd := exec.CommandContext(cmdCtx, parts[0], parts[1:]...)
+	// Kill the whole process tree on timeout, not just the direct child
+	configureProcessGroup(cmd)
 
 	// Set working directory
 	if job.Workdir != "" {
@@ -313,17 +840,35 @@ func (r *Runner) executeCommand(ctx context.Context, job *config.Job) (int, stri
 
 	// Set environment variables
 	cmd.Env = os.Environ()
-	for k, v := range job.Env {
+	for k, v := range fileEnv {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+	for k, v := range env {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
 	}
 
-	// Capture stdout and stderr
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	// Capture stdout and stderr, keeping only the last max_output_bytes of
+	// each in memory so a runaway command cannot exhaust it, and streaming
+	// everything to the run's log files
+	maxOutput := r.maxOutputBytes()
+	stdout, stderr := newTailBuffer(maxOutput), newTailBuffer(maxOutput)
+	stdoutLog := r.newRunLogWriter(job.ID, runID, "stdout", attempt)
+	stderrLog := r.newRunLogWriter(job.ID, runID, "stderr", attempt)
+	log := r.jobLogger(job.ID, runID)
+	defer func() {
+		// Close after Run has returned: its output copying is done by then,
+		// including when the command was killed on timeout
+		for _, w := range []*runLogWriter{stdoutLog, stderrLog} {
+			if err := w.Close(); err != nil {
+				log.Error("failed to write run log", "stream", w.stream, "error", err)
+			}
+		}
+	}()
+	cmd.Stdout = io.MultiWriter(stdout, stdoutLog)
+	cmd.Stderr = io.MultiWriter(stderr, stderrLog)
 
 	// Execute command
-	err := cmd.Run()
+	err = cmd.Run()
 
 	exitCode := 0
 	if err != nil {
@@ -331,12 +876,52 @@ func (r *Runner) executeCommand(ctx context.Context, job *config.Job) (int, stri
 			exitCode = exitErr.ExitCode()
 		} else {
 			exitCode = -1
+			err = classifyStartError(cmd, err)
+		}
+	}
+	// Tell a kill at the job's own timeout apart from a cancelled parent
+	// context (shutdown) and from the command failing on its own
+	if err != nil && errors.Is(cmdCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		err = fmt.Errorf("%w after %s: %v", errCommandTimeout, timeout, err)
+	}
+
+	for _, c := range []struct {
+		stream string
+		buf    *tailBuffer
+	}{{"stdout", stdout}, {"stderr", stderr}} {
+		if dropped := c.buf.Dropped(); dropped > 0 {
+			log.Warn("job output exceeded max_output_bytes; keeping the end",
+				"stream", c.stream,
+				"discarded_bytes", dropped,
+				"max_output_bytes", maxOutput)
 		}
 	}
 
 	return exitCode, stdout.String(), stderr.String(), err
 }
 
+// Output capture limits used when the defaults leave them unset.
+const (
+	defaultMaxOutputBytes  = 1 << 20
+	defaultOutputTailBytes = 10000
+)
+
+// maxOutputBytes returns defaults.max_output_bytes, or its default.
+func (r *Runner) maxOutputBytes() int {
+	if r.defaults.MaxOutputBytes > 0 {
+		return r.defaults.MaxOutputBytes
+	}
+	return defaultMaxOutputBytes
+}
+
+// outputTailBytes returns defaults.output_tail_bytes, or its default.
+func (r *Runner) outputTailBytes() int {
+	if r.defaults.OutputTailBytes > 0 {
+		return r.defaults.OutputTailBytes
+	}
+	return defaultOutputTailBytes
+}
+
 // RunJob is now an alias to Run for compatibility with scheduler.JobRunner interface
 func (r *Runner) Run(ctx context.Context, job *config.Job) error {
 	return r.RunJob(ctx, job)
@@ -349,25 +934,3 @@ func (r *Runner) tailOutput(output string, maxChars int) string {
 	}
 	return "..." + output[len(output)-maxChars:]
 }
-
-// saveFullLogs saves complete logs to history directory
-func (r *Runner) saveFullLogs(runID, jobID, stdout, stderr string) {
-	logDir := filepath.Join(r.historyDir, jobID)
-	os.MkdirAll(logDir, 0o755)
-
-	// Save stdout
-	if stdout != "" {
-		stdoutPath := filepath.Join(logDir, fmt.Sprintf("%s.stdout.log", runID))
-		if err := os.WriteFile(stdoutPath, []byte(stdout), 0o644); err != nil {
-			r.logger.Error("failed to save stdout", "run_id", runID, "error", err)
-		}
-	}
-
-	// Save stderr
-	if stderr != "" {
-		stderrPath := filepath.Join(logDir, fmt.Sprintf("%s.stderr.log", runID))
-		if err := os.WriteFile(stderrPath, []byte(stderr), 0o644); err != nil {
-			r.logger.Error("failed to save stderr", "run_id", runID, "error", err)
-		}
-	}
-}
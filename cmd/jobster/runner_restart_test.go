@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_RestartOnFailureIsBounded(t *testing.T) {
+	dir := t.TempDir()
+	script, counter := writeCountingScript(t, dir, 100) // always fails
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	job := &config.Job{
+		ID:             "crashing",
+		Schedule:       "@every 1h",
+		Command:        config.NewCommandSpec("/bin/sh " + script),
+		TimeoutSec:     5,
+		Env:            map[string]string{"COUNTER_FILE": counter, "SUCCEED_ON": "100"},
+		Restart:        config.RestartOnFailure,
+		RestartBackoff: "10ms",
+		MaxRestarts:    2,
+	}
+
+	require.Error(t, runner.RunJob(context.Background(), job), "the last run's failure is returned")
+	assert.Equal(t, 3, readCount(t, counter), "one run plus max_restarts restarts")
+
+	runs, err := st.GetJobRuns(context.Background(), "crashing", 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 3, "each restart is recorded as its own run")
+
+	triggers := map[string]int{}
+	for _, run := range runs {
+		assert.False(t, run.Success)
+		triggers[run.Trigger]++
+	}
+	assert.Equal(t, map[string]int{scheduler.TriggerManual: 1, scheduler.TriggerRestart: 2}, triggers)
+}
+
+func TestRunner_RestartOnFailureStopsOnSuccess(t *testing.T) {
+	dir := t.TempDir()
+	script, counter := writeCountingScript(t, dir, 2) // fails once, then succeeds
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	job := &config.Job{
+		ID:             "recovering",
+		Schedule:       "@every 1h",
+		Command:        config.NewCommandSpec("/bin/sh " + script),
+		TimeoutSec:     5,
+		Env:            map[string]string{"COUNTER_FILE": counter, "SUCCEED_ON": "2"},
+		Restart:        config.RestartOnFailure,
+		RestartBackoff: "10ms",
+	}
+
+	require.NoError(t, runner.RunJob(context.Background(), job))
+	assert.Equal(t, 2, readCount(t, counter))
+
+	runs, err := st.GetJobRuns(context.Background(), "recovering", 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.True(t, runs[0].Success, "the restarted run succeeded")
+	assert.Equal(t, scheduler.TriggerRestart, runs[0].Trigger)
+}
+
+func TestRunner_RestartAlways(t *testing.T) {
+	dir := t.TempDir()
+	script, counter := writeCountingScript(t, dir, 1) // always succeeds
+	runner, _ := newTestRunner(t, dir, config.Defaults{})
+
+	job := &config.Job{
+		ID:             "daemon",
+		Schedule:       "@every 1h",
+		Command:        config.NewCommandSpec("/bin/sh " + script),
+		TimeoutSec:     5,
+		Env:            map[string]string{"COUNTER_FILE": counter, "SUCCEED_ON": "1"},
+		Restart:        config.RestartAlways,
+		RestartBackoff: "10ms",
+		MaxRestarts:    1,
+	}
+
+	require.NoError(t, runner.RunJob(context.Background(), job))
+	assert.Equal(t, 2, readCount(t, counter), "always restarts even after success")
+}
+
+func TestRunner_RestartStopsOnCancel(t *testing.T) {
+	dir := t.TempDir()
+	script, counter := writeCountingScript(t, dir, 100)
+	runner, _ := newTestRunner(t, dir, config.Defaults{})
+
+	job := &config.Job{
+		ID:             "cancelled",
+		Schedule:       "@every 1h",
+		Command:        config.NewCommandSpec("/bin/sh " + script),
+		TimeoutSec:     5,
+		Env:            map[string]string{"COUNTER_FILE": counter, "SUCCEED_ON": "100"},
+		Restart:        config.RestartOnFailure,
+		RestartBackoff: "1h",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	done := make(chan struct{})
+	go func() {
+		_ = runner.RunJob(ctx, job)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunJob did not return after cancellation during restart backoff")
+	}
+	assert.Equal(t, 1, readCount(t, counter))
+}
+
+func TestRunner_RestartStopsWhenJobPausedOrRemoved(t *testing.T) {
+	for name, stop := range map[string]func(*scheduler.Scheduler, string) error{
+		"paused":  (*scheduler.Scheduler).PauseJob,
+		"removed": (*scheduler.Scheduler).RemoveJob,
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			script, counter := writeCountingScript(t, dir, 100) // always fails
+			runner, _ := newTestRunner(t, dir, config.Defaults{})
+
+			sched := scheduler.New(context.Background(), runner.logger)
+			require.NoError(t, sched.AddJob(&config.Job{
+				ID:             "crashing",
+				Schedule:       "@every 1h",
+				Command:        config.NewCommandSpec("/bin/sh " + script),
+				TimeoutSec:     5,
+				Env:            map[string]string{"COUNTER_FILE": counter, "SUCCEED_ON": "100"},
+				Restart:        config.RestartOnFailure,
+				RestartBackoff: "300ms",
+			}, runner))
+			require.NoError(t, sched.Start())
+			defer sched.Stop()
+
+			_, err := sched.RunJobNow("crashing")
+			require.NoError(t, err)
+			require.Eventually(t, func() bool { return readCount(t, counter) == 1 },
+				5*time.Second, 5*time.Millisecond)
+
+			// The first run failed and the restart backoff has begun
+			require.NoError(t, stop(sched, "crashing"))
+			require.Eventually(t, func() bool { return sched.InFlight() == 0 },
+				5*time.Second, 10*time.Millisecond)
+			assert.Equal(t, 1, readCount(t, counter), "no restart after the job was %s", name)
+		})
+	}
+}
+
+func TestRestartDelay(t *testing.T) {
+	assert.Equal(t, time.Second, restartDelay(time.Second, 1))
+	assert.Equal(t, 4*time.Second, restartDelay(time.Second, 3))
+	assert.Equal(t, maxBackoff, restartDelay(time.Second, 40))
+}
@@ -89,7 +89,7 @@ func TestRunner_NoRetryWhenSuccessful(t *testing.T) {
 
 	assert.Equal(t, 1, readCount(t, counter), "a successful job must run exactly once")
 
-	runs, err := st.GetJobRuns("happy-job", 5)
+	runs, err := st.GetJobRuns(context.Background(), "happy-job", 5)
 	require.NoError(t, err)
 	require.NotEmpty(t, runs)
 	assert.True(t, runs[0].Success, "job should be recorded as successful")
@@ -119,7 +119,7 @@ func TestRunner_RetrySucceedsAfterFailures(t *testing.T) {
 	assert.Equal(t, 2, readCount(t, counter), "job should be retried once and then succeed")
 	assert.GreaterOrEqual(t, elapsed, baseBackoff, "a linear backoff should delay the single retry by ~1s")
 
-	runs, err := st.GetJobRuns("flaky-job", 5)
+	runs, err := st.GetJobRuns(context.Background(), "flaky-job", 5)
 	require.NoError(t, err)
 	require.NotEmpty(t, runs)
 	assert.True(t, runs[0].Success, "job should ultimately succeed")
@@ -147,10 +147,79 @@ func TestRunner_RetriesExhausted(t *testing.T) {
 
 	assert.Equal(t, 3, readCount(t, counter), "job_retries=2 means exactly 3 attempts")
 
-	runs, err := st.GetJobRuns("doomed-job", 5)
+	runs, err := st.GetJobRuns(context.Background(), "doomed-job", 5)
 	require.NoError(t, err)
 	require.NotEmpty(t, runs)
 	assert.False(t, runs[0].Success, "job should be recorded as failed")
+	assert.EqualValues(t, 3, runs[0].Metadata["attempt"], "metadata should record the final attempt number")
+	assert.EqualValues(t, 3, runs[0].Metadata["max_attempts"])
+}
+
+func TestRunner_RetryAttemptSavedWhileRunning(t *testing.T) {
+	dir := t.TempDir()
+	startedPath := filepath.Join(dir, "started")
+	releasePath := filepath.Join(dir, "release")
+	scriptPath := filepath.Join(dir, "blocking.sh")
+
+	// The first attempt fails; the second marks itself started and then waits
+	// for the test to release it
+	script := `#!/bin/sh
+if [ ! -f "$FAILED_FILE" ]; then
+  : > "$FAILED_FILE"
+  exit 1
+fi
+: > "$STARTED_FILE"
+while [ ! -f "$RELEASE_FILE" ]; do sleep 0.05; done
+exit 0
+`
+	require.NoError(t, os.WriteFile(scriptPath, []byte(script), 0o755))
+
+	runner, st := newTestRunner(t, dir, config.Defaults{
+		JobRetries:         2,
+		JobBackoffStrategy: "linear",
+	})
+
+	job := &config.Job{
+		ID:         "slow-retry-job",
+		Schedule:   "@every 1s",
+		Command:    config.NewCommandSpec("/bin/sh " + scriptPath),
+		TimeoutSec: 10,
+		Env: map[string]string{
+			"FAILED_FILE":  filepath.Join(dir, "failed"),
+			"STARTED_FILE": startedPath,
+			"RELEASE_FILE": releasePath,
+		},
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- runner.RunJob(context.Background(), job) }()
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(startedPath)
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond, "second attempt never started")
+
+	// While the second attempt runs, the stored record already says so
+	runs, err := st.GetJobRuns(context.Background(), "slow-retry-job", 5)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	run, err := st.GetRun(context.Background(), runs[0].RunID)
+	require.NoError(t, err)
+	assert.True(t, run.IsRunning(), "run should still be in progress")
+	assert.EqualValues(t, 2, run.Metadata["attempt"])
+
+	require.NoError(t, os.WriteFile(releasePath, nil, 0o644))
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunJob did not return after the second attempt was released")
+	}
+
+	run, err = st.GetRun(context.Background(), runs[0].RunID)
+	require.NoError(t, err)
+	assert.True(t, run.Success)
+	assert.EqualValues(t, 2, run.Metadata["attempt"])
 }
 
 func TestRunner_RetryBackoffAbortedOnCancel(t *testing.T) {
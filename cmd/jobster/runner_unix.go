@@ -0,0 +1,21 @@
+//go:build unix
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// configureProcessGroup starts cmd in its own process group and makes
+// context cancellation (timeout or shutdown) SIGKILL the whole group, so
+// processes spawned by a shell script do not outlive the job.
+func configureProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	// A process that left the group could still hold the output pipes open;
+	// don't let it block the run from finishing.
+	cmd.WaitDelay = processWaitDelay
+}
@@ -0,0 +1,70 @@
+//go:build unix
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_TimeoutKillsProcessGroup(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	pidFile := filepath.Join(dir, "child.pid")
+
+	// The shell leaves a background child holding its stdout open
+	job := &config.Job{
+		ID:         "spawner",
+		Schedule:   "@every 1h",
+		Command:    config.NewCommandSpec(`sleep 30 & echo $! > child.pid; wait`),
+		Shell:      true,
+		Workdir:    dir,
+		TimeoutSec: 1,
+	}
+
+	start := time.Now()
+	runner.RunJob(context.Background(), job)
+	assert.Less(t, time.Since(start), 4*time.Second, "the child kept the job's output open")
+
+	runs, err := st.GetJobRuns(context.Background(), "spawner", 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.False(t, runs[0].Success)
+
+	data, err := os.ReadFile(pidFile)
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(string(bytes.TrimSpace(data)))
+	require.NoError(t, err)
+
+	if !assert.Eventually(t, func() bool { return !processAlive(pid) }, 2*time.Second, 20*time.Millisecond,
+		"child process %d survived the job timeout", pid) {
+		syscall.Kill(pid, syscall.SIGKILL)
+	}
+}
+
+// processAlive reports whether pid exists and is not a zombie waiting to be
+// reaped by init.
+func processAlive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err != nil {
+		return false
+	}
+	stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return true
+	}
+	// The state follows the parenthesised command name
+	if i := bytes.LastIndexByte(stat, ')'); i >= 0 && i+2 < len(stat) {
+		return stat[i+2] != 'Z'
+	}
+	return true
+}
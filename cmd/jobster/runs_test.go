@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/server"
+	"github.com/caevv/jobster/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_RunFilters(t *testing.T) {
+	dir := t.TempDir()
+	_, st := newTestRunner(t, dir, config.Defaults{})
+
+	// 10 runs a minute apart; odd-numbered runs fail
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		run := &store.JobRun{
+			RunID:     fmt.Sprintf("run-%d", i),
+			JobID:     "filter-job",
+			StartTime: start.Add(time.Duration(i) * time.Minute),
+			EndTime:   start.Add(time.Duration(i)*time.Minute + time.Second),
+			Success:   i%2 == 0,
+		}
+		require.NoError(t, st.SaveRun(context.Background(), run))
+	}
+
+	sched := scheduler.New(context.Background(), nil)
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), nil, "", nil)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	list := func(query url.Values) (int, []string) {
+		t.Helper()
+		resp, err := http.Get(ts.URL + "/api/runs?" + query.Encode())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, nil
+		}
+		var runs []server.RunRecord
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&runs))
+		ids := make([]string, len(runs))
+		for i, run := range runs {
+			ids[i] = run.RunID
+		}
+		return resp.StatusCode, ids
+	}
+
+	status, ids := list(url.Values{"status": {"failure"}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"run-9", "run-7", "run-5", "run-3", "run-1"}, ids)
+
+	status, ids = list(url.Values{
+		"since": {start.Add(2 * time.Minute).Format(time.RFC3339)},
+		"until": {start.Add(5 * time.Minute).Format(time.RFC3339)},
+	})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"run-4", "run-3", "run-2"}, ids)
+
+	status, ids = list(url.Values{"status": {"success"}, "limit": {"2"}, "offset": {"1"}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"run-6", "run-4"}, ids)
+
+	status, ids = list(url.Values{"offset": {"50"}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, ids)
+
+	for _, bad := range []url.Values{
+		{"status": {"ok"}},
+		{"since": {"yesterday"}},
+		{"offset": {"-1"}},
+	} {
+		status, _ = list(bad)
+		assert.Equal(t, http.StatusBadRequest, status, "query %s", bad.Encode())
+	}
+}
+
+func TestServe_RunPagination(t *testing.T) {
+	dir := t.TempDir()
+	_, st := newTestRunner(t, dir, config.Defaults{})
+
+	// 25 runs of one job and 5 of another, a second apart
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 30; i++ {
+		jobID := "paged-job"
+		if i >= 25 {
+			jobID = "other-job"
+		}
+		run := &store.JobRun{
+			RunID:     fmt.Sprintf("run-%02d", i),
+			JobID:     jobID,
+			StartTime: start.Add(time.Duration(i) * time.Second),
+			EndTime:   start.Add(time.Duration(i)*time.Second + time.Millisecond),
+			Success:   true,
+		}
+		require.NoError(t, st.SaveRun(context.Background(), run))
+	}
+
+	sched := scheduler.New(context.Background(), nil)
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), nil, "", nil)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	page := func(path string) (int, string, []string) {
+		t.Helper()
+		resp, err := http.Get(ts.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, "", nil
+		}
+		var runs []server.RunRecord
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&runs))
+		ids := make([]string, len(runs))
+		for i, run := range runs {
+			ids[i] = run.RunID
+		}
+		return resp.StatusCode, resp.Header.Get("X-Total-Count"), ids
+	}
+
+	// Walk the job's history in pages of 10
+	var all []string
+	for offset := 0; ; offset += 10 {
+		status, total, ids := page(fmt.Sprintf("/api/jobs/paged-job/runs?limit=10&offset=%d", offset))
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "25", total)
+		if len(ids) == 0 {
+			break
+		}
+		all = append(all, ids...)
+	}
+	require.Len(t, all, 25)
+	assert.Equal(t, "run-24", all[0])
+	assert.Equal(t, "run-00", all[24])
+
+	status, total, ids := page("/api/jobs/paged-job/runs?limit=10&offset=20")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "25", total)
+	assert.Equal(t, []string{"run-04", "run-03", "run-02", "run-01", "run-00"}, ids, "last partial page")
+
+	status, total, ids = page("/api/runs?limit=5&offset=5")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "30", total)
+	assert.Equal(t, []string{"run-24", "run-23", "run-22", "run-21", "run-20"}, ids)
+
+	status, _, _ = page("/api/jobs/paged-job/runs?offset=abc")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestServe_RunStatusMissingCommand(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	for runID, command := range map[string]string{
+		"missing-run": "/no/such/binary",
+		"failing-run": "/bin/sh -c 'exit 1'",
+	} {
+		job := &config.Job{ID: "job", Schedule: "@every 1h", Command: config.NewCommandSpec(command), TimeoutSec: 5}
+		require.Error(t, runner.RunJob(scheduler.ContextWithRunID(context.Background(), runID), job))
+	}
+
+	sched := scheduler.New(context.Background(), nil)
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), nil, "", nil)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	get := func(runID string) server.RunRecord {
+		t.Helper()
+		resp, err := http.Get(ts.URL + "/api/runs/" + runID)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var run server.RunRecord
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&run))
+		return run
+	}
+
+	missing := get("missing-run")
+	assert.Equal(t, store.StatusCommandNotFound, missing.Status)
+	assert.Equal(t, "/no/such/binary", missing.Metadata["missing_path"])
+
+	failing := get("failing-run")
+	assert.Equal(t, store.StatusFailure, failing.Status)
+	assert.Equal(t, 1, failing.ExitCode)
+	assert.NotContains(t, failing.Metadata, "missing_path")
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/server"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_JobSchedule(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	sched := scheduler.New(context.Background(), runner.logger)
+	require.NoError(t, sched.AddJob(&config.Job{
+		ID:         "hourly",
+		Schedule:   "@every 1h",
+		Command:    config.NewCommandSpec("echo hello"),
+		TimeoutSec: 5,
+	}, runner))
+	require.NoError(t, sched.AddJob(&config.Job{
+		ID:         "after-hourly",
+		DependsOn:  []string{"hourly"},
+		Command:    config.NewCommandSpec("echo after"),
+		TimeoutSec: 5,
+	}, runner))
+
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), server.NewSchedulerAdapter(sched), "", runner.logger)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	get := func(path string) (int, server.ScheduleResponse) {
+		t.Helper()
+		resp, err := http.Get(ts.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var body server.ScheduleResponse
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		}
+		return resp.StatusCode, body
+	}
+
+	status, body := get("/api/jobs/hourly/schedule")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "hourly", body.JobID)
+	require.Len(t, body.NextRuns, 5, "default count")
+	assert.Equal(t, time.Hour, body.NextRuns[1].Sub(body.NextRuns[0]))
+
+	status, body = get("/api/jobs/hourly/schedule?count=2")
+	require.Equal(t, http.StatusOK, status)
+	assert.Len(t, body.NextRuns, 2)
+
+	status, body = get("/api/jobs/after-hourly/schedule")
+	require.Equal(t, http.StatusOK, status)
+	assert.NotNil(t, body.NextRuns)
+	assert.Empty(t, body.NextRuns, "depends_on jobs have no schedule")
+
+	status, _ = get("/api/jobs/missing/schedule")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	for _, count := range []string{"0", "-1", "101", "abc"} {
+		status, _ = get("/api/jobs/hourly/schedule?count=" + count)
+		assert.Equal(t, http.StatusBadRequest, status, "count=%s", count)
+	}
+}
+
+func TestJobListCommand_Next(t *testing.T) {
+	prevLogger := logger
+	logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	t.Cleanup(func() { logger = prevLogger })
+
+	configPath := filepath.Join(t.TempDir(), "jobster.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+defaults:
+  timezone: "UTC"
+
+jobs:
+  - id: "nightly"
+    schedule: "0 3 * * *"
+    command: "/bin/true"
+  - id: "report"
+    depends_on: ["nightly"]
+    command: "/bin/true"
+`), 0o644))
+
+	out, err := executeRootCmd(t, []*cobra.Command{listJobsCmd}, "job", "list", "--config", configPath, "--next", "2")
+	require.NoError(t, err)
+
+	lines := strings.Split(out, "\n")
+	require.GreaterOrEqual(t, len(lines), 5)
+	assert.Contains(t, lines[0], "NEXT RUNS")
+	assert.Contains(t, lines[2], "nightly")
+	assert.Contains(t, lines[2], "03:00:00 UTC")
+	assert.Contains(t, lines[3], "03:00:00 UTC", "second run on a continuation row")
+	assert.NotContains(t, lines[3], "nightly")
+	assert.Contains(t, lines[4], "after nightly")
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(lines[4]), "-"))
+	assert.Contains(t, out, "Total jobs: 2")
+}
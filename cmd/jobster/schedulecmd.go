@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/spf13/cobra"
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Show upcoming scheduled runs across all jobs",
+	Long: `Show when each job fires next, soonest first, as computed from the
+configuration file. Nothing is started; jobs that are disabled or triggered
+by depends_on have no fire times and are left out.
+
+Examples:
+  jobster schedule --config jobster.yaml
+  jobster schedule --count 3 --config jobster.yaml
+  jobster schedule --json --config jobster.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runSchedule,
+}
+
+func init() {
+	scheduleCmd.Flags().StringP("config", "c", "jobster.yaml", "Path to configuration file")
+	scheduleCmd.Flags().IntP("count", "n", 1, "Number of upcoming runs to show per job")
+	scheduleCmd.Flags().Bool("json", false, "Print upcoming runs as JSON")
+	scheduleCmd.MarkFlagRequired("config")
+}
+
+// upcomingRun is one future fire time of a job.
+type upcomingRun struct {
+	JobID    string    `json:"job_id"`
+	Schedule string    `json:"schedule"`
+	NextRun  time.Time `json:"next_run"`
+}
+
+func runSchedule(cmd *cobra.Command, _ []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	count, _ := cmd.Flags().GetInt("count")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	if count <= 0 {
+		return fmt.Errorf("--count must be positive")
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	runs, err := upcomingRuns(cfg, time.Now(), count)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(runs)
+	}
+
+	if len(runs) == 0 {
+		fmt.Fprintln(out, "No scheduled jobs")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NEXT RUN\tJOB\tSCHEDULE")
+	fmt.Fprintln(w, "────────\t───\t────────")
+	for _, run := range runs {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", run.NextRun.Format("2006-01-02 15:04:05 MST"), run.JobID, run.Schedule)
+	}
+	return w.Flush()
+}
+
+// upcomingRuns returns the next count fire times after now of every enabled,
+// schedule-driven job in cfg, sorted by time and then job ID. Times are in
+// the zone each job's schedule is interpreted in.
+func upcomingRuns(cfg *config.Config, now time.Time, count int) ([]upcomingRun, error) {
+	loc, err := resolveLocation(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := []upcomingRun{}
+	for i := range cfg.Jobs {
+		job := &cfg.Jobs[i]
+		if !job.IsEnabled() || len(job.DependsOn) > 0 {
+			continue
+		}
+		jobLoc := loc
+		if job.Timezone != "" {
+			// Validated at config load
+			jobLoc, _ = config.LoadLocation(job.Timezone)
+		}
+
+		times, err := scheduler.NextRunTimes(job, loc, now, count)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range times {
+			runs = append(runs, upcomingRun{JobID: job.ID, Schedule: job.Schedule, NextRun: t.In(jobLoc)})
+		}
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		if !runs[i].NextRun.Equal(runs[j].NextRun) {
+			return runs[i].NextRun.Before(runs[j].NextRun)
+		}
+		return runs[i].JobID < runs[j].JobID
+	})
+	return runs, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scheduleTestConfig() *config.Config {
+	disabled := false
+	return &config.Config{
+		Defaults: config.Defaults{Timezone: "UTC"},
+		Jobs: []config.Job{
+			{ID: "daily", Schedule: "0 2 * * *"},
+			{ID: "quarter-hourly", Schedule: "*/15 * * * *"},
+			{ID: "hourly", Schedule: "@hourly"},
+			{ID: "after-daily", DependsOn: []string{"daily"}},
+			{ID: "off", Schedule: "@every 1m", Enabled: &disabled},
+		},
+	}
+}
+
+func TestUpcomingRuns_SortedByNextRun(t *testing.T) {
+	now := time.Date(2026, 3, 1, 1, 10, 0, 0, time.UTC)
+
+	runs, err := upcomingRuns(scheduleTestConfig(), now, 1)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, run := range runs {
+		ids = append(ids, run.JobID)
+	}
+	assert.Equal(t, []string{"quarter-hourly", "daily", "hourly"}, ids)
+	assert.Equal(t, time.Date(2026, 3, 1, 1, 15, 0, 0, time.UTC), runs[0].NextRun)
+	// daily and hourly both fire at 02:00; ties are ordered by job ID
+	assert.Equal(t, time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC), runs[1].NextRun)
+	assert.Equal(t, time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC), runs[2].NextRun)
+}
+
+func TestUpcomingRuns_Count(t *testing.T) {
+	now := time.Date(2026, 3, 1, 1, 10, 0, 0, time.UTC)
+
+	runs, err := upcomingRuns(scheduleTestConfig(), now, 3)
+	require.NoError(t, err)
+	require.Len(t, runs, 9)
+
+	perJob := map[string]int{}
+	for i, run := range runs {
+		perJob[run.JobID]++
+		if i > 0 {
+			assert.False(t, run.NextRun.Before(runs[i-1].NextRun), "run %d is out of order", i)
+		}
+	}
+	assert.Equal(t, map[string]int{"daily": 3, "hourly": 3, "quarter-hourly": 3}, perJob)
+}
+
+func TestScheduleCommand_JSON(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "jobster.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+jobs:
+  - id: "weekly"
+    schedule: "@weekly"
+    command: "/bin/true"
+  - id: "minutely"
+    schedule: "@every 1m"
+    command: "/bin/true"
+`), 0o644))
+
+	out, err := executeRootCmd(t, []*cobra.Command{scheduleCmd}, "schedule", "--config", configPath, "--json", "--count", "2")
+	require.NoError(t, err)
+
+	var runs []upcomingRun
+	require.NoError(t, json.Unmarshal([]byte(out), &runs))
+	require.Len(t, runs, 4)
+	assert.Equal(t, "minutely", runs[0].JobID)
+	assert.Equal(t, "minutely", runs[1].JobID)
+	assert.Equal(t, "weekly", runs[3].JobID)
+	for i := 1; i < len(runs); i++ {
+		assert.False(t, runs[i].NextRun.Before(runs[i-1].NextRun))
+	}
+}
@@ -4,13 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/events"
 	"github.com/caevv/jobster/internal/logging"
-	"github.com/caevv/jobster/internal/plugins"
+	"github.com/caevv/jobster/internal/metrics"
 	"github.com/caevv/jobster/internal/scheduler"
 	"github.com/caevv/jobster/internal/server"
-	"github.com/caevv/jobster/internal/store"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 )
@@ -22,22 +23,28 @@ var serveCmd = &cobra.Command{
 
 This command loads the configuration file, initializes the scheduler,
 starts all configured jobs, and serves a web dashboard for monitoring
-job execution and history.
+job execution and history. Send SIGHUP to reload job definitions
+without restarting.
 
 Example:
-  jobster serve --config ./jobster.yaml --addr :8080`,
+  jobster serve --config ./jobster.yaml --addr :8080
+  jobster serve --config ./jobster.yaml --addr :8443 --tls-cert cert.pem --tls-key key.pem`,
 	RunE: runServer,
 }
 
 func init() {
 	serveCmd.Flags().StringP("config", "c", "jobster.yaml", "Path to configuration file")
 	serveCmd.Flags().StringP("addr", "a", ":8080", "HTTP server address (host:port)")
+	serveCmd.Flags().String("tls-cert", "", "TLS certificate file (PEM); serves HTTPS together with --tls-key")
+	serveCmd.Flags().String("tls-key", "", "TLS private key file (PEM)")
 	serveCmd.MarkFlagRequired("config")
 }
 
 func runServer(cmd *cobra.Command, args []string) error {
 	configPath, _ := cmd.Flags().GetString("config")
 	addr, _ := cmd.Flags().GetString("addr")
+	tlsCert, _ := cmd.Flags().GetString("tls-cert")
+	tlsKey, _ := cmd.Flags().GetString("tls-key")
 
 	// Load configuration
 	cfg, err := config.LoadConfig(configPath)
@@ -45,9 +52,17 @@ func runServer(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	// Flags take precedence over the config file for TLS settings
+	if tlsCert == "" {
+		tlsCert = cfg.Server.TLSCert
+	}
+	if tlsKey == "" {
+		tlsKey = cfg.Server.TLSKey
+	}
+
 	// Apply logging config from YAML if provided
-	if cfg.Logging.Output != "" || cfg.Logging.Level != "" || cfg.Logging.Format != "" {
-		serveLogger, err := logging.NewFromConfig(cfg.Logging.Format, cfg.Logging.Level, cfg.Logging.Output)
+	if cfg.Logging.Output != "" || cfg.Logging.Level != "" || cfg.Logging.Format != "" || len(cfg.Logging.RedactPatterns) > 0 {
+		serveLogger, err := logging.NewFromConfigWithOptions(cfg.Logging.Format, cfg.Logging.Level, cfg.Logging.Output, loggingOptions(cfg))
 		if err != nil {
 			return fmt.Errorf("failed to initialize logger: %w", err)
 		}
@@ -63,8 +78,14 @@ func runServer(cmd *cobra.Command, args []string) error {
 		"timezone", cfg.Defaults.Timezone,
 		"store_driver", cfg.Store.Driver)
 
+	stopTracing, err := setupTracing(cfg)
+	if err != nil {
+		return err
+	}
+	defer stopTracing()
+
 	// Initialize store for run history
-	st, err := store.NewStore(cfg.Store.Driver, cfg.Store.Path)
+	st, err := openStore(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize store: %w", err)
 	}
@@ -77,15 +98,30 @@ func runServer(cmd *cobra.Command, args []string) error {
 	logger.Info("store initialized", "driver", cfg.Store.Driver, "path", cfg.Store.Path)
 
 	// Initialize plugin manager
-	pluginMgr := plugins.New(logger)
+	pluginMgr, err := newPluginManager(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize plugin manager: %w", err)
+	}
 
 	logger.Info("plugin manager initialized",
 		"timeout_sec", cfg.Defaults.AgentTimeoutSec,
 		"fail_on_error", cfg.Defaults.FailOnAgentError,
 		"allowed_agents", cfg.Security.AllowedAgents)
 
-	// Create job runner
-	runner := NewRunner(st, pluginMgr, cfg.Defaults, logger)
+	// Create job runner, publishing run events for the dashboard stream
+	bus := events.NewBus()
+	runner, err := newRunner(cfg, st, pluginMgr)
+	if err != nil {
+		return err
+	}
+	runner.SetEventBus(bus)
+
+	// Collect Prometheus metrics if enabled
+	var m *metrics.Metrics
+	if cfg.Server.MetricsEnabled {
+		m = metrics.New()
+		runner.SetMetrics(m)
+	}
 
 	// Setup signal handling for graceful shutdown
 	ctx := setupSignalHandler()
@@ -97,7 +133,9 @@ func runServer(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize scheduler
-	sched := scheduler.New(ctx, logger, scheduler.WithLocation(loc))
+	sched := scheduler.New(ctx, logger,
+		append(schedulerOptions(cfg, loc, st), scheduler.WithJobCountObserver(m.SetSchedulerJobs))...,
+	)
 
 	// Add jobs to scheduler
 	for i := range cfg.Jobs {
@@ -105,13 +143,38 @@ func runServer(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to add job %s: %w", cfg.Jobs[i].ID, err)
 		}
 	}
+	runner.SetDependents(cfg.Jobs, sched)
+
+	// Reload job definitions on SIGHUP
+	watchReload(ctx, configPath, nil, sched, runner)
 
 	// Create adapters for server
-	storeAdapter := server.NewStoreAdapter(st)
+	storeAdapter := server.NewStoreAdapter(st, sched)
 	schedAdapter := server.NewSchedulerAdapter(sched)
+	schedAdapter.SetStore(st)
 
 	// Initialize HTTP server
-	srv := server.New(addr, storeAdapter, schedAdapter, logger)
+	srv := server.New(addr, storeAdapter, schedAdapter, runner.historyDir, logger)
+	srv.SetEventSource(bus)
+	if m != nil {
+		srv.SetMetricsHandler(m.Handler())
+	}
+	scheme := "http"
+	if tlsCert != "" || tlsKey != "" {
+		if err := srv.SetTLS(tlsCert, tlsKey); err != nil {
+			return fmt.Errorf("failed to configure TLS: %w", err)
+		}
+		scheme = "https"
+	}
+	srv.SetCORSOrigins(cfg.Server.CORSOrigins)
+	srv.SetDashboardRefresh(time.Duration(cfg.Server.DashboardRefreshSec) * time.Second)
+	srv.SetRateLimit(cfg.Server.RateLimitRPS, cfg.Server.RateLimitBurst)
+	if token := cfg.Server.ResolveAuthToken(); token != "" {
+		srv.SetAuthToken(token)
+	} else {
+		logger.Warn("HTTP API and dashboard are unauthenticated",
+			"hint", "set server.auth_token or "+config.AuthTokenEnv)
+	}
 
 	// Use errgroup to run scheduler and server concurrently
 	g, gCtx := errgroup.WithContext(ctx)
@@ -136,6 +199,12 @@ func runServer(cmd *cobra.Command, args []string) error {
 		return nil
 	})
 
+	// Prune run history according to the retention policy
+	g.Go(func() error {
+		runPruner(gCtx, st, cfg.Store.Retention, logger)
+		return nil
+	})
+
 	// Shutdown handler
 	g.Go(func() error {
 		<-gCtx.Done()
@@ -159,7 +228,7 @@ func runServer(cmd *cobra.Command, args []string) error {
 
 	logger.Info("jobster serve mode started successfully",
 		"scheduled_jobs", len(cfg.Jobs),
-		"dashboard_url", fmt.Sprintf("http://localhost%s", addr))
+		"dashboard_url", fmt.Sprintf("%s://localhost%s", scheme, addr))
 
 	// Wait for all goroutines
 	if err := g.Wait(); err != nil && err != context.Canceled {
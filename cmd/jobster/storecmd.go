@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/caevv/jobster/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var storeCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Manage the run history store",
+	Long: `Manage the run history store.
+
+Subcommands:
+  migrate - Copy run history from one store driver to another
+
+Examples:
+  jobster store migrate --from-driver json --from-path runs.json --to-driver bbolt --to-path .jobster.db`,
+}
+
+var migrateStoreCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copy run history from one store driver to another",
+	Long: `Copy every run from a source store into a destination store, oldest
+first, so history is kept when switching store drivers. The source is only
+read.
+
+Runs whose ID already exists in the destination are skipped, or replaced
+with --overwrite. Point store.driver and store.path in the config at the
+destination afterwards.
+
+Example:
+  jobster store migrate \
+    --from-driver json --from-path ./.jobster.json \
+    --to-driver bbolt --to-path ./.jobster.db`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateStore,
+}
+
+func init() {
+	storeCmd.AddCommand(migrateStoreCmd)
+
+	migrateStoreCmd.Flags().String("from-driver", "", "Source store driver")
+	migrateStoreCmd.Flags().String("from-path", "", "Source store path")
+	migrateStoreCmd.Flags().String("to-driver", "", "Destination store driver")
+	migrateStoreCmd.Flags().String("to-path", "", "Destination store path")
+	migrateStoreCmd.Flags().Bool("overwrite", false, "Replace runs that already exist in the destination")
+	for _, name := range []string{"from-driver", "from-path", "to-driver", "to-path"} {
+		migrateStoreCmd.MarkFlagRequired(name)
+	}
+}
+
+func runMigrateStore(cmd *cobra.Command, args []string) error {
+	fromDriver, _ := cmd.Flags().GetString("from-driver")
+	fromPath, _ := cmd.Flags().GetString("from-path")
+	toDriver, _ := cmd.Flags().GetString("to-driver")
+	toPath, _ := cmd.Flags().GetString("to-path")
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+	if toDriver == "memory" {
+		return fmt.Errorf("cannot migrate into the memory driver: it does not persist runs")
+	}
+	if filepath.Clean(fromPath) == filepath.Clean(toPath) {
+		return fmt.Errorf("source and destination paths must differ")
+	}
+
+	src, err := store.NewStore(fromDriver, fromPath)
+	if err != nil {
+		return fmt.Errorf("failed to open source store: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := store.NewStore(toDriver, toPath)
+	if err != nil {
+		return fmt.Errorf("failed to open destination store: %w", err)
+	}
+
+	migrated, skipped, err := migrateRuns(context.Background(), src, dst, overwrite)
+	if closeErr := dst.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close destination store: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Migrated %d run(s) from %s (%s) to %s (%s)\n", migrated, fromDriver, fromPath, toDriver, toPath)
+	if skipped > 0 {
+		fmt.Fprintf(out, "Skipped %d run(s) already in the destination (use --overwrite to replace them)\n", skipped)
+	}
+	return nil
+}
+
+// migrateRuns copies every run in src into dst, oldest first. Runs already
+// in dst are replaced if overwrite is set and skipped otherwise.
+func migrateRuns(ctx context.Context, src, dst store.Store, overwrite bool) (migrated, skipped int, err error) {
+	runs, err := exportRuns(ctx, src, "", time.Time{}, time.Time{})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, run := range runs {
+		if !overwrite {
+			_, err := dst.GetRun(ctx, run.RunID)
+			if err == nil {
+				skipped++
+				continue
+			}
+			if !errors.Is(err, store.ErrRunNotFound) {
+				return migrated, skipped, fmt.Errorf("failed to check run %s: %w", run.RunID, err)
+			}
+		}
+		if err := dst.SaveRun(ctx, run); err != nil {
+			return migrated, skipped, fmt.Errorf("failed to save run %s: %w", run.RunID, err)
+		}
+		migrated++
+	}
+
+	return migrated, skipped, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/store"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runMigrateCmd executes "jobster store migrate" with args and returns its output.
+func runMigrateCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return executeRootCmd(t, []*cobra.Command{migrateStoreCmd}, append([]string{"store", "migrate"}, args...)...)
+}
+
+func TestStoreMigrate_JSONToBolt(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "runs.json")
+	boltPath := filepath.Join(dir, "runs.db")
+
+	src, err := store.NewStore("json", jsonPath)
+	require.NoError(t, err)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var want []*store.JobRun
+	for i := 0; i < 150; i++ {
+		run := &store.JobRun{
+			RunID:      fmt.Sprintf("run-%03d", i),
+			JobID:      fmt.Sprintf("job-%d", i%3),
+			StartTime:  base.Add(time.Duration(i) * time.Minute),
+			EndTime:    base.Add(time.Duration(i)*time.Minute + time.Second),
+			ExitCode:   i % 2,
+			Success:    i%2 == 0,
+			StdoutTail: fmt.Sprintf("output %d\n", i),
+			Metadata:   map[string]interface{}{"status": "done"},
+		}
+		require.NoError(t, src.SaveRun(ctx, run))
+		want = append(want, run)
+	}
+	require.NoError(t, src.Close())
+
+	// A run already in the destination, with different contents
+	dst, err := store.NewStore("bbolt", boltPath)
+	require.NoError(t, err)
+	require.NoError(t, dst.SaveRun(ctx, &store.JobRun{RunID: "run-000", JobID: "job-0", StartTime: base, StdoutTail: "stale\n"}))
+	require.NoError(t, dst.Close())
+
+	args := []string{"--from-driver", "json", "--from-path", jsonPath, "--to-driver", "bbolt", "--to-path", boltPath}
+	out, err := runMigrateCmd(t, args...)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Migrated 149 run(s)")
+	assert.Contains(t, out, "Skipped 1 run(s)")
+
+	dst, err = store.NewStore("bbolt", boltPath)
+	require.NoError(t, err)
+	stats, err := dst.GetStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 150, stats.TotalRuns)
+	for _, run := range want[1:] {
+		got, err := dst.GetRun(ctx, run.RunID)
+		require.NoError(t, err, "run %s migrated", run.RunID)
+		assert.Equal(t, run.JobID, got.JobID)
+		assert.True(t, run.StartTime.Equal(got.StartTime))
+		assert.Equal(t, run.Success, got.Success)
+		assert.Equal(t, run.StdoutTail, got.StdoutTail)
+		assert.Equal(t, "done", got.Metadata["status"])
+	}
+	got, err := dst.GetRun(ctx, "run-000")
+	require.NoError(t, err)
+	assert.Equal(t, "stale\n", got.StdoutTail, "existing run kept without --overwrite")
+	require.NoError(t, dst.Close())
+
+	out, err = runMigrateCmd(t, append(args, "--overwrite")...)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Migrated 150 run(s)")
+	assert.NotContains(t, out, "Skipped")
+
+	dst, err = store.NewStore("bbolt", boltPath)
+	require.NoError(t, err)
+	defer dst.Close()
+	got, err = dst.GetRun(ctx, "run-000")
+	require.NoError(t, err)
+	assert.Equal(t, "output 0\n", got.StdoutTail, "replaced with --overwrite")
+	stats, err = dst.GetStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 150, stats.TotalRuns)
+}
+
+func TestStoreMigrate_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runs.json")
+
+	_, err := runMigrateCmd(t, "--from-driver", "json", "--from-path", path, "--to-driver", "bbolt", "--to-path", path)
+	assert.ErrorContains(t, err, "paths must differ")
+
+	_, err = runMigrateCmd(t, "--from-driver", "json", "--from-path", path, "--to-driver", "memory", "--to-path", "x")
+	assert.ErrorContains(t, err, "memory driver")
+
+	_, err = runMigrateCmd(t, "--from-driver", "json", "--from-path", path, "--to-driver", "nope", "--to-path", path+".db")
+	assert.ErrorContains(t, err, "unsupported store driver")
+}
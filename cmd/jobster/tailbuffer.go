@@ -0,0 +1,85 @@
+package main
+
+import "fmt"
+
+// tailBuffer is an io.Writer that keeps only the last limit bytes written to
+// it, in a ring that is allocated as output arrives and never grows past limit.
+type tailBuffer struct {
+	limit int
+	buf   []byte
+	pos   int // once full, the index of the oldest byte
+	full  bool
+	total int64
+}
+
+// newTailBuffer returns a tailBuffer keeping the last limit bytes.
+func newTailBuffer(limit int) *tailBuffer {
+	return &tailBuffer{limit: limit}
+}
+
+// Write records p, discarding the oldest bytes once more than limit have been
+// written. It never fails.
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	b.total += int64(n)
+
+	// Only the last limit bytes of p can survive
+	if len(p) > b.limit {
+		p = p[len(p)-b.limit:]
+	}
+
+	if !b.full {
+		room := b.limit - len(b.buf)
+		fill := min(len(p), room)
+		b.grow(fill)
+		b.buf = append(b.buf, p[:fill]...)
+		p = p[fill:]
+		if fill < room {
+			return n, nil
+		}
+		b.full = true
+	}
+
+	for len(p) > 0 {
+		c := copy(b.buf[b.pos:], p)
+		p = p[c:]
+		b.pos = (b.pos + c) % b.limit
+	}
+	return n, nil
+}
+
+// grow makes room for n more bytes, doubling the capacity as append would
+// but never beyond limit.
+func (b *tailBuffer) grow(n int) {
+	if cap(b.buf)-len(b.buf) >= n {
+		return
+	}
+	size := min(max(2*cap(b.buf), len(b.buf)+n), b.limit)
+	buf := make([]byte, len(b.buf), size)
+	copy(buf, b.buf)
+	b.buf = buf
+}
+
+// Bytes returns the retained output, oldest byte first.
+func (b *tailBuffer) Bytes() []byte {
+	if !b.full {
+		return append([]byte(nil), b.buf...)
+	}
+	out := make([]byte, 0, b.limit)
+	out = append(out, b.buf[b.pos:]...)
+	return append(out, b.buf[:b.pos]...)
+}
+
+// Dropped returns how many bytes were discarded to stay within limit.
+func (b *tailBuffer) Dropped() int64 {
+	return b.total - int64(len(b.buf))
+}
+
+// String returns the retained output, prefixed with a note of how much was
+// discarded if the limit was reached.
+func (b *tailBuffer) String() string {
+	if dropped := b.Dropped(); dropped > 0 {
+		return fmt.Sprintf("[jobster: %d earlier bytes discarded, max_output_bytes is %d]\n", dropped, b.limit) + string(b.Bytes())
+	}
+	return string(b.Bytes())
+}
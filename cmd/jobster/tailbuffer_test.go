@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailBuffer(t *testing.T) {
+	b := newTailBuffer(10)
+	b.Write([]byte("abc"))
+	b.Write([]byte("defg"))
+	assert.Equal(t, "abcdefg", b.String(), "under the limit nothing is dropped")
+	assert.Zero(t, b.Dropped())
+
+	b.Write([]byte("hijkl"))
+	assert.Equal(t, "cdefghijkl", string(b.Bytes()))
+	assert.EqualValues(t, 2, b.Dropped())
+
+	b.Write([]byte("0123456789XYZ"))
+	assert.Equal(t, "3456789XYZ", string(b.Bytes()), "a write larger than the limit keeps its end")
+	assert.EqualValues(t, 15, b.Dropped())
+	assert.True(t, strings.HasPrefix(b.String(), "[jobster: 15 earlier bytes discarded"))
+	assert.True(t, strings.HasSuffix(b.String(), "]\n3456789XYZ"))
+}
+
+func TestTailBuffer_BoundedMemory(t *testing.T) {
+	const limit = 64 * 1024
+	b := newTailBuffer(limit)
+
+	var last []byte
+	for i := range 10000 {
+		last = []byte(fmt.Sprintf("line %06d %s\n", i, strings.Repeat("x", 100)))
+		b.Write(last)
+	}
+
+	assert.LessOrEqual(t, cap(b.buf), limit, "the ring never grows past the limit")
+	got := b.Bytes()
+	assert.Len(t, got, limit)
+	assert.True(t, bytes.HasSuffix(got, last))
+}
+
+func TestRunner_MaxOutputBytes(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{MaxOutputBytes: 32 * 1024, OutputTailBytes: 100})
+	runner.historyDir = filepath.Join(dir, "history")
+
+	// About 5 MB of numbered lines, far beyond the limit
+	job := &config.Job{
+		ID:         "noisy-job",
+		Schedule:   "@every 1h",
+		Command:    config.NewCommandSpec(`i=0; while [ $i -lt 200000 ]; do echo "line $i padding-padding"; i=$((i+1)); done`),
+		Shell:      true,
+		TimeoutSec: 60,
+	}
+	require.NoError(t, runner.RunJob(context.Background(), job))
+
+	runs, err := st.GetJobRuns(context.Background(), "noisy-job", 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	run := runs[0]
+	assert.True(t, run.Success)
+	assert.LessOrEqual(t, len(run.StdoutTail), 103, "tail respects output_tail_bytes")
+	assert.True(t, strings.HasSuffix(run.StdoutTail, "line 199999 padding-padding\n"))
+
+	// The run log file still gets everything; only the in-memory copy is bounded
+	saved, err := os.ReadFile(runLogPath(runner.historyDir, "noisy-job", run.RunID, "stdout"))
+	require.NoError(t, err)
+	assert.Equal(t, 200000, strings.Count(string(saved), "\n"))
+	assert.True(t, strings.HasPrefix(string(saved), "line 0 padding-padding\n"))
+}
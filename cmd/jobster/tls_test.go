@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSelfSignedCert generates a certificate for 127.0.0.1 and writes the
+// PEM cert and key into dir.
+func writeSelfSignedCert(t *testing.T, dir string) (certFile, keyFile string, der []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "jobster-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err = x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile, der
+}
+
+// freeAddr returns a loopback address with a currently unused port.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func TestServe_TLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, der := writeSelfSignedCert(t, dir)
+
+	addr := freeAddr(t)
+	srv := server.New(addr, nil, nil, "", nil)
+	require.NoError(t, srv.SetTLS(certFile, keyFile))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Start(ctx) }()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	client := &http.Client{
+		Timeout:   2 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+	}
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = client.Get("https://" + addr + "/api/health")
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond, "HTTPS request failed: %v", err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotNil(t, resp.TLS)
+
+	// A plain HTTP request to the TLS listener is rejected
+	resp, err = http.Get("http://" + addr + "/api/health")
+	if err == nil {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestServe_TLSValidation(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, _ := writeSelfSignedCert(t, dir)
+	srv := server.New(":0", nil, nil, "", nil)
+
+	assert.Error(t, srv.SetTLS(certFile, ""), "key missing")
+	assert.Error(t, srv.SetTLS(filepath.Join(dir, "absent.pem"), keyFile), "cert file does not exist")
+	assert.Error(t, srv.SetTLS(keyFile, certFile), "cert and key swapped")
+	assert.NoError(t, srv.SetTLS(certFile, keyFile))
+}
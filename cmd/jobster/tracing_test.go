@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a tracer provider that keeps finished spans in
+// memory for the duration of the test.
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+		_ = tp.Shutdown(context.Background())
+	})
+	return exporter
+}
+
+func spanAttrs(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("no %s span among %d spans", name, len(spans))
+	return tracetest.SpanStub{}
+}
+
+func TestRunner_TracesJobAndHooks(t *testing.T) {
+	exporter := recordSpans(t)
+
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{AgentTimeoutSec: 5})
+	runner.historyDir = filepath.Join(dir, "history")
+
+	agentsDir := filepath.Join(dir, "agents")
+	require.NoError(t, os.Mkdir(agentsDir, 0o755))
+	script := "#!/bin/sh\necho \"{\\\"traceparent\\\":\\\"$TRACEPARENT\\\"}\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(agentsDir, "notify.sh"), []byte(script), 0o755))
+	require.NoError(t, runner.pluginMgr.Discover([]string{agentsDir}))
+
+	job := &config.Job{
+		ID:         "traced",
+		Schedule:   "@hourly",
+		Command:    config.NewCommandSpec("true"),
+		TimeoutSec: 5,
+		Hooks:      config.Hooks{PostRun: []config.Agent{{Agent: "notify.sh"}}},
+	}
+	ctx := scheduler.ContextWithRunID(context.Background(), "run-1")
+	require.NoError(t, runner.RunJob(ctx, job))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	jobSpan := findSpan(t, spans, "job.run")
+	hookSpan := findSpan(t, spans, "hook.run")
+
+	attrs := spanAttrs(jobSpan)
+	assert.Equal(t, "traced", attrs["job.id"].AsString())
+	assert.Equal(t, "run-1", attrs["job.run_id"].AsString())
+	assert.Equal(t, int64(0), attrs["job.exit_code"].AsInt64())
+	assert.Contains(t, attrs, attribute.Key("job.duration_ms"))
+	assert.Equal(t, codes.Unset, jobSpan.Status.Code)
+
+	assert.Equal(t, jobSpan.SpanContext.SpanID(), hookSpan.Parent.SpanID(), "hook span is a child of the job span")
+	hookAttrs := spanAttrs(hookSpan)
+	assert.Equal(t, "post_run", hookAttrs["hook.type"].AsString())
+	assert.Equal(t, "notify.sh", hookAttrs["hook.agent"].AsString())
+	assert.Equal(t, int64(0), hookAttrs["hook.exit_code"].AsInt64())
+
+	// The agent received the hook span's trace context
+	run, err := st.GetRun(context.Background(), "run-1")
+	require.NoError(t, err)
+	output := run.Metadata["hooks"].(map[string]interface{})["post_run"].(map[string]interface{})["notify.sh"].(map[string]interface{})
+	traceparent, _ := output["traceparent"].(string)
+	assert.True(t, strings.Contains(traceparent, hookSpan.SpanContext.TraceID().String()), "traceparent %q", traceparent)
+	assert.Contains(t, traceparent, hookSpan.SpanContext.SpanID().String())
+}
+
+func TestRunner_TracesFailedJob(t *testing.T) {
+	exporter := recordSpans(t)
+
+	dir := t.TempDir()
+	runner, _ := newTestRunner(t, dir, config.Defaults{})
+	runner.historyDir = filepath.Join(dir, "history")
+
+	job := &config.Job{ID: "failing", Schedule: "@hourly", Command: config.NewCommandSpec("sh -c 'exit 3'"), TimeoutSec: 5}
+	require.Error(t, runner.RunJob(context.Background(), job))
+
+	span := findSpan(t, exporter.GetSpans(), "job.run")
+	assert.Equal(t, int64(3), spanAttrs(span)["job.exit_code"].AsInt64())
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Equal(t, "exit status 3", span.Status.Description)
+}
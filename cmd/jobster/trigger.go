@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var triggerCmd = &cobra.Command{
+	Use:   "trigger <job-id>",
+	Short: "Run a single job once and exit",
+	Long: `Run one configured job immediately, wait for it to finish, and exit.
+
+The job runs exactly as it would on its schedule, including retries and
+hooks, and the run is recorded in the configured store. Scheduled jobs and
+depends_on chains are not started. jobster exits with the job's exit code,
+so it can be driven from scripts or another scheduler.
+
+Examples:
+  jobster trigger backup --config jobster.yaml
+  jobster trigger backup --config jobster.yaml --timeout 60`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrigger,
+}
+
+func init() {
+	triggerCmd.Flags().StringP("config", "c", "jobster.yaml", "Path to configuration file")
+	triggerCmd.Flags().Int("timeout", 0, "Override the job's timeout_sec (seconds, 0 = use the configured value)")
+	triggerCmd.MarkFlagRequired("config")
+}
+
+func runTrigger(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	timeout, _ := cmd.Flags().GetInt("timeout")
+	if timeout < 0 {
+		return fmt.Errorf("--timeout must be non-negative")
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	stopTracing, err := setupTracing(cfg)
+	if err != nil {
+		return err
+	}
+	defer stopTracing()
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+	defer func() {
+		if err := st.Close(); err != nil {
+			logger.Error("failed to close store", "error", err)
+		}
+	}()
+
+	pluginMgr, err := newPluginManager(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize plugin manager: %w", err)
+	}
+	runner, err := newRunner(cfg, st, pluginMgr)
+	if err != nil {
+		return err
+	}
+
+	run, err := triggerJob(setupSignalHandler(), runner, st, cfg, args[0], timeout)
+	if err != nil {
+		return err
+	}
+	printRunSummary(os.Stdout, run)
+
+	if !run.Success {
+		// The summary already describes the failure; just forward the code
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		code := run.ExitCode
+		if code <= 0 {
+			code = 1
+		}
+		return &exitCodeError{code: code}
+	}
+	return nil
+}
+
+// triggerJob runs the job jobID from cfg once through runner and returns the
+// recorded run. A positive timeout replaces the job's timeout_sec.
+func triggerJob(ctx context.Context, runner *Runner, st store.Store, cfg *config.Config, jobID string, timeout int) (*store.JobRun, error) {
+	var job *config.Job
+	for i := range cfg.Jobs {
+		if cfg.Jobs[i].ID == jobID {
+			job = &cfg.Jobs[i]
+			break
+		}
+	}
+	if job == nil {
+		return nil, fmt.Errorf("job %q not found in configuration", jobID)
+	}
+	if timeout > 0 {
+		job.TimeoutSec = timeout
+	}
+
+	runID := scheduler.GenerateRunID()
+	// Errors are recorded on the run, which is reported below
+	_ = runner.RunJob(scheduler.ContextWithRunID(ctx, runID), job)
+
+	run, err := st.GetRun(context.Background(), runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run result: %w", err)
+	}
+	return run, nil
+}
+
+// printRunSummary writes the run's captured output and outcome to w.
+func printRunSummary(w io.Writer, run *store.JobRun) {
+	if out := strings.TrimRight(run.StdoutTail, "\n"); out != "" {
+		fmt.Fprintf(w, "--- stdout ---\n%s\n", out)
+	}
+	if out := strings.TrimRight(run.StderrTail, "\n"); out != "" {
+		fmt.Fprintf(w, "--- stderr ---\n%s\n", out)
+	}
+
+	status := "success"
+	if !run.Success {
+		status = "failure"
+	}
+	fmt.Fprintf(w, "\nJob:       %s\n", run.JobID)
+	fmt.Fprintf(w, "Run ID:    %s\n", run.RunID)
+	fmt.Fprintf(w, "Status:    %s\n", status)
+	fmt.Fprintf(w, "Exit code: %d\n", run.ExitCode)
+	fmt.Fprintf(w, "Duration:  %s\n", run.Duration().Round(time.Millisecond))
+	if attempts, ok := run.Metadata["attempt"]; ok {
+		fmt.Fprintf(w, "Attempts:  %v\n", attempts)
+	}
+	if errMsg, ok := run.Metadata["error"].(string); ok && errMsg != "" {
+		fmt.Fprintf(w, "Error:     %s\n", errMsg)
+	}
+}
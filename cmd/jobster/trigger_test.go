@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerJob(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	cfg := &config.Config{Jobs: []config.Job{
+		{ID: "ok", Schedule: "@daily", Command: config.NewCommandSpec("/bin/echo hello"), TimeoutSec: 5},
+		{ID: "exit-3", Schedule: "@daily", Command: config.NewCommandSpec("exit 3"), Shell: true, TimeoutSec: 5},
+		{ID: "slow", Schedule: "@daily", Command: config.NewCommandSpec("/bin/sleep 10"), TimeoutSec: 600},
+	}}
+
+	run, err := triggerJob(context.Background(), runner, st, cfg, "ok", 0)
+	require.NoError(t, err)
+	assert.True(t, run.Success)
+	assert.Equal(t, "hello\n", run.StdoutTail)
+
+	var out bytes.Buffer
+	printRunSummary(&out, run)
+	assert.Contains(t, out.String(), "--- stdout ---\nhello\n")
+	assert.Contains(t, out.String(), "Status:    success\n")
+	assert.Contains(t, out.String(), "Exit code: 0\n")
+
+	run, err = triggerJob(context.Background(), runner, st, cfg, "exit-3", 0)
+	require.NoError(t, err)
+	assert.False(t, run.Success)
+	assert.Equal(t, 3, run.ExitCode)
+
+	out.Reset()
+	printRunSummary(&out, run)
+	assert.Contains(t, out.String(), "Status:    failure\n")
+	assert.Contains(t, out.String(), "Error:     exit status 3\n")
+
+	// The --timeout override replaces the configured 600s
+	run, err = triggerJob(context.Background(), runner, st, cfg, "slow", 1)
+	require.NoError(t, err)
+	assert.False(t, run.Success)
+	assert.Less(t, run.Duration().Seconds(), 5.0)
+
+	_, err = triggerJob(context.Background(), runner, st, cfg, "missing", 0)
+	assert.ErrorContains(t, err, `job "missing" not found`)
+}
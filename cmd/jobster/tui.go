@@ -6,9 +6,7 @@ import (
 
 	"github.com/caevv/jobster/internal/config"
 	"github.com/caevv/jobster/internal/logging"
-	"github.com/caevv/jobster/internal/plugins"
 	"github.com/caevv/jobster/internal/scheduler"
-	"github.com/caevv/jobster/internal/store"
 	"github.com/caevv/jobster/internal/tui"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -29,16 +27,21 @@ Navigation:
   esc         - Go back to job list
   g/G         - Jump to top/bottom
   r           - Refresh data
-  q           - Quit
+  q           - Quit (press twice; ctrl+c quits at once)
 
-Example:
-  jobster tui --config ./jobster.yaml`,
+The detail view loads a job's 100 most recent runs; set tui.history_runs
+in the config, or --history, to change that.
+
+Examples:
+  jobster tui --config ./jobster.yaml
+  jobster tui --config ./jobster.yaml --history 500`,
 	RunE: runTUI,
 }
 
 func init() {
 	tuiCmd.Flags().StringP("config", "c", "jobster.yaml", "Path to configuration file")
 	tuiCmd.MarkFlagRequired("config")
+	tuiCmd.Flags().Int("history", 0, "Runs to load in a job's detail view (overrides tui.history_runs)")
 }
 
 func runTUI(cmd *cobra.Command, args []string) error {
@@ -49,6 +52,13 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if cmd.Flags().Changed("history") {
+		history, _ := cmd.Flags().GetInt("history")
+		if history < 1 {
+			return fmt.Errorf("--history must be at least 1")
+		}
+		cfg.TUI.HistoryRuns = history
+	}
 
 	// In TUI mode, suppress logs by default unless configured otherwise
 	logOutput := cfg.Logging.Output
@@ -58,15 +68,21 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create logger from config (or use discard for TUI)
-	tuiLogger, err := logging.NewFromConfig(cfg.Logging.Format, cfg.Logging.Level, logOutput)
+	tuiLogger, err := logging.NewFromConfigWithOptions(cfg.Logging.Format, cfg.Logging.Level, logOutput, loggingOptions(cfg))
 	if err != nil {
 		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
 	logger = tuiLogger
 	slog.SetDefault(tuiLogger)
 
+	stopTracing, err := setupTracing(cfg)
+	if err != nil {
+		return err
+	}
+	defer stopTracing()
+
 	// Initialize store for run history
-	st, err := store.NewStore(cfg.Store.Driver, cfg.Store.Path)
+	st, err := openStore(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize store: %w", err)
 	}
@@ -77,10 +93,16 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	}()
 
 	// Initialize plugin manager
-	pluginMgr := plugins.New(logger)
+	pluginMgr, err := newPluginManager(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize plugin manager: %w", err)
+	}
 
 	// Create job runner
-	runner := NewRunner(st, pluginMgr, cfg.Defaults, logger)
+	runner, err := newRunner(cfg, st, pluginMgr)
+	if err != nil {
+		return err
+	}
 
 	// Setup signal handling for graceful shutdown
 	ctx := setupSignalHandler()
@@ -92,7 +114,7 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize scheduler
-	sched := scheduler.New(ctx, logger, scheduler.WithLocation(loc))
+	sched := scheduler.New(ctx, logger, schedulerOptions(cfg, loc, st)...)
 
 	// Add jobs to scheduler
 	for i := range cfg.Jobs {
@@ -100,6 +122,7 @@ func runTUI(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to add job %s: %w", cfg.Jobs[i].ID, err)
 		}
 	}
+	runner.SetDependents(cfg.Jobs, sched)
 
 	// Start scheduler
 	if err := sched.Start(); err != nil {
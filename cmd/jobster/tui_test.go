@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/store"
+	"github.com/caevv/jobster/internal/tui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// limitRecordingStore records the limit of every GetJobRuns call.
+type limitRecordingStore struct {
+	store.Store
+	mu     sync.Mutex
+	limits []int
+}
+
+func (s *limitRecordingStore) GetJobRuns(ctx context.Context, jobID string, limit int) ([]*store.JobRun, error) {
+	s.mu.Lock()
+	s.limits = append(s.limits, limit)
+	s.mu.Unlock()
+	return s.Store.GetJobRuns(ctx, jobID, limit)
+}
+
+// lastLimit returns the limit of the latest GetJobRuns call.
+func (s *limitRecordingStore) lastLimit() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.limits) == 0 {
+		return 0
+	}
+	return s.limits[len(s.limits)-1]
+}
+
+func TestTUI_DetailHistoryRuns(t *testing.T) {
+	tests := []struct {
+		name        string
+		historyRuns int
+		want        int
+	}{
+		{name: "default", historyRuns: 0, want: 100},
+		{name: "configured", historyRuns: 7, want: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			runner, st := newTestRunner(t, dir, config.Defaults{})
+			recorder := &limitRecordingStore{Store: st}
+
+			cfg := &config.Config{
+				TUI: config.TUI{HistoryRuns: tt.historyRuns},
+				Jobs: []config.Job{
+					{ID: "job", Schedule: "@daily", Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+				},
+			}
+			sched := scheduler.New(context.Background(), runner.logger)
+			require.NoError(t, sched.AddJob(&cfg.Jobs[0], runner))
+
+			var model tea.Model = tui.New(cfg, recorder, sched, runner.logger)
+			press := func(key string) {
+				var keyMsg tea.KeyMsg
+				if key == "enter" {
+					keyMsg = tea.KeyMsg{Type: tea.KeyEnter}
+				} else {
+					keyMsg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+				}
+				model, _ = model.Update(keyMsg)
+			}
+
+			// Load the job list, then open the detail view
+			press("r")
+			press("enter")
+			assert.Equal(t, tt.want, recorder.lastLimit(), "enter")
+
+			// Refresh in the detail view requests the same depth
+			press("r")
+			assert.Equal(t, tt.want, recorder.lastLimit(), "refresh")
+		})
+	}
+}
+
+func TestTUI_ConfirmQuit(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	cfg := &config.Config{}
+	sched := scheduler.New(context.Background(), runner.logger)
+
+	press := func(model tea.Model, msg tea.KeyMsg) (tui.Model, tea.Cmd) {
+		t.Helper()
+		next, cmd := model.Update(msg)
+		m, ok := next.(tui.Model)
+		require.True(t, ok)
+		return m, cmd
+	}
+	q := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}
+	j := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}
+
+	// The first q only asks
+	m, cmd := press(tui.New(cfg, st, sched, runner.logger), q)
+	assert.False(t, m.Quitting())
+	assert.Nil(t, cmd)
+	assert.Contains(t, m.View(), "Press q again to quit")
+
+	// The second quits
+	m, cmd = press(m, q)
+	assert.True(t, m.Quitting())
+	assert.NotNil(t, cmd)
+
+	// Another key in between dismisses the prompt
+	m, _ = press(tui.New(cfg, st, sched, runner.logger), q)
+	m, _ = press(m, j)
+	assert.NotContains(t, m.View(), "Press q again to quit")
+	m, _ = press(m, q)
+	assert.False(t, m.Quitting())
+
+	// ctrl+c still quits at once
+	m, cmd = press(tui.New(cfg, st, sched, runner.logger), tea.KeyMsg{Type: tea.KeyCtrlC})
+	assert.True(t, m.Quitting())
+	assert.NotNil(t, cmd)
+}
+
+func TestTUI_Themes(t *testing.T) {
+	// Render colors even though the test's output is not a terminal
+	prevProfile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prevProfile) })
+
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+
+	views := make(map[string]string)
+	for _, theme := range []string{"", config.ThemeDark, config.ThemeLight, config.ThemeHighContrast} {
+		cfg := &config.Config{
+			TUI: config.TUI{Theme: theme},
+			Jobs: []config.Job{
+				{ID: "job", Schedule: "@daily", Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+			},
+		}
+		sched := scheduler.New(context.Background(), runner.logger)
+		require.NoError(t, sched.AddJob(&cfg.Jobs[0], runner))
+
+		var model tea.Model = tui.New(cfg, st, sched, runner.logger)
+		model, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		view := model.View()
+		require.Contains(t, view, "job", "theme %q", theme)
+		views[theme] = view
+	}
+
+	assert.Equal(t, views[config.ThemeDark], views[""], "dark is the default")
+	assert.NotEqual(t, views[config.ThemeDark], views[config.ThemeLight])
+	assert.NotEqual(t, views[config.ThemeDark], views[config.ThemeHighContrast])
+	assert.NotEqual(t, views[config.ThemeLight], views[config.ThemeHighContrast])
+}
+
+// tuiKey returns the key message bubbletea sends for key.
+func tuiKey(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// newTUITestModel returns a TUI model for a single scheduled job, sized to a
+// terminal of the given height.
+func newTUITestModel(t *testing.T, height int) (tea.Model, store.Store) {
+	t.Helper()
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	cfg := &config.Config{
+		Jobs: []config.Job{
+			{ID: "job", Schedule: "@daily", Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+		},
+	}
+	sched := scheduler.New(context.Background(), runner.logger)
+	require.NoError(t, sched.AddJob(&cfg.Jobs[0], runner))
+
+	var model tea.Model = tui.New(cfg, st, sched, runner.logger)
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: height})
+	return model, st
+}
+
+func TestTUI_DetailScroll(t *testing.T) {
+	model, st := newTUITestModel(t, 30)
+	press := func(key string) {
+		model, _ = model.Update(tuiKey(key))
+	}
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i := range 10 {
+		begin := start.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, st.SaveRun(context.Background(), &store.JobRun{
+			RunID:     "run-" + strconv.Itoa(i),
+			JobID:     "job",
+			StartTime: begin,
+			EndTime:   begin.Add(time.Second),
+			Success:   true,
+		}))
+	}
+
+	press("r")
+	press("enter")
+	require.Contains(t, model.View(), "Runs 1-")
+
+	// Scrolling up at the top stays there
+	press("k")
+	assert.Contains(t, model.View(), "Runs 1-")
+
+	// Scrolling down at the bottom stays there
+	press("G")
+	bottom := model.View()
+	require.Contains(t, bottom, "of 10")
+	require.NotContains(t, bottom, "Runs 1-")
+	press("j")
+	assert.Equal(t, bottom, model.View())
+
+	// A refresh that finds fewer runs pulls the offset back onto the last
+	// one, so a single step up scrolls to the top
+	for i := 2; i < 10; i++ {
+		require.NoError(t, st.DeleteRun(context.Background(), "run-"+strconv.Itoa(i)))
+	}
+	press("r")
+	assert.Contains(t, model.View(), "Runs 2-2 of 2")
+	press("k")
+	view := model.View()
+	assert.NotContains(t, view, "Runs 2-2 of 2")
+	assert.Contains(t, view, start.Format("2006-01-02 15:04:05"))
+	assert.Contains(t, view, start.Add(time.Minute).Format("2006-01-02 15:04:05"))
+}
+
+func TestTUI_TriggerResult(t *testing.T) {
+	model, _ := newTUITestModel(t, 40)
+	model, _ = model.Update(tuiKey("r"))
+
+	// x asks first; y starts the run
+	model, _ = model.Update(tuiKey("x"))
+	assert.Contains(t, model.View(), "Run job now? (y/n)")
+	model, cmd := model.Update(tuiKey("y"))
+	require.NotNil(t, cmd)
+
+	model, cmd = model.Update(cmd())
+	assert.Contains(t, model.View(), "Triggered job (run ")
+	require.NotNil(t, cmd)
+
+	// The returned command watches the run until it finishes
+	for range 10 {
+		model, cmd = model.Update(cmd())
+		if cmd == nil {
+			break
+		}
+	}
+	require.Nil(t, cmd, "run did not finish")
+	assert.Contains(t, model.View(), "Run of job succeeded")
+}
+
+func TestTUI_TriggerError(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	cfg := &config.Config{
+		Jobs: []config.Job{
+			{ID: "ghost", Schedule: "@daily", Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+		},
+	}
+	// The job is configured but was never added to the scheduler
+	sched := scheduler.New(context.Background(), runner.logger)
+
+	var model tea.Model = tui.New(cfg, st, sched, runner.logger)
+	model, _ = model.Update(tuiKey("r"))
+	model, _ = model.Update(tuiKey("x"))
+	model, cmd := model.Update(tuiKey("y"))
+	require.NotNil(t, cmd)
+
+	model, cmd = model.Update(cmd())
+	assert.Nil(t, cmd)
+	view := model.View()
+	assert.Contains(t, view, "Error: ")
+	assert.Contains(t, view, "ghost")
+	assert.NotContains(t, view, "Triggered")
+}
+
+func TestTUI_HelpScreen(t *testing.T) {
+	for _, closeKey := range []string{"?", "esc"} {
+		t.Run(closeKey, func(t *testing.T) {
+			model, _ := newTUITestModel(t, 40)
+			press := func(key string) {
+				model, _ = model.Update(tuiKey(key))
+			}
+
+			press("r")
+			press("enter")
+			require.Contains(t, model.View(), "Run History")
+
+			press("?")
+			require.Contains(t, model.View(), "Keyboard Shortcuts")
+
+			// Other keys are ignored while help is open
+			press("j")
+			require.Contains(t, model.View(), "Keyboard Shortcuts")
+
+			// Closing help returns to the detail view rather than leaving it
+			press(closeKey)
+			view := model.View()
+			assert.NotContains(t, view, "Keyboard Shortcuts")
+			assert.Contains(t, view, "Run History")
+		})
+	}
+}
+
+func TestTUI_PauseResumeWhileRunning(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	cfg := &config.Config{
+		Jobs: []config.Job{
+			{ID: "job", Schedule: "@daily", Command: config.NewCommandSpec("/bin/true"), TimeoutSec: 5},
+		},
+	}
+	sched := scheduler.New(context.Background(), runner.logger)
+	require.NoError(t, sched.AddJob(&cfg.Jobs[0], runner))
+
+	// A run still in flight makes the job show as running even once paused
+	require.NoError(t, st.SaveRun(context.Background(), &store.JobRun{
+		RunID:     "run-0",
+		JobID:     "job",
+		StartTime: time.Now(),
+	}))
+
+	var model tea.Model = tui.New(cfg, st, sched, runner.logger)
+	press := func(key string) {
+		model, _ = model.Update(tuiKey(key))
+	}
+	paused := func() bool {
+		stats, ok := sched.GetJobStats("job")
+		require.True(t, ok)
+		return stats.Paused
+	}
+
+	press("r")
+	press("p")
+	require.True(t, paused())
+
+	// p resumes it rather than trying to pause it again
+	press("p")
+	assert.False(t, paused())
+	assert.NotContains(t, model.View(), "Error: ")
+}
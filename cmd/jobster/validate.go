@@ -1,10 +1,19 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/plugins"
+	"github.com/caevv/jobster/internal/scheduler"
 	"github.com/spf13/cobra"
 )
 
@@ -15,41 +24,251 @@ var validateCmd = &cobra.Command{
 
 This command loads and validates the configuration file without starting
 the scheduler. It checks for:
-  - Valid YAML syntax
+  - Valid YAML syntax (or JSON, for files ending in .json)
   - Required fields
   - Valid cron expressions
   - Valid time zones
   - Valid store driver configuration
-  - Valid agent references
+  - Valid agent references (every hook agent exists in agents_paths)
+  - Readable env_file paths
 
-Example:
-  jobster validate --config ./jobster.yaml`,
+With --strict, the filesystem is checked as well, as a deployment would see
+it:
+  - Each job's workdir exists and is a directory
+  - Each command's executable resolves on PATH, or as a path relative to the
+    workdir (shell: true commands only need /bin/sh)
+
+With --output json, a JSON report is printed instead: on success the job
+count, store, timezone, and each job's schedule, next run and hook counts;
+on failure the errors, with a non-zero exit code.
+
+Examples:
+  jobster validate --config ./jobster.yaml
+  jobster validate --config ./jobster.yaml --strict
+  jobster validate --config ./jobster.yaml --output json`,
 	RunE: validateConfig,
 }
 
+// validateReport is the --output json document of `jobster validate`.
+type validateReport struct {
+	Valid    bool          `json:"valid"`
+	Path     string        `json:"path"`
+	JobCount int           `json:"job_count"`
+	Store    *storeInfo    `json:"store,omitempty"`
+	Timezone string        `json:"timezone,omitempty"`
+	Jobs     []validateJob `json:"jobs,omitempty"`
+	Errors   []string      `json:"errors,omitempty"`
+}
+
+// storeInfo describes the configured run history store.
+type storeInfo struct {
+	Driver string `json:"driver"`
+	Path   string `json:"path,omitempty"`
+}
+
+// validateJob summarizes one valid job in a validateReport.
+type validateJob struct {
+	ID        string         `json:"id"`
+	Schedule  string         `json:"schedule,omitempty"`
+	DependsOn []string       `json:"depends_on,omitempty"`
+	NextRun   *time.Time     `json:"next_run,omitempty"`
+	Hooks     map[string]int `json:"hooks"`
+}
+
 func init() {
 	validateCmd.Flags().StringP("config", "c", "jobster.yaml", "Path to configuration file")
+	validateCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	validateCmd.Flags().Bool("strict", false, "Also check that workdirs and command executables exist")
 	validateCmd.MarkFlagRequired("config")
 }
 
 func validateConfig(cmd *cobra.Command, args []string) error {
 	configPath, _ := cmd.Flags().GetString("config")
+	output, _ := cmd.Flags().GetString("output")
+	strict, _ := cmd.Flags().GetBool("strict")
+	if output != "text" && output != "json" {
+		return fmt.Errorf("unsupported output %q (must be text or json)", output)
+	}
+
+	cfg, errs := checkConfig(configPath)
+	if strict && cfg != nil {
+		errs = append(errs, checkFilesystem(cfg)...)
+	}
+
+	if output == "json" {
+		report, err := newValidateReport(configPath, cfg, errs)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+		if !report.Valid {
+			// The report already carries the errors
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			return &exitCodeError{code: 1}
+		}
+		return nil
+	}
 
+	if len(errs) > 0 {
+		return validationError(errs)
+	}
+	printValidConfig(cmd.OutOrStdout(), configPath, cfg)
+	return nil
+}
+
+// checkConfig loads the configuration at configPath and checks that every
+// hook agent exists. It returns every problem found; cfg is nil if the file
+// could not be loaded at all.
+func checkConfig(configPath string) (*config.Config, []error) {
 	logger.Info("validating configuration", "path", configPath)
 
 	// Check if file exists
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		logger.Error("configuration file not found", "path", configPath)
-		return fmt.Errorf("configuration file not found: %s", configPath)
+		return nil, []error{fmt.Errorf("configuration file not found: %s", configPath)}
 	}
 
 	// Load and validate configuration (LoadConfig validates automatically)
 	cfg, err := config.LoadConfig(configPath)
 	if err != nil {
 		logger.Error("configuration validation failed", "error", err)
-		return fmt.Errorf("validation failed: %w", err)
+		return nil, []error{err}
 	}
 
+	// Check that every hook agent resolves to an executable (or built-in)
+	// agent, reporting all problems at once
+	pluginMgr, err := newPluginManager(cfg, logger)
+	if err != nil {
+		return cfg, []error{fmt.Errorf("failed to initialize plugin manager: %w", err)}
+	}
+	var agentErrs []error
+	for _, job := range cfg.Jobs {
+		err := plugins.ValidateHooks(pluginMgr, job.Hooks, cfg.Security.AllowedAgents)
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				agentErrs = append(agentErrs, fmt.Errorf("job %s: %w", job.ID, e))
+			}
+		}
+	}
+	if len(agentErrs) > 0 {
+		logger.Error("configuration validation failed", "error", errors.Join(agentErrs...))
+	}
+	return cfg, agentErrs
+}
+
+// checkFilesystem reports, for every job, a workdir that is missing or not a
+// directory and a command executable that does not resolve, which the runner
+// would otherwise only fail on at run time. env_file paths are already
+// checked when the config is loaded.
+func checkFilesystem(cfg *config.Config) []error {
+	var errs []error
+	for i := range cfg.Jobs {
+		job := &cfg.Jobs[i]
+		for _, err := range checkJobFilesystem(job) {
+			errs = append(errs, fmt.Errorf("job %s: %w", job.ID, err))
+		}
+	}
+	if len(errs) > 0 {
+		logger.Error("strict validation failed", "error", errors.Join(errs...))
+	}
+	return errs
+}
+
+// checkJobFilesystem runs the --strict checks for one job.
+func checkJobFilesystem(job *config.Job) []error {
+	var errs []error
+
+	workdirOK := true
+	if info, err := os.Stat(job.Workdir); err != nil {
+		errs = append(errs, fmt.Errorf("workdir %s does not exist", job.Workdir))
+		workdirOK = false
+	} else if !info.IsDir() {
+		errs = append(errs, fmt.Errorf("workdir %s is not a directory", job.Workdir))
+		workdirOK = false
+	}
+
+	argv, _, _, err := resolveCommand(job, previewRun())
+	if err != nil {
+		return append(errs, err)
+	}
+	name := argv[0]
+	if strings.Contains(name, "/") {
+		// exec resolves a relative path against the command's Dir
+		if !filepath.IsAbs(name) {
+			if !workdirOK {
+				return errs
+			}
+			name = filepath.Join(job.Workdir, name)
+		}
+		if _, err := exec.LookPath(name); err != nil {
+			errs = append(errs, fmt.Errorf("command %s is not an executable file", argv[0]))
+		}
+	} else if _, err := exec.LookPath(name); err != nil {
+		errs = append(errs, fmt.Errorf("command %s not found in PATH", name))
+	}
+	return errs
+}
+
+// validationError combines the problems found by checkConfig into the
+// command's error.
+func validationError(errs []error) error {
+	if len(errs) == 1 {
+		return fmt.Errorf("validation failed: %w", errs[0])
+	}
+	return fmt.Errorf("validation failed:\n%w", errors.Join(errs...))
+}
+
+// newValidateReport builds the JSON report for a checked configuration.
+func newValidateReport(configPath string, cfg *config.Config, errs []error) (*validateReport, error) {
+	report := &validateReport{Valid: len(errs) == 0, Path: configPath}
+	for _, err := range errs {
+		report.Errors = append(report.Errors, err.Error())
+	}
+	if !report.Valid {
+		return report, nil
+	}
+
+	loc, err := resolveLocation(cfg)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	report.JobCount = len(cfg.Jobs)
+	report.Store = &storeInfo{Driver: cfg.Store.Driver, Path: cfg.Store.Path}
+	report.Timezone = cfg.Defaults.Timezone
+	for i := range cfg.Jobs {
+		job := &cfg.Jobs[i]
+		entry := validateJob{
+			ID:        job.ID,
+			Schedule:  job.Schedule,
+			DependsOn: job.DependsOn,
+			Hooks:     make(map[string]int),
+		}
+		times, err := scheduler.NextRunTimes(job, loc, now, 1)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.ID, err)
+		}
+		if len(times) > 0 {
+			entry.NextRun = &times[0]
+		}
+		for _, hookType := range []plugins.HookType{plugins.PreRun, plugins.OnSuccess, plugins.OnError, plugins.PostRun, plugins.OnCircuitOpen} {
+			entry.Hooks[string(hookType)] = len(plugins.GetHooksByType(job.Hooks, hookType))
+		}
+		report.Jobs = append(report.Jobs, entry)
+	}
+
+	return report, nil
+}
+
+// printValidConfig logs the details of a valid configuration and prints the
+// human-readable summary.
+func printValidConfig(w io.Writer, configPath string, cfg *config.Config) {
 	// Print validation summary
 	logger.Info("configuration is valid",
 		"path", configPath,
@@ -94,10 +313,8 @@ func validateConfig(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Fprintf(os.Stdout, "\n✓ Configuration is valid: %s\n", configPath)
-	fmt.Fprintf(os.Stdout, "  Jobs: %d\n", len(cfg.Jobs))
-	fmt.Fprintf(os.Stdout, "  Store: %s (%s)\n", cfg.Store.Driver, cfg.Store.Path)
-	fmt.Fprintf(os.Stdout, "  Timezone: %s\n", cfg.Defaults.Timezone)
-
-	return nil
+	fmt.Fprintf(w, "\n✓ Configuration is valid: %s\n", configPath)
+	fmt.Fprintf(w, "  Jobs: %d\n", len(cfg.Jobs))
+	fmt.Fprintf(w, "  Store: %s (%s)\n", cfg.Store.Driver, cfg.Store.Path)
+	fmt.Fprintf(w, "  Timezone: %s\n", cfg.Defaults.Timezone)
 }
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCommand_MissingAgents(t *testing.T) {
+	prevLogger := logger
+	logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	t.Cleanup(func() { logger = prevLogger })
+
+	dir := t.TempDir()
+	agentsDir := filepath.Join(dir, "agents")
+	require.NoError(t, os.Mkdir(agentsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(agentsDir, "notify.sh"), []byte("#!/bin/sh\nexit 0\n"), 0o755))
+
+	writeConfig := func(hooks string) string {
+		path := filepath.Join(dir, "jobster.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+agents_paths:
+  - "`+agentsDir+`"
+
+jobs:
+  - id: "backup"
+    schedule: "@daily"
+    command: "/bin/true"
+    hooks:
+`+hooks), 0o644))
+		return path
+	}
+
+	validate := func(configPath string) error {
+		_, err := executeRootCmd(t, []*cobra.Command{validateCmd}, "validate", "--config", configPath)
+		return err
+	}
+
+	err := validate(writeConfig(`      on_success:
+        - agent: "notify.sh"
+        - agent: "@webhook"
+          with: {url: "http://localhost"}
+`))
+	assert.NoError(t, err)
+
+	err = validate(writeConfig(`      pre_run:
+        - agent: "notfy.sh"
+      on_error:
+        - agent: "notify.sh"
+        - agent: "page-oncall.sh"
+`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "job backup: invalid agent in pre_run hook #0: agent not found: notfy.sh")
+	assert.Contains(t, err.Error(), "job backup: invalid agent in on_error hook #1: agent not found: page-oncall.sh")
+}
+
+func TestValidateCommand_JSONOutput(t *testing.T) {
+	prevLogger := logger
+	logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	t.Cleanup(func() { logger = prevLogger })
+
+	dir := t.TempDir()
+	agentsDir := filepath.Join(dir, "agents")
+	require.NoError(t, os.Mkdir(agentsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(agentsDir, "notify.sh"), []byte("#!/bin/sh\nexit 0\n"), 0o755))
+
+	validate := func(yaml string) (validateReport, error) {
+		t.Helper()
+		path := filepath.Join(dir, "jobster.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("agents_paths: [\""+agentsDir+"\"]\n"+yaml), 0o644))
+
+		out, err := executeRootCmd(t, []*cobra.Command{validateCmd}, "validate", "--config", path, "--output", "json")
+
+		var report validateReport
+		require.NoError(t, json.Unmarshal([]byte(out), &report), "output: %s", out)
+		return report, err
+	}
+
+	report, err := validate(`
+defaults:
+  timezone: "UTC"
+store:
+  driver: "json"
+  path: "./runs.json"
+jobs:
+  - id: "backup"
+    schedule: "0 2 * * *"
+    command: "/bin/true"
+    hooks:
+      on_error:
+        - agent: "notify.sh"
+  - id: "report"
+    depends_on: ["backup"]
+    command: "/bin/true"
+`)
+	require.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Equal(t, 2, report.JobCount)
+	assert.Equal(t, &storeInfo{Driver: "json", Path: "./runs.json"}, report.Store)
+	assert.Equal(t, "UTC", report.Timezone)
+	require.Len(t, report.Jobs, 2)
+	assert.Equal(t, "backup", report.Jobs[0].ID)
+	require.NotNil(t, report.Jobs[0].NextRun)
+	assert.Equal(t, 2, report.Jobs[0].NextRun.UTC().Hour())
+	assert.Equal(t, 1, report.Jobs[0].Hooks["on_error"])
+	assert.Equal(t, 0, report.Jobs[0].Hooks["pre_run"])
+	assert.Nil(t, report.Jobs[1].NextRun, "dependent jobs have no schedule")
+	assert.Equal(t, []string{"backup"}, report.Jobs[1].DependsOn)
+	assert.Empty(t, report.Errors)
+
+	report, err = validate(`
+jobs:
+  - id: "backup"
+    schedule: "@daily"
+    command: "/bin/true"
+    hooks:
+      pre_run:
+        - agent: "missing.sh"
+      post_run:
+        - agent: "also-missing.sh"
+`)
+	var exitErr *exitCodeError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.code)
+	assert.False(t, report.Valid)
+	assert.Len(t, report.Errors, 2)
+	assert.Contains(t, report.Errors[0], "agent not found: missing.sh")
+	assert.Empty(t, report.Jobs)
+
+	report, err = validate("jobs: []\n")
+	require.Error(t, err)
+	assert.False(t, report.Valid)
+	require.Len(t, report.Errors, 1)
+	assert.Contains(t, report.Errors[0], "no jobs defined in configuration")
+}
+
+func TestValidateCommand_Strict(t *testing.T) {
+	prevLogger := logger
+	logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	t.Cleanup(func() { logger = prevLogger })
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "run.sh"), []byte("#!/bin/sh\nexit 0\n"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a script\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.env"), []byte("MODE=prod\n"), 0o644))
+
+	validate := func(job string, strict bool) error {
+		t.Helper()
+		path := filepath.Join(dir, "jobster.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("jobs:\n  - id: \"job\"\n    schedule: \"@daily\"\n"+job), 0o644))
+
+		args := []string{"validate", "--config", path}
+		if strict {
+			args = append(args, "--strict")
+		}
+		_, err := executeRootCmd(t, []*cobra.Command{validateCmd}, args...)
+		return err
+	}
+
+	valid := `    command: "./run.sh --verbose"
+    workdir: "` + dir + `"
+    env_file: "app.env"
+`
+	assert.NoError(t, validate(valid, true))
+	assert.NoError(t, validate("    command: \"sh -c true\"\n", true), "bare names resolve on PATH")
+	assert.NoError(t, validate("    command: \"no-such-tool | wc -l\"\n    shell: true\n", true), "shell commands need only /bin/sh")
+
+	tests := []struct {
+		name string
+		job  string
+		want []string
+	}{
+		{
+			name: "missing workdir",
+			job:  "    command: \"/bin/true\"\n    workdir: \"" + filepath.Join(dir, "gone") + "\"\n",
+			want: []string{"job job: workdir " + filepath.Join(dir, "gone") + " does not exist"},
+		},
+		{
+			name: "workdir is a file",
+			job:  "    command: \"/bin/true\"\n    workdir: \"" + filepath.Join(dir, "notes.txt") + "\"\n",
+			want: []string{"job job: workdir " + filepath.Join(dir, "notes.txt") + " is not a directory"},
+		},
+		{
+			name: "command not on PATH",
+			job:  "    command: \"jobster-no-such-tool --flag\"\n",
+			want: []string{"job job: command jobster-no-such-tool not found in PATH"},
+		},
+		{
+			name: "command path not executable",
+			job:  "    command: \"./notes.txt\"\n    workdir: \"" + dir + "\"\n",
+			want: []string{"job job: command ./notes.txt is not an executable file"},
+		},
+		{
+			name: "all problems reported",
+			job:  "    command: \"/no/such/bin\"\n    workdir: \"" + filepath.Join(dir, "gone") + "\"\n",
+			want: []string{
+				"job job: workdir " + filepath.Join(dir, "gone") + " does not exist",
+				"job job: command /no/such/bin is not an executable file",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, validate(tt.job, false), "passes without --strict")
+
+			err := validate(tt.job, true)
+			require.Error(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
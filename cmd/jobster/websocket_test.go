@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/events"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/server"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readWS reads the next server message or fails the test.
+func readWS(t *testing.T, ctx context.Context, conn *websocket.Conn) server.WSMessage {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	var msg server.WSMessage
+	require.NoError(t, wsjson.Read(ctx, conn, &msg))
+	return msg
+}
+
+func TestServe_WebSocketTriggerAndEvents(t *testing.T) {
+	dir := t.TempDir()
+	runner, st := newTestRunner(t, dir, config.Defaults{})
+	bus := events.NewBus()
+	runner.SetEventBus(bus)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sched := scheduler.New(ctx, runner.logger)
+	for _, id := range []string{"ws-job", "other-job"} {
+		require.NoError(t, sched.AddJob(&config.Job{
+			ID:         id,
+			Schedule:   "@every 1h",
+			Command:    config.NewCommandSpec("echo hello"),
+			TimeoutSec: 5,
+		}, runner))
+	}
+	require.NoError(t, sched.Start())
+	defer sched.Stop()
+
+	srv := server.New(":0", server.NewStoreAdapter(st, sched), server.NewSchedulerAdapter(sched), "", runner.logger)
+	srv.SetEventSource(bus)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(ts.URL, "http")+"/api/ws", nil)
+	require.NoError(t, err)
+	// Close before ts.Close, which waits for open connections
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	snapshot := readWS(t, ctx, conn)
+	require.Equal(t, "jobs", snapshot.Type)
+	assert.Len(t, snapshot.Jobs, 2)
+
+	// Only events for ws-job should arrive from here on. Commands are
+	// handled in order, so the error reply shows the filter is in place.
+	require.NoError(t, wsjson.Write(ctx, conn, server.WSCommand{Action: "subscribe", Jobs: []string{"ws-job"}}))
+	require.NoError(t, wsjson.Write(ctx, conn, server.WSCommand{Action: "trigger", Job: "missing"}))
+	msg := readWS(t, ctx, conn)
+	assert.Equal(t, "error", msg.Type)
+	assert.Equal(t, "job not found", msg.Error)
+
+	// Run other-job to completion while subscribed to ws-job only
+	otherEvents, _, unsubscribe := bus.Subscribe(0)
+	defer unsubscribe()
+	_, err = sched.RunJobNow("other-job")
+	require.NoError(t, err)
+	for e := range otherEvents {
+		if e.JobID == "other-job" && e.Type == events.TypeRunFinished {
+			break
+		}
+	}
+
+	require.NoError(t, wsjson.Write(ctx, conn, server.WSCommand{Action: "trigger", Job: "ws-job"}))
+
+	var runID string
+	var statuses []string
+	for len(statuses) < 2 {
+		msg := readWS(t, ctx, conn)
+		switch msg.Type {
+		case "triggered":
+			assert.Equal(t, "ws-job", msg.JobID)
+			runID = msg.RunID
+		case "event":
+			require.NotNil(t, msg.Event)
+			assert.Equal(t, "ws-job", msg.Event.JobID, "unsubscribed job's events are filtered out")
+			statuses = append(statuses, msg.Event.Status)
+		default:
+			t.Fatalf("unexpected message %+v", msg)
+		}
+	}
+	assert.NotEmpty(t, runID)
+	assert.Equal(t, []string{"running", "success"}, statuses)
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -18,77 +21,404 @@ func LoadLocation(name string) (*time.Location, error) {
 	return time.LoadLocation(name)
 }
 
+// CurrentVersion is the config schema version this build of Jobster reads.
+// It is bumped only for incompatible changes to the config format.
+const CurrentVersion = 1
+
 // Config represents the top-level configuration structure for Jobster.
 type Config struct {
-	Defaults Defaults `yaml:"defaults"`
-	Logging  Logging  `yaml:"logging"`
-	Store    Store    `yaml:"store"`
-	Security Security `yaml:"security"`
-	Jobs     []Job    `yaml:"jobs"`
+	// Version is the config schema version; absent means CurrentVersion.
+	Version int `yaml:"version" json:"version"`
+
+	Defaults  Defaults  `yaml:"defaults" json:"defaults"`
+	Logging   Logging   `yaml:"logging" json:"logging"`
+	Store     Store     `yaml:"store" json:"store"`
+	Security  Security  `yaml:"security" json:"security"`
+	Server    Server    `yaml:"server" json:"server"`
+	Telemetry Telemetry `yaml:"telemetry" json:"telemetry"`
+	Audit     Audit     `yaml:"audit" json:"audit"`
+	Events    Events    `yaml:"events,omitempty" json:"events"`
+	TUI       TUI       `yaml:"tui,omitempty" json:"tui"`
+	Jobs      []Job     `yaml:"jobs" json:"jobs"`
+
+	// AgentsPaths lists the directories searched for agents, earlier entries
+	// first. Empty means ./agents, $JOBSTER_HOME/agents, and
+	// /usr/local/lib/jobster/agents.
+	AgentsPaths []string `yaml:"agents_paths" json:"agents_paths"`
+
+	// Include lists glob patterns, relative to this file's directory, of
+	// further files whose jobs are merged into Jobs. Included files may
+	// only define jobs; everything else comes from this file.
+	Include []string `yaml:"include,omitempty" json:"include,omitempty"`
+
+	// jobSources maps the ID of each job merged in from an included file to
+	// that file's path.
+	jobSources map[string]string
 }
 
 // Defaults holds default configuration values applied across jobs and agents.
 type Defaults struct {
-	Timezone           string `yaml:"timezone"`
-	AgentTimeoutSec    int    `yaml:"agent_timeout_sec"`
-	FailOnAgentError   bool   `yaml:"fail_on_agent_error"`
-	JobRetries         int    `yaml:"job_retries"`          // optional: default 0
-	JobBackoffStrategy string `yaml:"job_backoff_strategy"` // optional: "linear" or "exponential"
+	Timezone           string `yaml:"timezone" json:"timezone"`
+	AgentTimeoutSec    int    `yaml:"agent_timeout_sec" json:"agent_timeout_sec"`
+	FailOnAgentError   bool   `yaml:"fail_on_agent_error" json:"fail_on_agent_error"`
+	JobRetries         int    `yaml:"job_retries" json:"job_retries"`                   // optional: default 0
+	JobBackoffStrategy string `yaml:"job_backoff_strategy" json:"job_backoff_strategy"` // optional: "linear" or "exponential"
+	HookConcurrency    int    `yaml:"hook_concurrency" json:"hook_concurrency"`         // optional: agents of one hook list run at once (default 1, in order)
+
+	// MaxOutputBytes caps how much of each of a run's stdout and stderr is
+	// held in memory, for hooks and the stored tail; only the last
+	// MaxOutputBytes bytes are retained (default 1 MiB). The run log files
+	// in the history directory always receive the full output.
+	MaxOutputBytes int `yaml:"max_output_bytes" json:"max_output_bytes"`
+	// MaxConcurrentJobs limits how many job runs execute at once across all
+	// jobs (0 = unlimited). ConcurrencyPolicy decides what a scheduled run
+	// does when the limit is reached: "wait" (default) for a free slot, or
+	// "skip" until its next fire time.
+	MaxConcurrentJobs int    `yaml:"max_concurrent_jobs" json:"max_concurrent_jobs"`
+	ConcurrencyPolicy string `yaml:"concurrency_policy" json:"concurrency_policy"`
+
+	// Jitter delays each scheduled run by a random amount in [0, Jitter), so
+	// jobs sharing a schedule such as @hourly do not all start at once
+	// (e.g. "30s"). Jobs can override it; empty means no delay.
+	Jitter string `yaml:"jitter" json:"jitter"`
+
+	// OutputTailBytes is how much of the end of stdout and stderr is stored
+	// with each run record in the run history store (default 10000).
+	OutputTailBytes int `yaml:"output_tail_bytes" json:"output_tail_bytes"`
+
+	// ShutdownTimeoutSec bounds how long shutdown waits for in-flight runs
+	// to finish before cancelling them (default 10).
+	ShutdownTimeoutSec int `yaml:"shutdown_timeout_sec" json:"shutdown_timeout_sec"`
+
+	// FailureThreshold opens a job's circuit breaker after this many
+	// consecutive failed runs: the job is paused and its on_circuit_open
+	// hooks run (0 = never). It stays paused until resumed by hand, or
+	// until CircuitCooldown has passed if that is set (e.g. "30m").
+	FailureThreshold int    `yaml:"failure_threshold" json:"failure_threshold"`
+	CircuitCooldown  string `yaml:"circuit_cooldown" json:"circuit_cooldown"`
 }
 
 // Logging configuration for log output.
 type Logging struct {
-	Level  string `yaml:"level"`  // "debug", "info", "warn", "error" (default: "info")
-	Format string `yaml:"format"` // "json" or "text" (default: "json")
-	Output string `yaml:"output"` // file path or "stderr" (default: "stderr")
+	Level  string `yaml:"level" json:"level"`   // "debug", "info", "warn", "error" (default: "info")
+	Format string `yaml:"format" json:"format"` // "json" or "text" (default: "json")
+	Output string `yaml:"output" json:"output"` // file path or "stderr" (default: "stderr")
+
+	// Rotation of file output; enabled when any of these is positive.
+	MaxSizeMB  int `yaml:"max_size_mb" json:"max_size_mb"`   // rotate once the file reaches this size (default 100 when rotating)
+	MaxBackups int `yaml:"max_backups" json:"max_backups"`   // rotated files to keep (0 keeps all)
+	MaxAgeDays int `yaml:"max_age_days" json:"max_age_days"` // delete rotated files older than this (0 keeps them)
+
+	// PerJobDir, when set, also writes each job's execution log lines to
+	// <per_job_dir>/<job-id>.log.
+	PerJobDir string `yaml:"per_job_dir" json:"per_job_dir"`
+
+	// RedactPatterns are extra regular expressions, matched case-insensitively
+	// against log attribute keys, whose values are redacted. They add to the
+	// built-in *_TOKEN, *_SECRET, and *PASSWORD* patterns.
+	RedactPatterns []string `yaml:"redact_patterns" json:"redact_patterns"`
+
+	// RedactValues are regular expressions masked wherever they match in
+	// captured job output, before it is stored or written to run logs.
+	// Empty disables value redaction.
+	RedactValues []string `yaml:"redact_values" json:"redact_values"`
+}
+
+// AuthTokenEnv names the environment variable that supplies the dashboard
+// auth token, overriding server.auth_token so it can be kept out of the file.
+const AuthTokenEnv = "JOBSTER_AUTH_TOKEN"
+
+// Server configuration for the HTTP dashboard started by `jobster serve`.
+type Server struct {
+	MetricsEnabled bool   `yaml:"metrics_enabled" json:"metrics_enabled"` // expose Prometheus metrics at GET /metrics
+	AuthToken      string `yaml:"auth_token" json:"auth_token"`           // optional: require this bearer token (empty = no auth)
+	TLSCert        string `yaml:"tls_cert" json:"tls_cert"`               // optional: PEM certificate file; with tls_key, serve HTTPS
+	TLSKey         string `yaml:"tls_key" json:"tls_key"`                 // optional: PEM private key file
+
+	// CORSOrigins lists the browser origins (e.g. "https://ops.example.com")
+	// allowed to call the API; "*" allows any. Empty sends no CORS headers.
+	CORSOrigins []string `yaml:"cors_origins" json:"cors_origins"`
+
+	// DashboardRefreshSec makes the dashboard reload itself every N seconds
+	// via <meta http-equiv="refresh">, for screens left unattended. 0 disables.
+	DashboardRefreshSec int `yaml:"dashboard_refresh_sec" json:"dashboard_refresh_sec"`
+
+	// RateLimitRPS limits each client IP to this many API requests per
+	// second, with bursts of up to RateLimitBurst (default: RateLimitRPS
+	// rounded up). 0 disables rate limiting.
+	RateLimitRPS   float64 `yaml:"rate_limit_rps" json:"rate_limit_rps"`
+	RateLimitBurst int     `yaml:"rate_limit_burst" json:"rate_limit_burst"`
+}
+
+// ResolveAuthToken returns the token from $JOBSTER_AUTH_TOKEN if set, else
+// AuthToken. An empty result means authentication is disabled.
+func (s Server) ResolveAuthToken() string {
+	if token := os.Getenv(AuthTokenEnv); token != "" {
+		return token
+	}
+	return s.AuthToken
 }
 
+// Telemetry configures OpenTelemetry tracing of job runs and their hooks.
+type Telemetry struct {
+	// OTLPEndpoint is the OTLP/HTTP collector URL spans are exported to,
+	// e.g. "http://localhost:4318". Empty disables tracing.
+	OTLPEndpoint string `yaml:"otlp_endpoint" json:"otlp_endpoint"`
+	ServiceName  string `yaml:"service_name" json:"service_name"` // optional: service.name of exported spans (default: "jobster")
+}
+
+// Audit configures the append-only audit log of completed runs.
+type Audit struct {
+	Path string `yaml:"path" json:"path"` // JSON Lines file one record per run is appended to (empty = no audit log)
+}
+
+// Events configures publishing run_started and run_finished events to an
+// external message queue.
+type Events struct {
+	Sink string `yaml:"sink,omitempty" json:"sink"` // EventSinkNone (default) or EventSinkRedis
+	URL  string `yaml:"url,omitempty" json:"url"`   // sink address, e.g. "redis://localhost:6379?channel=jobster.events"
+}
+
+// Event sinks.
+const (
+	EventSinkNone  = "none"
+	EventSinkRedis = "redis"
+)
+
+// TUI configures the terminal dashboard started by "jobster tui".
+type TUI struct {
+	HistoryRuns int    `yaml:"history_runs,omitempty" json:"history_runs"` // runs loaded in a job's detail view (0 = 100)
+	Theme       string `yaml:"theme,omitempty" json:"theme"`               // color theme: ThemeDark, ThemeLight or ThemeHighContrast (default: dark)
+}
+
+// TUI color themes.
+const (
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+)
+
 // Store configuration for run history persistence.
 type Store struct {
-	Driver string `yaml:"driver"` // "bbolt", "sqlite", or "json"
-	Path   string `yaml:"path"`   // file path for the store
+	Driver    string    `yaml:"driver" json:"driver"`       // "bbolt", "sqlite", "json", or "memory"
+	Path      string    `yaml:"path" json:"path"`           // file path for the store (unused by "memory")
+	Retention Retention `yaml:"retention" json:"retention"` // optional: pruning of old run records
+
+	// FlushInterval batches JSON store writes: saves within the interval are
+	// coalesced into one file rewrite (e.g. "1s"). Empty writes through on
+	// every save. Ignored by other drivers.
+	FlushInterval string `yaml:"flush_interval" json:"flush_interval"`
+}
+
+// Retention controls how long run records are kept. A zero value for either
+// field disables that rule; with both unset, history is kept forever.
+type Retention struct {
+	MaxAge        string `yaml:"max_age" json:"max_age"`                   // delete runs older than this, e.g. "720h" or "30d"
+	MaxRunsPerJob int    `yaml:"max_runs_per_job" json:"max_runs_per_job"` // keep at most this many runs per job
+}
+
+// Enabled reports whether any retention rule is configured.
+func (r Retention) Enabled() bool {
+	return r.MaxAge != "" || r.MaxRunsPerJob > 0
+}
+
+// MaxAgeDuration parses MaxAge. It accepts Go durations ("720h") and whole
+// days ("30d"). An empty MaxAge returns zero.
+func (r Retention) MaxAgeDuration() (time.Duration, error) {
+	if r.MaxAge == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(r.MaxAge, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid max_age %q", r.MaxAge)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(r.MaxAge)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid max_age %q (must be like '720h' or '30d')", r.MaxAge)
+	}
+	return d, nil
+}
+
+// ParseJitter parses a jitter setting such as "30s" or "5m". An empty value
+// returns zero, meaning no delay.
+func ParseJitter(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid jitter %q (must be a non-negative duration like '30s')", s)
+	}
+	return d, nil
+}
+
+// ParseCircuitCooldown parses a circuit_cooldown value. Empty means the
+// circuit stays open until the job is resumed by hand, reported as 0.
+func ParseCircuitCooldown(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid circuit_cooldown %q (must be a positive duration like '30m')", s)
+	}
+	return d, nil
 }
 
 // Security configuration for agent restrictions and security policies.
 type Security struct {
-	AllowedAgents []string `yaml:"allowed_agents"` // optional: whitelist of allowed agents
+	AllowedAgents  []string          `yaml:"allowed_agents" json:"allowed_agents"`   // optional: whitelist of allowed agents
+	AgentChecksums map[string]string `yaml:"agent_checksums" json:"agent_checksums"` // optional: agent name -> expected SHA-256 of its file
 }
 
 // Job represents a single scheduled job.
 type Job struct {
-	ID         string            `yaml:"id"`          // unique job identifier
-	Schedule   string            `yaml:"schedule"`    // cron expression or human-readable interval
-	Command    CommandSpec       `yaml:"command"`     // command to execute (string or array)
-	Workdir    string            `yaml:"workdir"`     // working directory for the command
-	TimeoutSec int               `yaml:"timeout_sec"` // job execution timeout
-	Env        map[string]string `yaml:"env"`         // environment variables
-	Hooks      Hooks             `yaml:"hooks"`       // lifecycle hooks
+	ID         string            `yaml:"id" json:"id"`                   // unique job identifier
+	Schedule   string            `yaml:"schedule" json:"schedule"`       // cron expression or human-readable interval
+	Timezone   string            `yaml:"timezone" json:"timezone"`       // optional: time zone for the schedule (default: defaults.timezone)
+	DependsOn  []string          `yaml:"depends_on" json:"depends_on"`   // run after any of these jobs succeeds, instead of on a schedule
+	Command    CommandSpec       `yaml:"command" json:"command"`         // command to execute (string or array)
+	Workdir    string            `yaml:"workdir" json:"workdir"`         // working directory for the command
+	TimeoutSec int               `yaml:"timeout_sec" json:"timeout_sec"` // job execution timeout
+	Env        map[string]string `yaml:"env" json:"env"`                 // environment variables
+	EnvFile    string            `yaml:"env_file" json:"env_file"`       // dotenv file merged into the environment; env entries win
+	Shell      bool              `yaml:"shell" json:"shell"`             // run the command string via "sh -c" (pipes, globs, &&)
+	CatchUp    bool              `yaml:"catch_up" json:"catch_up"`       // on startup, run once if a scheduled run was missed while down
+	Jitter     string            `yaml:"jitter" json:"jitter"`           // delay scheduled runs by a random amount below this, e.g. "30s" (default: defaults.jitter)
+	Hooks      Hooks             `yaml:"hooks" json:"hooks"`             // lifecycle hooks
+
+	// NoInterpolate passes $ through literally instead of expanding $VAR and
+	// ${VAR} in command arguments and env values at execution time.
+	NoInterpolate bool `yaml:"no_interpolate" json:"no_interpolate"`
+
+	// TemplatedCommand expands each command argument as a Go template
+	// against the run, e.g. --date={{.Now.Format "2006-01-02"}} or
+	// --run-id={{.RunID}}. Off by default, so literal braces are safe. The
+	// arguments then skip $VAR interpolation; use {{.Env.NAME}} instead.
+	TemplatedCommand bool `yaml:"templated_command,omitempty" json:"templated_command,omitempty"`
+
+	// Enabled set to false keeps the job defined but never schedules it.
+	// Unset means enabled; use IsEnabled to read it.
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// Labels are free-form key/value tags, e.g. team: payments, used to
+	// select jobs with a LabelSelector.
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	// Restart re-launches the command as soon as a run ends instead of
+	// waiting for the next scheduled run, for daemon-like jobs:
+	// RestartOnFailure after a failed run, RestartAlways after any run.
+	// Empty means RestartNever. Each restart is recorded as its own run.
+	Restart string `yaml:"restart,omitempty" json:"restart,omitempty"`
+	// RestartBackoff is the delay before the first restart, doubling for
+	// each further one up to five minutes (default "1s").
+	RestartBackoff string `yaml:"restart_backoff,omitempty" json:"restart_backoff,omitempty"`
+	// MaxRestarts caps the restarts after one scheduled or triggered run,
+	// so a crashing job does not loop forever; the next scheduled run
+	// starts a fresh count (default DefaultMaxRestarts).
+	MaxRestarts int `yaml:"max_restarts,omitempty" json:"max_restarts,omitempty"`
+}
+
+// Job restart policies.
+const (
+	RestartNever     = "never"
+	RestartOnFailure = "on-failure"
+	RestartAlways    = "always"
+)
+
+// DefaultMaxRestarts is the restart cap used when max_restarts is unset.
+const DefaultMaxRestarts = 5
+
+// ShouldRestart reports whether the job's restart policy re-launches it
+// after a run that succeeded or failed.
+func (j Job) ShouldRestart(success bool) bool {
+	switch j.Restart {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return !success
+	}
+	return false
+}
+
+// RestartLimit returns the job's max_restarts, or DefaultMaxRestarts if unset.
+func (j Job) RestartLimit() int {
+	if j.MaxRestarts > 0 {
+		return j.MaxRestarts
+	}
+	return DefaultMaxRestarts
+}
+
+// ParseRestartBackoff parses a restart_backoff value. Empty means one second.
+func ParseRestartBackoff(s string) (time.Duration, error) {
+	if s == "" {
+		return time.Second, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid restart_backoff %q (must be a positive duration like '5s')", s)
+	}
+	return d, nil
+}
+
+// IsEnabled reports whether the job should be scheduled. Jobs are enabled
+// unless enabled: false is set.
+func (j Job) IsEnabled() bool {
+	return j.Enabled == nil || *j.Enabled
+}
+
+// SetEnabled enables or disables the job. Enabling clears the field, so the
+// saved config carries "enabled: false" only for disabled jobs.
+func (j *Job) SetEnabled(enabled bool) {
+	if enabled {
+		j.Enabled = nil
+		return
+	}
+	j.Enabled = &enabled
 }
 
 // Hooks defines lifecycle hook points for a job.
 type Hooks struct {
-	PreRun    []Agent `yaml:"pre_run"`    // agents to run before job execution
-	PostRun   []Agent `yaml:"post_run"`   // agents to run after job execution (success or failure)
-	OnSuccess []Agent `yaml:"on_success"` // agents to run on successful job completion
-	OnError   []Agent `yaml:"on_error"`   // agents to run on job failure
+	PreRun    []Agent `yaml:"pre_run" json:"pre_run"`       // agents to run before job execution
+	PostRun   []Agent `yaml:"post_run" json:"post_run"`     // agents to run after job execution (success or failure)
+	OnSuccess []Agent `yaml:"on_success" json:"on_success"` // agents to run on successful job completion
+	OnError   []Agent `yaml:"on_error" json:"on_error"`     // agents to run on job failure
+
+	// OnCircuitOpen agents run once when the job is paused after
+	// defaults.failure_threshold consecutive failures.
+	OnCircuitOpen []Agent `yaml:"on_circuit_open,omitempty" json:"on_circuit_open,omitempty"`
 }
 
 // Agent represents a plugin/agent to execute at a hook point.
 type Agent struct {
-	Agent string         `yaml:"agent"` // agent name (executable name)
-	With  map[string]any `yaml:"with"`  // configuration passed to the agent
+	Agent string         `yaml:"agent" json:"agent"` // agent name (executable name)
+	With  map[string]any `yaml:"with" json:"with"`   // configuration passed to the agent
 }
 
 // CommandSpec represents a command that can be specified as either:
-// - A string: "echo hello"
-// - An array: ["/bin/echo", "hello"]
+// - A string: "echo 'hello world'" (split into arguments with shell-style quoting)
+// - An array: ["/bin/echo", "hello world"]
 type CommandSpec struct {
 	parts []string // Store as array internally for proper execution
+	raw   string   // original string form; empty when specified as an array
 }
 
-// String returns the command as a string for display.
+// String returns the command as a string for display. String-form commands
+// are returned as written; for array-form commands, arguments containing
+// whitespace or quotes are single-quoted so the result parses back to the
+// same arguments.
 func (c CommandSpec) String() string {
-	return strings.Join(c.parts, " ")
+	if c.raw != "" {
+		return c.raw
+	}
+	quoted := make([]string, len(c.parts))
+	for i, part := range c.parts {
+		quoted[i] = quoteArg(part)
+	}
+	return strings.Join(quoted, " ")
 }
 
 // Parts returns the command as an array of arguments for execution.
@@ -96,14 +426,40 @@ func (c CommandSpec) Parts() []string {
 	return c.parts
 }
 
-// Set sets the command value from a string.
+// IsArray reports whether the command was specified as an array of arguments
+// rather than as a single string.
+func (c CommandSpec) IsArray() bool {
+	return c.raw == "" && len(c.parts) > 0
+}
+
+// Set sets the command value from a string. If the string has unbalanced
+// quotes it falls back to splitting on whitespace; use ParseCommandSpec to
+// surface the error instead.
 func (c *CommandSpec) Set(value string) {
-	c.parts = strings.Fields(value)
+	parts, err := splitCommand(value)
+	if err != nil {
+		parts = strings.Fields(value)
+	}
+	c.parts = parts
+	c.raw = strings.TrimSpace(value)
 }
 
 // NewCommandSpec creates a new CommandSpec from a string.
+// See Set for how malformed quoting is handled.
 func NewCommandSpec(value string) CommandSpec {
-	return CommandSpec{parts: strings.Fields(value)}
+	var c CommandSpec
+	c.Set(value)
+	return c
+}
+
+// ParseCommandSpec creates a new CommandSpec from a string, returning an
+// error if the string contains unbalanced quotes or a trailing backslash.
+func ParseCommandSpec(value string) (CommandSpec, error) {
+	parts, err := splitCommand(value)
+	if err != nil {
+		return CommandSpec{}, err
+	}
+	return CommandSpec{parts: parts, raw: strings.TrimSpace(value)}, nil
 }
 
 // UnmarshalYAML implements custom unmarshaling to support both string and array formats.
@@ -111,7 +467,12 @@ func (c *CommandSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// Try to unmarshal as a string first
 	var strValue string
 	if err := unmarshal(&strValue); err == nil {
-		c.parts = strings.Fields(strValue)
+		parts, err := splitCommand(strValue)
+		if err != nil {
+			return fmt.Errorf("invalid command %q: %w", strValue, err)
+		}
+		c.parts = parts
+		c.raw = strings.TrimSpace(strValue)
 		return nil
 	}
 
@@ -129,3 +490,19 @@ func (c *CommandSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 func (c CommandSpec) MarshalYAML() (interface{}, error) {
 	return c.String(), nil
 }
+
+// UnmarshalJSON accepts the same string or array forms as UnmarshalYAML.
+func (c *CommandSpec) UnmarshalJSON(data []byte) error {
+	return c.UnmarshalYAML(func(v interface{}) error {
+		return json.Unmarshal(data, v)
+	})
+}
+
+// MarshalJSON writes string-form commands as a string and array-form
+// commands as an array, so they load back unchanged.
+func (c CommandSpec) MarshalJSON() ([]byte, error) {
+	if c.IsArray() {
+		return json.Marshal(c.parts)
+	}
+	return json.Marshal(c.String())
+}
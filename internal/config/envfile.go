@@ -0,0 +1,60 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnvFilePath returns the job's env_file resolved against its Workdir, or ""
+// if no env file is configured. Absolute paths are returned unchanged.
+func (j Job) EnvFilePath() string {
+	if j.EnvFile == "" || filepath.IsAbs(j.EnvFile) {
+		return j.EnvFile
+	}
+	return filepath.Join(j.Workdir, j.EnvFile)
+}
+
+// ParseEnvFile reads a dotenv-style file of KEY=VALUE lines. Blank lines and
+// lines starting with '#' are ignored, an optional leading "export " is
+// accepted, and a value wrapped in matching single or double quotes has the
+// quotes removed. Values are otherwise taken literally: there are no escape
+// sequences, inline comments, or multi-line values.
+func ParseEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNum)
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		env[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	return env, nil
+}
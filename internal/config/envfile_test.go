@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "job.env")
+	content := `# database settings
+DB_HOST=db.internal
+
+export DB_USER = backup
+DB_PASS='pa$$word # not a comment'
+GREETING="hello world"
+EMPTY=
+URL=https://example.com/?a=b
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write env file: %v", err)
+	}
+
+	got, err := ParseEnvFile(path)
+	if err != nil {
+		t.Fatalf("ParseEnvFile() error = %v", err)
+	}
+
+	want := map[string]string{
+		"DB_HOST":  "db.internal",
+		"DB_USER":  "backup",
+		"DB_PASS":  "pa$$word # not a comment",
+		"GREETING": "hello world",
+		"EMPTY":    "",
+		"URL":      "https://example.com/?a=b",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseEnvFile() = %#v, want %#v", got, want)
+	}
+}
+
+func TestParseEnvFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := ParseEnvFile(filepath.Join(dir, "missing.env")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	bad := filepath.Join(dir, "bad.env")
+	if err := os.WriteFile(bad, []byte("GOOD=1\nnot a pair\n"), 0o600); err != nil {
+		t.Fatalf("failed to write env file: %v", err)
+	}
+	_, err := ParseEnvFile(bad)
+	if err == nil {
+		t.Fatal("expected error for malformed line")
+	}
+	if want := bad + ":2:"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q does not mention %q", err, want)
+	}
+}
+
+func TestJobEnvFilePath(t *testing.T) {
+	tests := []struct {
+		job  Job
+		want string
+	}{
+		{Job{}, ""},
+		{Job{EnvFile: "/etc/jobster/app.env", Workdir: "/srv"}, "/etc/jobster/app.env"},
+		{Job{EnvFile: "app.env", Workdir: "/srv/app"}, "/srv/app/app.env"},
+		{Job{EnvFile: "app.env"}, "app.env"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.job.EnvFilePath(); got != tt.want {
+			t.Errorf("EnvFilePath() for %+v = %q, want %q", tt.job, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.env"), []byte("KEY=value\n"), 0o600); err != nil {
+		t.Fatalf("failed to write env file: %v", err)
+	}
+
+	write := func(name, envFile string) string {
+		path := filepath.Join(dir, name)
+		yaml := `
+jobs:
+  - id: "with-env-file"
+    schedule: "@daily"
+    command: "/bin/true"
+    workdir: "` + dir + `"
+    env_file: "` + envFile + `"
+`
+		if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+			t.Fatalf("failed to write temp file: %v", err)
+		}
+		return path
+	}
+
+	cfg, err := LoadConfig(write("ok.yaml", "app.env"))
+	if err != nil {
+		t.Fatalf("unexpected error for existing env_file: %v", err)
+	}
+	if cfg.Jobs[0].EnvFile != "app.env" {
+		t.Errorf("expected env_file app.env, got %q", cfg.Jobs[0].EnvFile)
+	}
+
+	_, err = LoadConfig(write("missing.yaml", "missing.env"))
+	if err == nil {
+		t.Fatal("expected error for missing env_file")
+	}
+	if !strings.Contains(err.Error(), "env_file") {
+		t.Errorf("error %q does not mention env_file", err)
+	}
+}
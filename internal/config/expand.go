@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// envRefPattern matches ${VAR} and ${VAR:-default} references.
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-[^}]*)?\}`)
+
+// expandEnvRefs replaces ${VAR} and ${VAR:-default} in s with values from
+// lookup. The default is used when VAR is unset or empty; an unset VAR with
+// no default is an error.
+func expandEnvRefs(s string, lookup func(string) (string, bool)) (string, error) {
+	var firstErr error
+	out := envRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		m := envRefPattern.FindStringSubmatch(ref)
+		name, def := m[1], m[2]
+		if v, ok := lookup(name); ok && (v != "" || def == "") {
+			return v
+		}
+		if def != "" {
+			return strings.TrimPrefix(def, ":-")
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf("undefined variable ${%s} (set it, or give a default with ${%s:-value})", name, name)
+		}
+		return ""
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return out, nil
+}
+
+// expandConfigEnv substitutes ${VAR} references from the process
+// environment into the string fields of the config struct v points to.
+// Job commands, env values and agent with: options are left alone: the
+// runner interpolates commands and env at execution time, and expanding
+// them here would write secrets back when the config is saved.
+func expandConfigEnv(v interface{}) error {
+	return expandValue(reflect.ValueOf(v).Elem(), "")
+}
+
+func expandValue(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.String:
+		out, err := expandEnvRefs(v.String(), os.LookupEnv)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		v.SetString(out)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := expandValue(v.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return expandValue(v.Elem(), path)
+		}
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(CommandSpec{}) {
+			return nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if path != "" {
+				name = path + "." + name
+			}
+			if err := expandValue(v.Field(i), name); err != nil {
+				return err
+			}
+		}
+	}
+	// Maps (job env, agent with:, agent checksums) are not expanded
+	return nil
+}
+
+// ExpandPath expands a leading ~ to the user's home directory and $VAR and
+// ${VAR} references from the environment; unset variables expand to "".
+// The result is otherwise left as is, so relative paths stay relative.
+func ExpandPath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	return os.ExpandEnv(path)
+}
+
+// expandPaths applies ExpandPath to the config's filesystem paths that are
+// used as is at run time: job workdirs and the store path.
+func expandPaths(cfg *Config) {
+	cfg.Store.Path = ExpandPath(cfg.Store.Path)
+	for i := range cfg.Jobs {
+		cfg.Jobs[i].Workdir = ExpandPath(cfg.Jobs[i].Workdir)
+	}
+}
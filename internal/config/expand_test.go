@@ -0,0 +1,204 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandEnvRefs(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		v, ok := map[string]string{"DATA_DIR": "/var/lib/jobster", "EMPTY": ""}[name]
+		return v, ok
+	}
+
+	tests := []struct {
+		in, want string
+	}{
+		{"${DATA_DIR}/jobster.db", "/var/lib/jobster/jobster.db"},
+		{"${MISSING:-/tmp}/jobster.db", "/tmp/jobster.db"},
+		{"${DATA_DIR:-/tmp}", "/var/lib/jobster"},
+		{"${EMPTY:-fallback}", "fallback"},
+		{"${EMPTY}", ""},
+		{"${MISSING:-}", ""},
+		{"$DATA_DIR stays", "$DATA_DIR stays"},
+		{"no refs", "no refs"},
+	}
+	for _, tt := range tests {
+		got, err := expandEnvRefs(tt.in, lookup)
+		if err != nil {
+			t.Errorf("expandEnvRefs(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("expandEnvRefs(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	if _, err := expandEnvRefs("${MISSING}/x", lookup); err == nil || !strings.Contains(err.Error(), "undefined variable ${MISSING}") {
+		t.Errorf("expected an undefined variable error, got %v", err)
+	}
+}
+
+func TestLoadConfig_EnvSubstitution(t *testing.T) {
+	t.Setenv("JOBSTER_TEST_DATA_DIR", "/srv/data")
+	t.Setenv("JOBSTER_TEST_TOKEN", "s3cret")
+
+	path := filepath.Join(t.TempDir(), "jobster.yaml")
+	content := `defaults:
+  timezone: "${JOBSTER_TEST_TZ:-UTC}"
+store:
+  driver: bbolt
+  path: "${JOBSTER_TEST_DATA_DIR}/jobster.db"
+server:
+  auth_token: "${JOBSTER_TEST_TOKEN}"
+jobs:
+  - id: report
+    schedule: "@daily"
+    command: echo "${JOBSTER_TEST_TOKEN}"
+    workdir: "${JOBSTER_TEST_DATA_DIR}"
+    env:
+      API_KEY: "${JOBSTER_TEST_TOKEN}"
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Store.Path != "/srv/data/jobster.db" {
+		t.Errorf("store.path = %q, want /srv/data/jobster.db", cfg.Store.Path)
+	}
+	if cfg.Defaults.Timezone != "UTC" {
+		t.Errorf("defaults.timezone = %q, want the default UTC", cfg.Defaults.Timezone)
+	}
+	if cfg.Server.AuthToken != "s3cret" {
+		t.Errorf("server.auth_token = %q, want s3cret", cfg.Server.AuthToken)
+	}
+	job := cfg.Jobs[0]
+	if job.Workdir != "/srv/data" {
+		t.Errorf("workdir = %q, want /srv/data", job.Workdir)
+	}
+	// Commands and env are interpolated by the runner at execution time
+	if got := job.Env["API_KEY"]; got != "${JOBSTER_TEST_TOKEN}" {
+		t.Errorf("env API_KEY = %q, want it left for the runner", got)
+	}
+	if got := job.Command.String(); !strings.Contains(got, "${JOBSTER_TEST_TOKEN}") {
+		t.Errorf("command = %q, want it left for the runner", got)
+	}
+}
+
+func TestLoadConfig_EnvSubstitutionUnset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobster.yaml")
+	content := `store:
+  driver: bbolt
+  path: "${JOBSTER_TEST_UNSET_DIR}/jobster.db"
+jobs:
+  - id: report
+    schedule: "@daily"
+    command: echo hi
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected an error for an unset variable")
+	}
+	for _, want := range []string{"store.path", "${JOBSTER_TEST_UNSET_DIR}"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+func TestAddJob_KeepsEnvReferences(t *testing.T) {
+	t.Setenv("JOBSTER_TEST_DATA_DIR", "/srv/data")
+
+	path := filepath.Join(t.TempDir(), "jobster.yaml")
+	content := `store:
+  driver: bbolt
+  path: "${JOBSTER_TEST_DATA_DIR}/jobster.db"
+jobs:
+  - id: report
+    schedule: "@daily"
+    command: echo hi
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AddJob(path, Job{ID: "other", Schedule: "@hourly", Command: NewCommandSpec("echo other")}); err != nil {
+		t.Fatalf("AddJob() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "${JOBSTER_TEST_DATA_DIR}/jobster.db") {
+		t.Errorf("saved config lost the ${VAR} reference:\n%s", data)
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	t.Setenv("HOME", "/home/ops")
+	t.Setenv("JOBSTER_TEST_DATA_DIR", "/srv/data")
+
+	tests := []struct {
+		in, want string
+	}{
+		{"~", "/home/ops"},
+		{"~/projects", "/home/ops/projects"},
+		{"$HOME/projects", "/home/ops/projects"},
+		{"${JOBSTER_TEST_DATA_DIR}/jobster.db", "/srv/data/jobster.db"},
+		{"/opt/app", "/opt/app"},
+		{"./relative", "./relative"},
+		{"~other/dir", "~other/dir"},
+	}
+	for _, tt := range tests {
+		if got := ExpandPath(tt.in); got != tt.want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_ExpandsPaths(t *testing.T) {
+	t.Setenv("HOME", "/home/ops")
+	t.Setenv("JOBSTER_TEST_DATA_DIR", "/srv/data")
+
+	path := filepath.Join(t.TempDir(), "jobster.yaml")
+	content := `store:
+  driver: bbolt
+  path: "$JOBSTER_TEST_DATA_DIR/jobster.db"
+jobs:
+  - id: home
+    schedule: "@daily"
+    command: echo hi
+    workdir: "~/projects"
+  - id: absolute
+    schedule: "@daily"
+    command: echo hi
+    workdir: /opt/app
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Store.Path != "/srv/data/jobster.db" {
+		t.Errorf("store.path = %q, want /srv/data/jobster.db", cfg.Store.Path)
+	}
+	if got := cfg.Jobs[0].Workdir; got != "/home/ops/projects" {
+		t.Errorf("workdir = %q, want /home/ops/projects", got)
+	}
+	if got := cfg.Jobs[1].Workdir; got != "/opt/app" {
+		t.Errorf("workdir = %q, want /opt/app", got)
+	}
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// starterComments are the comments written above keys of the starter
+// configuration, by dotted path ("jobs" for the sequence, "jobs.id" for keys
+// of the sample job).
+var starterComments = map[string]string{
+	"version":                    "Config schema version",
+	"defaults":                   "Defaults applied to every job",
+	"defaults.timezone":          `IANA time zone for schedules, e.g. "UTC" or "Europe/Berlin" ("Local" = this host)`,
+	"defaults.agent_timeout_sec": "Seconds an agent (hook) may run before it is killed",
+	"store":                      `Where run history is kept: "bbolt", "sqlite", "json", or "memory"`,
+	"jobs":                       "Jobs to run. Schedules accept cron expressions (\"0 2 * * *\") and\n@-notation (\"@daily\", \"@every 15m\").",
+	"jobs.command":               "Run directly, without a shell; set shell: true for pipes and globs",
+	"jobs.timeout_sec":           "Kill the command after this many seconds",
+}
+
+// starterHooksExample is appended, commented out, to the sample job.
+const starterHooksExample = `Hooks run agents at points of a job's life: pre_run, post_run,
+on_success and on_error. For example:
+hooks:
+  on_error:
+    - agent: "@webhook"
+      with:
+        url: "https://hooks.example.com/jobster"`
+
+// StarterConfig returns NewDefaultConfig with a sample job, as written by
+// GenerateConfig.
+func StarterConfig() *Config {
+	cfg := NewDefaultConfig()
+	cfg.Jobs = append(cfg.Jobs, Job{
+		ID:         "hello",
+		Schedule:   "@every 1h",
+		Command:    NewCommandSpec(`echo "hello from jobster"`),
+		TimeoutSec: 60,
+	})
+	return cfg
+}
+
+// GenerateConfig writes StarterConfig to path as commented YAML, omitting
+// settings left at their zero value. The file is written atomically like
+// SaveConfig; an existing file is replaced.
+func GenerateConfig(path string) error {
+	data, err := marshalStarterConfig()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// marshalStarterConfig returns the commented YAML written by GenerateConfig.
+func marshalStarterConfig() ([]byte, error) {
+	cfg := StarterConfig()
+	if err := validate(cfg); err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", err)
+	}
+
+	var root yaml.Node
+	if err := root.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to marshal config to YAML: %w", err)
+	}
+	pruneZero(&root)
+	annotate(&root, "")
+
+	root.HeadComment = "Jobster configuration. Check it with: jobster validate --config <file>"
+	if jobs := mappingValue(&root, "jobs"); jobs != nil && len(jobs.Content) > 0 {
+		sample := jobs.Content[0]
+		last := sample.Content[len(sample.Content)-2]
+		last.FootComment = starterHooksExample
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return nil, fmt.Errorf("failed to marshal config to YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal config to YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// pruneZero removes mapping entries whose value is empty, zero, or false,
+// recursively, so only meaningful settings are written.
+func pruneZero(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.SequenceNode:
+		for _, child := range n.Content {
+			pruneZero(child)
+		}
+	case yaml.MappingNode:
+		kept := n.Content[:0]
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			pruneZero(value)
+			if isZeroNode(value) {
+				continue
+			}
+			kept = append(kept, key, value)
+		}
+		n.Content = kept
+	}
+}
+
+// isZeroNode reports whether n is an empty collection or a zero scalar.
+func isZeroNode(n *yaml.Node) bool {
+	switch n.Kind {
+	case yaml.SequenceNode, yaml.MappingNode:
+		return len(n.Content) == 0
+	case yaml.ScalarNode:
+		return n.Value == "" || n.Value == "0" || n.Value == "false"
+	}
+	return false
+}
+
+// annotate sets the head comments from starterComments on the keys of the
+// mapping n, found at path.
+func annotate(n *yaml.Node, path string) {
+	switch n.Kind {
+	case yaml.SequenceNode:
+		for _, child := range n.Content {
+			annotate(child, path)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i]
+			keyPath := key.Value
+			if path != "" {
+				keyPath = path + "." + key.Value
+			}
+			if comment, ok := starterComments[keyPath]; ok {
+				key.HeadComment = comment
+			}
+			annotate(n.Content[i+1], keyPath)
+		}
+	}
+}
+
+// mappingValue returns the value of key in the mapping n, or nil.
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// includedFile is the shape of a file named by include: it may only hold
+// jobs. Everything else comes from the base config.
+type includedFile struct {
+	Jobs []Job `yaml:"jobs" json:"jobs"`
+}
+
+// loadIncludes appends the jobs of every file matched by cfg.Include to
+// cfg.Jobs and records which file each came from. Patterns are globs
+// resolved against the directory of the base config at basePath; matches of
+// one pattern are taken in lexical order. A job ID defined in more than one
+// file is an error naming both files.
+func loadIncludes(cfg *Config, basePath string) error {
+	if len(cfg.Include) == 0 {
+		return nil
+	}
+
+	sources := make(map[string]string, len(cfg.Jobs))
+	for _, job := range cfg.Jobs {
+		if job.ID != "" {
+			sources[job.ID] = basePath
+		}
+	}
+
+	baseDir := filepath.Dir(basePath)
+	seen := make(map[string]bool)
+	for _, pattern := range cfg.Include {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(baseDir, pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid include pattern %q: %w", pattern, err)
+		}
+		sort.Strings(matches)
+
+		for _, path := range matches {
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+
+			jobs, err := loadIncludedJobs(path)
+			if err != nil {
+				return fmt.Errorf("include %s: %w", path, err)
+			}
+			for _, job := range jobs {
+				if job.ID != "" {
+					if other, ok := sources[job.ID]; ok {
+						return fmt.Errorf("duplicate job ID %q in %s and %s", job.ID, other, path)
+					}
+					sources[job.ID] = path
+					if cfg.jobSources == nil {
+						cfg.jobSources = make(map[string]string)
+					}
+					cfg.jobSources[job.ID] = path
+				}
+				cfg.Jobs = append(cfg.Jobs, job)
+			}
+		}
+	}
+	return nil
+}
+
+// loadIncludedJobs reads the jobs from an included file, rejecting any other
+// top-level section.
+func loadIncludedJobs(path string) ([]Job, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys map[string]interface{}
+	var file includedFile
+	if isJSONPath(path) {
+		if err := json.Unmarshal(data, &keys); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &keys); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+	}
+
+	for key := range keys {
+		if key != "jobs" {
+			return nil, fmt.Errorf("only jobs may be defined in an included file, found %q", key)
+		}
+	}
+	if err := expandConfigEnv(&file); err != nil {
+		return nil, err
+	}
+	return file.Jobs, nil
+}
+
+// JobSource returns the included file a job was loaded from, or "" if it is
+// defined in the base config (or unknown).
+func (c *Config) JobSource(jobID string) string {
+	return c.jobSources[jobID]
+}
+
+// baseJobs returns the jobs defined in the base config file itself, leaving
+// out those merged in from included files.
+func (c *Config) baseJobs() []Job {
+	if len(c.jobSources) == 0 {
+		return c.Jobs
+	}
+	jobs := make([]Job, 0, len(c.Jobs))
+	for _, job := range c.Jobs {
+		if _, included := c.jobSources[job.ID]; !included {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeIncludeTree writes a base config including jobs.d/*.yaml plus the
+// given files under jobs.d, and returns the base config's path.
+func writeIncludeTree(t *testing.T, included map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "jobs.d"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range included {
+		if err := os.WriteFile(filepath.Join(dir, "jobs.d", name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	base := filepath.Join(dir, "jobster.yaml")
+	content := `include:
+  - jobs.d/*.yaml
+defaults:
+  timezone: UTC
+store:
+  driver: memory
+jobs:
+  - id: base-job
+    schedule: "@hourly"
+    command: echo base
+`
+	if err := os.WriteFile(base, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return base
+}
+
+func TestLoadConfig_Include(t *testing.T) {
+	base := writeIncludeTree(t, map[string]string{
+		"team-a.yaml": `jobs:
+  - id: a-job
+    schedule: "@daily"
+    command: echo a
+`,
+		"team-b.yaml": `jobs:
+  - id: b-job
+    schedule: "@daily"
+    command: echo b
+`,
+	})
+
+	cfg, err := LoadConfig(base)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	var ids []string
+	for _, job := range cfg.Jobs {
+		ids = append(ids, job.ID)
+	}
+	if got, want := strings.Join(ids, ","), "base-job,a-job,b-job"; got != want {
+		t.Errorf("job IDs = %s, want %s", got, want)
+	}
+	if cfg.Defaults.Timezone != "UTC" || cfg.Store.Driver != "memory" {
+		t.Errorf("base settings not kept: timezone=%q driver=%q", cfg.Defaults.Timezone, cfg.Store.Driver)
+	}
+	// Included jobs get the usual job defaults
+	if cfg.Jobs[1].TimeoutSec != 600 {
+		t.Errorf("included job timeout_sec = %d, want 600", cfg.Jobs[1].TimeoutSec)
+	}
+
+	if got := cfg.JobSource("a-job"); filepath.Base(got) != "team-a.yaml" {
+		t.Errorf("JobSource(a-job) = %q, want .../team-a.yaml", got)
+	}
+	if got := cfg.JobSource("base-job"); got != "" {
+		t.Errorf("JobSource(base-job) = %q, want empty", got)
+	}
+}
+
+func TestLoadConfig_IncludeDuplicateID(t *testing.T) {
+	base := writeIncludeTree(t, map[string]string{
+		"team-a.yaml": `jobs:
+  - id: shared
+    schedule: "@daily"
+    command: echo a
+`,
+		"team-b.yaml": `jobs:
+  - id: shared
+    schedule: "@daily"
+    command: echo b
+`,
+	})
+
+	_, err := LoadConfig(base)
+	if err == nil {
+		t.Fatal("expected a duplicate job ID error")
+	}
+	for _, want := range []string{`"shared"`, "team-a.yaml", "team-b.yaml"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+func TestLoadConfig_IncludeDuplicatesBaseJob(t *testing.T) {
+	base := writeIncludeTree(t, map[string]string{
+		"team-a.yaml": `jobs:
+  - id: base-job
+    schedule: "@daily"
+    command: echo a
+`,
+	})
+
+	_, err := LoadConfig(base)
+	if err == nil || !strings.Contains(err.Error(), "jobster.yaml") || !strings.Contains(err.Error(), "team-a.yaml") {
+		t.Errorf("expected a duplicate error naming both files, got %v", err)
+	}
+}
+
+func TestLoadConfig_IncludeOnlyJobs(t *testing.T) {
+	base := writeIncludeTree(t, map[string]string{
+		"team-a.yaml": `store:
+  driver: sqlite
+jobs: []
+`,
+	})
+
+	_, err := LoadConfig(base)
+	if err == nil || !strings.Contains(err.Error(), `"store"`) {
+		t.Errorf("expected an error about the store section, got %v", err)
+	}
+}
+
+func TestWriter_IncludedJobs(t *testing.T) {
+	base := writeIncludeTree(t, map[string]string{
+		"team-a.yaml": `jobs:
+  - id: a-job
+    schedule: "@daily"
+    command: echo a
+`,
+	})
+
+	if err := AddJob(base, Job{ID: "a-job", Schedule: "@hourly", Command: NewCommandSpec("echo x")}); err == nil {
+		t.Error("AddJob() should reject an ID taken by an included job")
+	}
+	if err := RemoveJob(base, "a-job"); err == nil || !strings.Contains(err.Error(), "team-a.yaml") {
+		t.Errorf("RemoveJob() error = %v, want one naming team-a.yaml", err)
+	}
+
+	if err := AddJob(base, Job{ID: "new-job", Schedule: "@hourly", Command: NewCommandSpec("echo new")}); err != nil {
+		t.Fatalf("AddJob() error = %v", err)
+	}
+	data, err := os.ReadFile(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "a-job") {
+		t.Errorf("included job was written into the base config:\n%s", data)
+	}
+
+	cfg, err := LoadConfig(base)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if len(cfg.Jobs) != 3 {
+		t.Errorf("got %d jobs after AddJob, want 3", len(cfg.Jobs))
+	}
+}
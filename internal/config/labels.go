@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LabelSelector selects jobs by label. A job matches when it carries every
+// key with the same value; an empty selector matches every job.
+type LabelSelector map[string]string
+
+// ParseLabelSelector parses "key=value" terms, as given to --label or the
+// label query parameter, into a selector. Repeating a key with a different
+// value is an error, since no job could match both.
+func ParseLabelSelector(terms []string) (LabelSelector, error) {
+	sel := LabelSelector{}
+	for _, term := range terms {
+		key, value, ok := strings.Cut(term, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid label %q (want key=value)", term)
+		}
+		key = strings.TrimSpace(key)
+		if err := validateLabelKey(key); err != nil {
+			return nil, err
+		}
+		if prev, dup := sel[key]; dup && prev != value {
+			return nil, fmt.Errorf("label %q given twice with different values", key)
+		}
+		sel[key] = value
+	}
+	return sel, nil
+}
+
+// Matches reports whether labels carries every key/value pair in the
+// selector.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for key, want := range s {
+		got, ok := labels[key]
+		if !ok || got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the selector as sorted, comma-separated key=value terms.
+func (s LabelSelector) String() string {
+	return FormatLabels(s)
+}
+
+// FormatLabels renders labels as sorted, comma-separated key=value terms.
+func FormatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	terms := make([]string, len(keys))
+	for i, key := range keys {
+		terms[i] = key + "=" + labels[key]
+	}
+	return strings.Join(terms, ",")
+}
+
+func validateLabelKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("label key must not be empty")
+	}
+	if strings.ContainsAny(key, "=, \t") {
+		return fmt.Errorf("invalid label key %q (must not contain '=', ',' or spaces)", key)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package config
+
+import "testing"
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		terms   []string
+		want    LabelSelector
+		wantErr bool
+	}{
+		{terms: nil, want: LabelSelector{}},
+		{terms: []string{"team=payments"}, want: LabelSelector{"team": "payments"}},
+		{terms: []string{"team=payments", "tier=critical"}, want: LabelSelector{"team": "payments", "tier": "critical"}},
+		{terms: []string{"team="}, want: LabelSelector{"team": ""}},
+		{terms: []string{"url=a=b"}, want: LabelSelector{"url": "a=b"}},
+		{terms: []string{"team=payments", "team=payments"}, want: LabelSelector{"team": "payments"}},
+		{terms: []string{"team"}, wantErr: true},
+		{terms: []string{"=payments"}, wantErr: true},
+		{terms: []string{"team=payments", "team=search"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLabelSelector(tt.terms)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseLabelSelector(%q) = %v, want error", tt.terms, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseLabelSelector(%q) error = %v", tt.terms, err)
+			continue
+		}
+		if got.String() != tt.want.String() || len(got) != len(tt.want) {
+			t.Errorf("ParseLabelSelector(%q) = %v, want %v", tt.terms, got, tt.want)
+		}
+	}
+}
+
+func TestLabelSelector_Matches(t *testing.T) {
+	labels := map[string]string{"team": "payments", "tier": "critical"}
+
+	tests := []struct {
+		selector LabelSelector
+		want     bool
+	}{
+		{selector: nil, want: true},
+		{selector: LabelSelector{"team": "payments"}, want: true},
+		{selector: LabelSelector{"team": "payments", "tier": "critical"}, want: true},
+		{selector: LabelSelector{"team": "payments", "tier": "batch"}, want: false},
+		{selector: LabelSelector{"team": "search"}, want: false},
+		{selector: LabelSelector{"owner": "payments"}, want: false},
+		{selector: LabelSelector{"team": ""}, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.selector.Matches(labels); got != tt.want {
+			t.Errorf("%v.Matches(%v) = %v, want %v", tt.selector, labels, got, tt.want)
+		}
+	}
+
+	if (LabelSelector{"team": "payments"}).Matches(nil) {
+		t.Errorf("a job without labels should not match a non-empty selector")
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	got := FormatLabels(map[string]string{"tier": "critical", "team": "payments"})
+	if want := "team=payments,tier=critical"; got != want {
+		t.Errorf("FormatLabels = %q, want %q", got, want)
+	}
+}
@@ -1,45 +1,87 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
-// cronExpressionPattern is a basic regex to validate cron expressions.
-// Supports standard 5-field cron and robfig/cron's 6-field (with seconds) format.
-var cronExpressionPattern = regexp.MustCompile(`^(@(annually|yearly|monthly|weekly|daily|hourly|reboot))|(@every\s+\d+[smh])|(\*|\d+|\d+-\d+|\*/\d+)((/(\*|\d+|\d+-\d+|\*/\d+)){4,5})`)
-
-// LoadConfig loads and validates a Jobster configuration from a YAML file.
+// LoadConfig loads and validates a Jobster configuration file. Files ending
+// in .json are parsed as JSON; anything else as YAML. ${VAR} and
+// ${VAR:-default} references are replaced from the environment, then jobs
+// from the files named by the config's include patterns are merged in
+// before validation. Job workdirs and the store path also have ~ and $VAR
+// expanded (see ExpandPath).
 func LoadConfig(path string) (*Config, error) {
-	// Read the file
-	data, err := os.ReadFile(path)
+	cfg, err := parseConfigFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, err
 	}
 
-	// Parse YAML
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	// Substitute environment variables
+	if err := expandConfigEnv(cfg); err != nil {
+		return nil, err
+	}
+
+	// Merge in jobs from included files
+	if err := loadIncludes(cfg, path); err != nil {
+		return nil, err
 	}
 
+	// Expand ~ and $VAR in paths
+	expandPaths(cfg)
+
 	// Apply defaults
-	applyDefaults(&cfg)
+	applyDefaults(cfg)
 
 	// Validate configuration
-	if err := validate(&cfg); err != nil {
+	if err := validate(cfg); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
 	}
 
+	return cfg, nil
+}
+
+// parseConfigFile reads and parses a config file as written, without
+// substituting variables, merging includes or validating.
+func parseConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg Config
+	if isJSONPath(path) {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
 	return &cfg, nil
 }
 
+// isJSONPath reports whether a config file at path is in JSON format.
+func isJSONPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
 // applyDefaults sets default values for optional fields.
 func applyDefaults(cfg *Config) {
+	if cfg.Version == 0 {
+		cfg.Version = CurrentVersion
+	}
+
 	// Defaults section
 	if cfg.Defaults.Timezone == "" {
 		cfg.Defaults.Timezone = "Local"
@@ -55,7 +97,7 @@ func applyDefaults(cfg *Config) {
 	if cfg.Store.Driver == "" {
 		cfg.Store.Driver = "bbolt"
 	}
-	if cfg.Store.Path == "" {
+	if cfg.Store.Path == "" && cfg.Store.Driver != "memory" {
 		cfg.Store.Path = "./.jobster.db"
 	}
 
@@ -76,14 +118,84 @@ func applyDefaults(cfg *Config) {
 
 // validate checks the configuration for errors and inconsistencies.
 func validate(cfg *Config) error {
+	if cfg.Version > CurrentVersion {
+		return fmt.Errorf("config version %d is newer than this jobster supports (version %d); upgrade jobster to load it", cfg.Version, CurrentVersion)
+	}
+	if cfg.Version < 0 {
+		return fmt.Errorf("invalid config version %d", cfg.Version)
+	}
+
 	// Validate store driver
 	validDrivers := map[string]bool{
 		"bbolt":  true,
 		"sqlite": true,
 		"json":   true,
+		"memory": true,
 	}
 	if !validDrivers[cfg.Store.Driver] {
-		return fmt.Errorf("invalid store driver: %s (must be 'bbolt', 'sqlite', or 'json')", cfg.Store.Driver)
+		return fmt.Errorf("invalid store driver: %s (must be 'bbolt', 'sqlite', 'json', or 'memory')", cfg.Store.Driver)
+	}
+	if _, err := cfg.Store.Retention.MaxAgeDuration(); err != nil {
+		return fmt.Errorf("store.retention: %w", err)
+	}
+	if cfg.Store.Retention.MaxRunsPerJob < 0 {
+		return fmt.Errorf("store.retention.max_runs_per_job must be non-negative")
+	}
+	if cfg.Store.FlushInterval != "" {
+		if d, err := time.ParseDuration(cfg.Store.FlushInterval); err != nil || d < 0 {
+			return fmt.Errorf("invalid store.flush_interval %q (must be a duration like '1s')", cfg.Store.FlushInterval)
+		}
+	}
+
+	if cfg.Logging.MaxSizeMB < 0 || cfg.Logging.MaxBackups < 0 || cfg.Logging.MaxAgeDays < 0 {
+		return fmt.Errorf("logging.max_size_mb, max_backups and max_age_days must be non-negative")
+	}
+	for _, p := range cfg.Logging.RedactPatterns {
+		if _, err := regexp.Compile("(?i)" + p); err != nil {
+			return fmt.Errorf("logging.redact_patterns: invalid pattern %q: %w", p, err)
+		}
+	}
+	for _, p := range cfg.Logging.RedactValues {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("logging.redact_values: invalid pattern %q: %w", p, err)
+		}
+	}
+
+	for _, origin := range cfg.Server.CORSOrigins {
+		if !validCORSOrigin(origin) {
+			return fmt.Errorf("server.cors_origins: invalid origin %q (must be \"*\" or scheme://host[:port])", origin)
+		}
+	}
+
+	if cfg.Server.DashboardRefreshSec < 0 {
+		return fmt.Errorf("server.dashboard_refresh_sec must be non-negative")
+	}
+	if cfg.Server.RateLimitRPS < 0 || cfg.Server.RateLimitBurst < 0 {
+		return fmt.Errorf("server.rate_limit_rps and rate_limit_burst must be non-negative")
+	}
+	if cfg.TUI.HistoryRuns < 0 {
+		return fmt.Errorf("tui.history_runs must be non-negative")
+	}
+	switch cfg.TUI.Theme {
+	case "", ThemeDark, ThemeLight, ThemeHighContrast:
+	default:
+		return fmt.Errorf("invalid tui.theme %q (must be 'dark', 'light' or 'high-contrast')", cfg.TUI.Theme)
+	}
+
+	switch cfg.Events.Sink {
+	case "", EventSinkNone:
+	case EventSinkRedis:
+		if u, err := url.Parse(cfg.Events.URL); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Host == "" {
+			return fmt.Errorf("events.url: invalid URL %q (must be like \"redis://localhost:6379\")", cfg.Events.URL)
+		}
+	default:
+		return fmt.Errorf("invalid events.sink %q (must be 'none' or 'redis')", cfg.Events.Sink)
+	}
+
+	if endpoint := cfg.Telemetry.OTLPEndpoint; endpoint != "" {
+		if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("telemetry.otlp_endpoint: invalid URL %q (must be like \"http://localhost:4318\")", endpoint)
+		}
 	}
 
 	// Validate jobs
@@ -97,7 +209,12 @@ func validate(cfg *Config) error {
 		if job.ID == "" {
 			return fmt.Errorf("job at index %d is missing an ID", i)
 		}
-		if job.Schedule == "" {
+		// A dependent job is started by its upstream jobs, not by cron
+		if len(job.DependsOn) > 0 {
+			if job.Schedule != "" {
+				return fmt.Errorf("job %s: schedule and depends_on cannot both be set", job.ID)
+			}
+		} else if job.Schedule == "" {
 			return fmt.Errorf("job %s is missing a schedule", job.ID)
 		}
 		if job.Command.String() == "" {
@@ -111,14 +228,66 @@ func validate(cfg *Config) error {
 		jobIDs[job.ID] = true
 
 		// Validate schedule expression
-		if err := ValidateSchedule(job.Schedule); err != nil {
-			return fmt.Errorf("job %s has invalid schedule: %w", job.ID, err)
+		if job.Schedule != "" {
+			if err := ValidateSchedule(job.Schedule); err != nil {
+				return fmt.Errorf("job %s has invalid schedule: %w", job.ID, err)
+			}
+		}
+		if job.Timezone != "" {
+			if _, err := LoadLocation(job.Timezone); err != nil {
+				return fmt.Errorf("job %s has invalid timezone %q: %w", job.ID, job.Timezone, err)
+			}
+		}
+
+		// Shell mode hands a single string to "sh -c"; an argv array would
+		// be re-joined and re-split by the shell, which is rarely what was meant.
+		if job.Shell && job.Command.IsArray() {
+			return fmt.Errorf("job %s: shell mode requires command to be a string, not an array", job.ID)
+		}
+
+		if job.TemplatedCommand {
+			for _, arg := range job.TemplateArgs() {
+				if _, err := ParseArgTemplate(arg); err != nil {
+					return fmt.Errorf("job %s: templated_command: %w", job.ID, err)
+				}
+			}
+		}
+
+		// The env file is read at run time, but a missing one is almost
+		// certainly a deployment mistake worth catching up front.
+		if path := job.EnvFilePath(); path != "" {
+			info, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("job %s: env_file: %w", job.ID, err)
+			}
+			if info.IsDir() {
+				return fmt.Errorf("job %s: env_file %s is a directory", job.ID, path)
+			}
 		}
 
 		// Validate timeout
 		if job.TimeoutSec < 0 {
 			return fmt.Errorf("job %s has negative timeout_sec", job.ID)
 		}
+		if _, err := ParseJitter(job.Jitter); err != nil {
+			return fmt.Errorf("job %s: %w", job.ID, err)
+		}
+		switch job.Restart {
+		case "", RestartNever, RestartOnFailure, RestartAlways:
+		default:
+			return fmt.Errorf("job %s: invalid restart %q (must be 'never', 'on-failure' or 'always')", job.ID, job.Restart)
+		}
+		if _, err := ParseRestartBackoff(job.RestartBackoff); err != nil {
+			return fmt.Errorf("job %s: %w", job.ID, err)
+		}
+		if job.MaxRestarts < 0 {
+			return fmt.Errorf("job %s: max_restarts must be non-negative", job.ID)
+		}
+		for key := range job.Labels {
+			if err := validateLabelKey(key); err != nil {
+				return fmt.Errorf("job %s: %w", job.ID, err)
+			}
+		}
 
 		// Validate agents against allowed list if security is enabled
 		if len(cfg.Security.AllowedAgents) > 0 {
@@ -128,6 +297,16 @@ func validate(cfg *Config) error {
 		}
 	}
 
+	if err := validateDependencies(cfg.Jobs); err != nil {
+		return err
+	}
+
+	for name, sum := range cfg.Security.AgentChecksums {
+		if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size {
+			return fmt.Errorf("security.agent_checksums: %s: expected a hex-encoded SHA-256 digest", name)
+		}
+	}
+
 	// Validate defaults
 	if _, err := LoadLocation(cfg.Defaults.Timezone); err != nil {
 		return fmt.Errorf("invalid defaults.timezone %q: %w", cfg.Defaults.Timezone, err)
@@ -138,6 +317,35 @@ func validate(cfg *Config) error {
 	if cfg.Defaults.JobRetries < 0 {
 		return fmt.Errorf("defaults.job_retries must be non-negative")
 	}
+	if cfg.Defaults.HookConcurrency < 0 {
+		return fmt.Errorf("defaults.hook_concurrency must be non-negative")
+	}
+	if _, err := ParseJitter(cfg.Defaults.Jitter); err != nil {
+		return fmt.Errorf("defaults.jitter: %w", err)
+	}
+	if cfg.Defaults.MaxConcurrentJobs < 0 {
+		return fmt.Errorf("defaults.max_concurrent_jobs must be non-negative")
+	}
+	switch cfg.Defaults.ConcurrencyPolicy {
+	case "", "wait", "skip":
+	default:
+		return fmt.Errorf("invalid concurrency_policy: %s (must be 'wait' or 'skip')", cfg.Defaults.ConcurrencyPolicy)
+	}
+	if cfg.Defaults.MaxOutputBytes < 0 {
+		return fmt.Errorf("defaults.max_output_bytes must be non-negative")
+	}
+	if cfg.Defaults.OutputTailBytes < 0 {
+		return fmt.Errorf("defaults.output_tail_bytes must be non-negative")
+	}
+	if cfg.Defaults.ShutdownTimeoutSec < 0 {
+		return fmt.Errorf("defaults.shutdown_timeout_sec must be non-negative")
+	}
+	if cfg.Defaults.FailureThreshold < 0 {
+		return fmt.Errorf("defaults.failure_threshold must be non-negative")
+	}
+	if _, err := ParseCircuitCooldown(cfg.Defaults.CircuitCooldown); err != nil {
+		return fmt.Errorf("defaults.%w", err)
+	}
 	if cfg.Defaults.JobBackoffStrategy != "" {
 		validStrategies := map[string]bool{
 			"linear":      true,
@@ -151,44 +359,27 @@ func validate(cfg *Config) error {
 	return nil
 }
 
-// ValidateSchedule checks if a schedule expression is valid.
-// Supports cron expressions, @-prefixed shortcuts, and @every intervals.
+// ValidateSchedule checks if a schedule expression is valid, using the same
+// parser the scheduler does: cron expressions with 5 or 6 fields (range
+// checked, e.g. "0 99 * * *" is rejected), @-descriptors, @every intervals,
+// and "every 5m" intervals.
 func ValidateSchedule(schedule string) error {
-	schedule = strings.TrimSpace(schedule)
-	if schedule == "" {
-		return fmt.Errorf("schedule cannot be empty")
-	}
-
-	// Check for @-prefixed shortcuts
-	if strings.HasPrefix(schedule, "@") {
-		shortcuts := []string{"@annually", "@yearly", "@monthly", "@weekly", "@daily", "@hourly", "@reboot"}
-		for _, shortcut := range shortcuts {
-			if schedule == shortcut {
-				return nil
-			}
-		}
-
-		// Check for @every interval
-		if strings.HasPrefix(schedule, "@every ") {
-			interval := strings.TrimPrefix(schedule, "@every ")
-			if matched, _ := regexp.MatchString(`^\d+[smh]$`, interval); matched {
-				return nil
-			}
-			return fmt.Errorf("invalid @every interval: %s (must be like '5m', '1h', '30s')", interval)
-		}
+	_, err := ParseSchedule(schedule)
+	return err
+}
 
-		return fmt.Errorf("unknown schedule shortcut: %s", schedule)
+// validCORSOrigin reports whether origin is "*" or a bare scheme://host[:port]
+// origin as browsers send it in the Origin header.
+func validCORSOrigin(origin string) bool {
+	if origin == "*" {
+		return true
 	}
-
-	// Validate cron expression (basic validation)
-	fields := strings.Fields(schedule)
-	if len(fields) < 5 || len(fields) > 6 {
-		return fmt.Errorf("cron expression must have 5 or 6 fields, got %d", len(fields))
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
 	}
-
-	// More detailed validation could be added here, but robfig/cron will
-	// validate at runtime. This basic check catches obvious errors early.
-	return nil
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" &&
+		u.Path == "" && u.RawQuery == "" && u.Fragment == "" && u.User == nil
 }
 
 // validateAgents checks that all agents used in hooks are in the allowed list.
@@ -222,3 +413,60 @@ func validateAgents(job Job, allowedAgents []string) error {
 
 	return nil
 }
+
+// validateDependencies checks that every depends_on entry names a defined job
+// and that the dependency graph has no cycles, which would otherwise chain
+// runs forever.
+func validateDependencies(jobs []Job) error {
+	deps := make(map[string][]string, len(jobs))
+	for _, job := range jobs {
+		deps[job.ID] = job.DependsOn
+	}
+
+	for _, job := range jobs {
+		for _, dep := range job.DependsOn {
+			if _, ok := deps[dep]; !ok {
+				return fmt.Errorf("job %s depends on unknown job %q", job.ID, dep)
+			}
+		}
+	}
+
+	// Depth-first search; a job found again while still on the path closes a cycle
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(jobs))
+	var path []string
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case done:
+			return nil
+		case visiting:
+			start := slices.Index(path, id)
+			cycle := append(slices.Clone(path[start:]), id)
+			return fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[id] = visiting
+		path = append(path, id)
+		for _, dep := range deps[id] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+		return nil
+	}
+
+	for _, job := range jobs {
+		if err := visit(job.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
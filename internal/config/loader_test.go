@@ -1,9 +1,13 @@
 package config
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -240,14 +244,561 @@ jobs:
     schedule: "@daily"
     command: "/bin/test"
     timeout_sec: -1
+`,
+			wantError: true,
+		},
+		{
+			name: "job timezone",
+			yaml: `
+jobs:
+  - id: "test-job"
+    schedule: "0 2 * * *"
+    command: "/bin/test"
+    timezone: "Europe/Berlin"
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Jobs[0].Timezone != "Europe/Berlin" {
+					t.Errorf("expected job timezone Europe/Berlin, got %s", cfg.Jobs[0].Timezone)
+				}
+			},
+		},
+		{
+			name: "job dependencies",
+			yaml: `
+jobs:
+  - id: "extract"
+    schedule: "@daily"
+    command: "/bin/extract"
+  - id: "load"
+    depends_on: ["extract"]
+    command: "/bin/load"
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				if len(cfg.Jobs[1].DependsOn) != 1 || cfg.Jobs[1].DependsOn[0] != "extract" {
+					t.Errorf("expected load to depend on extract, got %v", cfg.Jobs[1].DependsOn)
+				}
+			},
+		},
+		{
+			name: "dependency on unknown job",
+			yaml: `
+jobs:
+  - id: "load"
+    depends_on: ["extract"]
+    command: "/bin/load"
+`,
+			wantError: true,
+		},
+		{
+			name: "dependency cycle",
+			yaml: `
+jobs:
+  - id: "a"
+    depends_on: ["b"]
+    command: "/bin/a"
+  - id: "b"
+    depends_on: ["a"]
+    command: "/bin/b"
+`,
+			wantError: true,
+		},
+		{
+			name: "dependent job with a schedule",
+			yaml: `
+jobs:
+  - id: "extract"
+    schedule: "@daily"
+    command: "/bin/extract"
+  - id: "load"
+    schedule: "@daily"
+    depends_on: ["extract"]
+    command: "/bin/load"
+`,
+			wantError: true,
+		},
+		{
+			name: "invalid job timezone",
+			yaml: `
+jobs:
+  - id: "test-job"
+    schedule: "0 2 * * *"
+    command: "/bin/test"
+    timezone: "Mars/Olympus_Mons"
 `,
 			wantError: true,
 		},
 		{
 			name: "negative agent timeout",
 			yaml: `
-defaults:
-  agent_timeout_sec: -1
+defaults:
+  agent_timeout_sec: -1
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "negative job retries",
+			yaml: `
+defaults:
+  job_retries: -1
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "negative hook concurrency",
+			yaml: `
+defaults:
+  hook_concurrency: -1
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "negative max output bytes",
+			yaml: `
+defaults:
+  max_output_bytes: -1
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "output limits",
+			yaml: `
+defaults:
+  max_output_bytes: 65536
+  output_tail_bytes: 2000
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Defaults.MaxOutputBytes != 65536 || cfg.Defaults.OutputTailBytes != 2000 {
+					t.Errorf("output limits = %d/%d, want 65536/2000", cfg.Defaults.MaxOutputBytes, cfg.Defaults.OutputTailBytes)
+				}
+			},
+		},
+		{
+			name: "shutdown timeout",
+			yaml: `
+defaults:
+  shutdown_timeout_sec: 45
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Defaults.ShutdownTimeoutSec != 45 {
+					t.Errorf("expected shutdown_timeout_sec 45, got %d", cfg.Defaults.ShutdownTimeoutSec)
+				}
+			},
+		},
+		{
+			name: "negative shutdown timeout",
+			yaml: `
+defaults:
+  shutdown_timeout_sec: -5
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "max concurrent jobs",
+			yaml: `
+defaults:
+  max_concurrent_jobs: 2
+  concurrency_policy: "skip"
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Defaults.MaxConcurrentJobs != 2 || cfg.Defaults.ConcurrencyPolicy != "skip" {
+					t.Errorf("concurrency = %d/%q, want 2/skip", cfg.Defaults.MaxConcurrentJobs, cfg.Defaults.ConcurrencyPolicy)
+				}
+			},
+		},
+		{
+			name: "negative max concurrent jobs",
+			yaml: `
+defaults:
+  max_concurrent_jobs: -1
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "invalid concurrency policy",
+			yaml: `
+defaults:
+  concurrency_policy: "queue"
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "jitter",
+			yaml: `
+defaults:
+  jitter: "30s"
+
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    jitter: "2m"
+    command: "/bin/test"
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Defaults.Jitter != "30s" || cfg.Jobs[0].Jitter != "2m" {
+					t.Errorf("jitter = %q/%q, want 30s/2m", cfg.Defaults.Jitter, cfg.Jobs[0].Jitter)
+				}
+			},
+		},
+		{
+			name: "invalid job jitter",
+			yaml: `
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    jitter: "-5s"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "invalid default jitter",
+			yaml: `
+defaults:
+  jitter: "a bit"
+
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "circuit breaker",
+			yaml: `
+defaults:
+  failure_threshold: 3
+  circuit_cooldown: "15m"
+
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: "/bin/test"
+    hooks:
+      on_circuit_open:
+        - agent: "page.sh"
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Defaults.FailureThreshold != 3 || cfg.Defaults.CircuitCooldown != "15m" {
+					t.Errorf("circuit breaker = %d/%q, want 3/15m", cfg.Defaults.FailureThreshold, cfg.Defaults.CircuitCooldown)
+				}
+				if len(cfg.Jobs[0].Hooks.OnCircuitOpen) != 1 {
+					t.Errorf("on_circuit_open hooks = %v, want 1", cfg.Jobs[0].Hooks.OnCircuitOpen)
+				}
+			},
+		},
+		{
+			name: "negative failure threshold",
+			yaml: `
+defaults:
+  failure_threshold: -1
+
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "invalid circuit cooldown",
+			yaml: `
+defaults:
+  failure_threshold: 3
+  circuit_cooldown: "soon"
+
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "redis event sink",
+			yaml: `
+events:
+  sink: "redis"
+  url: "redis://localhost:6379?channel=runs"
+
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: "/bin/test"
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Events.Sink != EventSinkRedis || cfg.Events.URL != "redis://localhost:6379?channel=runs" {
+					t.Errorf("events = %+v", cfg.Events)
+				}
+			},
+		},
+		{
+			name: "unknown event sink",
+			yaml: `
+events:
+  sink: "kafka"
+
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "redis event sink without url",
+			yaml: `
+events:
+  sink: "redis"
+
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "templated command",
+			yaml: `
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: 'report.sh --run-id={{.RunID}} "--date={{.Now.Format \"2006-01-02\"}}"'
+    templated_command: true
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				job := cfg.Jobs[0]
+				if !job.TemplatedCommand {
+					t.Error("expected templated_command to be set")
+				}
+				if got := job.TemplateArgs(); len(got) != 3 || got[1] != "--run-id={{.RunID}}" {
+					t.Errorf("TemplateArgs() = %q", got)
+				}
+			},
+		},
+		{
+			name: "invalid command template",
+			yaml: `
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: "report.sh --run-id={{.RunID"
+    templated_command: true
+`,
+			wantError: true,
+		},
+		{
+			name: "template syntax ignored without templated_command",
+			yaml: `
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: "report.sh --run-id={{.RunID"
+`,
+			wantError: false,
+		},
+		{
+			name: "telemetry endpoint",
+			yaml: `
+telemetry:
+  otlp_endpoint: "http://localhost:4318"
+
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: "/bin/test"
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Telemetry.OTLPEndpoint != "http://localhost:4318" {
+					t.Errorf("otlp_endpoint = %q", cfg.Telemetry.OTLPEndpoint)
+				}
+			},
+		},
+		{
+			name: "invalid telemetry endpoint",
+			yaml: `
+telemetry:
+  otlp_endpoint: "localhost:4318"
+
+jobs:
+  - id: "test-job"
+    schedule: "@hourly"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "invalid agent checksum",
+			yaml: `
+security:
+  agent_checksums:
+    notify.sh: "not-a-digest"
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "negative log rotation setting",
+			yaml: `
+logging:
+  output: "/tmp/jobster.log"
+  max_backups: -1
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "invalid redact pattern",
+			yaml: `
+logging:
+  redact_patterns: ["(unclosed"]
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "cors origins",
+			yaml: `
+server:
+  cors_origins: ["https://ops.example.com", "http://localhost:3000"]
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			validate: func(t *testing.T, cfg *Config) {
+				if len(cfg.Server.CORSOrigins) != 2 || cfg.Server.CORSOrigins[1] != "http://localhost:3000" {
+					t.Errorf("unexpected cors_origins %v", cfg.Server.CORSOrigins)
+				}
+			},
+		},
+		{
+			name: "dashboard refresh",
+			yaml: `
+server:
+  dashboard_refresh_sec: 30
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Server.DashboardRefreshSec != 30 {
+					t.Errorf("expected dashboard_refresh_sec 30, got %d", cfg.Server.DashboardRefreshSec)
+				}
+			},
+		},
+		{
+			name: "rate limit",
+			yaml: `
+server:
+  rate_limit_rps: 2.5
+  rate_limit_burst: 10
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Server.RateLimitRPS != 2.5 || cfg.Server.RateLimitBurst != 10 {
+					t.Errorf("unexpected rate limit %v/%d", cfg.Server.RateLimitRPS, cfg.Server.RateLimitBurst)
+				}
+			},
+		},
+		{
+			name: "negative rate limit burst",
+			yaml: `
+server:
+  rate_limit_rps: 5
+  rate_limit_burst: -1
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "negative dashboard refresh",
+			yaml: `
+server:
+  dashboard_refresh_sec: -1
 
 jobs:
   - id: "test-job"
@@ -257,10 +808,23 @@ jobs:
 			wantError: true,
 		},
 		{
-			name: "negative job retries",
+			name: "cors origin with a path",
 			yaml: `
-defaults:
-  job_retries: -1
+server:
+  cors_origins: ["https://ops.example.com/app"]
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "invalid redact value pattern",
+			yaml: `
+logging:
+  redact_values: ["AKIA[0-9A-Z"]
 
 jobs:
   - id: "test-job"
@@ -304,6 +868,199 @@ jobs:
 				}
 			},
 		},
+		{
+			name: "store retention",
+			yaml: `
+store:
+  retention:
+    max_age: "30d"
+    max_runs_per_job: 100
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				maxAge, err := cfg.Store.Retention.MaxAgeDuration()
+				if err != nil {
+					t.Fatalf("MaxAgeDuration() error = %v", err)
+				}
+				if maxAge != 30*24*time.Hour {
+					t.Errorf("expected max_age 720h, got %s", maxAge)
+				}
+				if cfg.Store.Retention.MaxRunsPerJob != 100 {
+					t.Errorf("expected max_runs_per_job 100, got %d", cfg.Store.Retention.MaxRunsPerJob)
+				}
+			},
+		},
+		{
+			name: "server section",
+			yaml: `
+server:
+  metrics_enabled: true
+  auth_token: "s3cret"
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				if !cfg.Server.MetricsEnabled {
+					t.Error("expected metrics_enabled to be true")
+				}
+				if cfg.Server.AuthToken != "s3cret" {
+					t.Errorf("expected auth_token s3cret, got %q", cfg.Server.AuthToken)
+				}
+			},
+		},
+		{
+			name: "invalid retention max_age",
+			yaml: `
+store:
+  retention:
+    max_age: "a month"
+
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`,
+			wantError: true,
+		},
+		{
+			name: "restart policy",
+			yaml: `
+jobs:
+  - id: "daemon"
+    schedule: "@every 5m"
+    command: "/bin/daemon"
+    restart: "on-failure"
+    restart_backoff: "2s"
+    max_restarts: 10
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				job := cfg.Jobs[0]
+				if !job.ShouldRestart(false) || job.ShouldRestart(true) {
+					t.Errorf("on-failure should restart only failed runs")
+				}
+				if job.RestartLimit() != 10 {
+					t.Errorf("RestartLimit() = %d, want 10", job.RestartLimit())
+				}
+			},
+		},
+		{
+			name: "restart defaults",
+			yaml: `
+jobs:
+  - id: "daemon"
+    schedule: "@every 5m"
+    command: "/bin/daemon"
+    restart: "always"
+`,
+			wantError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				if got := cfg.Jobs[0].RestartLimit(); got != DefaultMaxRestarts {
+					t.Errorf("RestartLimit() = %d, want %d", got, DefaultMaxRestarts)
+				}
+				if d, _ := ParseRestartBackoff(cfg.Jobs[0].RestartBackoff); d != time.Second {
+					t.Errorf("restart backoff = %s, want 1s", d)
+				}
+			},
+		},
+		{
+			name: "invalid restart policy",
+			yaml: `
+jobs:
+  - id: "daemon"
+    schedule: "@every 5m"
+    command: "/bin/daemon"
+    restart: "sometimes"
+`,
+			wantError: true,
+		},
+		{
+			name: "invalid restart backoff",
+			yaml: `
+jobs:
+  - id: "daemon"
+    schedule: "@every 5m"
+    command: "/bin/daemon"
+    restart: "always"
+    restart_backoff: "0s"
+`,
+			wantError: true,
+		},
+		{
+			name: "negative max_restarts",
+			yaml: `
+jobs:
+  - id: "daemon"
+    schedule: "@every 5m"
+    command: "/bin/daemon"
+    restart: "always"
+    max_restarts: -1
+`,
+			wantError: true,
+		},
+		{
+			name: "job labels",
+			yaml: `
+jobs:
+  - id: "charge"
+    schedule: "@hourly"
+    command: "/bin/charge"
+    labels:
+      team: payments
+      tier: critical
+`,
+			validate: func(t *testing.T, cfg *Config) {
+				labels := cfg.Jobs[0].Labels
+				if labels["team"] != "payments" || labels["tier"] != "critical" {
+					t.Errorf("unexpected labels: %v", labels)
+				}
+			},
+		},
+		{
+			name: "negative tui history_runs",
+			yaml: `
+tui:
+  history_runs: -1
+jobs:
+  - id: "job"
+    schedule: "@daily"
+    command: "/bin/true"
+`,
+			wantError: true,
+		},
+		{
+			name: "invalid tui theme",
+			yaml: `
+tui:
+  theme: "solarized"
+jobs:
+  - id: "job"
+    schedule: "@daily"
+    command: "/bin/true"
+`,
+			wantError: true,
+		},
+		{
+			name: "invalid label key",
+			yaml: `
+jobs:
+  - id: "charge"
+    schedule: "@hourly"
+    command: "/bin/charge"
+    labels:
+      "team=x": payments
+`,
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -333,6 +1090,63 @@ jobs:
 	}
 }
 
+func TestLoadConfigShellMode(t *testing.T) {
+	dir := t.TempDir()
+
+	stringPath := filepath.Join(dir, "string.yaml")
+	stringYAML := `
+jobs:
+  - id: "pipeline"
+    schedule: "@daily"
+    shell: true
+    command: "ls -1 | wc -l"
+`
+	if err := os.WriteFile(stringPath, []byte(stringYAML), 0o644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	cfg, err := LoadConfig(stringPath)
+	if err != nil {
+		t.Fatalf("unexpected error for shell mode with string command: %v", err)
+	}
+	if !cfg.Jobs[0].Shell {
+		t.Error("expected shell mode to be enabled")
+	}
+	if got := cfg.Jobs[0].Command.String(); got != "ls -1 | wc -l" {
+		t.Errorf("expected command string to be preserved, got %q", got)
+	}
+
+	arrayPath := filepath.Join(dir, "array.yaml")
+	arrayYAML := `
+jobs:
+  - id: "pipeline"
+    schedule: "@daily"
+    shell: true
+    command: ["ls", "-1"]
+`
+	if err := os.WriteFile(arrayPath, []byte(arrayYAML), 0o644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	if _, err := LoadConfig(arrayPath); err == nil {
+		t.Error("expected error for shell mode with array command")
+	}
+}
+
+func TestServerResolveAuthToken(t *testing.T) {
+	srv := Server{AuthToken: "from-file"}
+
+	t.Setenv(AuthTokenEnv, "")
+	if got := srv.ResolveAuthToken(); got != "from-file" {
+		t.Errorf("ResolveAuthToken() = %q, want %q", got, "from-file")
+	}
+
+	t.Setenv(AuthTokenEnv, "from-env")
+	if got := srv.ResolveAuthToken(); got != "from-env" {
+		t.Errorf("ResolveAuthToken() = %q, want %q", got, "from-env")
+	}
+}
+
 func TestLoadConfigFileNotFound(t *testing.T) {
 	_, err := LoadConfig("/nonexistent/config.yaml")
 	if err == nil {
@@ -378,6 +1192,25 @@ func TestValidateSchedule(t *testing.T) {
 		{"empty schedule", "", true},
 		{"too few fields", "0 2 *", true},
 		{"too many fields", "0 0 0 2 * * * *", true},
+		{"valid every interval", "every 5m", false},
+		{"minute out of range", "60 * * * *", true},
+		{"hour out of range", "0 99 * * *", true},
+		{"day of month zero", "0 0 0 * *", true},
+		{"day of month out of range", "0 0 32 * *", true},
+		{"month out of range", "0 0 1 13 *", true},
+		{"day of week out of range", "0 0 * * 8", true},
+		{"second out of range", "61 0 0 * * *", true},
+		{"reversed range", "0 5-2 * * *", true},
+		{"zero step", "*/0 * * * *", true},
+		{"unknown month name", "0 0 1 foo *", true},
+		{"non-numeric field", "0 x * * *", true},
+		{"unsupported @reboot", "@reboot", true},
+		{"invalid @every duration", "@every 5x", true},
+		{"valid @every 1d", "@every 1d", false},
+		{"valid @every 7d", "@every 7d", false},
+		{"valid @every 2 days", "@every 2 days", false},
+		{"valid @every compound duration", "@every 1h30m", false},
+		{"invalid @every 0d", "@every 0d", true},
 	}
 
 	for _, tt := range tests {
@@ -393,6 +1226,27 @@ func TestValidateSchedule(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_OutOfRangeCron(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+jobs:
+  - id: "bad-hour"
+    schedule: "0 99 * * *"
+    command: "echo test"
+`
+	if err := os.WriteFile(tmpFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	_, err := LoadConfig(tmpFile)
+	if err == nil {
+		t.Fatal("expected error for out-of-range hour, got nil")
+	}
+	if !strings.Contains(err.Error(), "bad-hour has invalid schedule") {
+		t.Errorf("error = %v, want it to name the job", err)
+	}
+}
+
 func TestValidateAgents(t *testing.T) {
 	allowedAgents := []string{"notify.sh", "webhook.js"}
 
@@ -509,3 +1363,225 @@ func TestApplyDefaults(t *testing.T) {
 		t.Error("expected env map to be initialized")
 	}
 }
+
+func TestValidateDependencyCycles(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobs    []Job
+		wantErr string
+	}{
+		{
+			name: "self dependency",
+			jobs: []Job{
+				{ID: "a", DependsOn: []string{"a"}},
+			},
+			wantErr: "dependency cycle: a -> a",
+		},
+		{
+			name: "three job cycle",
+			jobs: []Job{
+				{ID: "root", Schedule: "@daily"},
+				{ID: "a", DependsOn: []string{"root", "c"}},
+				{ID: "b", DependsOn: []string{"a"}},
+				{ID: "c", DependsOn: []string{"b"}},
+			},
+			wantErr: "dependency cycle: a -> c -> b -> a",
+		},
+		{
+			name: "diamond is not a cycle",
+			jobs: []Job{
+				{ID: "root", Schedule: "@daily"},
+				{ID: "left", DependsOn: []string{"root"}},
+				{ID: "right", DependsOn: []string{"root"}},
+				{ID: "join", DependsOn: []string{"left", "right"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDependencies(tt.jobs)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_JSONMatchesYAML(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "jobster.yaml")
+	jsonPath := filepath.Join(dir, "jobster.json")
+
+	yamlConfig := `
+defaults:
+  timezone: "UTC"
+  job_retries: 2
+store:
+  driver: "json"
+  path: "./runs.json"
+server:
+  cors_origins: ["https://ops.example.com"]
+jobs:
+  - id: "backup"
+    schedule: "@daily"
+    command: "/usr/bin/backup --full 'my dir'"
+    timeout_sec: 60
+    env:
+      TARGET: "s3://bucket"
+    hooks:
+      on_error:
+        - agent: "notify"
+          with:
+            channel: "#ops"
+            retries: 3
+  - id: "report"
+    command: ["/usr/bin/report", "--out", "a b"]
+    depends_on: ["backup"]
+    enabled: false
+`
+	jsonConfig := `{
+  "defaults": {"timezone": "UTC", "job_retries": 2},
+  "store": {"driver": "json", "path": "./runs.json"},
+  "server": {"cors_origins": ["https://ops.example.com"]},
+  "jobs": [
+    {
+      "id": "backup",
+      "schedule": "@daily",
+      "command": "/usr/bin/backup --full 'my dir'",
+      "timeout_sec": 60,
+      "env": {"TARGET": "s3://bucket"},
+      "hooks": {
+        "on_error": [{"agent": "notify", "with": {"channel": "#ops", "retries": 3}}]
+      }
+    },
+    {
+      "id": "report",
+      "command": ["/usr/bin/report", "--out", "a b"],
+      "depends_on": ["backup"],
+      "enabled": false
+    }
+  ]
+}`
+	if err := os.WriteFile(yamlPath, []byte(yamlConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jsonPath, []byte(jsonConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fromYAML, err := LoadConfig(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadConfig(yaml) error = %v", err)
+	}
+	fromJSON, err := LoadConfig(jsonPath)
+	if err != nil {
+		t.Fatalf("LoadConfig(json) error = %v", err)
+	}
+
+	// YAML decodes integers in with: maps as int, JSON as float64
+	normalizeWith := func(cfg *Config) {
+		for _, job := range cfg.Jobs {
+			for _, agent := range job.Hooks.OnError {
+				for k, v := range agent.With {
+					if n, ok := v.(int); ok {
+						agent.With[k] = float64(n)
+					}
+				}
+			}
+		}
+	}
+	normalizeWith(fromYAML)
+
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Errorf("configs differ:\nyaml: %+v\njson: %+v", fromYAML, fromJSON)
+	}
+}
+
+func TestSaveConfig_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobster.json")
+	cfg := &Config{
+		Jobs: []Job{{ID: "hello", Schedule: "@hourly", Command: NewCommandSpec("echo hi")}},
+	}
+	applyDefaults(cfg)
+	if err := SaveConfig(cfg, path); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(data) {
+		t.Fatalf("SaveConfig wrote invalid JSON:\n%s", data)
+	}
+
+	loaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := loaded.Jobs[0].Command.String(); got != "echo hi" {
+		t.Errorf("command = %q, want %q", got, "echo hi")
+	}
+}
+
+func TestLoadConfigInvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobster.json")
+	if err := os.WriteFile(path, []byte(`{"jobs": [`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "failed to parse JSON") {
+		t.Errorf("expected a JSON parse error, got %v", err)
+	}
+}
+
+func TestLoadConfig_Version(t *testing.T) {
+	const jobs = `
+jobs:
+  - id: "test-job"
+    schedule: "@daily"
+    command: "/bin/test"
+`
+	tests := []struct {
+		name    string
+		header  string
+		want    int
+		wantErr string
+	}{
+		{name: "missing version defaults to current", header: "", want: CurrentVersion},
+		{name: "current version", header: "version: 1\n", want: CurrentVersion},
+		{name: "newer version", header: "version: 2\n", wantErr: "upgrade jobster"},
+		{name: "negative version", header: "version: -1\n", wantErr: "invalid config version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "jobster.yaml")
+			if err := os.WriteFile(path, []byte(tt.header+jobs), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := LoadConfig(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if cfg.Version != tt.want {
+				t.Errorf("version = %d, want %d", cfg.Version, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// splitCommand tokenizes a command string the way a POSIX shell splits words,
+// without performing any expansion:
+//   - whitespace separates arguments
+//   - 'single quotes' preserve everything literally
+//   - "double quotes" preserve whitespace; a backslash escapes ", \, $ and `
+//   - outside quotes, a backslash escapes the next character (e.g. "my\ file")
+//
+// An empty pair of double or single quotes produces an empty argument. Unlike
+// a shell, '#' is not treated as a comment, so arguments like "#ops" survive
+// unquoted.
+func splitCommand(s string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inWord  bool
+	)
+
+	const (
+		stateNone = iota
+		stateSingle
+		stateDouble
+	)
+	state := stateNone
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch state {
+		case stateSingle:
+			if r == '\'' {
+				state = stateNone
+				continue
+			}
+			current.WriteRune(r)
+
+		case stateDouble:
+			switch {
+			case r == '"':
+				state = stateNone
+			case r == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`", runes[i+1]):
+				i++
+				current.WriteRune(runes[i])
+			default:
+				current.WriteRune(r)
+			}
+
+		default:
+			switch {
+			case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+				if inWord {
+					args = append(args, current.String())
+					current.Reset()
+					inWord = false
+				}
+			case r == '\'':
+				state = stateSingle
+				inWord = true
+			case r == '"':
+				state = stateDouble
+				inWord = true
+			case r == '\\':
+				if i+1 >= len(runes) {
+					return nil, fmt.Errorf("trailing backslash in command")
+				}
+				i++
+				current.WriteRune(runes[i])
+				inWord = true
+			default:
+				current.WriteRune(r)
+				inWord = true
+			}
+		}
+	}
+
+	switch state {
+	case stateSingle:
+		return nil, fmt.Errorf("unterminated single quote in command")
+	case stateDouble:
+		return nil, fmt.Errorf("unterminated double quote in command")
+	}
+
+	if inWord {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+// quoteArg quotes a single argument so that splitCommand returns it unchanged.
+// Arguments without special characters are returned as-is.
+func quoteArg(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+	if !strings.ContainsAny(arg, " \t\n\r'\"\\") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
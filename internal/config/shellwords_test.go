@@ -0,0 +1,138 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{"plain words", "echo hello world", []string{"echo", "hello", "world"}, false},
+		{"double quotes", `echo "hello world"`, []string{"echo", "hello world"}, false},
+		{"single quotes", `echo 'hello world'`, []string{"echo", "hello world"}, false},
+		{"single quotes are literal", `echo '$HOME \n'`, []string{"echo", `$HOME \n`}, false},
+		{"escaped quote in double quotes", `echo "say \"hi\""`, []string{"echo", `say "hi"`}, false},
+		{"escaped space", `cat my\ file.txt`, []string{"cat", "my file.txt"}, false},
+		{"adjacent quoted segments", `echo foo"bar baz"'qux'`, []string{"echo", "foobar bazqux"}, false},
+		{"empty quoted argument", `printf "%s" ""`, []string{"printf", "%s", ""}, false},
+		{"extra whitespace", "  ls \t -la  ", []string{"ls", "-la"}, false},
+		{"hash is not a comment", "notify #ops", []string{"notify", "#ops"}, false},
+		{"empty string", "", nil, false},
+		{"only whitespace", "   ", nil, false},
+		{"unterminated double quote", `echo "oops`, nil, true},
+		{"unterminated single quote", `echo 'oops`, nil, true},
+		{"trailing backslash", `echo oops\`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitCommand(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitCommand(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandSpecStringRoundTrip(t *testing.T) {
+	tests := [][]string{
+		{"/bin/echo", "hello"},
+		{"/bin/echo", "hello world"},
+		{"sh", "-c", "echo 'quoted' && exit 1"},
+		{"printf", "%s", ""},
+		{"grep", `back\slash`},
+	}
+
+	for _, parts := range tests {
+		spec := CommandSpec{parts: parts}
+		parsed, err := ParseCommandSpec(spec.String())
+		if err != nil {
+			t.Fatalf("ParseCommandSpec(%q) error: %v", spec.String(), err)
+		}
+		if !reflect.DeepEqual(parsed.Parts(), parts) {
+			t.Errorf("round trip of %q via %q = %q", parts, spec.String(), parsed.Parts())
+		}
+	}
+
+	if got := NewCommandSpec("/bin/echo hello").String(); got != "/bin/echo hello" {
+		t.Errorf("String() = %q, want unquoted simple command", got)
+	}
+}
+
+func TestCommandSpecUnmarshalYAML(t *testing.T) {
+	var job Job
+	if err := yaml.Unmarshal([]byte(`command: echo "hello world"`), &job); err != nil {
+		t.Fatalf("unmarshal string command: %v", err)
+	}
+	if want := []string{"echo", "hello world"}; !reflect.DeepEqual(job.Command.Parts(), want) {
+		t.Errorf("string command parts = %q, want %q", job.Command.Parts(), want)
+	}
+
+	job = Job{}
+	if err := yaml.Unmarshal([]byte(`command: ["echo", "\"kept\" as-is"]`), &job); err != nil {
+		t.Fatalf("unmarshal array command: %v", err)
+	}
+	if want := []string{"echo", `"kept" as-is`}; !reflect.DeepEqual(job.Command.Parts(), want) {
+		t.Errorf("array command parts = %q, want %q", job.Command.Parts(), want)
+	}
+
+	job = Job{}
+	if err := yaml.Unmarshal([]byte(`command: 'echo "unterminated'`), &job); err == nil {
+		t.Error("expected error for unterminated quote")
+	}
+}
+
+func TestCommandSpecUnmarshalJSON(t *testing.T) {
+	var job Job
+	if err := json.Unmarshal([]byte(`{"command": "echo \"hello world\""}`), &job); err != nil {
+		t.Fatalf("unmarshal string command: %v", err)
+	}
+	if want := []string{"echo", "hello world"}; !reflect.DeepEqual(job.Command.Parts(), want) {
+		t.Errorf("string command parts = %q, want %q", job.Command.Parts(), want)
+	}
+
+	job = Job{}
+	if err := json.Unmarshal([]byte(`{"command": ["echo", "\"kept\" as-is"]}`), &job); err != nil {
+		t.Fatalf("unmarshal array command: %v", err)
+	}
+	if want := []string{"echo", `"kept" as-is`}; !reflect.DeepEqual(job.Command.Parts(), want) {
+		t.Errorf("array command parts = %q, want %q", job.Command.Parts(), want)
+	}
+
+	// Both forms survive a marshal round trip
+	for _, spec := range []CommandSpec{NewCommandSpec(`echo "hello world"`), {parts: []string{"echo", "a b"}}} {
+		data, err := json.Marshal(spec)
+		if err != nil {
+			t.Fatalf("marshal %v: %v", spec, err)
+		}
+		var got CommandSpec
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("unmarshal %s: %v", data, err)
+		}
+		if !reflect.DeepEqual(got, spec) {
+			t.Errorf("round trip of %s = %+v, want %+v", data, got, spec)
+		}
+	}
+
+	job = Job{}
+	if err := json.Unmarshal([]byte(`{"command": 42}`), &job); err == nil {
+		t.Error("expected error for a numeric command")
+	}
+}
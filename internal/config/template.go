@@ -0,0 +1,22 @@
+package config
+
+import (
+	"text/template"
+)
+
+// ParseArgTemplate parses one command argument of a templated_command job
+// as a text/template. Referencing a missing key, such as an unset
+// {{.Env.NAME}}, is an error when the template is executed.
+func ParseArgTemplate(arg string) (*template.Template, error) {
+	return template.New("command").Option("missingkey=error").Parse(arg)
+}
+
+// TemplateArgs returns the strings of a job's command that are expanded as
+// templates when TemplatedCommand is set: each argument, or in shell mode
+// the whole command string.
+func (j Job) TemplateArgs() []string {
+	if j.Shell {
+		return []string{j.Command.String()}
+	}
+	return j.Command.Parts()
+}
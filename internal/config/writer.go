@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,21 +9,47 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// SaveConfig writes a Config to a YAML file.
-// It performs an atomic write by writing to a temporary file first,
-// then renaming it to the target path.
+// SaveConfig writes a Config to a YAML file, or a JSON file if path ends in
+// .json. It performs an atomic write by writing to a temporary file first,
+// then renaming it to the target path. Jobs that were loaded from included
+// files are left out; only the base file's own jobs are written.
 func SaveConfig(cfg *Config, path string) error {
 	// Validate config before saving
 	if err := validate(cfg); err != nil {
 		return fmt.Errorf("config validation failed: %w", err)
 	}
 
+	if len(cfg.jobSources) > 0 {
+		base := *cfg
+		base.Jobs = cfg.baseJobs()
+		cfg = &base
+	}
+	return writeConfig(cfg, path)
+}
+
+// writeConfig marshals cfg in the format path calls for and writes it
+// atomically.
+func writeConfig(cfg *Config, path string) error {
+	if isJSONPath(path) {
+		data, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal config to JSON: %w", err)
+		}
+		return writeFileAtomic(path, append(data, '\n'))
+	}
+
 	// Marshal config to YAML
 	data, err := yaml.Marshal(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config to YAML: %w", err)
 	}
 
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes data to a temporary file next to path, then renames
+// it into place, creating the parent directory if needed.
+func writeFileAtomic(path string, data []byte) error {
 	// Ensure parent directory exists
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
@@ -46,104 +73,106 @@ func SaveConfig(cfg *Config, path string) error {
 // AddJob adds a new job to an existing config file.
 // If the config file doesn't exist, it creates a new one with sensible defaults.
 func AddJob(configPath string, job Job) error {
-	var cfg *Config
-	var err error
-
-	// Try to load existing config
-	if _, statErr := os.Stat(configPath); statErr == nil {
-		cfg, err = LoadConfig(configPath)
-		if err != nil {
-			return fmt.Errorf("failed to load existing config: %w", err)
+	return editConfig(configPath, true, func(cfg *Config) error {
+		// Check for duplicate job ID
+		for _, existingJob := range cfg.Jobs {
+			if existingJob.ID == job.ID {
+				return fmt.Errorf("job with ID '%s' already exists", job.ID)
+			}
 		}
-	} else {
-		// Create new config with defaults
-		cfg = NewDefaultConfig()
-	}
 
-	// Check for duplicate job ID
-	for _, existingJob := range cfg.Jobs {
-		if existingJob.ID == job.ID {
-			return fmt.Errorf("job with ID '%s' already exists", job.ID)
-		}
-	}
-
-	// Add the job
-	cfg.Jobs = append(cfg.Jobs, job)
-
-	// Save config
-	if err := SaveConfig(cfg, configPath); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
-	}
-
-	return nil
+		// Add the job
+		cfg.Jobs = append(cfg.Jobs, job)
+		return nil
+	})
 }
 
 // RemoveJob removes a job from the config file by ID.
 func RemoveJob(configPath string, jobID string) error {
-	// Load existing config
-	cfg, err := LoadConfig(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// Find and remove the job
-	found := false
-	newJobs := make([]Job, 0, len(cfg.Jobs))
-	for _, job := range cfg.Jobs {
-		if job.ID == jobID {
-			found = true
-			continue
+	return editConfig(configPath, false, func(cfg *Config) error {
+		if source := cfg.JobSource(jobID); source != "" {
+			return fmt.Errorf("job with ID '%s' is defined in included file %s; edit that file instead", jobID, source)
 		}
-		newJobs = append(newJobs, job)
-	}
-
-	if !found {
-		return fmt.Errorf("job with ID '%s' not found", jobID)
-	}
 
-	cfg.Jobs = newJobs
+		// Find and remove the job
+		found := false
+		newJobs := make([]Job, 0, len(cfg.Jobs))
+		for _, job := range cfg.Jobs {
+			if job.ID == jobID {
+				found = true
+				continue
+			}
+			newJobs = append(newJobs, job)
+		}
 
-	// Save config
-	if err := SaveConfig(cfg, configPath); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
-	}
+		if !found {
+			return fmt.Errorf("job with ID '%s' not found", jobID)
+		}
 
-	return nil
+		cfg.Jobs = newJobs
+		return nil
+	})
 }
 
 // UpdateJob updates an existing job in the config file.
 func UpdateJob(configPath string, job Job) error {
-	// Load existing config
-	cfg, err := LoadConfig(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
+	return editConfig(configPath, false, func(cfg *Config) error {
+		if source := cfg.JobSource(job.ID); source != "" {
+			return fmt.Errorf("job with ID '%s' is defined in included file %s; edit that file instead", job.ID, source)
+		}
 
-	// Find and update the job
-	found := false
-	for i := range cfg.Jobs {
-		if cfg.Jobs[i].ID == job.ID {
-			cfg.Jobs[i] = job
-			found = true
-			break
+		// Find and update the job
+		for i := range cfg.Jobs {
+			if cfg.Jobs[i].ID == job.ID {
+				cfg.Jobs[i] = job
+				return nil
+			}
 		}
+		return fmt.Errorf("job with ID '%s' not found", job.ID)
+	})
+}
+
+// editConfig applies edit to the config file at configPath and saves it. The
+// edit is checked against the fully loaded config, with variables
+// substituted and includes merged, but applied to the file as written, so
+// ${VAR} references survive and included jobs stay in their own files. With
+// create set, a missing file starts from NewDefaultConfig.
+func editConfig(configPath string, create bool, edit func(cfg *Config) error) error {
+	var cfg, raw *Config
+	var err error
+
+	if _, statErr := os.Stat(configPath); statErr == nil || !create {
+		if cfg, err = LoadConfig(configPath); err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if raw, err = parseConfigFile(configPath); err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		applyDefaults(raw)
+	} else {
+		cfg, raw = NewDefaultConfig(), NewDefaultConfig()
 	}
 
-	if !found {
-		return fmt.Errorf("job with ID '%s' not found", job.ID)
+	if err := edit(cfg); err != nil {
+		return err
+	}
+	if err := validate(cfg); err != nil {
+		return fmt.Errorf("failed to save config: config validation failed: %w", err)
+	}
+	if err := edit(raw); err != nil {
+		return err
 	}
 
-	// Save config
-	if err := SaveConfig(cfg, configPath); err != nil {
+	if err := writeConfig(raw, configPath); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
-
 	return nil
 }
 
 // NewDefaultConfig creates a new Config with sensible defaults.
 func NewDefaultConfig() *Config {
 	return &Config{
+		Version: CurrentVersion,
 		Defaults: Defaults{
 			Timezone:         "Local",
 			AgentTimeoutSec:  10,
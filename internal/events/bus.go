@@ -0,0 +1,123 @@
+// Package events provides an in-process publish/subscribe bus for job run
+// lifecycle events, used to push live updates to dashboard clients.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types published by the runner.
+const (
+	TypeRunStarted  = "run_started"
+	TypeRunFinished = "run_finished"
+)
+
+// historySize is the number of recent events kept for replay to reconnecting
+// subscribers.
+const historySize = 256
+
+// subscriberBuffer is the per-subscriber channel capacity. A subscriber that
+// falls this far behind is disconnected rather than blocking publishers.
+const subscriberBuffer = 64
+
+// Event describes a change in a job run's state.
+type Event struct {
+	ID         uint64    `json:"id"`
+	Type       string    `json:"type"`
+	JobID      string    `json:"job_id"`
+	RunID      string    `json:"run_id"`
+	Time       time.Time `json:"time"`
+	Status     string    `json:"status"`
+	ExitCode   int       `json:"exit_code"`
+	DurationMs float64   `json:"duration_ms,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Bus fans published events out to all current subscribers and keeps a short
+// history so reconnecting subscribers can catch up on what they missed.
+// A nil *Bus is valid and discards everything published to it.
+type Bus struct {
+	mu          sync.Mutex
+	nextID      uint64
+	history     []Event
+	subscribers map[chan Event]struct{}
+}
+
+// NewBus creates an empty event bus.
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish assigns the event an ID (and a timestamp if unset), records it in
+// the replay history and delivers it to every subscriber without blocking.
+// Subscribers whose buffer is full are dropped; their channel is closed so the
+// consumer can reconnect and replay from its last seen ID.
+func (b *Bus) Publish(e Event) Event {
+	if b == nil {
+		return e
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	e.ID = b.nextID
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	if len(b.history) >= historySize {
+		b.history = append(b.history[:0], b.history[1:]...)
+	}
+	b.history = append(b.history, e)
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return e
+}
+
+// Subscribe registers a new subscriber. If lastID is non-zero, backlog holds
+// the retained events published after it, in order; an ID newer than anything
+// this bus has issued (e.g. from before a restart) replays the whole history.
+// The returned cancel function unregisters the subscriber and must be called
+// once the consumer is done; it is safe to call more than once.
+func (b *Bus) Subscribe(lastID uint64) (ch <-chan Event, backlog []Event, cancel func()) {
+	c := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if lastID > 0 {
+		if lastID > b.nextID {
+			lastID = 0
+		}
+		for _, e := range b.history {
+			if e.ID > lastID {
+				backlog = append(backlog, e)
+			}
+		}
+	}
+
+	b.subscribers[c] = struct{}{}
+
+	cancel = func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[c]; ok {
+			delete(b.subscribers, c)
+			close(c)
+		}
+	}
+
+	return c, backlog, cancel
+}
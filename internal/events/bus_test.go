@@ -0,0 +1,116 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBus_PublishSubscribe(t *testing.T) {
+	bus := NewBus()
+
+	ch, backlog, cancel := bus.Subscribe(0)
+	defer cancel()
+
+	if len(backlog) != 0 {
+		t.Fatalf("Subscribe(0) backlog = %d events, want 0", len(backlog))
+	}
+
+	published := bus.Publish(Event{Type: TypeRunStarted, JobID: "job", RunID: "run-1"})
+	if published.ID != 1 {
+		t.Errorf("Publish() ID = %d, want 1", published.ID)
+	}
+	if published.Time.IsZero() {
+		t.Error("Publish() did not set Time")
+	}
+
+	select {
+	case got := <-ch:
+		if got.ID != published.ID || got.RunID != "run-1" {
+			t.Errorf("received %+v, want %+v", got, published)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+func TestBus_SubscribeReplaysAfterLastID(t *testing.T) {
+	bus := NewBus()
+	for i := 0; i < 5; i++ {
+		bus.Publish(Event{Type: TypeRunFinished, JobID: "job"})
+	}
+
+	_, backlog, cancel := bus.Subscribe(3)
+	defer cancel()
+
+	if len(backlog) != 2 {
+		t.Fatalf("Subscribe(3) backlog = %d events, want 2", len(backlog))
+	}
+	if backlog[0].ID != 4 || backlog[1].ID != 5 {
+		t.Errorf("backlog IDs = %d, %d; want 4, 5", backlog[0].ID, backlog[1].ID)
+	}
+
+	// An ID from before a restart replays everything retained
+	_, backlog, cancel2 := bus.Subscribe(100)
+	defer cancel2()
+	if len(backlog) != 5 {
+		t.Errorf("Subscribe(100) backlog = %d events, want 5", len(backlog))
+	}
+}
+
+func TestBus_HistoryIsBounded(t *testing.T) {
+	bus := NewBus()
+	for i := 0; i < historySize+10; i++ {
+		bus.Publish(Event{Type: TypeRunStarted})
+	}
+
+	_, backlog, cancel := bus.Subscribe(1)
+	defer cancel()
+
+	if len(backlog) != historySize {
+		t.Fatalf("backlog = %d events, want %d", len(backlog), historySize)
+	}
+	if backlog[0].ID != 11 {
+		t.Errorf("oldest retained ID = %d, want 11", backlog[0].ID)
+	}
+}
+
+func TestBus_CancelClosesChannel(t *testing.T) {
+	bus := NewBus()
+	ch, _, cancel := bus.Subscribe(0)
+
+	cancel()
+	cancel() // idempotent
+
+	if _, ok := <-ch; ok {
+		t.Error("channel still open after cancel")
+	}
+
+	// Publishing with no subscribers must not block or panic
+	bus.Publish(Event{Type: TypeRunStarted})
+}
+
+func TestBus_SlowSubscriberIsDropped(t *testing.T) {
+	bus := NewBus()
+	ch, _, cancel := bus.Subscribe(0)
+	defer cancel()
+
+	for i := 0; i < subscriberBuffer+1; i++ {
+		bus.Publish(Event{Type: TypeRunStarted})
+	}
+
+	received := 0
+	for range ch {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("received %d events before close, want %d", received, subscriberBuffer)
+	}
+}
+
+func TestBus_NilIsNoop(t *testing.T) {
+	var bus *Bus
+	e := bus.Publish(Event{Type: TypeRunStarted})
+	if e.ID != 0 {
+		t.Errorf("nil Publish() ID = %d, want 0", e.ID)
+	}
+}
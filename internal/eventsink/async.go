@@ -0,0 +1,56 @@
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrBusy is returned by Async.Publish when its queue is full and the event
+// was dropped.
+var ErrBusy = errors.New("event sink busy: queue full")
+
+// Async hands events to another Sink from a single background goroutine, so
+// Publish never waits on the network. Up to size events are queued; when the
+// queue is full Publish drops the event and returns ErrBusy.
+type Async struct {
+	sink    Sink
+	timeout time.Duration
+	onError func(RunEvent, error)
+	queue   chan RunEvent
+}
+
+// NewAsync starts publishing to sink in the background, giving each event up
+// to timeout. Errors from sink are passed to onError, which may be nil. The
+// goroutine runs for the life of the process.
+func NewAsync(sink Sink, size int, timeout time.Duration, onError func(RunEvent, error)) *Async {
+	a := &Async{
+		sink:    sink,
+		timeout: timeout,
+		onError: onError,
+		queue:   make(chan RunEvent, size),
+	}
+	go a.run()
+	return a
+}
+
+// Publish queues e without blocking. ctx is not used.
+func (a *Async) Publish(_ context.Context, e RunEvent) error {
+	select {
+	case a.queue <- e:
+		return nil
+	default:
+		return ErrBusy
+	}
+}
+
+func (a *Async) run() {
+	for e := range a.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+		err := a.sink.Publish(ctx, e)
+		cancel()
+		if err != nil && a.onError != nil {
+			a.onError(e, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// gatedSink records events once gate lets each Publish through, or fails
+// them after the publish timeout.
+type gatedSink struct {
+	gate chan struct{}
+	got  chan RunEvent
+}
+
+func (s *gatedSink) Publish(ctx context.Context, e RunEvent) error {
+	select {
+	case <-s.gate:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.got <- e
+	return nil
+}
+
+func TestAsync_QueuesInOrderAndDropsWhenFull(t *testing.T) {
+	sink := &gatedSink{gate: make(chan struct{}), got: make(chan RunEvent, 8)}
+	async := NewAsync(sink, 2, time.Minute, nil)
+
+	// The worker takes the first event and blocks on the gate; two more fill
+	// the queue and the fourth is dropped
+	for _, id := range []string{"run-1", "run-2", "run-3"} {
+		if err := async.Publish(context.Background(), RunEvent{RunID: id}); err != nil {
+			t.Fatalf("Publish(%s) error = %v", id, err)
+		}
+		if id == "run-1" {
+			waitForQueue(t, async, 0)
+		}
+	}
+	if err := async.Publish(context.Background(), RunEvent{RunID: "run-4"}); !errors.Is(err, ErrBusy) {
+		t.Errorf("Publish() on a full queue error = %v, want ErrBusy", err)
+	}
+
+	close(sink.gate)
+	for _, want := range []string{"run-1", "run-2", "run-3"} {
+		select {
+		case e := <-sink.got:
+			if e.RunID != want {
+				t.Errorf("published %s, want %s", e.RunID, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("%s was not published", want)
+		}
+	}
+}
+
+func TestAsync_ReportsErrors(t *testing.T) {
+	sink := &gatedSink{gate: make(chan struct{}), got: make(chan RunEvent, 1)}
+	failed := make(chan error, 1)
+	async := NewAsync(sink, 1, 10*time.Millisecond, func(e RunEvent, err error) {
+		failed <- err
+	})
+
+	if err := async.Publish(context.Background(), RunEvent{RunID: "run-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	select {
+	case err := <-failed:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("onError error = %v, want the publish timeout", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("onError was not called")
+	}
+}
+
+// waitForQueue waits until n events are queued.
+func waitForQueue(t *testing.T, async *Async, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(async.queue) != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("queue length = %d, want %d", len(async.queue), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
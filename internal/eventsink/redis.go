@@ -0,0 +1,185 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// DefaultRedisChannel is the channel events are published on when the URL
+// does not name one.
+const DefaultRedisChannel = "jobster.events"
+
+// Redis publishes each event as JSON to a Redis pub/sub channel with
+// PUBLISH. It speaks just enough of the Redis protocol for that, keeps one
+// connection open between events, and reconnects after an error.
+type Redis struct {
+	addr     string
+	user     string
+	password string
+	channel  string
+	tls      bool
+
+	// sem holds the connection: a one-slot semaphore rather than a mutex,
+	// so a caller waiting behind a slow publish gives up when its ctx ends.
+	sem  chan struct{}
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+// NewRedis creates a Redis sink from a URL of the form
+// redis://[user:password@]host[:port][?channel=name]. Use rediss:// for TLS.
+// The port defaults to 6379 and the channel to DefaultRedisChannel.
+func NewRedis(rawURL string) (*Redis, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// Keep the reason but not the URL it quotes, password included
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, fmt.Errorf("invalid redis URL %q (must start with redis:// or rediss://)", u.Redacted())
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid redis URL %q: missing host", u.Redacted())
+	}
+
+	r := &Redis{
+		sem:     make(chan struct{}, 1),
+		addr:    u.Host,
+		channel: u.Query().Get("channel"),
+		tls:     u.Scheme == "rediss",
+	}
+	if u.Port() == "" {
+		r.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if r.channel == "" {
+		r.channel = DefaultRedisChannel
+	}
+	if u.User != nil {
+		r.user = u.User.Username()
+		r.password, _ = u.User.Password()
+	}
+	return r, nil
+}
+
+// Publish sends e to the channel, connecting first if needed.
+func (r *Redis) Publish(ctx context.Context, e RunEvent) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case r.sem <- struct{}{}:
+	case <-ctx.Done():
+		return fmt.Errorf("redis: %w", ctx.Err())
+	}
+	defer func() { <-r.sem }()
+
+	if r.conn == nil {
+		if err := r.connect(ctx); err != nil {
+			return fmt.Errorf("redis: %w", err)
+		}
+	}
+	if err := r.do(ctx, "PUBLISH", r.channel, string(payload)); err != nil {
+		r.closeLocked()
+		return fmt.Errorf("redis: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection, if any. A later Publish reconnects.
+func (r *Redis) Close() error {
+	r.sem <- struct{}{}
+	defer func() { <-r.sem }()
+	return r.closeLocked()
+}
+
+func (r *Redis) closeLocked() error {
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn, r.rd = nil, nil
+	return err
+}
+
+// connect dials the server and authenticates if the URL had credentials.
+func (r *Redis) connect(ctx context.Context) error {
+	var conn net.Conn
+	var err error
+	if r.tls {
+		d := &tls.Dialer{Config: &tls.Config{ServerName: hostOnly(r.addr)}}
+		conn, err = d.DialContext(ctx, "tcp", r.addr)
+	} else {
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, "tcp", r.addr)
+	}
+	if err != nil {
+		return err
+	}
+	r.conn, r.rd = conn, bufio.NewReader(conn)
+
+	if r.password != "" {
+		args := []string{"AUTH", r.password}
+		if r.user != "" {
+			args = []string{"AUTH", r.user, r.password}
+		}
+		if err := r.do(ctx, args...); err != nil {
+			r.closeLocked()
+			return err
+		}
+	}
+	return nil
+}
+
+// do sends one command and reads its reply, returning Redis error replies
+// as errors.
+func (r *Redis) do(ctx context.Context, args ...string) error {
+	deadline, _ := ctx.Deadline() // zero, clearing any deadline, when ctx has none
+	if err := r.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := r.conn.Write([]byte(b.String())); err != nil {
+		return err
+	}
+
+	line, err := r.rd.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	switch {
+	case strings.HasPrefix(line, "-"):
+		return errors.New(line[1:])
+	case strings.HasPrefix(line, "+"), strings.HasPrefix(line, ":"):
+		return nil
+	default:
+		return fmt.Errorf("unexpected reply %q", line)
+	}
+}
+
+// hostOnly strips the port from a host:port address.
+func hostOnly(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
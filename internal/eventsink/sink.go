@@ -0,0 +1,52 @@
+// Package eventsink publishes job run lifecycle events to an external
+// message queue, so other systems can react to runs starting and finishing.
+package eventsink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+)
+
+// RunEvent is the payload published for a run starting or finishing. It is
+// encoded as JSON.
+type RunEvent struct {
+	Type       string    `json:"type"` // run_started or run_finished
+	JobID      string    `json:"job_id"`
+	RunID      string    `json:"run_id"`
+	Time       time.Time `json:"time"`
+	Status     string    `json:"status"` // running, success, or failure
+	Trigger    string    `json:"trigger,omitempty"`
+	ExitCode   int       `json:"exit_code"`
+	DurationMs float64   `json:"duration_ms,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Sink publishes run events. Implementations must be safe for concurrent
+// use and should give up when ctx is done.
+type Sink interface {
+	Publish(ctx context.Context, e RunEvent) error
+}
+
+// Nop is a Sink that discards every event. It is used when no sink is
+// configured.
+type Nop struct{}
+
+// Publish discards e.
+func (Nop) Publish(context.Context, RunEvent) error { return nil }
+
+// New returns the sink configured by cfg: Nop when cfg.Sink is empty or
+// "none", otherwise a publisher to cfg.URL. Connections are made on the
+// first Publish, so a sink that is down does not stop jobster starting.
+func New(cfg config.Events) (Sink, error) {
+	switch cfg.Sink {
+	case "", config.EventSinkNone:
+		return Nop{}, nil
+	case config.EventSinkRedis:
+		return NewRedis(cfg.URL)
+	default:
+		return nil, fmt.Errorf("unknown event sink %q (must be none or redis)", cfg.Sink)
+	}
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AuditRecord is one line of the audit log: the outcome of a completed run.
+type AuditRecord struct {
+	JobID       string    `json:"job_id"`
+	RunID       string    `json:"run_id"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	ExitCode    int       `json:"exit_code"`
+	Success     bool      `json:"success"`
+	TriggeredBy string    `json:"triggered_by"` // "schedule", "manual", "dependency", "catchup", or "restart"
+}
+
+// AuditLog appends AuditRecords to a JSON Lines file. The file is only ever
+// opened in append mode, so existing lines are never rewritten, and appends
+// are serialized so concurrent runs cannot interleave their lines. A nil
+// *AuditLog is valid and records nothing.
+type AuditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewAuditLog returns an AuditLog appending to path, creating the file and
+// its directory if needed so a bad path is reported at startup rather than
+// after the first run.
+func NewAuditLog(path string) (*AuditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("audit log: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("audit log: %w", err)
+	}
+	return &AuditLog{path: path}, nil
+}
+
+// Append writes rec as one line at the end of the file. The file is opened
+// per record, so it can be rotated by moving it aside.
+func (a *AuditLog) Append(rec AuditRecord) error {
+	if a == nil {
+		return nil
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.OpenFile(a.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
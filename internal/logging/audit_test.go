@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAuditLog_AppendsConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "runs.jsonl")
+	audit, err := NewAuditLog(path)
+	if err != nil {
+		t.Fatalf("NewAuditLog: %v", err)
+	}
+
+	// An existing line must survive
+	if err := os.WriteFile(path, []byte(`{"job_id":"earlier"}`+"\n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 50
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			now := time.Now()
+			rec := AuditRecord{
+				JobID:       "job",
+				RunID:       fmt.Sprintf("run-%d", i),
+				StartTime:   now,
+				EndTime:     now,
+				Success:     true,
+				TriggeredBy: "schedule",
+			}
+			if err := audit.Append(rec); err != nil {
+				t.Errorf("Append: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var lines []AuditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("malformed line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, rec)
+	}
+	if len(lines) != n+1 {
+		t.Fatalf("got %d lines, want %d", len(lines), n+1)
+	}
+	if lines[0].JobID != "earlier" {
+		t.Errorf("first line = %+v, want the pre-existing record", lines[0])
+	}
+
+	seen := make(map[string]bool)
+	for _, rec := range lines[1:] {
+		seen[rec.RunID] = true
+	}
+	if len(seen) != n {
+		t.Errorf("got %d distinct run IDs, want %d", len(seen), n)
+	}
+}
+
+func TestAuditLog_NilIsNoop(t *testing.T) {
+	var audit *AuditLog
+	if err := audit.Append(AuditRecord{JobID: "job"}); err != nil {
+		t.Errorf("nil Append: %v", err)
+	}
+}
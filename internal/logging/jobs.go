@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// JobLoggers opens one log file per job, <dir>/<job-id>.log, sharing the
+// format, level, redaction, and rotation settings of the main logger. Each
+// file is opened on first use and kept open.
+type JobLoggers struct {
+	dir    string
+	format string
+	level  string
+	opts   Options
+
+	mu      sync.Mutex
+	loggers map[string]*slog.Logger
+}
+
+// NewJobLoggers creates dir if needed and returns a JobLoggers writing there.
+func NewJobLoggers(dir, format, level string, opts Options) (*JobLoggers, error) {
+	if _, err := CompileRedactPatterns(opts.RedactPatterns); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &JobLoggers{
+		dir:     dir,
+		format:  format,
+		level:   level,
+		opts:    opts,
+		loggers: make(map[string]*slog.Logger),
+	}, nil
+}
+
+// Logger returns the logger writing to jobID's file. Job IDs that are not a
+// plain file name are rejected rather than written outside the directory.
+func (j *JobLoggers) Logger(jobID string) (*slog.Logger, error) {
+	if jobID == "" || jobID == "." || jobID == ".." || filepath.Base(jobID) != jobID {
+		return nil, fmt.Errorf("job ID %q cannot be used as a log file name", jobID)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if l, ok := j.loggers[jobID]; ok {
+		return l, nil
+	}
+	l, err := NewFromConfigWithOptions(j.format, j.level, filepath.Join(j.dir, jobID+".log"), j.opts)
+	if err != nil {
+		return nil, err
+	}
+	j.loggers[jobID] = l
+	return l, nil
+}
+
+// Tee returns a logger that sends every record to each of loggers.
+func Tee(loggers ...*slog.Logger) *slog.Logger {
+	handlers := make([]slog.Handler, len(loggers))
+	for i, l := range loggers {
+		handlers[i] = l.Handler()
+	}
+	return slog.New(teeHandler(handlers))
+}
+
+// teeHandler is a slog.Handler fanning records out to several handlers,
+// each applying its own level.
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var firstErr error
+	for _, h := range t {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(teeHandler, len(t))
+	for i, h := range t {
+		out[i] = h.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	out := make(teeHandler, len(t))
+	for i, h := range t {
+		out[i] = h.WithGroup(name)
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJobLoggers(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "jobs")
+	jobLoggers, err := NewJobLoggers(dir, "json", "info", Options{RedactPatterns: []string{`_KEY$`}})
+	if err != nil {
+		t.Fatalf("NewJobLoggers() error = %v", err)
+	}
+
+	first, err := jobLoggers.Logger("backup")
+	if err != nil {
+		t.Fatalf("Logger() error = %v", err)
+	}
+	again, err := jobLoggers.Logger("backup")
+	if err != nil {
+		t.Fatalf("Logger() error = %v", err)
+	}
+	if first != again {
+		t.Error("expected the same logger for repeated calls")
+	}
+
+	first.Info("job started", "run_id", "r1", "api_key", "k", "api_token", "t")
+	first.Debug("below level")
+
+	data, err := os.ReadFile(filepath.Join(dir, "backup.log"))
+	if err != nil {
+		t.Fatalf("failed to read job log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 entry, got %d: %s", len(lines), data)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("failed to parse job log: %v", err)
+	}
+	if entry["msg"] != "job started" || entry["run_id"] != "r1" {
+		t.Errorf("unexpected entry: %v", entry)
+	}
+	if entry["api_key"] != "***REDACTED***" || entry["api_token"] != "***REDACTED***" {
+		t.Errorf("expected custom and default redaction, got: %v", entry)
+	}
+
+	for _, id := range []string{"../escape", "a/b", ".."} {
+		if _, err := jobLoggers.Logger(id); err == nil {
+			t.Errorf("Logger(%q) expected error", id)
+		}
+	}
+}
+
+func TestTee(t *testing.T) {
+	var infoBuf, debugBuf bytes.Buffer
+	info := NewWithWriter(&infoBuf, "info")
+	debug := NewWithWriter(&debugBuf, "debug")
+
+	logger := Tee(info, debug).With("job_id", "backup")
+	logger.Debug("debug only")
+	logger.Info("both")
+
+	if strings.Contains(infoBuf.String(), "debug only") {
+		t.Error("info logger should skip debug records")
+	}
+	for name, buf := range map[string]*bytes.Buffer{"info": &infoBuf, "debug": &debugBuf} {
+		if !strings.Contains(buf.String(), `"msg":"both"`) || !strings.Contains(buf.String(), `"job_id":"backup"`) {
+			t.Errorf("%s logger missing teed record: %s", name, buf.String())
+		}
+	}
+	if strings.Count(debugBuf.String(), "\n") != 2 {
+		t.Errorf("expected 2 records in debug logger, got: %s", debugBuf.String())
+	}
+
+}
@@ -2,11 +2,15 @@ package logging
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
+
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // contextKey is a private type for context keys to avoid collisions.
@@ -56,16 +60,74 @@ func NewWithWriter(w io.Writer, level string) *slog.Logger {
 
 // redactSecrets is a ReplaceAttr function that redacts sensitive fields.
 func redactSecrets(groups []string, a slog.Attr) slog.Attr {
-	// Check if the attribute key matches any secret pattern
-	for _, pattern := range secretPatterns {
-		if pattern.MatchString(a.Key) {
-			return slog.Attr{
-				Key:   a.Key,
-				Value: slog.StringValue("***REDACTED***"),
+	return redactor(secretPatterns)(groups, a)
+}
+
+// redactor returns a ReplaceAttr function that redacts attributes whose key
+// matches any of patterns.
+func redactor(patterns []*regexp.Regexp) func([]string, slog.Attr) slog.Attr {
+	return func(_ []string, a slog.Attr) slog.Attr {
+		for _, pattern := range patterns {
+			if pattern.MatchString(a.Key) {
+				return slog.Attr{
+					Key:   a.Key,
+					Value: slog.StringValue("***REDACTED***"),
+				}
 			}
 		}
+		return a
+	}
+}
+
+// CompileRedactPatterns compiles user-supplied redaction patterns. Patterns
+// are matched case-insensitively against attribute keys.
+func CompileRedactPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// ValueRedactor masks secrets inside free-form text, such as captured job
+// output, by replacing every match of its patterns. A nil *ValueRedactor
+// leaves text unchanged.
+type ValueRedactor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewValueRedactor compiles patterns into a ValueRedactor. Unlike key
+// patterns they are case-sensitive; prefix one with (?i) to change that.
+// It returns nil if patterns is empty.
+func NewValueRedactor(patterns []string) (*ValueRedactor, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact value pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return &ValueRedactor{patterns: compiled}, nil
+}
+
+// Redact returns s with every match of the redactor's patterns replaced by
+// ***REDACTED***.
+func (r *ValueRedactor) Redact(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	for _, pattern := range r.patterns {
+		s = pattern.ReplaceAllLiteralString(s, "***REDACTED***")
 	}
-	return a
+	return s
 }
 
 // WithContext attaches a logger to a context.
@@ -97,6 +159,46 @@ func WithFields(logger *slog.Logger, fields map[string]any) *slog.Logger {
 // NewFromConfig creates a logger based on configuration settings.
 // Supports format (json/text), level (debug/info/warn/error), and output (file path or stderr).
 func NewFromConfig(format, level, output string) (*slog.Logger, error) {
+	return NewFromConfigWithRedaction(format, level, output, nil)
+}
+
+// NewFromConfigWithRedaction is like NewFromConfig, but also redacts
+// attributes whose key matches any of redactPatterns, in addition to the
+// built-in secret patterns.
+func NewFromConfigWithRedaction(format, level, output string, redactPatterns []string) (*slog.Logger, error) {
+	return NewFromConfigWithOptions(format, level, output, Options{RedactPatterns: redactPatterns})
+}
+
+// Options holds the optional logger settings beyond format, level and output.
+type Options struct {
+	// RedactPatterns are extra key patterns to redact; see
+	// NewFromConfigWithRedaction.
+	RedactPatterns []string
+
+	// MaxSizeMB, MaxBackups and MaxAgeDays enable rotation of file output
+	// when any is positive: the file is rotated once it reaches MaxSizeMB
+	// (default 100), and rotated files beyond MaxBackups or older than
+	// MaxAgeDays are deleted (0 keeps them). They do not affect stderr,
+	// stdout or discard output.
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+}
+
+// rotates reports whether any rotation setting is enabled.
+func (o Options) rotates() bool {
+	return o.MaxSizeMB > 0 || o.MaxBackups > 0 || o.MaxAgeDays > 0
+}
+
+// NewFromConfigWithOptions is like NewFromConfig, with key redaction and
+// file rotation configured by opts.
+func NewFromConfigWithOptions(format, level, output string, opts Options) (*slog.Logger, error) {
+	extra, err := CompileRedactPatterns(opts.RedactPatterns)
+	if err != nil {
+		return nil, err
+	}
+	patterns := append(slices.Clone(secretPatterns), extra...)
+
 	// Determine log level
 	var logLevel slog.Level
 	switch strings.ToLower(level) {
@@ -127,19 +229,31 @@ func NewFromConfig(format, level, output string) (*slog.Logger, error) {
 			return nil, err
 		}
 		writer = f
+
+		// The file was opened above only so a bad path fails here rather
+		// than on the first write; lumberjack reopens it itself.
+		if opts.rotates() {
+			f.Close()
+			writer = &lumberjack.Logger{
+				Filename:   output,
+				MaxSize:    opts.MaxSizeMB,
+				MaxBackups: opts.MaxBackups,
+				MaxAge:     opts.MaxAgeDays,
+			}
+		}
 	}
 
-	opts := &slog.HandlerOptions{
+	handlerOpts := &slog.HandlerOptions{
 		Level:       logLevel,
-		ReplaceAttr: redactSecrets,
+		ReplaceAttr: redactor(patterns),
 	}
 
 	// Create handler based on format
 	var handler slog.Handler
 	if strings.ToLower(format) == "text" {
-		handler = slog.NewTextHandler(writer, opts)
+		handler = slog.NewTextHandler(writer, handlerOpts)
 	} else {
-		handler = slog.NewJSONHandler(writer, opts)
+		handler = slog.NewJSONHandler(writer, handlerOpts)
 	}
 
 	return slog.New(handler), nil
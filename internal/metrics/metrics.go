@@ -0,0 +1,85 @@
+// Package metrics exposes job execution metrics in the Prometheus format.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds jobster's Prometheus collectors in a dedicated registry.
+// A nil *Metrics is valid and records nothing.
+type Metrics struct {
+	registry      *prometheus.Registry
+	runsTotal     *prometheus.CounterVec
+	runDuration   *prometheus.HistogramVec
+	lastSuccess   *prometheus.GaugeVec
+	schedulerJobs prometheus.Gauge
+}
+
+// New creates the collectors and registers them, along with the standard Go
+// runtime and process collectors, in a new registry.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		runsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "jobster_runs_total",
+			Help: "Completed job runs by job and status.",
+		}, []string{"job", "status"}),
+		runDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "jobster_run_duration_seconds",
+			Help: "Wall-clock duration of completed job runs.",
+			// Jobs range from sub-second scripts to hour-long batches.
+			Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 300, 900, 1800, 3600},
+		}, []string{"job"}),
+		lastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "jobster_job_last_success_timestamp",
+			Help: "Unix time the job last completed successfully.",
+		}, []string{"job"}),
+		schedulerJobs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "jobster_scheduler_jobs",
+			Help: "Number of jobs registered with the scheduler.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.runsTotal,
+		m.runDuration,
+		m.lastSuccess,
+		m.schedulerJobs,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// ObserveRun records a completed run. status is "success" or "failure".
+func (m *Metrics) ObserveRun(jobID, status string, duration time.Duration, end time.Time) {
+	if m == nil {
+		return
+	}
+
+	m.runsTotal.WithLabelValues(jobID, status).Inc()
+	m.runDuration.WithLabelValues(jobID).Observe(duration.Seconds())
+	if status == "success" {
+		m.lastSuccess.WithLabelValues(jobID).Set(float64(end.Unix()))
+	}
+}
+
+// SetSchedulerJobs sets the registered job count. Its signature matches
+// scheduler.WithJobCountObserver.
+func (m *Metrics) SetSchedulerJobs(count int) {
+	if m == nil {
+		return
+	}
+	m.schedulerJobs.Set(float64(count))
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics_ObserveRun(t *testing.T) {
+	m := New()
+	end := time.Unix(1700000000, 0)
+
+	m.ObserveRun("backup", "success", 2*time.Second, end)
+	m.ObserveRun("backup", "failure", time.Second, end.Add(time.Minute))
+	m.ObserveRun("backup", "success", time.Second, end.Add(2*time.Minute))
+
+	if got := testutil.ToFloat64(m.runsTotal.WithLabelValues("backup", "success")); got != 2 {
+		t.Errorf("runs_total{status=success} = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.runsTotal.WithLabelValues("backup", "failure")); got != 1 {
+		t.Errorf("runs_total{status=failure} = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.lastSuccess.WithLabelValues("backup")); got != float64(end.Add(2*time.Minute).Unix()) {
+		t.Errorf("last_success_timestamp = %v, want %v", got, end.Add(2*time.Minute).Unix())
+	}
+	if got := testutil.CollectAndCount(m.runDuration); got != 1 {
+		t.Errorf("run_duration_seconds series = %d, want 1", got)
+	}
+}
+
+func TestMetrics_SetSchedulerJobs(t *testing.T) {
+	m := New()
+	m.SetSchedulerJobs(3)
+
+	if got := testutil.ToFloat64(m.schedulerJobs); got != 3 {
+		t.Errorf("scheduler_jobs = %v, want 3", got)
+	}
+}
+
+func TestMetrics_NilIsNoop(t *testing.T) {
+	var m *Metrics
+	m.ObserveRun("job", "success", time.Second, time.Now())
+	m.SetSchedulerJobs(1)
+}
@@ -0,0 +1,187 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/caevv/jobster/internal/telemetry"
+)
+
+// builtinAgent runs a built-in agent. Like an executable agent it reports
+// failure through a non-zero ExitCode with a message on Stderr; a returned
+// error means the agent could not be run at all.
+type builtinAgent func(ctx context.Context, params AgentParams, with map[string]interface{}) (*AgentResult, error)
+
+// builtinAgents are the agents implemented in Go. Their names start with "@"
+// so they cannot clash with discovered executables.
+var builtinAgents = map[string]builtinAgent{
+	"@webhook": webhookAgent,
+}
+
+// BuiltinAgentNames returns the names of the built-in agents, sorted.
+func BuiltinAgentNames() []string {
+	names := make([]string, 0, len(builtinAgents))
+	for name := range builtinAgents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsBuiltinAgent reports whether name refers to a built-in agent.
+func IsBuiltinAgent(name string) bool {
+	_, ok := builtinAgents[name]
+	return ok
+}
+
+// executeBuiltin runs a built-in agent under the same timeout as an
+// executable one.
+func (e *AgentExecutor) executeBuiltin(ctx context.Context, agentName string, agent builtinAgent, params AgentParams) (*AgentResult, error) {
+	execCtx := ctx
+	if params.TimeoutSec > 0 {
+		var cancel context.CancelFunc
+		execCtx, cancel = context.WithTimeout(ctx, time.Duration(params.TimeoutSec)*time.Second)
+		defer cancel()
+	}
+
+	var with map[string]interface{}
+	if params.ConfigJSON != "" {
+		if err := json.Unmarshal([]byte(params.ConfigJSON), &with); err != nil {
+			return nil, fmt.Errorf("agent %s: invalid config: %w", agentName, err)
+		}
+	}
+
+	e.logger.Info("executing built-in agent",
+		slog.String("agent", agentName),
+		slog.String("job_id", params.JobID),
+		slog.String("run_id", params.RunID),
+		slog.String("hook", params.Hook))
+
+	startTime := time.Now()
+	result, err := agent(execCtx, params, with)
+	if err == nil && errors.Is(execCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		err = fmt.Errorf("agent timed out after %ds", params.TimeoutSec)
+	}
+	if err != nil {
+		e.logger.Error("agent execution failed",
+			slog.String("agent", agentName),
+			slog.String("job_id", params.JobID),
+			slog.String("run_id", params.RunID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	result.Duration = time.Since(startTime)
+	result.JSONOutput = e.parseJSONOutput(result.Stdout)
+
+	logLevel := slog.LevelInfo
+	if result.ExitCode != 0 {
+		logLevel = slog.LevelWarn
+	}
+	e.logger.Log(ctx, logLevel, "agent execution completed",
+		slog.String("agent", agentName),
+		slog.String("job_id", params.JobID),
+		slog.String("run_id", params.RunID),
+		slog.Int("exit_code", result.ExitCode),
+		slog.Duration("duration", result.Duration))
+
+	return result, nil
+}
+
+// webhookMaxResponse caps how much of a webhook response is kept.
+const webhookMaxResponse = 64 * 1024
+
+// webhookAgent sends the run context as JSON to with.url. with.method
+// defaults to POST and with.headers adds request headers. The payload is the
+// $HISTORY_FILE document when one is available, else the job and run IDs
+// known at this hook, plus the hook name. A non-2xx response exits with 1;
+// the response body becomes the agent's stdout.
+func webhookAgent(ctx context.Context, params AgentParams, with map[string]interface{}) (*AgentResult, error) {
+	url, _ := with["url"].(string)
+	if url == "" {
+		return nil, fmt.Errorf("@webhook: with.url is required")
+	}
+	method := http.MethodPost
+	if m, ok := with["method"].(string); ok && m != "" {
+		method = strings.ToUpper(m)
+	}
+
+	payload, err := webhookPayload(params)
+	if err != nil {
+		return nil, fmt.Errorf("@webhook: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("@webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "jobster")
+	for name, value := range telemetry.EnvCarrier(ctx) {
+		req.Header.Set(strings.ToLower(name), value)
+	}
+	if headers, ok := with["headers"].(map[string]interface{}); ok {
+		for name, value := range headers {
+			req.Header.Set(name, fmt.Sprint(value))
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return &AgentResult{ExitCode: -1}, nil
+		}
+		return &AgentResult{ExitCode: 1, Stderr: err.Error()}, nil
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, webhookMaxResponse))
+	if err != nil {
+		return &AgentResult{ExitCode: 1, Stderr: fmt.Sprintf("read response: %v", err)}, nil
+	}
+
+	result := &AgentResult{Stdout: string(body)}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		result.ExitCode = 1
+		result.Stderr = fmt.Sprintf("%s %s: %s", method, url, resp.Status)
+	}
+	return result, nil
+}
+
+// webhookPayload builds the request body for webhookAgent.
+func webhookPayload(params AgentParams) ([]byte, error) {
+	doc := map[string]interface{}{}
+	if params.HistoryFile != "" {
+		data, err := os.ReadFile(params.HistoryFile)
+		if err != nil {
+			return nil, fmt.Errorf("read run context: %w", err)
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("decode run context: %w", err)
+		}
+	} else {
+		// pre_run has no finished run to describe
+		doc["job"] = map[string]interface{}{
+			"id":       params.JobID,
+			"command":  params.JobCommand,
+			"schedule": params.JobSchedule,
+		}
+		doc["run"] = map[string]interface{}{
+			"run_id":     params.RunID,
+			"attempt":    params.Attempt,
+			"start_time": params.StartTS,
+		}
+	}
+	doc["hook"] = params.Hook
+
+	return json.Marshal(doc)
+}
@@ -0,0 +1,171 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+)
+
+func TestWebhookAgent(t *testing.T) {
+	type request struct {
+		method  string
+		headers http.Header
+		body    map[string]interface{}
+	}
+	requests := make(chan request, 1)
+	status := http.StatusOK
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var body map[string]interface{}
+		json.Unmarshal(data, &body)
+		requests <- request{method: r.Method, headers: r.Header, body: body}
+
+		if r.URL.Path == "/slow" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(3 * time.Second):
+			}
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(`{"ticket":"OPS-42"}`))
+	}))
+	defer srv.Close()
+
+	executor := New(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError})))
+
+	historyFile := filepath.Join(t.TempDir(), "run.json")
+	if err := os.WriteFile(historyFile, []byte(`{"job":{"id":"backup"},"run":{"run_id":"run-1","exit_code":2}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	params := AgentParams{
+		JobID:       "backup",
+		RunID:       "run-1",
+		Hook:        OnError.String(),
+		HistoryFile: historyFile,
+		TimeoutSec:  5,
+	}
+
+	t.Run("posts run context with headers", func(t *testing.T) {
+		hooks := []config.Agent{{
+			Agent: "@webhook",
+			With: map[string]interface{}{
+				"url":     srv.URL + "/hook",
+				"headers": map[string]interface{}{"Authorization": "Bearer secret"},
+			},
+		}}
+
+		outputs, err := ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{})
+		if err != nil {
+			t.Fatalf("ExecuteHooks should not error: %v", err)
+		}
+
+		req := <-requests
+		if req.method != http.MethodPost {
+			t.Errorf("Expected POST, got %s", req.method)
+		}
+		if got := req.headers.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Expected Authorization header, got %q", got)
+		}
+		if got := req.headers.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Expected JSON content type, got %q", got)
+		}
+		if req.body["hook"] != "on_error" {
+			t.Errorf("Expected hook in payload, got %v", req.body["hook"])
+		}
+		run, _ := req.body["run"].(map[string]interface{})
+		if run["run_id"] != "run-1" || run["exit_code"] != float64(2) {
+			t.Errorf("Expected run context in payload, got %v", req.body)
+		}
+		if got := outputs["@webhook"]["ticket"]; got != "OPS-42" {
+			t.Errorf("Expected JSON response as agent output, got %v", outputs)
+		}
+	})
+
+	t.Run("method and pre_run payload", func(t *testing.T) {
+		preRun := params
+		preRun.Hook = PreRun.String()
+		preRun.HistoryFile = ""
+		preRun.ConfigJSON = `{"url":"` + srv.URL + `","method":"put"}`
+
+		result, err := executor.Execute(context.Background(), "@webhook", preRun)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.ExitCode != 0 {
+			t.Errorf("Expected exit code 0, got %d", result.ExitCode)
+		}
+
+		req := <-requests
+		if req.method != http.MethodPut {
+			t.Errorf("Expected PUT, got %s", req.method)
+		}
+		job, _ := req.body["job"].(map[string]interface{})
+		if job["id"] != "backup" {
+			t.Errorf("Expected job ID in payload, got %v", req.body)
+		}
+	})
+
+	t.Run("non-2xx response", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		defer func() { status = http.StatusOK }()
+
+		params := params
+		params.ConfigJSON = `{"url":"` + srv.URL + `"}`
+		result, err := executor.Execute(context.Background(), "@webhook", params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		<-requests
+		if result.ExitCode != 1 {
+			t.Errorf("Expected exit code 1, got %d", result.ExitCode)
+		}
+		if !strings.Contains(result.Stderr, "500") {
+			t.Errorf("Expected status in stderr, got %q", result.Stderr)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		params := params
+		params.TimeoutSec = 1
+		params.ConfigJSON = `{"url":"` + srv.URL + `/slow"}`
+
+		start := time.Now()
+		_, err := executor.Execute(context.Background(), "@webhook", params)
+		<-requests
+		if err == nil || !strings.Contains(err.Error(), "timed out") {
+			t.Errorf("Expected timeout error, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("Execute took %s with a 1s timeout", elapsed)
+		}
+	})
+
+	t.Run("missing url", func(t *testing.T) {
+		params := params
+		params.ConfigJSON = `{}`
+		if _, err := executor.Execute(context.Background(), "@webhook", params); err == nil {
+			t.Error("Expected error without with.url")
+		}
+	})
+
+	t.Run("validates without discovery", func(t *testing.T) {
+		if err := executor.ValidateAgent("@webhook", nil); err != nil {
+			t.Errorf("Built-in agent should be valid: %v", err)
+		}
+		if err := executor.ValidateAgent("@unknown", nil); err == nil {
+			t.Error("Expected unknown built-in agent to be invalid")
+		}
+	})
+}
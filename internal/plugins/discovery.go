@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/caevv/jobster/internal/config"
 )
 
 // DiscoverAgents searches for executable agents in configured paths and returns
@@ -13,6 +15,32 @@ import (
 // 3. /usr/local/lib/jobster/agents/
 func DiscoverAgents(paths []string) (map[string]string, error) {
 	agents := make(map[string]string)
+	for _, file := range ScanAgents(paths) {
+		if file.ShadowedBy == "" {
+			agents[file.Name] = file.Path
+		}
+	}
+
+	return agents, nil
+}
+
+// AgentFile is an executable found by ScanAgents.
+type AgentFile struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+
+	// ShadowedBy is the path of the same-named agent found in an earlier
+	// search path, which is the one that runs. It is empty for agents that
+	// run.
+	ShadowedBy string `json:"shadowed_by,omitempty"`
+}
+
+// ScanAgents searches paths (or the default search paths when empty) like
+// DiscoverAgents, but returns every executable found in search order,
+// including those shadowed by an agent of the same name in an earlier path.
+func ScanAgents(paths []string) []AgentFile {
+	var files []AgentFile
+	winners := make(map[string]string)
 
 	// If no paths provided, use default search paths
 	if len(paths) == 0 {
@@ -50,17 +78,20 @@ func DiscoverAgents(paths []string) (map[string]string, error) {
 
 			// Check if file is executable
 			if isExecutable(fullPath) {
-				// Use basename as agent name, don't overwrite if already found
-				// (earlier paths have priority)
+				// Use basename as agent name; earlier paths have priority
 				name := entry.Name()
-				if _, exists := agents[name]; !exists {
-					agents[name] = fullPath
+				file := AgentFile{Name: name, Path: fullPath}
+				if winner, exists := winners[name]; exists {
+					file.ShadowedBy = winner
+				} else {
+					winners[name] = fullPath
 				}
+				files = append(files, file)
 			}
 		}
 	}
 
-	return agents, nil
+	return files
 }
 
 // getDefaultAgentPaths returns the default agent search paths in priority order
@@ -80,10 +111,10 @@ func getDefaultAgentPaths() []string {
 	return paths
 }
 
-// expandPath expands environment variables and resolves relative paths
+// expandPath expands ~ and environment variables and resolves relative paths
 func expandPath(path string) string {
-	// Expand environment variables
-	expanded := os.ExpandEnv(path)
+	// Expand ~ and environment variables
+	expanded := config.ExpandPath(path)
 
 	// Convert to absolute path if relative
 	if !filepath.IsAbs(expanded) {
@@ -100,6 +100,29 @@ func TestDiscoverAgents_MultiplePaths(t *testing.T) {
 	}
 }
 
+func TestScanAgents_ReportsShadowed(t *testing.T) {
+	dir1 := t.TempDir()
+	dir2 := t.TempDir()
+	first := filepath.Join(dir1, "notify")
+	second := filepath.Join(dir2, "notify")
+	for _, path := range []string{first, second} {
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := ScanAgents([]string{dir1, dir2})
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 agent files, got %d: %+v", len(files), files)
+	}
+	if files[0].Path != first || files[0].ShadowedBy != "" {
+		t.Errorf("Expected %s to run, got %+v", first, files[0])
+	}
+	if files[1].Path != second || files[1].ShadowedBy != first {
+		t.Errorf("Expected %s to be shadowed by %s, got %+v", second, first, files[1])
+	}
+}
+
 func TestDiscoverAgents_NonExistentPath(t *testing.T) {
 	// Test with non-existent path - should not error
 	agents, err := DiscoverAgents([]string{"/non/existent/path"})
@@ -184,6 +207,19 @@ func TestExpandPath(t *testing.T) {
 				return filepath.IsAbs(result)
 			},
 		},
+		{
+			name:  "tilde expansion",
+			input: "~/agents",
+			setup: func() {
+				os.Setenv("HOME", "/test/home")
+			},
+			teardown: func() {
+				os.Unsetenv("HOME")
+			},
+			validate: func(result string) bool {
+				return result == "/test/home/agents"
+			},
+		},
 		{
 			name:  "env var expansion",
 			input: "$HOME/path",
@@ -3,20 +3,27 @@ package plugins
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"os/exec"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/caevv/jobster/internal/telemetry"
 )
 
 // AgentExecutor manages agent discovery and execution
 type AgentExecutor struct {
-	logger *slog.Logger
-	agents map[string]string
+	logger    *slog.Logger
+	agents    map[string]string
+	checksums map[string]string
 }
 
 // AgentParams contains all parameters needed to execute an agent
@@ -44,6 +51,9 @@ type AgentParams struct {
 	// Additional environment variables
 	ExtraEnv map[string]string
 
+	// Data piped to the agent's stdin (empty = no stdin)
+	Stdin string
+
 	// Timeout for agent execution
 	TimeoutSec int
 }
@@ -82,13 +92,59 @@ func (e *AgentExecutor) Discover(paths []string) error {
 	return nil
 }
 
+// SetChecksums sets the expected SHA-256 digest (hex) of agent files by agent
+// name. Execute refuses to run a listed agent whose file does not match;
+// agents not listed are not checked.
+func (e *AgentExecutor) SetChecksums(checksums map[string]string) {
+	e.checksums = checksums
+}
+
+// verifyChecksum checks the agent file at path against its configured digest.
+func (e *AgentExecutor) verifyChecksum(agentName, path string) error {
+	expected, ok := e.checksums[agentName]
+	if !ok {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("agent %s: %w", agentName, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("agent %s: %w", agentName, err)
+	}
+	actual := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("agent %s checksum mismatch: expected sha256 %s, got %s", agentName, strings.ToLower(expected), actual)
+	}
+	return nil
+}
+
+// processWaitDelay bounds how long a killed agent's output pipes may stay
+// open before Wait gives up on them.
+const processWaitDelay = 5 * time.Second
+
 // Execute runs an agent with the specified parameters
 func (e *AgentExecutor) Execute(ctx context.Context, agentName string, params AgentParams) (*AgentResult, error) {
+	if agent, ok := builtinAgents[agentName]; ok {
+		return e.executeBuiltin(ctx, agentName, agent, params)
+	}
+
 	// Find agent path
 	agentPath, err := FindAgent(e.agents, agentName)
 	if err != nil {
 		return nil, err
 	}
+	if err := e.verifyChecksum(agentName, agentPath); err != nil {
+		e.logger.Error("refusing to run agent",
+			slog.String("agent", agentName),
+			slog.String("path", agentPath),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
 
 	// Create context with timeout
 	execCtx := ctx
@@ -100,9 +156,14 @@ func (e *AgentExecutor) Execute(ctx context.Context, agentName string, params Ag
 
 	// Create command
 	cmd := exec.CommandContext(execCtx, agentPath)
+	configureProcessGroup(cmd)
 
 	// Set up environment variables
-	cmd.Env = e.buildEnvironment(params)
+	cmd.Env = e.buildEnvironment(ctx, params)
+
+	if params.Stdin != "" {
+		cmd.Stdin = strings.NewReader(params.Stdin)
+	}
 
 	// Set up output buffers
 	var stdout, stderr bytes.Buffer
@@ -122,6 +183,16 @@ func (e *AgentExecutor) Execute(ctx context.Context, agentName string, params Ag
 	execErr := cmd.Run()
 	duration := time.Since(startTime)
 
+	// A killed agent reports exit code -1; surface the timeout instead
+	if execErr != nil && errors.Is(execCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		e.logger.Error("agent timed out",
+			slog.String("agent", agentName),
+			slog.String("job_id", params.JobID),
+			slog.String("run_id", params.RunID),
+			slog.Int("timeout_sec", params.TimeoutSec))
+		return nil, fmt.Errorf("agent timed out after %ds", params.TimeoutSec)
+	}
+
 	// Determine exit code
 	exitCode := 0
 	if execErr != nil {
@@ -171,8 +242,9 @@ func (e *AgentExecutor) Execute(ctx context.Context, agentName string, params Ag
 	return result, nil
 }
 
-// buildEnvironment creates the environment variables for agent execution
-func (e *AgentExecutor) buildEnvironment(params AgentParams) []string {
+// buildEnvironment creates the environment variables for agent execution.
+// When the run is traced, TRACEPARENT lets the agent join the trace.
+func (e *AgentExecutor) buildEnvironment(ctx context.Context, params AgentParams) []string {
 	env := os.Environ()
 
 	// Add agent-specific environment variables
@@ -191,6 +263,10 @@ func (e *AgentExecutor) buildEnvironment(params AgentParams) []string {
 		"HISTORY_FILE": params.HistoryFile,
 	}
 
+	for k, v := range telemetry.EnvCarrier(ctx) {
+		envVars[k] = v
+	}
+
 	// Add extra environment variables
 	for k, v := range params.ExtraEnv {
 		envVars[k] = v
@@ -256,8 +332,10 @@ func (e *AgentExecutor) GetAgents() map[string]string {
 // ValidateAgent checks if an agent exists and is allowed
 func (e *AgentExecutor) ValidateAgent(agentName string, allowedAgents []string) error {
 	// Check if agent exists
-	if _, err := FindAgent(e.agents, agentName); err != nil {
-		return err
+	if !IsBuiltinAgent(agentName) {
+		if _, err := FindAgent(e.agents, agentName); err != nil {
+			return err
+		}
 	}
 
 	// If no allow list configured, all agents are allowed
@@ -2,9 +2,12 @@ package plugins
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -50,6 +53,12 @@ echo "CONFIG_JSON=$CONFIG_JSON"
 		t.Fatal(err)
 	}
 
+	// Create agent that outlives any reasonable timeout
+	slowAgent := filepath.Join(agentsDir, "slow.sh")
+	if err := os.WriteFile(slowAgent, []byte("#!/bin/bash\nsleep 30\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
 	// Create executor
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelError, // Suppress logs during tests
@@ -139,11 +148,22 @@ echo "CONFIG_JSON=$CONFIG_JSON"
 	})
 
 	t.Run("timeout", func(t *testing.T) {
-		t.Skip("Skipping timeout test - process group handling is platform-specific")
-		// Note: Proper timeout handling with process groups would require
-		// platform-specific code (syscall.SysProcAttr on Unix systems)
-		// The timeout mechanism is implemented, but testing it reliably
-		// requires more complex setup
+		params := AgentParams{
+			JobID:      "test-job",
+			RunID:      "run-123",
+			Hook:       "test_hook",
+			ConfigJSON: "{}",
+			TimeoutSec: 1,
+		}
+
+		start := time.Now()
+		_, err := executor.Execute(context.Background(), "slow.sh", params)
+		if err == nil {
+			t.Error("Expected error for agent exceeding its timeout")
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Execute took %s; the agent was not killed at its timeout", elapsed)
+		}
 	})
 
 	t.Run("non-existent agent", func(t *testing.T) {
@@ -299,3 +319,70 @@ func containsInMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+func TestAgentExecutor_Stdin(t *testing.T) {
+	agentsDir := t.TempDir()
+	script := "#!/bin/bash\ntr a-z A-Z\n"
+	if err := os.WriteFile(filepath.Join(agentsDir, "upper.sh"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := New(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError})))
+	if err := executor.Discover([]string{agentsDir}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := executor.Execute(context.Background(), "upper.sh", AgentParams{
+		JobID:      "test-job",
+		RunID:      "run-123",
+		Hook:       "post_run",
+		TimeoutSec: 5,
+		Stdin:      "job output\nline two\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Stdout != "JOB OUTPUT\nLINE TWO\n" {
+		t.Errorf("Expected stdin to be transformed, got %q", result.Stdout)
+	}
+}
+
+func TestAgentExecutor_Checksums(t *testing.T) {
+	agentsDir := t.TempDir()
+	script := []byte("#!/bin/bash\necho trusted\n")
+	for _, name := range []string{"trusted.sh", "tampered.sh", "unlisted.sh"} {
+		if err := os.WriteFile(filepath.Join(agentsDir, name), script, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sum := sha256.Sum256(script)
+	digest := hex.EncodeToString(sum[:])
+
+	executor := New(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError})))
+	if err := executor.Discover([]string{agentsDir}); err != nil {
+		t.Fatal(err)
+	}
+	executor.SetChecksums(map[string]string{
+		"trusted.sh":  strings.ToUpper(digest),
+		"tampered.sh": digest,
+	})
+
+	// Swap in different contents after the checksum was recorded
+	if err := os.WriteFile(filepath.Join(agentsDir, "tampered.sh"), []byte("#!/bin/bash\necho pwned\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	params := AgentParams{JobID: "test-job", RunID: "run-123", Hook: "post_run", TimeoutSec: 5}
+
+	if _, err := executor.Execute(context.Background(), "trusted.sh", params); err != nil {
+		t.Errorf("Agent with matching checksum should run: %v", err)
+	}
+	if _, err := executor.Execute(context.Background(), "unlisted.sh", params); err != nil {
+		t.Errorf("Agent without a configured checksum should run: %v", err)
+	}
+
+	_, err := executor.Execute(context.Background(), "tampered.sh", params)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Expected checksum mismatch error, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+//go:build unix
+
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestAgentExecutor_TimeoutKillsProcessGroup(t *testing.T) {
+	agentsDir := t.TempDir()
+	pidFile := filepath.Join(agentsDir, "child.pid")
+
+	// The agent leaves a background child holding its stdout open
+	script := "#!/bin/bash\nsleep 30 &\necho $! > \"$(dirname \"$0\")/child.pid\"\nwait\n"
+	if err := os.WriteFile(filepath.Join(agentsDir, "spawner.sh"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := New(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError})))
+	if err := executor.Discover([]string{agentsDir}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err := executor.Execute(context.Background(), "spawner.sh", AgentParams{
+		JobID:      "test-job",
+		RunID:      "run-123",
+		Hook:       "test_hook",
+		TimeoutSec: 1,
+	})
+	if err == nil {
+		t.Fatal("Expected error for agent exceeding its timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("Execute took %s; the child kept the agent's output open", elapsed)
+	}
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := strconv.Atoi(string(bytes.TrimSpace(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for processAlive(pid) {
+		if time.Now().After(deadline) {
+			syscall.Kill(pid, syscall.SIGKILL)
+			t.Fatalf("child process %d survived the agent timeout", pid)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// processAlive reports whether pid exists and is not a zombie waiting to be
+// reaped by init.
+func processAlive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err != nil {
+		return false
+	}
+	stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return true
+	}
+	// The state follows the parenthesised command name
+	if i := bytes.LastIndexByte(stat, ')'); i >= 0 && i+2 < len(stat) {
+		return stat[i+2] != 'Z'
+	}
+	return true
+}
@@ -0,0 +1,10 @@
+package plugins
+
+import "os/exec"
+
+// configureProcessGroup bounds how long a killed command's output pipes may
+// stay open. Windows has no process groups to signal, so cancellation kills
+// only the direct child.
+func configureProcessGroup(cmd *exec.Cmd) {
+	cmd.WaitDelay = processWaitDelay
+}
@@ -3,10 +3,16 @@ package plugins
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 
 	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // HookType represents different types of job lifecycle hooks
@@ -24,6 +30,10 @@ const (
 
 	// OnError is executed only when a job fails
 	OnError HookType = "on_error"
+
+	// OnCircuitOpen is executed once when a job is paused after too many
+	// consecutive failures
+	OnCircuitOpen HookType = "on_circuit_open"
 )
 
 // String returns the string representation of HookType
@@ -31,135 +41,203 @@ func (h HookType) String() string {
 	return string(h)
 }
 
-// ExecuteHooks runs all hooks of a given type for a job
+// HookOutputs maps an agent name to the JSON object it printed on stdout.
+type HookOutputs map[string]map[string]interface{}
+
+// ReceiveStdinKey is the `with:` option an agent sets to true to be sent the
+// hook's Stdin; agents without it get no stdin.
+const ReceiveStdinKey = "receive_stdin"
+
+// HookOptions controls how a hook list is executed.
+type HookOptions struct {
+	// FailOnError stops at the first failing agent: agents not yet started
+	// are skipped and, when running in parallel, running ones are cancelled.
+	FailOnError bool
+
+	// Concurrency is how many agents of the list may run at once. Values
+	// below 2 run the agents one after another in list order.
+	Concurrency int
+}
+
+// ExecuteHooks runs all hooks of a given type for a job. It returns the JSON
+// output of every agent that ran successfully, even when an error is also
+// returned; if the same agent runs twice in one hook list, the later output
+// wins. With FailOnError the first failure is returned, otherwise every
+// failure is joined into the error.
 func ExecuteHooks(
 	ctx context.Context,
 	executor *AgentExecutor,
 	hooks []config.Agent,
 	params AgentParams,
-	failOnError bool,
-) error {
+	opts HookOptions,
+) (HookOutputs, error) {
 	if len(hooks) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	executor.logger.Debug("executing hooks",
 		slog.String("hook_type", params.Hook),
 		slog.Int("count", len(hooks)),
+		slog.Int("concurrency", max(opts.Concurrency, 1)),
 		slog.String("job_id", params.JobID),
 		slog.String("run_id", params.RunID))
 
-	var firstError error
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	for i, hook := range hooks {
-		// Prepare config JSON
-		configJSON, err := json.Marshal(hook.With)
-		if err != nil {
-			executor.logger.Error("failed to marshal hook config",
-				slog.String("agent", hook.Agent),
-				slog.String("hook_type", params.Hook),
-				slog.String("error", err.Error()))
+	type hookResult struct {
+		output map[string]interface{}
+		err    error
+	}
+	results := make([]hookResult, len(hooks))
 
-			if failOnError {
-				return fmt.Errorf("failed to marshal config for agent %s: %w", hook.Agent, err)
-			}
+	var (
+		wg       sync.WaitGroup
+		failOnce sync.Once
+		failErr  error
+	)
+	sem := make(chan struct{}, max(opts.Concurrency, 1))
 
-			if firstError == nil {
-				firstError = err
-			}
-			continue
+	for i, hook := range hooks {
+		sem <- struct{}{}
+		if opts.FailOnError && ctx.Err() != nil {
+			<-sem
+			break
 		}
 
-		// Update params with hook-specific config
-		hookParams := params
-		hookParams.ConfigJSON = string(configJSON)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
 
-		// Execute the agent
-		result, err := executor.Execute(ctx, hook.Agent, hookParams)
-		if err != nil {
-			executor.logger.Error("hook execution failed",
-				slog.String("agent", hook.Agent),
-				slog.String("hook_type", params.Hook),
-				slog.Int("hook_index", i),
-				slog.String("job_id", params.JobID),
-				slog.String("run_id", params.RunID),
-				slog.String("error", err.Error()))
-
-			if failOnError {
-				return fmt.Errorf("hook %s (agent: %s) failed: %w", params.Hook, hook.Agent, err)
+			output, err := runHook(ctx, executor, i, hook, params)
+			results[i] = hookResult{output: output, err: err}
+			if err != nil && opts.FailOnError {
+				failOnce.Do(func() {
+					failErr = err
+					cancel()
+				})
 			}
+		}()
+	}
+	wg.Wait()
 
-			if firstError == nil {
-				firstError = err
+	var outputs HookOutputs
+	var errs []error
+	for i, result := range results {
+		if result.output != nil {
+			if outputs == nil {
+				outputs = make(HookOutputs)
 			}
-			continue
+			outputs[hooks[i].Agent] = result.output
+		}
+		if result.err != nil {
+			errs = append(errs, result.err)
 		}
+	}
 
-		// Check exit code
-		if result.ExitCode != 0 {
-			executor.logger.Warn("hook returned non-zero exit code",
-				slog.String("agent", hook.Agent),
-				slog.String("hook_type", params.Hook),
-				slog.Int("hook_index", i),
-				slog.Int("exit_code", result.ExitCode),
-				slog.String("job_id", params.JobID),
-				slog.String("run_id", params.RunID),
-				slog.String("stderr", result.Stderr))
-
-			if failOnError {
-				return fmt.Errorf("hook %s (agent: %s) exited with code %d",
-					params.Hook, hook.Agent, result.ExitCode)
-			}
+	if opts.FailOnError {
+		return outputs, failErr
+	}
+	return outputs, errors.Join(errs...)
+}
 
-			if firstError == nil {
-				firstError = fmt.Errorf("agent %s exited with code %d", hook.Agent, result.ExitCode)
-			}
-			continue
+// runHook executes a single agent of a hook list and returns its JSON output.
+// A non-zero exit code is reported as an error.
+func runHook(ctx context.Context, executor *AgentExecutor, i int, hook config.Agent, params AgentParams) (output map[string]interface{}, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "hook.run", trace.WithAttributes(
+		attribute.String("hook.type", params.Hook),
+		attribute.String("hook.agent", hook.Agent),
+		attribute.Int("hook.index", i),
+	))
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
 		}
+		span.End()
+	}()
 
-		// Log successful execution
-		executor.logger.Info("hook executed successfully",
+	// Prepare config JSON
+	configJSON, err := json.Marshal(hook.With)
+	if err != nil {
+		executor.logger.Error("failed to marshal hook config",
+			slog.String("agent", hook.Agent),
+			slog.String("hook_type", params.Hook),
+			slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to marshal config for agent %s: %w", hook.Agent, err)
+	}
+
+	// Update params with hook-specific config
+	hookParams := params
+	hookParams.ConfigJSON = string(configJSON)
+	if receive, _ := hook.With[ReceiveStdinKey].(bool); !receive {
+		hookParams.Stdin = ""
+	}
+
+	// Execute the agent
+	result, err := executor.Execute(ctx, hook.Agent, hookParams)
+	if err != nil {
+		executor.logger.Error("hook execution failed",
 			slog.String("agent", hook.Agent),
 			slog.String("hook_type", params.Hook),
 			slog.Int("hook_index", i),
 			slog.String("job_id", params.JobID),
 			slog.String("run_id", params.RunID),
-			slog.Duration("duration", result.Duration))
+			slog.String("error", err.Error()))
+		return nil, fmt.Errorf("hook %s (agent: %s) failed: %w", params.Hook, hook.Agent, err)
+	}
 
-		// Log JSON output if present
-		if result.JSONOutput != nil {
-			executor.logger.Debug("hook output",
-				slog.String("agent", hook.Agent),
-				slog.Any("output", result.JSONOutput))
-		}
+	span.SetAttributes(attribute.Int("hook.exit_code", result.ExitCode))
+
+	// Check exit code
+	if result.ExitCode != 0 {
+		executor.logger.Warn("hook returned non-zero exit code",
+			slog.String("agent", hook.Agent),
+			slog.String("hook_type", params.Hook),
+			slog.Int("hook_index", i),
+			slog.Int("exit_code", result.ExitCode),
+			slog.String("job_id", params.JobID),
+			slog.String("run_id", params.RunID),
+			slog.String("stderr", result.Stderr))
+		return nil, fmt.Errorf("hook %s (agent: %s) exited with code %d",
+			params.Hook, hook.Agent, result.ExitCode)
 	}
 
-	return firstError
+	// Log successful execution
+	executor.logger.Info("hook executed successfully",
+		slog.String("agent", hook.Agent),
+		slog.String("hook_type", params.Hook),
+		slog.Int("hook_index", i),
+		slog.String("job_id", params.JobID),
+		slog.String("run_id", params.RunID),
+		slog.Duration("duration", result.Duration))
+
+	if result.JSONOutput != nil {
+		executor.logger.Debug("hook output",
+			slog.String("agent", hook.Agent),
+			slog.Any("output", result.JSONOutput))
+	}
+
+	return result.JSONOutput, nil
 }
 
-// ValidateHooks validates all hooks in a job configuration
+// ValidateHooks validates all hooks in a job configuration. Every invalid
+// agent is reported, joined into one error in hook order.
 func ValidateHooks(
 	executor *AgentExecutor,
 	hooks config.Hooks,
 	allowedAgents []string,
 ) error {
-	// Validate all hook types
-	hookLists := map[string][]config.Agent{
-		"pre_run":    hooks.PreRun,
-		"post_run":   hooks.PostRun,
-		"on_success": hooks.OnSuccess,
-		"on_error":   hooks.OnError,
-	}
-
-	for hookType, hookList := range hookLists {
-		for i, hook := range hookList {
+	var errs []error
+	for _, hookType := range []HookType{PreRun, PostRun, OnSuccess, OnError, OnCircuitOpen} {
+		for i, hook := range GetHooksByType(hooks, hookType) {
 			if err := executor.ValidateAgent(hook.Agent, allowedAgents); err != nil {
-				return fmt.Errorf("invalid agent in %s hook #%d: %w", hookType, i, err)
+				errs = append(errs, fmt.Errorf("invalid agent in %s hook #%d: %w", hookType, i, err))
 			}
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // GetHooksByType returns hooks for a specific hook type from a Hooks configuration
@@ -173,6 +251,8 @@ func GetHooksByType(hooks config.Hooks, hookType HookType) []config.Agent {
 		return hooks.OnSuccess
 	case OnError:
 		return hooks.OnError
+	case OnCircuitOpen:
+		return hooks.OnCircuitOpen
 	default:
 		return nil
 	}
@@ -5,7 +5,9 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/caevv/jobster/internal/config"
 )
@@ -83,10 +85,13 @@ exit 1
 			TimeoutSec: 5,
 		}
 
-		err := ExecuteHooks(context.Background(), executor, hooks, params, false)
+		outputs, err := ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{})
 		if err != nil {
 			t.Errorf("ExecuteHooks should not error with failOnError=false: %v", err)
 		}
+		if got := outputs["success.sh"]["status"]; got != "ok" {
+			t.Errorf("Expected JSON output status=ok for success.sh, got %v", got)
+		}
 	})
 
 	t.Run("multiple hooks", func(t *testing.T) {
@@ -102,7 +107,7 @@ exit 1
 			TimeoutSec: 5,
 		}
 
-		err := ExecuteHooks(context.Background(), executor, hooks, params, false)
+		_, err := ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{})
 		if err != nil {
 			t.Errorf("ExecuteHooks should not error: %v", err)
 		}
@@ -120,7 +125,7 @@ exit 1
 			TimeoutSec: 5,
 		}
 
-		err := ExecuteHooks(context.Background(), executor, hooks, params, false)
+		_, err := ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{})
 		if err == nil {
 			t.Error("Expected error to be returned even with failOnError=false")
 		}
@@ -138,7 +143,7 @@ exit 1
 			TimeoutSec: 5,
 		}
 
-		err := ExecuteHooks(context.Background(), executor, hooks, params, true)
+		_, err := ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{FailOnError: true})
 		if err == nil {
 			t.Error("Expected error with failOnError=true")
 		}
@@ -159,13 +164,16 @@ exit 1
 		}
 
 		// With failOnError=false, should continue and return first error
-		err := ExecuteHooks(context.Background(), executor, hooks, params, false)
+		outputs, err := ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{})
 		if err == nil {
 			t.Error("Expected error to be returned")
 		}
+		if _, ok := outputs["success.sh"]; !ok {
+			t.Error("Expected output of successful agents alongside the error")
+		}
 
 		// With failOnError=true, should stop at first failure
-		err = ExecuteHooks(context.Background(), executor, hooks, params, true)
+		_, err = ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{FailOnError: true})
 		if err == nil {
 			t.Error("Expected error with failOnError=true")
 		}
@@ -179,13 +187,107 @@ exit 1
 			TimeoutSec: 5,
 		}
 
-		err := ExecuteHooks(context.Background(), executor, []config.Agent{}, params, false)
+		_, err := ExecuteHooks(context.Background(), executor, []config.Agent{}, params, HookOptions{})
 		if err != nil {
 			t.Errorf("Empty hooks should not error: %v", err)
 		}
 	})
 }
 
+func TestExecuteHooks_Parallel(t *testing.T) {
+	agentsDir := t.TempDir()
+	agents := map[string]string{
+		"slow-a.sh": "#!/bin/bash\nsleep 1\necho '{\"agent\":\"a\"}'\n",
+		"slow-b.sh": "#!/bin/bash\nsleep 1\necho '{\"agent\":\"b\"}'\n",
+		"slow-c.sh": "#!/bin/bash\nsleep 1\necho '{\"agent\":\"c\"}'\n",
+		"fail-a.sh": "#!/bin/bash\nsleep 0.2\nexit 1\n",
+		"fail-b.sh": "#!/bin/bash\nsleep 0.2\nexit 2\n",
+		"hang.sh":   "#!/bin/bash\nsleep 30\n",
+	}
+	for name, script := range agents {
+		if err := os.WriteFile(filepath.Join(agentsDir, name), []byte(script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	executor := New(logger)
+	if err := executor.Discover([]string{agentsDir}); err != nil {
+		t.Fatal(err)
+	}
+
+	params := AgentParams{
+		JobID:      "test-job",
+		RunID:      "run-123",
+		Hook:       OnSuccess.String(),
+		TimeoutSec: 60,
+	}
+
+	t.Run("runs agents concurrently", func(t *testing.T) {
+		hooks := []config.Agent{{Agent: "slow-a.sh"}, {Agent: "slow-b.sh"}, {Agent: "slow-c.sh"}}
+
+		start := time.Now()
+		outputs, err := ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{Concurrency: 3})
+		elapsed := time.Since(start)
+		if err != nil {
+			t.Fatalf("ExecuteHooks should not error: %v", err)
+		}
+		if elapsed >= 2*time.Second {
+			t.Errorf("Three 1s agents took %s with concurrency 3", elapsed)
+		}
+		if len(outputs) != 3 {
+			t.Errorf("Expected output from all 3 agents, got %v", outputs)
+		}
+	})
+
+	t.Run("limits concurrency", func(t *testing.T) {
+		hooks := []config.Agent{{Agent: "slow-a.sh"}, {Agent: "slow-b.sh"}, {Agent: "slow-c.sh"}}
+
+		start := time.Now()
+		if _, err := ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{Concurrency: 2}); err != nil {
+			t.Fatalf("ExecuteHooks should not error: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < 2*time.Second {
+			t.Errorf("Three 1s agents took only %s with concurrency 2", elapsed)
+		}
+	})
+
+	t.Run("surfaces all errors", func(t *testing.T) {
+		hooks := []config.Agent{{Agent: "fail-a.sh"}, {Agent: "slow-a.sh"}, {Agent: "fail-b.sh"}}
+
+		outputs, err := ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{Concurrency: 3})
+		if err == nil {
+			t.Fatal("Expected error from failing agents")
+		}
+		for _, want := range []string{"fail-a.sh", "fail-b.sh"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected error to mention %s, got: %v", want, err)
+			}
+		}
+		if _, ok := outputs["slow-a.sh"]; !ok {
+			t.Error("Expected output of the successful agent alongside the errors")
+		}
+	})
+
+	t.Run("fail_on_error cancels remaining agents", func(t *testing.T) {
+		hooks := []config.Agent{{Agent: "hang.sh"}, {Agent: "fail-a.sh"}, {Agent: "hang.sh"}}
+
+		start := time.Now()
+		_, err := ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{FailOnError: true, Concurrency: 2})
+		if err == nil {
+			t.Fatal("Expected error with FailOnError")
+		}
+		if !strings.Contains(err.Error(), "fail-a.sh") {
+			t.Errorf("Expected the first failure to be returned, got: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("ExecuteHooks took %s; running agents were not cancelled", elapsed)
+		}
+	})
+}
+
 func TestValidateHooks(t *testing.T) {
 	// Create temporary directory for test agents
 	tempDir := t.TempDir()
@@ -264,11 +366,17 @@ func TestValidateHooks(t *testing.T) {
 		hooks := config.Hooks{
 			PreRun:    []config.Agent{{Agent: "agent1.sh"}},
 			OnSuccess: []config.Agent{{Agent: "nonexistent.sh"}},
+			OnError:   []config.Agent{{Agent: "agent2.sh"}, {Agent: "typo.sh"}},
 		}
 
 		err := ValidateHooks(executor, hooks, nil)
 		if err == nil {
-			t.Error("Expected error for invalid agent")
+			t.Fatal("Expected error for invalid agent")
+		}
+		want := "invalid agent in on_success hook #0: agent not found: nonexistent.sh\n" +
+			"invalid agent in on_error hook #1: agent not found: typo.sh"
+		if err.Error() != want {
+			t.Errorf("Expected every invalid agent to be reported:\nwant: %s\ngot:  %s", want, err)
 		}
 	})
 }
@@ -357,7 +465,7 @@ echo "$CONFIG_JSON"
 		TimeoutSec: 5,
 	}
 
-	err := ExecuteHooks(context.Background(), executor, hooks, params, false)
+	_, err := ExecuteHooks(context.Background(), executor, hooks, params, HookOptions{})
 	if err != nil {
 		t.Errorf("ExecuteHooks should not error: %v", err)
 	}
@@ -0,0 +1,174 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+)
+
+// failingRunner fails every run unless pass is set, and reports
+// CircuitOpened calls on opened.
+type failingRunner struct {
+	runs   atomic.Int64
+	pass   atomic.Bool
+	opened chan int
+}
+
+func (r *failingRunner) Run(context.Context, *config.Job) error {
+	r.runs.Add(1)
+	if r.pass.Load() {
+		return nil
+	}
+	return errors.New("boom")
+}
+
+func (r *failingRunner) CircuitOpened(_ context.Context, _ *config.Job, _ string, failures int) {
+	r.opened <- failures
+}
+
+// runAndWait triggers jobID and waits until the scheduler has recorded the
+// result of that run.
+func runAndWait(t *testing.T, sched *Scheduler, runner *failingRunner, jobID string) {
+	t.Helper()
+	want := runner.runs.Load() + 1
+	if _, err := sched.RunJobNow(jobID); err != nil {
+		t.Fatalf("RunJobNow() error = %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for runner.runs.Load() < want || sched.InFlight() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("run did not finish")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func newCircuitJob(t *testing.T, sched *Scheduler, runner *failingRunner) {
+	t.Helper()
+	job := &config.Job{
+		ID:       "flaky",
+		Schedule: "@every 1h",
+		Command:  config.NewCommandSpec("false"),
+	}
+	if err := sched.AddJob(job, runner); err != nil {
+		t.Fatalf("AddJob() error = %v", err)
+	}
+	if err := sched.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+}
+
+func TestScheduler_CircuitBreakerPausesFailingJob(t *testing.T) {
+	sched := New(context.Background(), quietLogger(), WithCircuitBreaker(3, 0))
+	runner := &failingRunner{opened: make(chan int, 1)}
+	newCircuitJob(t, sched, runner)
+	defer sched.Stop()
+
+	for i := 1; i <= 2; i++ {
+		runAndWait(t, sched, runner, "flaky")
+		stats, _ := sched.GetJobStats("flaky")
+		if stats.Paused || stats.CircuitOpen {
+			t.Fatalf("job paused after %d failures, want 3", i)
+		}
+		if stats.ConsecutiveFailures != i {
+			t.Errorf("ConsecutiveFailures = %d, want %d", stats.ConsecutiveFailures, i)
+		}
+	}
+
+	runAndWait(t, sched, runner, "flaky")
+	select {
+	case failures := <-runner.opened:
+		if failures != 3 {
+			t.Errorf("CircuitOpened failures = %d, want 3", failures)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("CircuitOpened was not called")
+	}
+
+	stats, _ := sched.GetJobStats("flaky")
+	if !stats.Paused || !stats.CircuitOpen {
+		t.Fatalf("stats = %+v, want paused with circuit open", stats)
+	}
+	if !stats.NextRun.IsZero() {
+		t.Errorf("NextRun = %v for a tripped job, want zero", stats.NextRun)
+	}
+
+	// Further manual failures neither re-trip the breaker nor call the hook again.
+	runAndWait(t, sched, runner, "flaky")
+	select {
+	case <-runner.opened:
+		t.Error("CircuitOpened called again for an already open circuit")
+	default:
+	}
+
+	if err := sched.ResumeJob("flaky"); err != nil {
+		t.Fatalf("ResumeJob() error = %v", err)
+	}
+	stats, _ = sched.GetJobStats("flaky")
+	if stats.Paused || stats.CircuitOpen || stats.ConsecutiveFailures != 0 {
+		t.Errorf("stats after resume = %+v, want running with no failures", stats)
+	}
+}
+
+func TestScheduler_CircuitBreakerSuccessResetsCount(t *testing.T) {
+	sched := New(context.Background(), quietLogger(), WithCircuitBreaker(2, 0))
+	runner := &failingRunner{opened: make(chan int, 1)}
+	newCircuitJob(t, sched, runner)
+	defer sched.Stop()
+
+	runAndWait(t, sched, runner, "flaky")
+	runner.pass.Store(true)
+	runAndWait(t, sched, runner, "flaky")
+	runner.pass.Store(false)
+	runAndWait(t, sched, runner, "flaky")
+
+	stats, _ := sched.GetJobStats("flaky")
+	if stats.Paused || stats.ConsecutiveFailures != 1 {
+		t.Errorf("stats = %+v, want unpaused with 1 consecutive failure", stats)
+	}
+}
+
+func TestScheduler_CircuitBreakerCooldown(t *testing.T) {
+	sched := New(context.Background(), quietLogger(), WithCircuitBreaker(1, 100*time.Millisecond))
+	runner := &failingRunner{opened: make(chan int, 1)}
+	newCircuitJob(t, sched, runner)
+	defer sched.Stop()
+
+	runAndWait(t, sched, runner, "flaky")
+	if stats, _ := sched.GetJobStats("flaky"); !stats.CircuitOpen {
+		t.Fatalf("stats = %+v, want circuit open", stats)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		stats, _ := sched.GetJobStats("flaky")
+		if !stats.Paused && !stats.CircuitOpen {
+			if stats.NextRun.IsZero() {
+				t.Error("NextRun not set after the cooldown resumed the job")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("job was not resumed after the cooldown")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestScheduler_CircuitBreakerDisabled(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+	runner := &failingRunner{opened: make(chan int, 1)}
+	newCircuitJob(t, sched, runner)
+	defer sched.Stop()
+
+	for range 5 {
+		runAndWait(t, sched, runner, "flaky")
+	}
+	if stats, _ := sched.GetJobStats("flaky"); stats.Paused || stats.CircuitOpen {
+		t.Errorf("stats = %+v, want job left running without a circuit breaker", stats)
+	}
+}
@@ -0,0 +1,144 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caevv/jobster/internal/config"
+)
+
+// startRecordingRunner records when each run started and the jitter the
+// scheduler reported for it.
+type startRecordingRunner struct {
+	mu      sync.Mutex
+	starts  []time.Time
+	jitters []time.Duration
+}
+
+func (r *startRecordingRunner) Run(ctx context.Context, _ *config.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.starts = append(r.starts, time.Now())
+	r.jitters = append(r.jitters, JitterFromContext(ctx))
+	return nil
+}
+
+func (r *startRecordingRunner) snapshot() ([]time.Time, []time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]time.Time(nil), r.starts...), append([]time.Duration(nil), r.jitters...)
+}
+
+// offsetInSecond returns how far into its wall-clock second t is. @every 1s
+// ticks land on whole seconds, so this is how late a run started.
+func offsetInSecond(t time.Time) time.Duration {
+	return t.Sub(t.Truncate(time.Second))
+}
+
+func runEverySecond(t *testing.T, jitter string, opts ...Option) *startRecordingRunner {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sched := New(ctx, quietLogger(), opts...)
+	runner := &startRecordingRunner{}
+	job := &config.Job{
+		ID:       "jittered",
+		Schedule: "@every 1s",
+		Jitter:   jitter,
+		Command:  config.NewCommandSpec("echo jitter"),
+	}
+	require.NoError(t, sched.AddJob(job, runner))
+	require.NoError(t, sched.Start())
+	time.Sleep(3500 * time.Millisecond)
+	require.NoError(t, sched.Stop())
+	return runner
+}
+
+func TestScheduler_JitterDelaysRuns(t *testing.T) {
+	const bound = 600 * time.Millisecond
+	runner := runEverySecond(t, "600ms")
+
+	starts, jitters := runner.snapshot()
+	require.NotEmpty(t, starts)
+	for i, start := range starts {
+		assert.GreaterOrEqual(t, jitters[i], time.Duration(0))
+		assert.Less(t, jitters[i], bound, "jitter must stay below the configured bound")
+
+		offset := offsetInSecond(start)
+		assert.GreaterOrEqual(t, offset, jitters[i], "run %d started before its jitter elapsed", i)
+		assert.Less(t, offset, jitters[i]+150*time.Millisecond, "run %d started long after its jitter", i)
+	}
+}
+
+func TestScheduler_DefaultJitter(t *testing.T) {
+	runner := runEverySecond(t, "", WithDefaultJitter(600*time.Millisecond))
+
+	_, jitters := runner.snapshot()
+	require.NotEmpty(t, jitters)
+	for _, j := range jitters {
+		assert.Less(t, j, 600*time.Millisecond)
+	}
+
+	// A job's own jitter overrides the default; "0s" turns it off.
+	runner = runEverySecond(t, "0s", WithDefaultJitter(600*time.Millisecond))
+	_, jitters = runner.snapshot()
+	require.NotEmpty(t, jitters)
+	for _, j := range jitters {
+		assert.Zero(t, j)
+	}
+}
+
+func TestScheduler_ZeroJitterRunsOnTime(t *testing.T) {
+	runner := runEverySecond(t, "")
+
+	starts, jitters := runner.snapshot()
+	require.GreaterOrEqual(t, len(starts), 3)
+	for i, start := range starts {
+		assert.Zero(t, jitters[i])
+		assert.Less(t, offsetInSecond(start), 150*time.Millisecond, "run %d was delayed without jitter", i)
+	}
+}
+
+// TestScheduler_StopInterruptsJitter verifies that a run still waiting out
+// its jitter is dropped on Stop instead of holding shutdown up.
+func TestScheduler_StopInterruptsJitter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sched := New(ctx, quietLogger())
+	runner := &startRecordingRunner{}
+	job := &config.Job{
+		ID:       "sleepy",
+		Schedule: "@every 1s",
+		Jitter:   "1h",
+		Command:  config.NewCommandSpec("echo sleepy"),
+	}
+	require.NoError(t, sched.AddJob(job, runner))
+	require.NoError(t, sched.Start())
+	time.Sleep(1500 * time.Millisecond)
+
+	stopStart := time.Now()
+	require.NoError(t, sched.Stop())
+	assert.Less(t, time.Since(stopStart), time.Second, "Stop should not wait for the jitter delay")
+
+	starts, _ := runner.snapshot()
+	assert.Empty(t, starts)
+}
+
+func TestScheduler_InvalidJitter(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+	job := &config.Job{
+		ID:       "bad",
+		Schedule: "@hourly",
+		Jitter:   "soon",
+		Command:  config.NewCommandSpec("echo bad"),
+	}
+	assert.Error(t, ValidateJob(job))
+	assert.Error(t, sched.AddJob(job, &mockJobRunner{}))
+}
@@ -16,6 +16,13 @@ type JobRunner interface {
 	Run(ctx context.Context, job *config.Job) error
 }
 
+// CircuitHandler is implemented by a JobRunner that wants to know when the
+// scheduler's circuit breaker (WithCircuitBreaker) pauses one of its jobs.
+// CircuitOpened is called once, after the failed run that tripped it.
+type CircuitHandler interface {
+	CircuitOpened(ctx context.Context, job *config.Job, runID string, failures int)
+}
+
 // Execution tracks metadata for a single job execution.
 type Execution struct {
 	RunID     string            `json:"run_id"`
@@ -58,6 +65,88 @@ func (e *Execution) Duration() time.Duration {
 	return e.EndTime.Sub(e.StartTime)
 }
 
+// runIDKey is the context key under which the scheduler passes a run ID to a JobRunner.
+type runIDKey struct{}
+
+// ContextWithRunID returns a copy of ctx carrying the run ID that a JobRunner
+// should record the execution under.
+func ContextWithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+// RunIDFromContext returns the run ID attached by ContextWithRunID, or an empty
+// string if the context carries none.
+func RunIDFromContext(ctx context.Context) string {
+	runID, _ := ctx.Value(runIDKey{}).(string)
+	return runID
+}
+
+// Reasons a run was started, passed to the JobRunner with ContextWithTrigger.
+const (
+	TriggerSchedule   = "schedule"   // the job's cron schedule fired
+	TriggerManual     = "manual"     // RunJobNow, e.g. from the API or CLI
+	TriggerDependency = "dependency" // a job it depends on succeeded
+	TriggerCatchUp    = "catchup"    // a run missed while stopped, made up at Start
+	TriggerRestart    = "restart"    // re-launched by the job's restart policy after its previous run ended
+)
+
+// triggerKey is the context key under which the scheduler passes the reason
+// a run was started.
+type triggerKey struct{}
+
+// ContextWithTrigger returns a copy of ctx recording why the run was started
+// (TriggerSchedule, TriggerManual, TriggerDependency, TriggerCatchUp, or
+// TriggerRestart).
+func ContextWithTrigger(ctx context.Context, trigger string) context.Context {
+	return context.WithValue(ctx, triggerKey{}, trigger)
+}
+
+// TriggerFromContext returns the reason attached by ContextWithTrigger, or
+// an empty string if the context carries none.
+func TriggerFromContext(ctx context.Context) string {
+	trigger, _ := ctx.Value(triggerKey{}).(string)
+	return trigger
+}
+
+// activeKey is the context key under which the scheduler passes a check
+// that the job being run is still scheduled.
+type activeKey struct{}
+
+// ContextWithJobActive returns a copy of ctx carrying active, which reports
+// whether the job is still registered with the same definition and neither
+// paused nor disabled.
+func ContextWithJobActive(ctx context.Context, active func() bool) context.Context {
+	return context.WithValue(ctx, activeKey{}, active)
+}
+
+// JobActiveFromContext runs the check attached by ContextWithJobActive. A
+// context without one, as for runs started outside a scheduler, reports
+// true.
+func JobActiveFromContext(ctx context.Context) bool {
+	active, _ := ctx.Value(activeKey{}).(func() bool)
+	return active == nil || active()
+}
+
+// jitterKey is the context key under which the scheduler passes the jitter
+// delay applied before a scheduled run.
+type jitterKey struct{}
+
+// ContextWithJitter returns a copy of ctx recording that the run was delayed
+// by d before starting.
+func ContextWithJitter(ctx context.Context, d time.Duration) context.Context {
+	if d <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, jitterKey{}, d)
+}
+
+// JitterFromContext returns the delay attached by ContextWithJitter, or zero
+// if the run was not delayed.
+func JitterFromContext(ctx context.Context) time.Duration {
+	d, _ := ctx.Value(jitterKey{}).(time.Duration)
+	return d
+}
+
 // GenerateRunID generates a unique UUID for a job run.
 func GenerateRunID() string {
 	return uuid.New().String()
@@ -0,0 +1,326 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+)
+
+// runIDRecordingRunner captures the run ID the scheduler passes through the context.
+type runIDRecordingRunner struct {
+	runIDs chan string
+}
+
+func (r *runIDRecordingRunner) Run(ctx context.Context, _ *config.Job) error {
+	r.runIDs <- RunIDFromContext(ctx)
+	return nil
+}
+
+func TestScheduler_RunJobNow(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+
+	runner := &runIDRecordingRunner{runIDs: make(chan string, 1)}
+	job := &config.Job{
+		ID:       "manual",
+		Schedule: "@every 1h",
+		Command:  config.NewCommandSpec("echo manual"),
+	}
+	if err := sched.AddJob(job, runner); err != nil {
+		t.Fatalf("AddJob() error = %v", err)
+	}
+	if err := sched.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer sched.Stop()
+
+	before, _ := sched.GetJobStats("manual")
+
+	runID, err := sched.RunJobNow("manual")
+	if err != nil {
+		t.Fatalf("RunJobNow() error = %v", err)
+	}
+	if runID == "" {
+		t.Fatal("RunJobNow() returned an empty run ID")
+	}
+
+	select {
+	case got := <-runner.runIDs:
+		if got != runID {
+			t.Errorf("runner saw run ID %q, want %q", got, runID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("job was not run after RunJobNow()")
+	}
+
+	after, _ := sched.GetJobStats("manual")
+	if after.RunCount != 1 {
+		t.Errorf("RunCount = %d, want 1", after.RunCount)
+	}
+	if after.LastRun.IsZero() {
+		t.Error("LastRun not set after manual trigger")
+	}
+	if !after.NextRun.Equal(before.NextRun) {
+		t.Errorf("NextRun changed from %v to %v after manual trigger", before.NextRun, after.NextRun)
+	}
+}
+
+func TestScheduler_RunJobNow_UnknownJob(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+
+	_, err := sched.RunJobNow("missing")
+	if !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("RunJobNow() error = %v, want ErrJobNotFound", err)
+	}
+}
+
+func TestScheduler_RunJobNow_AfterStop(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+
+	job := &config.Job{
+		ID:       "stopped",
+		Schedule: "@every 1h",
+		Command:  config.NewCommandSpec("echo stopped"),
+	}
+	if err := sched.AddJob(job, &mockJobRunner{}); err != nil {
+		t.Fatalf("AddJob() error = %v", err)
+	}
+	sched.Start()
+	sched.Stop()
+
+	if _, err := sched.RunJobNow("stopped"); !errors.Is(err, ErrSchedulerStopped) {
+		t.Fatalf("RunJobNow() after Stop error = %v, want ErrSchedulerStopped", err)
+	}
+}
+
+func TestScheduler_Running(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+
+	if sched.Running() {
+		t.Error("Running() = true before Start")
+	}
+	sched.Start()
+	if !sched.Running() {
+		t.Error("Running() = false after Start")
+	}
+	sched.Stop()
+	if sched.Running() {
+		t.Error("Running() = true after Stop")
+	}
+}
+
+func TestScheduler_StopWaitsForManualRuns(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+
+	runner := &mockJobRunner{runDelay: 300 * time.Millisecond}
+	job := &config.Job{
+		ID:       "slow-manual",
+		Schedule: "@every 1h",
+		Command:  config.NewCommandSpec("sleep"),
+	}
+	if err := sched.AddJob(job, runner); err != nil {
+		t.Fatalf("AddJob() error = %v", err)
+	}
+	sched.Start()
+
+	if _, err := sched.RunJobNow("slow-manual"); err != nil {
+		t.Fatalf("RunJobNow() error = %v", err)
+	}
+
+	start := time.Now()
+	if err := sched.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Stop() returned after %v, before the manual run finished", elapsed)
+	}
+	if runner.runCount.Load() != 1 {
+		t.Errorf("runCount = %d, want 1", runner.runCount.Load())
+	}
+}
+
+func TestScheduler_PauseResumeJob(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+
+	runner := &mockJobRunner{}
+	job := &config.Job{
+		ID:       "pausable",
+		Schedule: "@every 1s",
+		Command:  config.NewCommandSpec("echo pausable"),
+	}
+	if err := sched.AddJob(job, runner); err != nil {
+		t.Fatalf("AddJob() error = %v", err)
+	}
+	if err := sched.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer sched.Stop()
+
+	if err := sched.PauseJob("pausable"); err != nil {
+		t.Fatalf("PauseJob() error = %v", err)
+	}
+	if err := sched.PauseJob("pausable"); err == nil {
+		t.Error("PauseJob() on a paused job expected error, got nil")
+	}
+
+	// Paused jobs stay registered but report no next run
+	if _, exists := sched.GetJob("pausable"); !exists {
+		t.Fatal("paused job missing from GetJob()")
+	}
+	stats, _ := sched.GetJobStats("pausable")
+	if !stats.Paused {
+		t.Error("GetJobStats().Paused = false, want true")
+	}
+	if !stats.NextRun.IsZero() {
+		t.Errorf("GetJobStats().NextRun = %v, want zero while paused", stats.NextRun)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	if got := runner.runCount.Load(); got != 0 {
+		t.Fatalf("paused job ran %d time(s)", got)
+	}
+
+	if err := sched.ResumeJob("pausable"); err != nil {
+		t.Fatalf("ResumeJob() error = %v", err)
+	}
+	if err := sched.ResumeJob("pausable"); err == nil {
+		t.Error("ResumeJob() on a running job expected error, got nil")
+	}
+
+	stats, _ = sched.GetJobStats("pausable")
+	if stats.Paused || stats.NextRun.IsZero() {
+		t.Errorf("after resume: Paused = %v, NextRun = %v", stats.Paused, stats.NextRun)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	if runner.runCount.Load() == 0 {
+		t.Error("resumed job did not run")
+	}
+
+	if err := sched.PauseJob("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("PauseJob(missing) error = %v, want ErrJobNotFound", err)
+	}
+}
+
+func TestScheduler_DisabledJob(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+
+	runner := &mockJobRunner{}
+	job := &config.Job{
+		ID:       "disabled",
+		Schedule: "@every 1s",
+		Command:  config.NewCommandSpec("echo disabled"),
+	}
+	job.SetEnabled(false)
+	if err := sched.AddJob(job, runner); err != nil {
+		t.Fatalf("AddJob() error = %v", err)
+	}
+	if err := sched.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer sched.Stop()
+
+	// Disabled jobs are tracked but have no next run
+	if len(sched.ListJobs()) != 1 {
+		t.Fatalf("ListJobs() = %d jobs, want 1", len(sched.ListJobs()))
+	}
+	stats, ok := sched.GetJobStats("disabled")
+	if !ok || !stats.Disabled {
+		t.Fatalf("GetJobStats() = %+v, want Disabled", stats)
+	}
+	if !stats.NextRun.IsZero() {
+		t.Errorf("GetJobStats().NextRun = %v, want zero", stats.NextRun)
+	}
+	if times, _ := sched.NextRuns("disabled", 3); len(times) != 0 {
+		t.Errorf("NextRuns() = %v, want none", times)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	if got := runner.runCount.Load(); got != 0 {
+		t.Fatalf("disabled job ran %d time(s)", got)
+	}
+
+	if err := sched.ResumeJob("disabled"); !errors.Is(err, ErrJobDisabled) {
+		t.Errorf("ResumeJob() error = %v, want ErrJobDisabled", err)
+	}
+	if err := sched.PauseJob("disabled"); !errors.Is(err, ErrJobDisabled) {
+		t.Errorf("PauseJob() error = %v, want ErrJobDisabled", err)
+	}
+	if _, err := sched.RunDependentJob("disabled"); !errors.Is(err, ErrJobDisabled) {
+		t.Errorf("RunDependentJob() error = %v, want ErrJobDisabled", err)
+	}
+
+	// An explicit trigger still runs it
+	if _, err := sched.RunJobNow("disabled"); err != nil {
+		t.Fatalf("RunJobNow() error = %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for runner.runCount.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if runner.runCount.Load() != 1 {
+		t.Errorf("run count after RunJobNow() = %d, want 1", runner.runCount.Load())
+	}
+}
+
+func TestScheduler_DisabledJobInvalidSchedule(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+
+	job := &config.Job{ID: "bad", Schedule: "not a schedule", Command: config.NewCommandSpec("true")}
+	job.SetEnabled(false)
+	if err := sched.AddJob(job, &mockJobRunner{}); err == nil {
+		t.Error("AddJob() with an invalid schedule expected error even when disabled")
+	}
+}
+
+func TestScheduler_CatchUp(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		catchUp bool
+		last    time.Time
+		hasRun  bool
+		wantRun bool
+	}{
+		{name: "missed fire is caught up", catchUp: true, last: now.Add(-3 * time.Hour), hasRun: true, wantRun: true},
+		{name: "no missed fire", catchUp: true, last: now.Add(-10 * time.Minute), hasRun: true, wantRun: false},
+		{name: "never ran", catchUp: true, hasRun: false, wantRun: false},
+		{name: "catch_up disabled", catchUp: false, last: now.Add(-3 * time.Hour), hasRun: true, wantRun: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := func(string) (time.Time, bool, error) {
+				return tt.last, tt.hasRun, nil
+			}
+			sched := New(context.Background(), quietLogger(), WithLastRunLookup(lookup))
+
+			runner := &mockJobRunner{}
+			job := &config.Job{
+				ID:       "hourly",
+				Schedule: "@every 1h",
+				Command:  config.NewCommandSpec("echo hourly"),
+				CatchUp:  tt.catchUp,
+			}
+			if err := sched.AddJob(job, runner); err != nil {
+				t.Fatalf("AddJob() error = %v", err)
+			}
+			if err := sched.Start(); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			// Stop waits for the catch-up run to finish
+			sched.Stop()
+
+			// At most one catch-up run, even when several fires were missed
+			want := int32(0)
+			if tt.wantRun {
+				want = 1
+			}
+			if got := runner.runCount.Load(); got != want {
+				t.Errorf("runCount = %d, want %d", got, want)
+			}
+		})
+	}
+}
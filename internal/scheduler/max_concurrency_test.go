@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caevv/jobster/internal/config"
+)
+
+// TestScheduler_MaxConcurrentJobs verifies that with a limit of 1, several
+// jobs firing every second never execute at the same time: runs wait for the
+// single slot instead of overlapping.
+func TestScheduler_MaxConcurrentJobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sched := New(ctx, quietLogger(), WithMaxConcurrentJobs(1, PolicyWait))
+	assert.Equal(t, 1, sched.MaxConcurrentJobs())
+
+	runner := &concurrencyTrackingRunner{runDelay: 300 * time.Millisecond}
+	for i := range 3 {
+		job := &config.Job{
+			ID:       fmt.Sprintf("fast-%d", i),
+			Schedule: "@every 1s",
+			Command:  config.NewCommandSpec("echo fast"),
+		}
+		require.NoError(t, sched.AddJob(job, runner))
+	}
+	require.NoError(t, sched.Start())
+
+	time.Sleep(2500 * time.Millisecond)
+	require.NoError(t, sched.Stop())
+
+	runCount, maxObserved := runner.snapshot()
+	t.Logf("runs started: %d, peak concurrency: %d", runCount, maxObserved)
+
+	assert.GreaterOrEqual(t, runCount, 3, "waiting runs should still execute")
+	assert.Equal(t, 1, maxObserved, "runs must not exceed max_concurrent_jobs")
+	assert.Equal(t, 0, sched.InFlight())
+}
+
+// TestScheduler_MaxConcurrentJobsSkip verifies that with the skip policy a
+// scheduled run that finds every slot taken is dropped rather than queued.
+func TestScheduler_MaxConcurrentJobsSkip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sched := New(ctx, quietLogger(), WithMaxConcurrentJobs(1, PolicySkip))
+
+	// Each run outlasts the 1s interval, so the two jobs always collide and
+	// only one of them can hold the slot at each tick.
+	runner := &concurrencyTrackingRunner{runDelay: 1500 * time.Millisecond}
+	for i := range 2 {
+		job := &config.Job{
+			ID:       fmt.Sprintf("slow-%d", i),
+			Schedule: "@every 1s",
+			Command:  config.NewCommandSpec("echo slow"),
+		}
+		require.NoError(t, sched.AddJob(job, runner))
+	}
+	require.NoError(t, sched.Start())
+
+	time.Sleep(1500 * time.Millisecond)
+	assert.Equal(t, 1, sched.InFlight())
+
+	require.NoError(t, sched.Stop())
+
+	runCount, maxObserved := runner.snapshot()
+	t.Logf("runs started: %d, peak concurrency: %d", runCount, maxObserved)
+
+	assert.Equal(t, 1, runCount, "the colliding run should have been skipped")
+	assert.Equal(t, 1, maxObserved)
+}
+
+// TestScheduler_InFlightUnlimited verifies that without a limit InFlight
+// still counts running jobs and MaxConcurrentJobs reports 0.
+func TestScheduler_InFlightUnlimited(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sched := New(ctx, quietLogger())
+	assert.Equal(t, 0, sched.MaxConcurrentJobs())
+
+	runner := &ctxAwareRunner{started: make(chan struct{}), runDelay: time.Second}
+	job := &config.Job{
+		ID:       "manual",
+		Schedule: "@daily",
+		Command:  config.NewCommandSpec("echo manual"),
+	}
+	require.NoError(t, sched.AddJob(job, runner))
+	require.NoError(t, sched.Start())
+
+	_, err := sched.RunJobNow("manual")
+	require.NoError(t, err)
+	<-runner.started
+	assert.Equal(t, 1, sched.InFlight())
+
+	require.NoError(t, sched.Stop())
+	assert.Equal(t, 0, sched.InFlight())
+}
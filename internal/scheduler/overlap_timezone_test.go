@@ -1,12 +1,14 @@
 package scheduler
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"log/slog"
 	"sync"
 	"testing"
 	"time"
+	_ "time/tzdata" // resolve IANA zone names regardless of the host
 
 	"github.com/caevv/jobster/internal/config"
 	"github.com/stretchr/testify/assert"
@@ -90,6 +92,44 @@ func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
 	assert.Equal(t, 1, maxObserved, "overlapping runs of the same job must be skipped")
 }
 
+// TestScheduler_SkipsOverlappingRunsAfterResume verifies that pausing and
+// resuming a job while a run is in flight does not let the resumed schedule
+// start a second run alongside it.
+func TestScheduler_SkipsOverlappingRunsAfterResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sched := New(ctx, quietLogger())
+
+	runner := &concurrencyTrackingRunner{runDelay: 3 * time.Second}
+	job := &config.Job{
+		ID:       "slow-job",
+		Schedule: "@every 1s",
+		Command:  config.NewCommandSpec("echo slow"),
+	}
+
+	require.NoError(t, sched.AddJob(job, runner))
+	require.NoError(t, sched.Start())
+
+	// Wait for the first run to start, then pause and resume it mid-run
+	require.Eventually(t, func() bool {
+		runCount, _ := runner.snapshot()
+		return runCount == 1
+	}, 3*time.Second, 10*time.Millisecond)
+	require.NoError(t, sched.PauseJob(job.ID))
+	require.NoError(t, sched.ResumeJob(job.ID))
+
+	// The resumed entry ticks while the first run is still in flight
+	time.Sleep(1500 * time.Millisecond)
+
+	require.NoError(t, sched.Stop())
+
+	runCount, maxObserved := runner.snapshot()
+	t.Logf("runs started: %d, peak concurrency: %d", runCount, maxObserved)
+
+	assert.Equal(t, 1, maxObserved, "a resumed job must not overlap its in-flight run")
+}
+
 // ctxAwareRunner blocks for runDelay but returns early if its context is
 // cancelled, like a real job whose command is killed on shutdown.
 type ctxAwareRunner struct {
@@ -150,6 +190,37 @@ func TestScheduler_StopForceCancelsAfterGrace(t *testing.T) {
 	}
 }
 
+// TestScheduler_StopLogsTerminatedJobs verifies that Stop returns shortly
+// after a short grace period and logs that in-flight jobs were terminated.
+func TestScheduler_StopLogsTerminatedJobs(t *testing.T) {
+	var buf syncBuffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	sched := New(context.Background(), logger, WithShutdownGracePeriod(100*time.Millisecond))
+
+	runner := &ctxAwareRunner{started: make(chan struct{}), runDelay: 30 * time.Second}
+	require.NoError(t, sched.AddJob(&config.Job{
+		ID:       "long-runner",
+		Schedule: "@every 1s",
+		Command:  config.NewCommandSpec("sleep 30"),
+	}, runner))
+	require.NoError(t, sched.Start())
+
+	select {
+	case <-runner.started:
+	case <-time.After(3 * time.Second):
+		t.Fatal("job never started")
+	}
+
+	start := time.Now()
+	require.NoError(t, sched.Stop())
+	elapsed := time.Since(start)
+
+	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond, "Stop should honour the grace period")
+	assert.Less(t, elapsed, 2*time.Second, "Stop should not wait for the job to finish")
+	assert.Contains(t, buf.String(), "terminating in-flight jobs")
+	assert.Contains(t, buf.String(), "in_flight=1")
+}
+
 // TestScheduler_WithLocation_ShiftsCronSchedule verifies that WithLocation is
 // actually wired into the underlying cron engine: two schedulers given the same
 // daily cron expression but different time zones must compute different absolute
@@ -200,3 +271,154 @@ func TestScheduler_WithLocation_ShiftsCronSchedule(t *testing.T) {
 	assert.False(t, nextEast.Equal(nextWest),
 		"04:30 daily must resolve to different absolute instants in zones 10h apart")
 }
+
+// TestScheduler_JobTimezone_OverridesSchedulerLocation verifies that a job's
+// timezone field pins its cron expression to that zone even though the
+// scheduler itself runs in another one.
+func TestScheduler_JobTimezone_OverridesSchedulerLocation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	sched := New(ctx, quietLogger(), WithLocation(time.UTC))
+	require.NoError(t, sched.AddJob(&config.Job{
+		ID:       "tokyo-job",
+		Schedule: "30 4 * * *",
+		Timezone: "Asia/Tokyo",
+		Command:  config.NewCommandSpec("echo tokyo"),
+	}, &concurrencyTrackingRunner{}))
+	require.NoError(t, sched.AddJob(&config.Job{
+		ID:       "utc-job",
+		Schedule: "30 4 * * *",
+		Command:  config.NewCommandSpec("echo utc"),
+	}, &concurrencyTrackingRunner{}))
+	require.NoError(t, sched.Start())
+	defer sched.Stop()
+
+	// Let the cron run loop compute each entry's Next
+	time.Sleep(100 * time.Millisecond)
+
+	tokyoStats, ok := sched.GetJobStats("tokyo-job")
+	require.True(t, ok)
+	next := tokyoStats.NextRun.In(tokyo)
+	assert.Equal(t, 4, next.Hour(), "next run %s should be 04:30 Tokyo time", next)
+	assert.Equal(t, 30, next.Minute())
+
+	utcStats, ok := sched.GetJobStats("utc-job")
+	require.True(t, ok)
+	next = utcStats.NextRun.In(time.UTC)
+	assert.Equal(t, 4, next.Hour(), "job without timezone follows the scheduler location")
+	assert.Equal(t, 30, next.Minute())
+}
+
+func TestScheduler_JobTimezone_Invalid(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+	err := sched.AddJob(&config.Job{
+		ID:       "bad-tz",
+		Schedule: "@daily",
+		Timezone: "Nowhere/Special",
+		Command:  config.NewCommandSpec("echo"),
+	}, &concurrencyTrackingRunner{})
+	assert.ErrorContains(t, err, "invalid timezone")
+}
+
+func TestNextRunTimes(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	from := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	times, err := NextRunTimes(&config.Job{ID: "utc", Schedule: "0 6 * * *"}, time.UTC, from, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 3, 11, 6, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 12, 6, 0, 0, 0, time.UTC),
+	}, times)
+
+	times, err = NextRunTimes(&config.Job{ID: "tokyo", Schedule: "0 6 * * *", Timezone: "Asia/Tokyo"}, time.UTC, from, 1)
+	require.NoError(t, err)
+	require.Len(t, times, 1)
+	assert.True(t, times[0].Equal(time.Date(2025, 3, 11, 6, 0, 0, 0, tokyo)), "job timezone overrides loc: %s", times[0])
+
+	times, err = NextRunTimes(&config.Job{ID: "dep", DependsOn: []string{"utc"}}, time.UTC, from, 3)
+	require.NoError(t, err)
+	assert.Empty(t, times, "depends_on jobs have no schedule")
+}
+
+func TestScheduler_EveryDays(t *testing.T) {
+	from := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	for schedule, interval := range map[string]time.Duration{
+		"@every 1d": 24 * time.Hour,
+		"@every 7d": 7 * 24 * time.Hour,
+	} {
+		job := &config.Job{ID: "days", Schedule: schedule, Command: config.NewCommandSpec("echo")}
+		require.NoError(t, config.ValidateSchedule(schedule), "config accepts %s", schedule)
+
+		times, err := NextRunTimes(job, time.UTC, from, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{from.Add(interval), from.Add(2 * interval)}, times, schedule)
+
+		sched := New(context.Background(), quietLogger(), WithLocation(time.UTC))
+		require.NoError(t, sched.AddJob(job, &concurrencyTrackingRunner{}), schedule)
+		next, err := sched.NextRuns("days", 1)
+		require.NoError(t, err)
+		require.Len(t, next, 1)
+		assert.WithinDuration(t, time.Now().Add(interval), next[0], 2*time.Second, schedule)
+	}
+}
+
+func TestScheduler_NextRuns(t *testing.T) {
+	sched := New(context.Background(), quietLogger(), WithLocation(time.UTC))
+	require.NoError(t, sched.AddJob(&config.Job{
+		ID:       "every",
+		Schedule: "@every 10m",
+		Command:  config.NewCommandSpec("echo"),
+	}, &concurrencyTrackingRunner{}))
+	require.NoError(t, sched.AddJob(&config.Job{
+		ID:       "daily",
+		Schedule: "@daily",
+		Command:  config.NewCommandSpec("echo"),
+	}, &concurrencyTrackingRunner{}))
+
+	before := time.Now()
+	times, err := sched.NextRuns("every", 3)
+	require.NoError(t, err)
+	require.Len(t, times, 3)
+	assert.True(t, times[0].After(before))
+	assert.Equal(t, 10*time.Minute, times[1].Sub(times[0]))
+	assert.Equal(t, 10*time.Minute, times[2].Sub(times[1]))
+
+	times, err = sched.NextRuns("daily", 2)
+	require.NoError(t, err)
+	require.Len(t, times, 2)
+	assert.Equal(t, 0, times[0].Hour())
+	assert.Equal(t, 24*time.Hour, times[1].Sub(times[0]))
+
+	require.NoError(t, sched.PauseJob("daily"))
+	times, err = sched.NextRuns("daily", 2)
+	require.NoError(t, err)
+	assert.Empty(t, times, "paused jobs have no upcoming runs")
+
+	_, err = sched.NextRuns("missing", 1)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes by job goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
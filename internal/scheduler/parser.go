@@ -1,91 +1,46 @@
 package scheduler
 
 import (
-	"fmt"
-	"regexp"
-	"strconv"
-	"strings"
 	"time"
 
+	"github.com/caevv/jobster/internal/config"
 	"github.com/robfig/cron/v3"
 )
 
-var (
-	// Parser with seconds support for more granular scheduling
-	cronParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
-
-	// Regex for human-readable interval format: "every 5m", "every 2h", "every 30s"
-	intervalRegex = regexp.MustCompile(`^every\s+(\d+)\s*(s|sec|second|seconds|m|min|minute|minutes|h|hour|hours|d|day|days)$`)
-)
-
 // ParseSchedule parses a schedule expression and returns a cron.Schedule.
-// Supports:
-// - Standard cron expressions (5 or 6 fields): "0 2 * * *", "*/5 * * * *"
-// - Human-readable intervals: "every 5m", "every 2h", "every 30s"
-// - Descriptive shortcuts: "@hourly", "@daily", "@weekly", "@monthly"
+// It is config.ParseSchedule, which config load also uses to validate
+// schedules, so anything that validates will schedule.
 func ParseSchedule(expr string) (cron.Schedule, error) {
-	if expr == "" {
-		return nil, fmt.Errorf("schedule expression cannot be empty")
-	}
-
-	// Normalize whitespace
-	expr = strings.TrimSpace(expr)
-
-	// Try parsing as human-readable interval first
-	if strings.HasPrefix(strings.ToLower(expr), "every ") {
-		schedule, err := parseInterval(expr)
-		if err != nil {
-			return nil, fmt.Errorf("invalid interval expression %q: %w", expr, err)
-		}
-		return schedule, nil
-	}
-
-	// Try parsing as cron expression (supports descriptors like @hourly, @daily, etc.)
-	schedule, err := cronParser.Parse(expr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
-	}
-
-	return schedule, nil
+	return config.ParseSchedule(expr)
 }
 
-// parseInterval parses human-readable interval expressions like "every 5m" or "every 2h".
-func parseInterval(expr string) (cron.Schedule, error) {
-	matches := intervalRegex.FindStringSubmatch(strings.ToLower(expr))
-	if len(matches) != 3 {
-		return nil, fmt.Errorf("invalid format, expected 'every <number> <unit>' (e.g., 'every 5m')")
-	}
-
-	value, err := strconv.Atoi(matches[1])
-	if err != nil || value <= 0 {
-		return nil, fmt.Errorf("invalid interval value: must be a positive integer")
+// ParseScheduleIn parses expr like ParseSchedule, but interprets cron
+// expressions in loc rather than the scheduler's time zone. Interval
+// schedules are absolute durations and are unaffected. A nil loc is
+// equivalent to ParseSchedule.
+func ParseScheduleIn(expr string, loc *time.Location) (cron.Schedule, error) {
+	schedule, err := ParseSchedule(expr)
+	if err != nil || loc == nil {
+		return schedule, err
 	}
+	return zonedSchedule{schedule: schedule, loc: loc}, nil
+}
 
-	unit := matches[2]
-	var duration time.Duration
-
-	switch unit {
-	case "s", "sec", "second", "seconds":
-		duration = time.Duration(value) * time.Second
-	case "m", "min", "minute", "minutes":
-		duration = time.Duration(value) * time.Minute
-	case "h", "hour", "hours":
-		duration = time.Duration(value) * time.Hour
-	case "d", "day", "days":
-		duration = time.Duration(value) * 24 * time.Hour
-	default:
-		return nil, fmt.Errorf("unsupported time unit %q", unit)
-	}
+// zonedSchedule evaluates a schedule in a fixed location. Setting
+// cron.SpecSchedule.Location is not enough: time.Local there means "the
+// caller's zone", so a job pinned to Local would follow the scheduler's zone.
+type zonedSchedule struct {
+	schedule cron.Schedule
+	loc      *time.Location
+}
 
-	// Validate duration bounds
-	if duration < time.Second {
-		return nil, fmt.Errorf("interval must be at least 1 second")
+// Next returns the next activation after t, in t's location.
+func (z zonedSchedule) Next(t time.Time) time.Time {
+	next := z.schedule.Next(t.In(z.loc))
+	if next.IsZero() {
+		return next
 	}
-	if duration > 24*time.Hour*365 {
-		return nil, fmt.Errorf("interval cannot exceed 1 year")
-	}
-
-	return cron.Every(duration), nil
+	return next.In(t.Location())
 }
 
 // ValidateSchedule validates a schedule expression without creating a scheduler.
@@ -2,15 +2,33 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/logging"
 	"github.com/robfig/cron/v3"
 )
 
+// ErrJobNotFound is returned when an operation references a job ID the
+// scheduler does not know about.
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrSchedulerStopped is returned when a job is triggered after Stop was called.
+var ErrSchedulerStopped = errors.New("scheduler is stopped")
+
+// ErrJobPaused is returned by RunDependentJob when the job is paused.
+var ErrJobPaused = errors.New("job is paused")
+
+// ErrJobDisabled is returned when an operation needs a job that the config
+// disables (enabled: false) to be scheduled.
+var ErrJobDisabled = errors.New("job is disabled")
+
 // Scheduler wraps robfig/cron and manages job lifecycle with context support.
 type Scheduler struct {
 	cron          *cron.Cron
@@ -19,18 +37,40 @@ type Scheduler struct {
 	logger        *slog.Logger
 	jobs          map[string]*scheduledJob // jobID -> scheduledJob
 	shutdownGrace time.Duration
+	location      *time.Location
+	lastRun       LastRunFunc
+	jobCount      func(int)
+	slots         chan struct{} // nil: no limit on concurrent runs
+	skipWhenFull  bool
+	inFlight      atomic.Int64
+	defaultJitter time.Duration
+	failThreshold int           // consecutive failures that open a job's circuit; 0 disables the breaker
+	cooldown      time.Duration // how long an open circuit stays open; 0 means until ResumeJob
+	stopCh        chan struct{} // closed by Stop, wakes runs sleeping off their jitter
+	started       bool
+	stopping      bool
 	mu            sync.RWMutex
 	wg            sync.WaitGroup
 }
 
-// scheduledJob tracks a job and its cron entry.
+// scheduledJob tracks a job and its cron entry. Its fields are guarded by
+// the Scheduler's mu; runs update lastRun and runCount together.
 type scheduledJob struct {
 	job      *config.Job
 	runner   JobRunner
+	schedule cron.Schedule // nil for jobs triggered by depends_on
 	entryID  cron.EntryID
 	lastRun  time.Time
 	nextRun  time.Time
 	runCount int64
+	jitter   time.Duration // upper bound of the random delay before each scheduled run
+	paused   bool          // cron entry removed by PauseJob; entryID is zero
+	disabled bool          // enabled: false in the config; never given a cron entry
+	running  bool          // a scheduled run is in flight; later ticks are skipped
+
+	consecutiveFailures int         // failed runs since the last success or resume
+	circuitOpen         bool        // paused by the circuit breaker rather than PauseJob
+	cooldownTimer       *time.Timer // auto-resumes an open circuit; nil without a cooldown
 }
 
 // Option configures a Scheduler at construction time.
@@ -40,8 +80,29 @@ type Option func(*options)
 type options struct {
 	location      *time.Location
 	shutdownGrace time.Duration
+	lastRun       LastRunFunc
+	jobCount      func(int)
+	maxConcurrent int
+	policy        string
+	jitter        time.Duration
+	failThreshold int
+	cooldown      time.Duration
 }
 
+// Policies for a scheduled run that fires while WithMaxConcurrentJobs's
+// limit is reached.
+const (
+	// PolicyWait waits for a running job to finish, then runs.
+	PolicyWait = "wait"
+	// PolicySkip skips the run; the job fires again at its next scheduled
+	// time.
+	PolicySkip = "skip"
+)
+
+// LastRunFunc reports when a job last started, as recorded in run history.
+// ok is false when the job has never run.
+type LastRunFunc func(jobID string) (last time.Time, ok bool, err error)
+
 // WithLocation sets the time zone used to interpret cron schedules. When unset
 // (or nil), cron expressions are interpreted in the server's local time.
 //
@@ -66,6 +127,60 @@ func WithShutdownGracePeriod(d time.Duration) Option {
 	}
 }
 
+// WithLastRunLookup enables catch-up for jobs with catch_up set: at Start, the
+// scheduler asks lookup when each such job last ran and, if a scheduled fire
+// time was missed since then, runs the job once immediately.
+func WithLastRunLookup(lookup LastRunFunc) Option {
+	return func(o *options) {
+		o.lastRun = lookup
+	}
+}
+
+// WithJobCountObserver registers fn to be told the number of registered jobs
+// whenever a job is added or removed, e.g. to drive a metrics gauge. fn is
+// called with the scheduler's lock held and must not call back into it.
+func WithJobCountObserver(fn func(count int)) Option {
+	return func(o *options) {
+		o.jobCount = fn
+	}
+}
+
+// WithMaxConcurrentJobs limits how many job runs execute at once across all
+// jobs. When a scheduled run fires with n runs in flight, policy decides
+// whether it waits for a slot (PolicyWait, the default for "") or is skipped
+// (PolicySkip). Manual and dependent triggers always wait. A non-positive n
+// means no limit.
+func WithMaxConcurrentJobs(n int, policy string) Option {
+	return func(o *options) {
+		o.maxConcurrent = n
+		o.policy = policy
+	}
+}
+
+// WithDefaultJitter delays each scheduled run of a job without its own
+// jitter setting by a random amount in [0, d). Manual and dependent triggers
+// are never delayed.
+func WithDefaultJitter(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.jitter = d
+		}
+	}
+}
+
+// WithCircuitBreaker pauses a job once threshold runs in a row have failed,
+// and tells its runner through CircuitHandler if it implements it. The job
+// is resumed automatically after cooldown, or only by ResumeJob when
+// cooldown is zero. A non-positive threshold disables the breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(o *options) {
+		if threshold > 0 {
+			o.failThreshold = threshold
+			o.cooldown = cooldown
+		}
+	}
+}
+
 // New creates a new Scheduler instance with context support.
 // The context is used for graceful shutdown and job cancellation.
 func New(ctx context.Context, logger *slog.Logger, opts ...Option) *Scheduler {
@@ -73,7 +188,7 @@ func New(ctx context.Context, logger *slog.Logger, opts ...Option) *Scheduler {
 		logger = slog.Default()
 	}
 
-	o := options{shutdownGrace: shutdownGracePeriod}
+	o := options{shutdownGrace: shutdownGracePeriod, location: time.Local}
 	for _, opt := range opts {
 		opt(&o)
 	}
@@ -90,12 +205,17 @@ func New(ctx context.Context, logger *slog.Logger, opts ...Option) *Scheduler {
 			cron.SkipIfStillRunning(cronLogger), // Skip a tick if the previous run is still in flight
 		),
 	}
-	if o.location != nil {
+	if o.location != time.Local {
 		cronOpts = append(cronOpts, cron.WithLocation(o.location))
 	}
 
 	c := cron.New(cronOpts...)
 
+	var slots chan struct{}
+	if o.maxConcurrent > 0 {
+		slots = make(chan struct{}, o.maxConcurrent)
+	}
+
 	return &Scheduler{
 		cron:          c,
 		ctx:           schedCtx,
@@ -103,12 +223,23 @@ func New(ctx context.Context, logger *slog.Logger, opts ...Option) *Scheduler {
 		logger:        logger,
 		jobs:          make(map[string]*scheduledJob),
 		shutdownGrace: o.shutdownGrace,
+		location:      o.location,
+		lastRun:       o.lastRun,
+		jobCount:      o.jobCount,
+		slots:         slots,
+		skipWhenFull:  o.policy == PolicySkip,
+		defaultJitter: o.jitter,
+		failThreshold: o.failThreshold,
+		cooldown:      o.cooldown,
+		stopCh:        make(chan struct{}),
 	}
 }
 
 // AddJob adds a job to the scheduler with the given runner.
 // The job will be scheduled according to its schedule expression.
 // Returns an error if the job ID already exists or if the schedule is invalid.
+// A disabled job (enabled: false) is validated and tracked, so it appears in
+// ListJobs and GetJobStats, but is never scheduled.
 func (s *Scheduler) AddJob(job *config.Job, runner JobRunner) error {
 	if job == nil {
 		return fmt.Errorf("job cannot be nil")
@@ -128,10 +259,42 @@ func (s *Scheduler) AddJob(job *config.Job, runner JobRunner) error {
 		return fmt.Errorf("job with ID %q already exists", job.ID)
 	}
 
+	if !job.IsEnabled() {
+		if err := ValidateJob(job); err != nil {
+			return err
+		}
+		s.jobs[job.ID] = &scheduledJob{job: job, runner: runner, disabled: true}
+		s.notifyJobCount()
+
+		s.logger.Info(
+			"disabled job added to scheduler",
+			slog.String("job_id", job.ID),
+		)
+		return nil
+	}
+
+	// Jobs with depends_on have no cron entry; they are started through
+	// RunDependentJob when a job they depend on succeeds.
+	if len(job.DependsOn) > 0 {
+		s.jobs[job.ID] = &scheduledJob{job: job, runner: runner}
+		s.notifyJobCount()
+
+		s.logger.Info(
+			"job added to scheduler",
+			slog.String("job_id", job.ID),
+			slog.Any("depends_on", job.DependsOn),
+		)
+		return nil
+	}
+
 	// Parse and validate schedule
-	schedule, err := ParseSchedule(job.Schedule)
+	schedule, err := jobSchedule(job)
 	if err != nil {
-		return fmt.Errorf("failed to parse schedule for job %q: %w", job.ID, err)
+		return err
+	}
+	jitter, err := s.jobJitter(job)
+	if err != nil {
+		return err
 	}
 
 	// Create wrapped job function with context support
@@ -142,11 +305,14 @@ func (s *Scheduler) AddJob(job *config.Job, runner JobRunner) error {
 
 	// Track the scheduled job
 	s.jobs[job.ID] = &scheduledJob{
-		job:     job,
-		runner:  runner,
-		entryID: entryID,
-		nextRun: schedule.Next(time.Now()),
+		job:      job,
+		runner:   runner,
+		schedule: schedule,
+		entryID:  entryID,
+		nextRun:  schedule.Next(time.Now()),
+		jitter:   jitter,
 	}
+	s.notifyJobCount()
 
 	s.logger.Info(
 		"job added to scheduler",
@@ -158,18 +324,298 @@ func (s *Scheduler) AddJob(job *config.Job, runner JobRunner) error {
 	return nil
 }
 
+// ValidateJob reports whether AddJob would accept job's schedule and
+// timezone, without registering it. Use it to check a whole set of jobs
+// before changing a running scheduler.
+func ValidateJob(job *config.Job) error {
+	if len(job.DependsOn) > 0 {
+		return nil
+	}
+	if _, err := jobSchedule(job); err != nil {
+		return err
+	}
+	if _, err := config.ParseJitter(job.Jitter); err != nil {
+		return fmt.Errorf("job %q: %w", job.ID, err)
+	}
+	return nil
+}
+
+// jobJitter returns the jitter bound for job: its own setting, or the
+// scheduler's WithDefaultJitter when it has none.
+func (s *Scheduler) jobJitter(job *config.Job) (time.Duration, error) {
+	if job.Jitter == "" {
+		return s.defaultJitter, nil
+	}
+	d, err := config.ParseJitter(job.Jitter)
+	if err != nil {
+		return 0, fmt.Errorf("job %q: %w", job.ID, err)
+	}
+	return d, nil
+}
+
+// jobSchedule parses job's schedule. A per-job timezone overrides the
+// scheduler's location for this job's cron expression only.
+func jobSchedule(job *config.Job) (cron.Schedule, error) {
+	var jobLoc *time.Location
+	if job.Timezone != "" {
+		loc, err := config.LoadLocation(job.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q for job %q: %w", job.Timezone, job.ID, err)
+		}
+		jobLoc = loc
+	}
+	schedule, err := ParseScheduleIn(job.Schedule, jobLoc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schedule for job %q: %w", job.ID, err)
+	}
+	return schedule, nil
+}
+
+// NextRunTimes returns the next n times job's schedule fires after from, as a
+// Scheduler created WithLocation(loc) would run it. A nil loc means the
+// server's local time. Jobs triggered by depends_on have no fire times.
+func NextRunTimes(job *config.Job, loc *time.Location, from time.Time, n int) ([]time.Time, error) {
+	if len(job.DependsOn) > 0 {
+		return nil, nil
+	}
+	schedule, err := jobSchedule(job)
+	if err != nil {
+		return nil, err
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+
+	times := make([]time.Time, 0, n)
+	next := from.In(loc)
+	for range n {
+		next = schedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+		times = append(times, next)
+	}
+	return times, nil
+}
+
+// RemoveJob unschedules a job and forgets it. A run that is already in flight
+// is allowed to finish; no further runs are started.
+func (s *Scheduler) RemoveJob(jobID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sj, exists := s.jobs[jobID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+
+	if sj.cooldownTimer != nil {
+		sj.cooldownTimer.Stop()
+	}
+	s.cron.Remove(sj.entryID)
+	delete(s.jobs, jobID)
+	s.notifyJobCount()
+
+	s.logger.Info("job removed from scheduler", slog.String("job_id", jobID))
+
+	return nil
+}
+
+// notifyJobCount reports the current job count to the observer, if any.
+// s.mu must be held.
+func (s *Scheduler) notifyJobCount() {
+	if s.jobCount != nil {
+		s.jobCount(len(s.jobs))
+	}
+}
+
+// PauseJob stops a job from firing on its schedule while keeping it registered,
+// so it still appears in ListJobs and GetJobStats. A run already in flight is
+// allowed to finish. Use ResumeJob to schedule it again.
+func (s *Scheduler) PauseJob(jobID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sj, exists := s.jobs[jobID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+	if sj.disabled {
+		return fmt.Errorf("%w: %s", ErrJobDisabled, jobID)
+	}
+	if sj.paused {
+		return fmt.Errorf("job %q is already paused", jobID)
+	}
+
+	s.cron.Remove(sj.entryID)
+	sj.entryID = 0
+	sj.nextRun = time.Time{}
+	sj.paused = true
+
+	s.logger.Info("job paused", slog.String("job_id", jobID))
+
+	return nil
+}
+
+// ResumeJob re-schedules a job previously paused with PauseJob. The next run
+// time is computed from now, so runs missed while paused are not replayed.
+// If a scheduled run started before the pause is still in flight, ticks are
+// skipped until it finishes.
+func (s *Scheduler) ResumeJob(jobID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sj, exists := s.jobs[jobID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+	if sj.disabled {
+		return fmt.Errorf("%w: %s", ErrJobDisabled, jobID)
+	}
+	if !sj.paused {
+		return fmt.Errorf("job %q is not paused", jobID)
+	}
+
+	s.resumeLocked(sj)
+	s.logger.Info(
+		"job resumed",
+		slog.String("job_id", jobID),
+		slog.Time("next_run", sj.nextRun),
+	)
+
+	return nil
+}
+
+// resumeLocked re-schedules a paused job and closes its circuit breaker.
+// The caller must hold s.mu.
+func (s *Scheduler) resumeLocked(sj *scheduledJob) {
+	if sj.cooldownTimer != nil {
+		sj.cooldownTimer.Stop()
+		sj.cooldownTimer = nil
+	}
+	sj.paused = false
+	sj.circuitOpen = false
+	sj.consecutiveFailures = 0
+	if sj.schedule != nil {
+		sj.entryID = s.cron.Schedule(sj.schedule, s.wrapJob(sj.job, sj.runner))
+		sj.nextRun = sj.schedule.Next(time.Now().In(s.location))
+	}
+}
+
+// recordResult updates the job's consecutive failure count after a run and
+// opens its circuit once WithCircuitBreaker's threshold is reached. It
+// reports the failure count when the circuit has just opened, or 0.
+func (s *Scheduler) recordResult(log *slog.Logger, jobID string, err error) int {
+	if s.failThreshold <= 0 {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sj, exists := s.jobs[jobID]
+	if !exists {
+		return 0
+	}
+	if err == nil {
+		sj.consecutiveFailures = 0
+		return 0
+	}
+	// A run cut short by shutdown says nothing about the job's health.
+	if s.ctx.Err() != nil || s.stopping {
+		return 0
+	}
+
+	sj.consecutiveFailures++
+	if sj.consecutiveFailures < s.failThreshold || sj.paused || sj.disabled {
+		return 0
+	}
+
+	s.cron.Remove(sj.entryID)
+	sj.entryID = 0
+	sj.nextRun = time.Time{}
+	sj.paused = true
+	sj.circuitOpen = true
+	if s.cooldown > 0 {
+		sj.cooldownTimer = time.AfterFunc(s.cooldown, func() { s.closeCircuit(jobID, sj) })
+	}
+
+	log.Warn(
+		"circuit breaker opened; job paused",
+		slog.Int("consecutive_failures", sj.consecutiveFailures),
+		slog.Duration("cooldown", s.cooldown),
+	)
+	return sj.consecutiveFailures
+}
+
+// closeCircuit resumes a job whose circuit breaker cooldown has elapsed,
+// unless it was resumed, removed, or replaced in the meantime.
+func (s *Scheduler) closeCircuit(jobID string, sj *scheduledJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopping || s.jobs[jobID] != sj || !sj.circuitOpen {
+		return
+	}
+	sj.cooldownTimer = nil
+	s.resumeLocked(sj)
+	s.logger.Info(
+		"circuit breaker cooldown elapsed; job resumed",
+		slog.String("job_id", jobID),
+		slog.Time("next_run", sj.nextRun),
+	)
+}
+
 // wrapJob wraps a JobRunner in a cron.Job that respects context cancellation.
 func (s *Scheduler) wrapJob(job *config.Job, runner JobRunner) cron.FuncJob {
 	return func() {
-		s.mu.Lock()
+		s.mu.RLock()
 		sj, exists := s.jobs[job.ID]
-		if !exists {
+		runnable := exists && !sj.paused
+		var bound time.Duration
+		if exists {
+			bound = sj.jitter
+		}
+		s.mu.RUnlock()
+		if !runnable {
+			return
+		}
+
+		runID := GenerateRunID()
+		log := s.runLogger(job.ID, runID)
+
+		delay, ok := s.sleepJitter(log, bound)
+		if !ok {
+			return
+		}
+
+		if !s.acquireSlot(log, s.skipWhenFull) {
+			return
+		}
+		defer s.releaseSlot()
+
+		s.mu.Lock()
+		sj, exists = s.jobs[job.ID]
+		if !exists || sj.paused {
+			s.mu.Unlock()
+			return
+		}
+		// SkipIfStillRunning only guards one cron entry, and resuming a
+		// paused job gives it a new one
+		if sj.running {
 			s.mu.Unlock()
+			log.Info("previous run still in flight; skipping scheduled run")
 			return
 		}
+		sj.running = true
 		sj.lastRun = time.Now()
 		sj.runCount++
 		s.mu.Unlock()
+		defer func() {
+			s.mu.Lock()
+			sj.running = false
+			s.mu.Unlock()
+		}()
 
 		s.wg.Add(1)
 		defer s.wg.Done()
@@ -178,43 +624,227 @@ func (s *Scheduler) wrapJob(job *config.Job, runner JobRunner) cron.FuncJob {
 		// timeout (job.TimeoutSec) is enforced by the runner on each command
 		// execution, so the whole retry sequence is not capped by a single
 		// timeout. Cancelling s.ctx (graceful shutdown) still aborts in-flight work.
-		jobCtx := s.ctx
+		ctx := ContextWithTrigger(ContextWithJitter(s.ctx, delay), TriggerSchedule)
+		s.runJob(ctx, job, runner, runID)
+	}
+}
 
-		s.logger.Info(
-			"starting job execution",
-			slog.String("job_id", job.ID),
-			slog.String("command", job.Command.String()),
+// sleepJitter waits a random delay in [0, bound) and returns it. It returns
+// false without waiting out the delay if the scheduler stops meanwhile.
+func (s *Scheduler) sleepJitter(log *slog.Logger, bound time.Duration) (time.Duration, bool) {
+	if bound <= 0 {
+		return 0, true
+	}
+	delay := rand.N(bound)
+	log.Debug("delaying scheduled run by jitter", slog.Duration("jitter", delay))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return delay, true
+	case <-s.stopCh:
+	case <-s.ctx.Done():
+	}
+	log.Info("scheduled run dropped: scheduler stopped during jitter delay")
+	return 0, false
+}
+
+// RunJobNow executes a job immediately, outside its cron schedule, and returns
+// the run ID the execution will be recorded under. The job runs in its own
+// goroutine; the cron entry is left untouched, so the next scheduled run time
+// is not affected. Stop waits for manually triggered runs like scheduled ones.
+func (s *Scheduler) RunJobNow(jobID string) (string, error) {
+	return s.runNow(jobID, TriggerManual)
+}
+
+// RunDependentJob starts jobID because a job it depends on succeeded. It
+// behaves like RunJobNow, except that a paused or disabled job is not started
+// and ErrJobPaused or ErrJobDisabled is returned instead.
+func (s *Scheduler) RunDependentJob(jobID string) (string, error) {
+	return s.runNow(jobID, TriggerDependency)
+}
+
+// runNow starts a run of jobID in its own goroutine, passing trigger to the
+// runner. Dependency triggers refuse paused and disabled jobs.
+func (s *Scheduler) runNow(jobID, trigger string) (string, error) {
+	skipPaused := trigger == TriggerDependency
+	s.mu.Lock()
+	if s.stopping {
+		s.mu.Unlock()
+		return "", ErrSchedulerStopped
+	}
+	sj, exists := s.jobs[jobID]
+	if !exists {
+		s.mu.Unlock()
+		return "", fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+	if skipPaused && sj.disabled {
+		s.mu.Unlock()
+		return "", fmt.Errorf("%w: %s", ErrJobDisabled, jobID)
+	}
+	if skipPaused && sj.paused {
+		s.mu.Unlock()
+		return "", fmt.Errorf("%w: %s", ErrJobPaused, jobID)
+	}
+	sj.lastRun = time.Now()
+	sj.runCount++
+	job, runner := sj.job, sj.runner
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	runID := GenerateRunID()
+	log := s.runLogger(jobID, runID)
+	switch trigger {
+	case TriggerDependency:
+		log.Info("dependent job triggered")
+	case TriggerCatchUp:
+		log.Info("catch-up run triggered")
+	default:
+		log.Info("job triggered manually")
+	}
+
+	go func() {
+		defer s.wg.Done()
+		if !s.acquireSlot(log, false) {
+			return
+		}
+		defer s.releaseSlot()
+		s.runJob(ContextWithTrigger(s.ctx, trigger), job, runner, runID)
+	}()
+
+	return runID, nil
+}
+
+// acquireSlot takes one of the WithMaxConcurrentJobs slots, waiting for a
+// run to finish if all are taken, or giving up at once when skip is set. It
+// returns false if no slot was taken, which also happens when the scheduler
+// starts shutting down while waiting. Every successful call must be paired
+// with releaseSlot.
+func (s *Scheduler) acquireSlot(log *slog.Logger, skip bool) bool {
+	if s.slots == nil {
+		s.inFlight.Add(1)
+		return true
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		s.inFlight.Add(1)
+		return true
+	default:
+	}
+
+	if skip {
+		log.Warn(
+			"max_concurrent_jobs reached; skipping scheduled run",
+			slog.Int("max_concurrent_jobs", cap(s.slots)),
 		)
+		return false
+	}
+
+	log.Info(
+		"max_concurrent_jobs reached; waiting for a running job to finish",
+		slog.Int("max_concurrent_jobs", cap(s.slots)),
+	)
+	select {
+	case s.slots <- struct{}{}:
+	case <-s.ctx.Done():
+		return false
+	}
+	if s.isStopping() {
+		<-s.slots
+		log.Warn("run not started: scheduler stopped while it waited")
+		return false
+	}
+	s.inFlight.Add(1)
+	return true
+}
 
-		startTime := time.Now()
-		err := runner.Run(jobCtx, job)
-		duration := time.Since(startTime)
+// releaseSlot returns a slot taken by acquireSlot.
+func (s *Scheduler) releaseSlot() {
+	s.inFlight.Add(-1)
+	if s.slots != nil {
+		<-s.slots
+	}
+}
 
-		if err != nil {
-			s.logger.Error(
-				"job execution failed",
-				slog.String("job_id", job.ID),
-				slog.String("error", err.Error()),
-				slog.Duration("duration", duration),
-			)
-		} else {
-			s.logger.Info(
-				"job execution completed",
-				slog.String("job_id", job.ID),
-				slog.Duration("duration", duration),
-			)
+// isStopping reports whether Stop has been called.
+func (s *Scheduler) isStopping() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.stopping
+}
+
+// InFlight returns the number of job runs currently executing, across all
+// jobs. Runs waiting for a WithMaxConcurrentJobs slot are not counted.
+func (s *Scheduler) InFlight() int {
+	return int(s.inFlight.Load())
+}
+
+// MaxConcurrentJobs returns the WithMaxConcurrentJobs limit, or 0 if runs
+// are not limited.
+func (s *Scheduler) MaxConcurrentJobs() int {
+	return cap(s.slots)
+}
+
+// jobActive reports whether job is still registered under its ID, not
+// replaced by a reload, and neither paused nor disabled.
+func (s *Scheduler) jobActive(job *config.Job) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sj, exists := s.jobs[job.ID]
+	return exists && sj.job == job && !sj.paused && !sj.disabled
+}
+
+// runLogger returns the scheduler's logger bound to one execution of a job.
+// The run ID is handed to the JobRunner, which logs under the same fields.
+func (s *Scheduler) runLogger(jobID, runID string) *slog.Logger {
+	return logging.WithFields(s.logger, map[string]any{"job_id": jobID, "run_id": runID})
+}
+
+// runJob executes a single run of job under runID and refreshes the job's
+// next-run bookkeeping afterwards.
+func (s *Scheduler) runJob(ctx context.Context, job *config.Job, runner JobRunner, runID string) {
+	log := s.runLogger(job.ID, runID)
+	log.Info("starting job execution", slog.String("command", job.Command.String()))
+
+	ctx = ContextWithJobActive(ContextWithRunID(ctx, runID), func() bool {
+		return s.jobActive(job)
+	})
+
+	startTime := time.Now()
+	err := runner.Run(ctx, job)
+	duration := time.Since(startTime)
+
+	if err != nil {
+		log.Error(
+			"job execution failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", duration),
+		)
+	} else {
+		log.Info(
+			"job execution completed",
+			slog.Duration("duration", duration),
+		)
+	}
+
+	if failures := s.recordResult(log, job.ID, err); failures > 0 {
+		if h, ok := runner.(CircuitHandler); ok {
+			h.CircuitOpened(s.ctx, job, runID, failures)
 		}
+	}
 
-		// Update next run time
-		s.mu.Lock()
-		if sj, exists := s.jobs[job.ID]; exists {
-			entry := s.cron.Entry(sj.entryID)
-			if entry.ID != 0 {
-				sj.nextRun = entry.Next
-			}
+	// Update next run time
+	s.mu.Lock()
+	if sj, exists := s.jobs[job.ID]; exists {
+		entry := s.cron.Entry(sj.entryID)
+		if entry.ID != 0 {
+			sj.nextRun = entry.Next
 		}
-		s.mu.Unlock()
 	}
+	s.mu.Unlock()
 }
 
 // Start begins the scheduler. Jobs will start running according to their schedules.
@@ -230,24 +860,98 @@ func (s *Scheduler) Start() error {
 	s.logger.Info("starting scheduler", slog.Int("job_count", jobCount))
 	s.cron.Start()
 
+	s.mu.Lock()
+	s.started = true
+	s.mu.Unlock()
+
+	s.catchUp()
+
 	return nil
 }
 
+// catchUp runs each catch_up job once if a scheduled fire time passed while
+// the scheduler was not running. However many fires were missed, at most one
+// catch-up run is started per job. Jobs with no recorded runs are skipped.
+func (s *Scheduler) catchUp() {
+	if s.lastRun == nil {
+		return
+	}
+
+	type candidate struct {
+		jobID    string
+		schedule cron.Schedule
+	}
+
+	s.mu.RLock()
+	var candidates []candidate
+	for id, sj := range s.jobs {
+		if sj.job.CatchUp && !sj.paused && !sj.disabled && sj.schedule != nil {
+			candidates = append(candidates, candidate{jobID: id, schedule: sj.schedule})
+		}
+	}
+	s.mu.RUnlock()
+
+	now := time.Now().In(s.location)
+	for _, c := range candidates {
+		last, ok, err := s.lastRun(c.jobID)
+		if err != nil {
+			s.logger.Warn(
+				"catch-up skipped: failed to look up last run",
+				slog.String("job_id", c.jobID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		missed := c.schedule.Next(last.In(s.location))
+		if missed.IsZero() || !missed.Before(now) {
+			continue
+		}
+
+		s.logger.Info(
+			"missed scheduled run; catching up",
+			slog.String("job_id", c.jobID),
+			slog.Time("last_run", last),
+			slog.Time("missed_run", missed),
+		)
+		if _, err := s.runNow(c.jobID, TriggerCatchUp); err != nil {
+			s.logger.Error(
+				"catch-up run failed to start",
+				slog.String("job_id", c.jobID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
 // shutdownGracePeriod bounds how long Stop lets an in-flight job keep running
 // before it forcibly cancels it. It gives a job that is mid-execution a chance
 // to finish normally, while ensuring shutdown cannot hang for the (potentially
 // multi-minute) duration of a job's retry backoff.
 const shutdownGracePeriod = 10 * time.Second
 
+// Running reports whether the scheduler has been started and is not
+// stopping.
+func (s *Scheduler) Running() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.started && !s.stopping
+}
+
 // Stop gracefully stops the scheduler and waits for all running jobs to return.
 //
 // It stops scheduling new ticks, then lets any in-flight job finish normally for
-// up to shutdownGracePeriod. If a job is still running after that, its context is
+// up to the grace period (shutdownGracePeriod unless set with
+// WithShutdownGracePeriod). If a job is still running after that, its context is
 // cancelled (its command is killed and any pending retry backoff aborts) and Stop
 // waits for it to unwind. Either way Stop blocks until every job goroutine has
-// returned before it returns — that unconditional join is what makes Stop a safe
-// synchronization point: callers that read run history afterwards cannot race a
-// job goroutine that is still writing its record.
+// returned before it returns, including runs started through RunJobNow — that
+// unconditional join is what makes Stop a safe synchronization point: callers
+// that read run history afterwards cannot race a job goroutine that is still
+// writing its record.
 //
 // When the context passed to New is already cancelled (e.g. a SIGINT/SIGTERM
 // signal handler cancelled it), in-flight jobs are already winding down, so the
@@ -255,24 +959,40 @@ const shutdownGracePeriod = 10 * time.Second
 func (s *Scheduler) Stop() error {
 	s.logger.Info("stopping scheduler")
 
+	// Refuse new manual triggers so nothing is added to the WaitGroup while we
+	// wait on it below.
+	s.mu.Lock()
+	if !s.stopping {
+		close(s.stopCh)
+	}
+	s.stopping = true
+	s.mu.Unlock()
+
 	// Stop scheduling new ticks. cron.Stop returns a context that completes only
-	// once every job function cron started has returned.
+	// once every job function cron started has returned. Manually triggered runs
+	// are not tracked by cron, so also wait on our own WaitGroup.
 	cronStopCtx := s.cron.Stop()
+	done := make(chan struct{})
+	go func() {
+		<-cronStopCtx.Done()
+		s.wg.Wait()
+		close(done)
+	}()
 
 	select {
-	case <-cronStopCtx.Done():
+	case <-done:
 		// All in-flight jobs finished on their own within the grace period.
 	case <-time.After(s.shutdownGrace):
 		// A job is still running; cancel it and wait for it to unwind.
-		s.logger.Warn("grace period elapsed; cancelling in-flight jobs")
+		s.logger.Warn(
+			"shutdown grace period elapsed; terminating in-flight jobs",
+			slog.Duration("grace_period", s.shutdownGrace),
+			slog.Int("in_flight", s.InFlight()),
+		)
 		s.cancel()
-		<-cronStopCtx.Done()
+		<-done
 	}
 
-	// Belt-and-suspenders: also wait on our own WaitGroup so any wrapJob
-	// bookkeeping that runs after the runner returns is complete.
-	s.wg.Wait()
-
 	// Release the scheduler context now that every job has finished.
 	s.cancel()
 
@@ -292,6 +1012,27 @@ func (s *Scheduler) GetJob(jobID string) (*config.Job, bool) {
 	return sj.job, true
 }
 
+// NextRuns returns the next n times the job is scheduled to fire. Paused and
+// disabled jobs and jobs triggered by depends_on have none.
+func (s *Scheduler) NextRuns(jobID string, n int) ([]time.Time, error) {
+	s.mu.RLock()
+	sj, exists := s.jobs[jobID]
+	var job *config.Job
+	var paused bool
+	if exists {
+		job, paused = sj.job, sj.paused || sj.disabled
+	}
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+	if paused {
+		return nil, nil
+	}
+	return NextRunTimes(job, s.location, time.Now(), n)
+}
+
 // ListJobs returns a list of all scheduled jobs.
 func (s *Scheduler) ListJobs() []*config.Job {
 	s.mu.RLock()
@@ -310,9 +1051,19 @@ type JobStats struct {
 	LastRun  time.Time `json:"last_run"`
 	NextRun  time.Time `json:"next_run"`
 	RunCount int64     `json:"run_count"`
+	Paused   bool      `json:"paused"`
+	Disabled bool      `json:"disabled"`
+
+	// ConsecutiveFailures counts failed runs since the last success, and
+	// CircuitOpen reports that WithCircuitBreaker paused the job.
+	ConsecutiveFailures int  `json:"consecutive_failures"`
+	CircuitOpen         bool `json:"circuit_open"`
 }
 
-// GetJobStats returns statistics for a given job ID.
+// GetJobStats returns statistics for a given job ID. The stats are a
+// snapshot copied under a single lock acquisition, so they are consistent
+// with each other even while the job runs: LastRun and RunCount are always
+// updated together when a run starts, and RunCount never decreases.
 func (s *Scheduler) GetJobStats(jobID string) (*JobStats, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -321,20 +1072,33 @@ func (s *Scheduler) GetJobStats(jobID string) (*JobStats, bool) {
 	if !exists {
 		return nil, false
 	}
+	stats := s.statsLocked(jobID, sj)
+	return &stats, true
+}
 
+// statsLocked copies sj's bookkeeping into a JobStats. The caller must hold
+// s.mu.
+func (s *Scheduler) statsLocked(jobID string, sj *scheduledJob) JobStats {
 	// Get the most up-to-date next run time from cron
 	nextRun := sj.nextRun
-	entry := s.cron.Entry(sj.entryID)
-	if entry.ID != 0 {
-		nextRun = entry.Next
+	if !sj.paused {
+		entry := s.cron.Entry(sj.entryID)
+		if entry.ID != 0 {
+			nextRun = entry.Next
+		}
 	}
 
-	return &JobStats{
+	return JobStats{
 		JobID:    jobID,
 		LastRun:  sj.lastRun,
 		NextRun:  nextRun,
 		RunCount: sj.runCount,
-	}, true
+		Paused:   sj.paused,
+		Disabled: sj.disabled,
+
+		ConsecutiveFailures: sj.consecutiveFailures,
+		CircuitOpen:         sj.circuitOpen,
+	}
 }
 
 // cronSlogAdapter adapts slog.Logger to cron.Logger interface.
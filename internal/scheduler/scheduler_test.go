@@ -227,6 +227,56 @@ func TestScheduler_ListJobs(t *testing.T) {
 	}
 }
 
+func TestScheduler_RemoveJob(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	sched := New(ctx, logger)
+
+	runner := &mockJobRunner{}
+	job := &config.Job{
+		ID:       "remove-test",
+		Schedule: "@every 1s",
+		Command:  config.NewCommandSpec("echo test"),
+	}
+
+	if err := sched.AddJob(job, runner); err != nil {
+		t.Fatalf("AddJob() error = %v", err)
+	}
+	if err := sched.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer sched.Stop()
+
+	// Let it fire at least once before removing it
+	time.Sleep(1500 * time.Millisecond)
+	if runner.runCount.Load() == 0 {
+		t.Fatal("job did not run before removal")
+	}
+
+	if err := sched.RemoveJob("remove-test"); err != nil {
+		t.Fatalf("RemoveJob() error = %v", err)
+	}
+
+	if len(sched.ListJobs()) != 0 {
+		t.Errorf("ListJobs() returned %d jobs after removal, want 0", len(sched.ListJobs()))
+	}
+	if _, exists := sched.GetJob("remove-test"); exists {
+		t.Error("GetJob() found removed job")
+	}
+
+	// The job must stop firing once removed
+	countAfterRemove := runner.runCount.Load()
+	time.Sleep(2 * time.Second)
+	if got := runner.runCount.Load(); got != countAfterRemove {
+		t.Errorf("job ran %d more time(s) after removal", got-countAfterRemove)
+	}
+
+	// Removing an unknown job is an error
+	if err := sched.RemoveJob("remove-test"); err == nil {
+		t.Error("RemoveJob() expected error for unknown job, got nil")
+	}
+}
+
 func TestScheduler_StartStop(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
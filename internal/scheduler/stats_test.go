@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+)
+
+// sleepyRunner holds each run briefly so stats are read while runs are in
+// flight.
+type sleepyRunner struct{}
+
+func (sleepyRunner) Run(ctx context.Context, _ *config.Job) error {
+	select {
+	case <-time.After(time.Millisecond):
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+// TestScheduler_StatsConsistentUnderConcurrency triggers jobs from several
+// goroutines while others read their stats. Run with -race: besides the race
+// detector's checks, every snapshot must be internally consistent and run
+// counts must never go backwards.
+func TestScheduler_StatsConsistentUnderConcurrency(t *testing.T) {
+	sched := New(context.Background(), quietLogger())
+	jobIDs := []string{"alpha", "beta"}
+	for _, id := range jobIDs {
+		job := &config.Job{
+			ID:       id,
+			Schedule: "@every 1s",
+			Command:  config.NewCommandSpec("true"),
+		}
+		if err := sched.AddJob(job, sleepyRunner{}); err != nil {
+			t.Fatalf("AddJob() error = %v", err)
+		}
+	}
+	if err := sched.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	const triggers = 50
+	done := make(chan struct{})
+	var readers, writers sync.WaitGroup
+	errs := make(chan error, len(jobIDs)*4)
+
+	for _, id := range jobIDs {
+		for range 3 {
+			readers.Add(1)
+			go func() {
+				defer readers.Done()
+				var lastCount int64
+				var lastRun time.Time
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					stats, ok := sched.GetJobStats(id)
+					if !ok {
+						errs <- fmt.Errorf("%s: GetJobStats() found no job", id)
+						return
+					}
+					if stats.RunCount < lastCount {
+						errs <- fmt.Errorf("%s: RunCount went from %d to %d", id, lastCount, stats.RunCount)
+						return
+					}
+					if stats.LastRun.Before(lastRun) {
+						errs <- fmt.Errorf("%s: LastRun went from %v to %v", id, lastRun, stats.LastRun)
+						return
+					}
+					if (stats.RunCount > 0) == stats.LastRun.IsZero() {
+						errs <- fmt.Errorf("%s: inconsistent snapshot: RunCount %d, LastRun %v", id, stats.RunCount, stats.LastRun)
+						return
+					}
+					lastCount, lastRun = stats.RunCount, stats.LastRun
+				}
+			}()
+		}
+
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			for range triggers {
+				if _, err := sched.RunJobNow(id); err != nil {
+					errs <- fmt.Errorf("%s: RunJobNow() error = %v", id, err)
+					return
+				}
+			}
+		}()
+	}
+
+	writers.Wait()
+	if err := sched.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	close(done)
+	readers.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	for _, id := range jobIDs {
+		stats, _ := sched.GetJobStats(id)
+		// Scheduled ticks may add to the manual triggers
+		if stats.RunCount < triggers {
+			t.Errorf("%s: RunCount = %d, want at least %d", id, stats.RunCount, triggers)
+		}
+	}
+}
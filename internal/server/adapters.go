@@ -2,121 +2,200 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/caevv/jobster/internal/config"
 	"github.com/caevv/jobster/internal/scheduler"
 	"github.com/caevv/jobster/internal/store"
 )
 
 // StoreAdapter adapts store.Store to server.Store interface
 type StoreAdapter struct {
-	store store.Store
+	store     store.Store
+	scheduler *scheduler.Scheduler // optional: used for job counts in stats
 }
 
-// NewStoreAdapter creates a new store adapter
-func NewStoreAdapter(s store.Store) *StoreAdapter {
-	return &StoreAdapter{store: s}
+// NewStoreAdapter creates a new store adapter. The scheduler may be nil, in
+// which case job counts in stats are reported as zero.
+func NewStoreAdapter(s store.Store, sched *scheduler.Scheduler) *StoreAdapter {
+	return &StoreAdapter{store: s, scheduler: sched}
 }
 
-// GetRuns returns recent runs, optionally filtered by job ID
-func (a *StoreAdapter) GetRuns(ctx context.Context, jobID *string, limit int) ([]RunRecord, error) {
-	var runs []*store.JobRun
-	var err error
-
+// GetRuns returns recent runs, optionally filtered by job ID, skipping the
+// newest offset runs
+func (a *StoreAdapter) GetRuns(ctx context.Context, jobID *string, limit, offset int) ([]RunRecord, error) {
+	filter := store.RunFilter{Limit: limit, Offset: offset}
 	if jobID != nil {
-		runs, err = a.store.GetJobRuns(*jobID, limit)
-	} else {
-		runs, err = a.store.GetAllRuns(limit)
+		filter.JobID = *jobID
 	}
 
+	return a.GetRunsFiltered(ctx, filter)
+}
+
+// GetRun returns a specific run by ID
+func (a *StoreAdapter) GetRun(ctx context.Context, runID string) (*RunRecord, error) {
+	run, err := a.store.GetRun(ctx, runID)
+	if errors.Is(err, store.ErrRunNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	records := make([]RunRecord, len(runs))
-	for i, run := range runs {
-		status := "success"
-		if !run.Success {
-			status = "failure"
-		}
-		if run.IsRunning() {
-			status = "running"
-		}
+	record := runRecord(run)
+	return &record, nil
+}
 
-		records[i] = RunRecord{
-			RunID:     run.RunID,
-			JobID:     run.JobID,
-			StartTime: run.StartTime,
-			EndTime:   run.EndTime,
-			Duration:  float64(run.Duration().Milliseconds()),
-			ExitCode:  run.ExitCode,
-			Status:    status,
-			Stdout:    run.StdoutTail,
-			Stderr:    run.StderrTail,
-		}
+// GetRunsFiltered returns the runs matching filter, newest first
+func (a *StoreAdapter) GetRunsFiltered(ctx context.Context, filter store.RunFilter) ([]RunRecord, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
 	}
 
-	return records, nil
-}
-
-// GetRun returns a specific run by ID
-func (a *StoreAdapter) GetRun(ctx context.Context, runID string) (*RunRecord, error) {
-	run, err := a.store.GetRun(runID)
+	runs, err := a.store.GetRunsFiltered(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	status := "success"
-	if !run.Success {
-		status = "failure"
+	records := make([]RunRecord, len(runs))
+	for i, run := range runs {
+		records[i] = runRecord(run)
 	}
-	if run.IsRunning() {
-		status = "running"
+
+	return records, nil
+}
+
+// CountRuns returns how many runs match filter, ignoring its limit and offset
+func (a *StoreAdapter) CountRuns(ctx context.Context, filter store.RunFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
 	}
 
-	return &RunRecord{
+	return a.store.CountRuns(ctx, filter)
+}
+
+// runRecord converts a stored run to its API representation
+func runRecord(run *store.JobRun) RunRecord {
+	return RunRecord{
 		RunID:     run.RunID,
 		JobID:     run.JobID,
 		StartTime: run.StartTime,
 		EndTime:   run.EndTime,
 		Duration:  float64(run.Duration().Milliseconds()),
 		ExitCode:  run.ExitCode,
-		Status:    status,
+		Status:    runStatus(run),
+		Trigger:   run.Trigger,
 		Stdout:    run.StdoutTail,
 		Stderr:    run.StderrTail,
-	}, nil
+		Metadata:  run.Metadata,
+	}
+}
+
+// runStatus is run.Status(), except that runs killed at their timeout report
+// store.StatusTimeout, and runs that never started because their command or
+// workdir was missing report store.StatusCommandNotFound or
+// store.StatusWorkdirNotFound.
+func runStatus(run *store.JobRun) string {
+	if run.Success {
+		return run.Status()
+	}
+	if run.TimedOut() {
+		return store.StatusTimeout
+	}
+	if status, _ := run.MissingPath(); status != "" {
+		return status
+	}
+	return run.Status()
+}
+
+// GetJobStats returns aggregate stats for one job's runs
+func (a *StoreAdapter) GetJobStats(ctx context.Context, jobID string) (*JobStatsResponse, error) {
+	stats, err := a.store.GetJobStats(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &JobStatsResponse{
+		JobID:         jobID,
+		TotalRuns:     stats.TotalRuns,
+		SuccessCount:  stats.SuccessCount,
+		FailureCount:  stats.FailureCount,
+		RunningCount:  stats.RunningCount,
+		SuccessRate:   stats.SuccessRate,
+		AvgDurationMs: float64(stats.AvgDuration.Milliseconds()),
+	}
+	if !stats.LastRunTime.IsZero() {
+		response.LastRunTime = &stats.LastRunTime
+	}
+
+	return response, nil
+}
+
+// Ping reads one run to check that the store is usable
+func (a *StoreAdapter) Ping(ctx context.Context) error {
+	_, err := a.store.GetAllRuns(ctx, 1)
+	return err
 }
 
 // GetStats returns overall statistics
 func (a *StoreAdapter) GetStats(ctx context.Context) (*StatsResponse, error) {
-	// Get all runs to calculate stats
-	runs, err := a.store.GetAllRuns(1000)
+	storeStats, err := a.store.GetStats(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	stats := &StatsResponse{
-		TotalRuns:    len(runs),
-		SuccessCount: 0,
-		FailureCount: 0,
-		TotalJobs:    0,
-		ActiveJobs:   0,
+		TotalRuns:    storeStats.TotalRuns,
+		SuccessCount: storeStats.SuccessCount,
+		FailureCount: storeStats.FailureCount,
+		SuccessRate:  storeStats.SuccessRate(),
 	}
 
-	for _, run := range runs {
-		if run.Success {
-			stats.SuccessCount++
-		} else {
-			stats.FailureCount++
+	if storeStats.RunningCount > 0 {
+		if stats.ActiveJobs, err = a.activeJobs(ctx, storeStats.RunningCount); err != nil {
+			return nil, err
 		}
 	}
 
+	if a.scheduler != nil {
+		stats.TotalJobs = len(a.scheduler.ListJobs())
+		stats.InFlightRuns = a.scheduler.InFlight()
+		stats.MaxConcurrentJobs = a.scheduler.MaxConcurrentJobs()
+	}
+
 	return stats, nil
 }
 
+// activeJobs counts the distinct jobs among the in-progress runs. A manual
+// trigger can overlap a scheduled run, so one job may have several.
+func (a *StoreAdapter) activeJobs(ctx context.Context, running int) (int, error) {
+	runs, err := a.store.GetRunsFiltered(ctx, store.RunFilter{Status: store.StatusRunning, Limit: running})
+	if err != nil {
+		return 0, err
+	}
+
+	jobs := make(map[string]bool, len(runs))
+	for _, run := range runs {
+		jobs[run.JobID] = true
+	}
+	return len(jobs), nil
+}
+
+// DeleteRun removes a run record
+func (a *StoreAdapter) DeleteRun(ctx context.Context, runID string) error {
+	err := a.store.DeleteRun(ctx, runID)
+	if errors.Is(err, store.ErrRunNotFound) {
+		return fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+	}
+	return err
+}
+
 // SchedulerAdapter adapts scheduler.Scheduler to server.Scheduler interface
 type SchedulerAdapter struct {
 	scheduler *scheduler.Scheduler
+	store     store.Store // optional: used for last run and run counts
 }
 
 // NewSchedulerAdapter creates a new scheduler adapter
@@ -124,28 +203,24 @@ func NewSchedulerAdapter(s *scheduler.Scheduler) *SchedulerAdapter {
 	return &SchedulerAdapter{scheduler: s}
 }
 
+// SetStore lets job summaries include the last run and the success and
+// failure counts from run history. Without a store only the scheduler's
+// in-memory last run time is reported and the counts are zero.
+func (a *SchedulerAdapter) SetStore(st store.Store) {
+	a.store = st
+}
+
 // GetJobs returns all configured jobs with their status
 func (a *SchedulerAdapter) GetJobs(ctx context.Context) ([]JobSummary, error) {
 	jobs := a.scheduler.ListJobs()
 	summaries := make([]JobSummary, 0, len(jobs))
 
 	for _, job := range jobs {
-		stats, _ := a.scheduler.GetJobStats(job.ID)
-
-		summary := JobSummary{
-			ID:       job.ID,
-			Schedule: job.Schedule,
-			Command:  job.Command.String(),
+		summary, err := a.summary(ctx, job)
+		if err != nil {
+			return nil, err
 		}
-
-		if stats != nil && !stats.LastRun.IsZero() {
-			summary.LastRunTime = &stats.LastRun
-		}
-		if stats != nil && !stats.NextRun.IsZero() {
-			summary.NextRunTime = &stats.NextRun
-		}
-
-		summaries = append(summaries, summary)
+		summaries = append(summaries, *summary)
 	}
 
 	return summaries, nil
@@ -158,12 +233,21 @@ func (a *SchedulerAdapter) GetJob(ctx context.Context, jobID string) (*JobSummar
 		return nil, fmt.Errorf("job not found: %s", jobID)
 	}
 
-	stats, _ := a.scheduler.GetJobStats(jobID)
+	return a.summary(ctx, job)
+}
+
+// summary builds a job's summary from the scheduler's state and, when a
+// store is set, its run history. The store's last run wins over the
+// scheduler's, which starts empty after a restart.
+func (a *SchedulerAdapter) summary(ctx context.Context, job *config.Job) (*JobSummary, error) {
+	stats, _ := a.scheduler.GetJobStats(job.ID)
 
 	summary := &JobSummary{
 		ID:       job.ID,
 		Schedule: job.Schedule,
 		Command:  job.Command.String(),
+		Enabled:  job.IsEnabled(),
+		Labels:   job.Labels,
 	}
 
 	if stats != nil && !stats.LastRun.IsZero() {
@@ -172,6 +256,63 @@ func (a *SchedulerAdapter) GetJob(ctx context.Context, jobID string) (*JobSummar
 	if stats != nil && !stats.NextRun.IsZero() {
 		summary.NextRunTime = &stats.NextRun
 	}
+	if stats != nil {
+		summary.ConsecutiveFailures = stats.ConsecutiveFailures
+		summary.CircuitOpen = stats.CircuitOpen
+	}
+
+	if a.store != nil {
+		runStats, err := a.store.GetJobStats(ctx, job.ID)
+		if err != nil {
+			return nil, err
+		}
+		summary.SuccessCount = runStats.SuccessCount
+		summary.FailureCount = runStats.FailureCount
+
+		lastRuns, err := a.store.GetJobRuns(ctx, job.ID, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(lastRuns) > 0 {
+			last := lastRuns[0]
+			status := runStatus(last)
+			summary.LastRunID = &last.RunID
+			summary.LastRunTime = &last.StartTime
+			summary.LastStatus = &status
+		}
+	}
 
 	return summary, nil
 }
+
+// NextRuns returns the job's upcoming fire times
+func (a *SchedulerAdapter) NextRuns(ctx context.Context, jobID string, count int) ([]time.Time, error) {
+	times, err := a.scheduler.NextRuns(jobID, count)
+	if errors.Is(err, scheduler.ErrJobNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+	return times, err
+}
+
+// JobStats returns the scheduler's stats for a job
+func (a *SchedulerAdapter) JobStats(ctx context.Context, jobID string) (*scheduler.JobStats, error) {
+	stats, found := a.scheduler.GetJobStats(jobID)
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+	return stats, nil
+}
+
+// TriggerJob runs a job immediately without affecting its schedule
+func (a *SchedulerAdapter) TriggerJob(ctx context.Context, jobID string) (string, error) {
+	runID, err := a.scheduler.RunJobNow(jobID)
+	if errors.Is(err, scheduler.ErrJobNotFound) {
+		return "", fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+	return runID, err
+}
+
+// Running reports whether the scheduler is started and not stopping
+func (a *SchedulerAdapter) Running() bool {
+	return a.scheduler.Running()
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// SetAuthToken requires every request except the health probes (GET
+// /api/health, /api/livez, /api/readyz) to present token, either as
+// "Authorization: Bearer <token>" or as the password of HTTP Basic auth
+// (which browsers prompt for, so the dashboard stays usable). An empty token
+// leaves the server open. It must be called before Start.
+func (s *Server) SetAuthToken(token string) {
+	s.authToken = token
+}
+
+// probePaths are health check endpoints served without authentication, so
+// load balancers and orchestrators can reach them.
+var probePaths = map[string]bool{
+	"/api/health": true,
+	"/api/livez":  true,
+	"/api/readyz": true,
+}
+
+// authMiddleware rejects requests that do not carry the configured token.
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.authToken == "" || probePaths[r.URL.Path] || s.authorized(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="jobster"`)
+			s.writeError(w, http.StatusUnauthorized, "missing or invalid token", nil)
+			return
+		}
+
+		w.Header().Set("WWW-Authenticate", `Basic realm="jobster"`)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	})
+}
+
+// authorized reports whether r carries the configured token.
+func (s *Server) authorized(r *http.Request) bool {
+	var presented string
+	if _, password, ok := r.BasicAuth(); ok {
+		presented = password
+	} else if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		presented = strings.TrimSpace(bearer)
+	} else {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(presented), []byte(s.authToken)) == 1
+}
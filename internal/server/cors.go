@@ -0,0 +1,68 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// corsMaxAge is how long, in seconds, browsers may cache a preflight result.
+const corsMaxAge = "600"
+
+// corsAllowedHeaders are the request headers allowed when a preflight does
+// not list any.
+const corsAllowedHeaders = "Authorization, Content-Type"
+
+// SetCORSOrigins allows browser apps served from origins (for example
+// "https://ops.example.com") to call the /api/ routes. "*" allows any origin.
+// With no origins, no CORS headers are sent and browsers enforce the
+// same-origin policy. Credentials (cookies, Basic auth) are not allowed
+// cross-origin; browser apps send the bearer token in the Authorization
+// header instead. It must be called before Start.
+func (s *Server) SetCORSOrigins(origins []string) {
+	s.corsOrigins = origins
+}
+
+// corsMiddleware adds CORS headers to /api/ responses for allowed origins
+// and answers their OPTIONS preflight requests. It runs before
+// authMiddleware, since browsers send preflights without credentials.
+func (s *Server) corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if len(s.corsOrigins) == 0 || !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		if origin == "" || !s.corsAllowed(origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Expose-Headers", "X-Total-Count")
+
+		if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Preflight: echo what the browser asked for
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+		headers := r.Header.Get("Access-Control-Request-Headers")
+		if headers == "" {
+			headers = corsAllowedHeaders
+		}
+		w.Header().Set("Access-Control-Allow-Headers", headers)
+		w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// corsAllowed reports whether origin is in the configured allow list.
+func (s *Server) corsAllowed(origin string) bool {
+	return slices.Contains(s.corsOrigins, "*") || slices.Contains(s.corsOrigins, origin)
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/caevv/jobster/internal/events"
+)
+
+const (
+	// sseRetryMs is the reconnect delay suggested to EventSource clients.
+	sseRetryMs = 3000
+
+	// sseKeepAlive is how often a comment line is sent on an idle stream so
+	// proxies do not close the connection.
+	sseKeepAlive = 15 * time.Second
+)
+
+// handleEvents streams run events to the client as Server-Sent Events.
+// Clients resuming a dropped stream send the Last-Event-ID header (or the
+// last_event_id query parameter) and receive the events they missed first.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if s.events == nil {
+		s.writeError(w, http.StatusServiceUnavailable, "event stream not available", nil)
+		return
+	}
+
+	lastID, err := parseLastEventID(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid Last-Event-ID", err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+
+	// The stream outlives the server's WriteTimeout, so lift the deadline for
+	// this connection.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		s.writeError(w, http.StatusInternalServerError, "failed to start event stream", err)
+		return
+	}
+
+	ch, backlog, cancel := s.events.Subscribe(lastID)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetryMs)
+	for _, e := range backlog {
+		if err := writeSSE(w, e); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-ch:
+			if !ok {
+				// Dropped for falling behind; the client reconnects and
+				// replays from its last event ID.
+				return
+			}
+			if err := writeSSE(w, e); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// parseLastEventID reads the resume position from the request, returning 0
+// when the client has none.
+func parseLastEventID(r *http.Request) (uint64, error) {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("last_event_id")
+	}
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(raw, 10, 64)
+}
+
+// writeSSE writes a single event in text/event-stream framing.
+func writeSSE(w http.ResponseWriter, e events.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// gzipMinSize is the smallest response body worth compressing; smaller ones
+// are sent as is.
+const gzipMinSize = 1024
+
+var gzipWriters = sync.Pool{
+	New: func() any { return gzip.NewWriter(nil) },
+}
+
+// gzipMiddleware compresses responses for clients that accept gzip. Bodies
+// under gzipMinSize, responses that already set a Content-Encoding, partial
+// content, and event streams are passed through unchanged.
+func (s *Server) gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead || !acceptsGzip(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.close()
+
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip reports whether the request's Accept-Encoding allows gzip.
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if strings.TrimSpace(coding) != "gzip" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			v, err := strconv.ParseFloat(q, 64)
+			return err == nil && v > 0
+		}
+		return true
+	}
+	return false
+}
+
+// gzipResponseWriter buffers the start of a response until it knows whether
+// to compress it: once gzipMinSize bytes are written, on Flush, or when the
+// handler returns.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	status  int
+	buf     []byte
+	decided bool
+	gz      *gzip.Writer
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = code
+	if !w.compressible() {
+		w.passThrough()
+	}
+}
+
+func (w *gzipResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.decided {
+		w.buf = append(w.buf, p...)
+		if len(w.buf) >= gzipMinSize {
+			if err := w.startGzip(); err != nil {
+				return 0, err
+			}
+		}
+		return len(p), nil
+	}
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush sends what has been written so far. A response flushed before it
+// reaches gzipMinSize is still compressed if eligible, since more is coming.
+func (w *gzipResponseWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.decided {
+		if err := w.startGzip(); err != nil {
+			return
+		}
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// compressible reports whether the response, as described by its status and
+// headers so far, may be compressed.
+func (w *gzipResponseWriter) compressible() bool {
+	switch w.status {
+	case http.StatusNoContent, http.StatusPartialContent, http.StatusNotModified:
+		return false
+	}
+	if w.status < http.StatusOK {
+		return false
+	}
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" || strings.HasPrefix(h.Get("Content-Type"), "text/event-stream") {
+		return false
+	}
+	if n, err := strconv.Atoi(h.Get("Content-Length")); err == nil && n < gzipMinSize {
+		return false
+	}
+	return true
+}
+
+// passThrough sends the header and any buffered body uncompressed.
+func (w *gzipResponseWriter) passThrough() error {
+	w.decided = true
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf)
+	w.buf = nil
+	return err
+}
+
+// startGzip sends the header with gzip encoding and compresses any buffered
+// body.
+func (w *gzipResponseWriter) startGzip() error {
+	w.decided = true
+	h := w.Header()
+	h.Set("Content-Encoding", "gzip")
+	h.Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+
+	w.gz = gzipWriters.Get().(*gzip.Writer)
+	w.gz.Reset(w.ResponseWriter)
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.gz.Write(w.buf)
+	w.buf = nil
+	return err
+}
+
+// close finishes the response once the handler returns.
+func (w *gzipResponseWriter) close() {
+	if !w.decided {
+		if w.status == 0 {
+			// The handler wrote nothing; let net/http send its default
+			return
+		}
+		w.passThrough()
+		return
+	}
+	if w.gz != nil {
+		w.gz.Close()
+		gzipWriters.Put(w.gz)
+		w.gz = nil
+	}
+}
@@ -1,18 +1,29 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/caevv/jobster/internal/config"
+	"github.com/caevv/jobster/internal/store"
 )
 
 const (
 	version      = "v0.1.0"
 	defaultLimit = 100
 	maxLimit     = 1000
+
+	defaultScheduleCount = 5
+	maxScheduleCount     = 100
 )
 
-// handleHealth returns the health status of the server
+// handleHealth returns the health status of the server. It only shows the
+// process is up, and also serves GET /api/livez.
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	response := HealthResponse{
 		Status:  "ok",
@@ -23,6 +34,48 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, response)
 }
 
+// readyTimeout bounds the dependency checks of GET /api/readyz.
+const readyTimeout = 2 * time.Second
+
+// handleReady reports whether the store and scheduler are usable, returning
+// 503 with the failing checks if not.
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+	defer cancel()
+
+	response := ReadyResponse{Status: "ok", Checks: map[string]string{}}
+
+	if s.store == nil {
+		response.Checks["store"] = "not configured"
+	} else if err := s.store.Ping(ctx); err != nil {
+		response.Checks["store"] = err.Error()
+	} else {
+		response.Checks["store"] = "ok"
+	}
+
+	switch {
+	case s.scheduler == nil:
+		response.Checks["scheduler"] = "not configured"
+	case !s.scheduler.Running():
+		response.Checks["scheduler"] = "not running"
+	default:
+		response.Checks["scheduler"] = "ok"
+	}
+
+	status := http.StatusOK
+	for _, check := range response.Checks {
+		if check != "ok" {
+			response.Status = "unavailable"
+			status = http.StatusServiceUnavailable
+		}
+	}
+	if status != http.StatusOK {
+		s.logger.Warn("readiness check failed", "checks", response.Checks)
+	}
+
+	s.writeJSON(w, status, response)
+}
+
 // handleListJobs returns all configured jobs
 func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -32,6 +85,13 @@ func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Optional ?label=key=value filters, repeatable; all must match
+	selector, err := config.ParseLabelSelector(r.URL.Query()["label"])
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
 	jobs, err := s.scheduler.GetJobs(ctx)
 	if err != nil {
 		s.logger.Error("failed to get jobs", "error", err)
@@ -39,6 +99,16 @@ func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(selector) > 0 {
+		matched := make([]JobSummary, 0, len(jobs))
+		for _, job := range jobs {
+			if selector.Matches(job.Labels) {
+				matched = append(matched, job)
+			}
+		}
+		jobs = matched
+	}
+
 	s.writeJSON(w, http.StatusOK, jobs)
 }
 
@@ -83,13 +153,21 @@ func (s *Server) handleGetJobRuns(w http.ResponseWriter, r *http.Request) {
 	}
 
 	limit := s.parseLimitParam(r)
+	offset, err := s.parseOffsetParam(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
 
 	if s.store == nil {
 		s.writeError(w, http.StatusServiceUnavailable, "store not available", nil)
 		return
 	}
 
-	runs, err := s.store.GetRuns(ctx, &jobID, limit)
+	runs, err := s.store.GetRuns(ctx, &jobID, limit, offset)
+	if err == nil {
+		err = s.setTotalCount(w, r, store.RunFilter{JobID: jobID})
+	}
 	if err != nil {
 		s.logger.Error("failed to get job runs", "job_id", jobID, "error", err)
 		s.writeError(w, http.StatusInternalServerError, "failed to retrieve job runs", err)
@@ -99,17 +177,163 @@ func (s *Server) handleGetJobRuns(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, runs)
 }
 
-// handleListRuns returns all recent runs
+// handleGetJobStats returns aggregate run stats for a job
+func (s *Server) handleGetJobStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	jobID := r.PathValue("id")
+
+	if jobID == "" {
+		s.writeError(w, http.StatusBadRequest, "job ID is required", nil)
+		return
+	}
+
+	if s.store == nil {
+		s.writeError(w, http.StatusServiceUnavailable, "store not available", nil)
+		return
+	}
+
+	stats, err := s.store.GetJobStats(ctx, jobID)
+	if err != nil {
+		s.logger.Error("failed to get job stats", "job_id", jobID, "error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to retrieve job stats", err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, stats)
+}
+
+// handleGetSchedulerStats returns the scheduler's in-memory stats for a job:
+// runs started since it was scheduled, last and next run. Unlike
+// handleGetJobStats these do not come from the store.
+func (s *Server) handleGetSchedulerStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	jobID := r.PathValue("id")
+
+	if jobID == "" {
+		s.writeError(w, http.StatusBadRequest, "job ID is required", nil)
+		return
+	}
+
+	if s.scheduler == nil {
+		s.writeError(w, http.StatusServiceUnavailable, "scheduler not available", nil)
+		return
+	}
+
+	stats, err := s.scheduler.JobStats(ctx, jobID)
+	if errors.Is(err, ErrJobNotFound) {
+		s.writeError(w, http.StatusNotFound, "job not found", err)
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to get scheduler stats", "job_id", jobID, "error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to retrieve scheduler stats", err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, stats)
+}
+
+// handleTriggerJob starts a job immediately, outside its schedule
+func (s *Server) handleTriggerJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	jobID := r.PathValue("id")
+
+	if jobID == "" {
+		s.writeError(w, http.StatusBadRequest, "job ID is required", nil)
+		return
+	}
+
+	if s.scheduler == nil {
+		s.writeError(w, http.StatusServiceUnavailable, "scheduler not available", nil)
+		return
+	}
+
+	runID, err := s.scheduler.TriggerJob(ctx, jobID)
+	if errors.Is(err, ErrJobNotFound) {
+		s.writeError(w, http.StatusNotFound, "job not found", err)
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to trigger job", "job_id", jobID, "error", err)
+		s.writeError(w, http.StatusServiceUnavailable, "failed to trigger job", err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusAccepted, TriggerResponse{
+		JobID:  jobID,
+		RunID:  runID,
+		Status: "accepted",
+	})
+}
+
+// handleGetJobSchedule returns a job's next fire times; ?count= picks how
+// many (default 5, at most maxScheduleCount)
+func (s *Server) handleGetJobSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	jobID := r.PathValue("id")
+
+	if jobID == "" {
+		s.writeError(w, http.StatusBadRequest, "job ID is required", nil)
+		return
+	}
+
+	count := defaultScheduleCount
+	if countStr := r.URL.Query().Get("count"); countStr != "" {
+		n, err := strconv.Atoi(countStr)
+		if err != nil || n <= 0 || n > maxScheduleCount {
+			s.writeError(w, http.StatusBadRequest,
+				fmt.Sprintf("count must be an integer between 1 and %d", maxScheduleCount), nil)
+			return
+		}
+		count = n
+	}
+
+	if s.scheduler == nil {
+		s.writeError(w, http.StatusServiceUnavailable, "scheduler not available", nil)
+		return
+	}
+
+	times, err := s.scheduler.NextRuns(ctx, jobID, count)
+	if errors.Is(err, ErrJobNotFound) {
+		s.writeError(w, http.StatusNotFound, "job not found", err)
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to compute schedule", "job_id", jobID, "error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to compute schedule", err)
+		return
+	}
+	if times == nil {
+		times = []time.Time{}
+	}
+
+	s.writeJSON(w, http.StatusOK, ScheduleResponse{JobID: jobID, NextRuns: times})
+}
+
+// handleListRuns returns recent runs, newest first. ?status=, ?since= and
+// ?until= (RFC 3339) filter them; ?limit= and ?offset= page through them.
 func (s *Server) handleListRuns(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	limit := s.parseLimitParam(r)
+
+	filter, err := s.parseRunFilter(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
 
 	if s.store == nil {
 		s.writeError(w, http.StatusServiceUnavailable, "store not available", nil)
 		return
 	}
 
-	runs, err := s.store.GetRuns(ctx, nil, limit)
+	runs, err := s.store.GetRunsFiltered(ctx, filter)
+	if err == nil {
+		err = s.setTotalCount(w, r, filter)
+	}
+	if errors.Is(err, ErrInvalidFilter) {
+		s.writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
 	if err != nil {
 		s.logger.Error("failed to get runs", "error", err)
 		s.writeError(w, http.StatusInternalServerError, "failed to retrieve runs", err)
@@ -119,6 +343,45 @@ func (s *Server) handleListRuns(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, runs)
 }
 
+// parseRunFilter builds a run filter from the status, since, until, limit
+// and offset query parameters.
+func (s *Server) parseRunFilter(r *http.Request) (store.RunFilter, error) {
+	query := r.URL.Query()
+	filter := store.RunFilter{
+		Status: query.Get("status"),
+		Limit:  s.parseLimitParam(r),
+	}
+
+	for name, dst := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if v := query.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return filter, fmt.Errorf("%s must be an RFC 3339 timestamp", name)
+			}
+			*dst = t
+		}
+	}
+
+	offset, err := s.parseOffsetParam(r)
+	if err != nil {
+		return filter, err
+	}
+	filter.Offset = offset
+
+	return filter, nil
+}
+
+// setTotalCount sets the X-Total-Count header to the number of runs matching
+// filter, so clients paging with limit and offset know when to stop.
+func (s *Server) setTotalCount(w http.ResponseWriter, r *http.Request, filter store.RunFilter) error {
+	total, err := s.store.CountRuns(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	return nil
+}
+
 // handleGetRun returns a specific run by ID
 func (s *Server) handleGetRun(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -149,6 +412,36 @@ func (s *Server) handleGetRun(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, run)
 }
 
+// handleDeleteRun removes a run record
+func (s *Server) handleDeleteRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	runID := r.PathValue("id")
+
+	if runID == "" {
+		s.writeError(w, http.StatusBadRequest, "run ID is required", nil)
+		return
+	}
+
+	if s.store == nil {
+		s.writeError(w, http.StatusServiceUnavailable, "store not available", nil)
+		return
+	}
+
+	err := s.store.DeleteRun(ctx, runID)
+	if errors.Is(err, ErrRunNotFound) {
+		s.writeError(w, http.StatusNotFound, "run not found", err)
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to delete run", "run_id", runID, "error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to delete run", err)
+		return
+	}
+
+	s.logger.Info("run deleted", "run_id", runID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // handleGetStats returns overall statistics
 func (s *Server) handleGetStats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -168,6 +461,16 @@ func (s *Server) handleGetStats(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, stats)
 }
 
+// handleMetrics serves Prometheus metrics when enabled
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if s.metrics == nil {
+		s.writeError(w, http.StatusNotFound, "metrics not enabled", nil)
+		return
+	}
+
+	s.metrics.ServeHTTP(w, r)
+}
+
 // parseLimitParam parses the limit query parameter
 func (s *Server) parseLimitParam(r *http.Request) int {
 	limitStr := r.URL.Query().Get("limit")
@@ -191,6 +494,21 @@ func (s *Server) parseLimitParam(r *http.Request) int {
 	return limit
 }
 
+// parseOffsetParam parses the offset query parameter, which defaults to 0
+func (s *Server) parseOffsetParam(r *http.Request) (int, error) {
+	offsetStr := r.URL.Query().Get("offset")
+	if offsetStr == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("offset must be a non-negative integer")
+	}
+
+	return offset, nil
+}
+
 // writeJSON writes a JSON response
 func (s *Server) writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// tailChunkSize is how much of a log file is read per step when scanning
+// backwards for the last N lines.
+const tailChunkSize = 32 * 1024
+
+// handleGetRunLogs streams a run's full stdout or stderr log from the history
+// directory. ?stream= selects stdout (default) or stderr; ?tail=N returns only
+// the last N lines. A download may take longer than writeTimeout as long as
+// the client keeps reading.
+func (s *Server) handleGetRunLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	runID := r.PathValue("id")
+
+	if runID == "" {
+		s.writeError(w, http.StatusBadRequest, "run ID is required", nil)
+		return
+	}
+
+	stream := r.URL.Query().Get("stream")
+	if stream == "" {
+		stream = "stdout"
+	}
+	if stream != "stdout" && stream != "stderr" {
+		s.writeError(w, http.StatusBadRequest, "stream must be stdout or stderr", nil)
+		return
+	}
+
+	tail := 0
+	if tailStr := r.URL.Query().Get("tail"); tailStr != "" {
+		n, err := strconv.Atoi(tailStr)
+		if err != nil || n <= 0 {
+			s.writeError(w, http.StatusBadRequest, "tail must be a positive integer", nil)
+			return
+		}
+		tail = n
+	}
+
+	if s.store == nil || s.historyDir == "" {
+		s.writeError(w, http.StatusServiceUnavailable, "run logs not available", nil)
+		return
+	}
+
+	run, err := s.store.GetRun(ctx, runID)
+	if errors.Is(err, ErrRunNotFound) {
+		s.writeError(w, http.StatusNotFound, "run not found", err)
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to get run", "run_id", runID, "error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to retrieve run", err)
+		return
+	}
+
+	path, ok := s.runLogPath(run.JobID, run.RunID, stream)
+	if !ok {
+		s.writeError(w, http.StatusNotFound, "log not found", nil)
+		return
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// Runs without output, or whose logs were cleaned up, have no file
+		s.writeError(w, http.StatusNotFound, "log not found", nil)
+		return
+	}
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, "failed to open log", err)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, "failed to open log", err)
+		return
+	}
+
+	w = newProgressWriter(w, writeTimeout)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	if tail == 0 {
+		http.ServeContent(w, r, filepath.Base(path), info.ModTime(), f)
+		return
+	}
+
+	data, err := tailLines(f, info.Size(), tail)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, "failed to read log", err)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Write(data)
+}
+
+// runLogPath returns the file the runner writes a run's stream to. ok is
+// false if the IDs could escape the history directory.
+func (s *Server) runLogPath(jobID, runID, stream string) (string, bool) {
+	for _, id := range []string{jobID, runID} {
+		if id == "" || id == "." || id == ".." || filepath.Base(id) != id {
+			return "", false
+		}
+	}
+	return filepath.Join(s.historyDir, jobID, fmt.Sprintf("%s.%s.log", runID, stream)), true
+}
+
+// tailLines returns the last n lines of the first size bytes of r, reading
+// backwards so large logs are not loaded whole. A trailing newline does not
+// count as an extra empty line.
+func tailLines(r io.ReaderAt, size int64, n int) ([]byte, error) {
+	var buf []byte
+	offset := size
+
+	for offset > 0 {
+		chunk := min(int64(tailChunkSize), offset)
+		offset -= chunk
+
+		part := make([]byte, chunk)
+		if _, err := r.ReadAt(part, offset); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		buf = append(part, buf...)
+
+		// buf always ends at the end of the file; find the newline that
+		// precedes the n-th line from the end
+		search := bytes.TrimSuffix(buf, []byte{'\n'})
+		if bytes.Count(search, []byte{'\n'}) >= n {
+			cut := len(search)
+			for range n {
+				cut = bytes.LastIndexByte(search[:cut], '\n')
+			}
+			return buf[cut+1:], nil
+		}
+	}
+
+	return buf, nil
+}
+
+// progressWriter moves the connection's write deadline forward before every
+// write, so a response is cut off only when a single write stalls for the
+// timeout, not when the whole body takes longer than the server's
+// WriteTimeout.
+type progressWriter struct {
+	http.ResponseWriter
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+func newProgressWriter(w http.ResponseWriter, timeout time.Duration) *progressWriter {
+	return &progressWriter{ResponseWriter: w, rc: http.NewResponseController(w), timeout: timeout}
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	if err := w.rc.SetWriteDeadline(time.Now().Add(w.timeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return 0, err
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *progressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
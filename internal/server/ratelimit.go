@@ -0,0 +1,114 @@
+package server
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterIdle is how long a client's limiter is kept after its last
+// request. By then its bucket has refilled, so dropping it changes nothing.
+const rateLimiterIdle = 5 * time.Minute
+
+// SetRateLimit limits each client IP to rps requests per second to /api/
+// routes, allowing bursts of up to burst requests. Requests over the limit
+// get 429 with a Retry-After header. The dashboard pages, the event stream,
+// the WebSocket and the health probes are never limited. rps <= 0 disables
+// limiting; burst <= 0 defaults to rps rounded up. It must be called before
+// Start.
+func (s *Server) SetRateLimit(rps float64, burst int) {
+	if rps <= 0 {
+		s.limiters = nil
+		return
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(rps))
+	}
+	s.limiters = &ipLimiters{
+		limit: rate.Limit(rps),
+		burst: burst,
+		byIP:  make(map[string]*ipLimiter),
+	}
+}
+
+// ipLimiters holds a token bucket per client IP.
+type ipLimiters struct {
+	limit rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	byIP      map[string]*ipLimiter
+	lastSweep time.Time
+}
+
+type ipLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// get returns the limiter for ip, creating it on first use. Limiters idle
+// for longer than rateLimiterIdle are dropped along the way.
+func (l *ipLimiters) get(ip string, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > rateLimiterIdle {
+		for key, entry := range l.byIP {
+			if now.Sub(entry.lastSeen) > rateLimiterIdle {
+				delete(l.byIP, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	entry, ok := l.byIP[ip]
+	if !ok {
+		entry = &ipLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.byIP[ip] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter
+}
+
+// rateLimitMiddleware rejects /api/ requests from clients over their limit.
+func (s *Server) rateLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.limiters == nil || !rateLimited(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		now := time.Now()
+		reservation := s.limiters.get(clientIP(r), now).ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			// Give the token back; the request is rejected, not queued
+			reservation.CancelAt(now)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			s.writeError(w, http.StatusTooManyRequests, "rate limit exceeded", nil)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimited reports whether requests to path count against the limit.
+func rateLimited(path string) bool {
+	return strings.HasPrefix(path, "/api/") && path != "/api/events" && path != "/api/ws" && !probePaths[path]
+}
+
+// clientIP returns the IP part of the request's remote address. Proxy
+// headers such as X-Forwarded-For are ignored, since clients can forge them.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -2,25 +2,56 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"os"
 	"sync"
 	"time"
+
+	"github.com/caevv/jobster/internal/events"
+	"github.com/caevv/jobster/internal/scheduler"
+	"github.com/caevv/jobster/internal/store"
 )
 
+// writeTimeout bounds writing a whole response. Streaming routes lift it:
+// the event stream entirely, log downloads in favor of a per-write deadline
+// (see progressWriter).
+const writeTimeout = 15 * time.Second
+
 // Store defines the interface for accessing job run history
 type Store interface {
-	// GetRuns returns recent runs, optionally filtered by job ID
-	GetRuns(ctx context.Context, jobID *string, limit int) ([]RunRecord, error)
+	// GetRuns returns recent runs, optionally filtered by job ID, skipping
+	// the newest offset runs
+	GetRuns(ctx context.Context, jobID *string, limit, offset int) ([]RunRecord, error)
+
+	// GetRunsFiltered returns the runs matching filter, newest first. It
+	// returns an error wrapping ErrInvalidFilter if the filter is invalid.
+	GetRunsFiltered(ctx context.Context, filter store.RunFilter) ([]RunRecord, error)
 
-	// GetRun returns a specific run by ID
+	// CountRuns returns how many runs match filter, ignoring its limit and
+	// offset. It wraps ErrInvalidFilter like GetRunsFiltered.
+	CountRuns(ctx context.Context, filter store.RunFilter) (int, error)
+
+	// GetRun returns a specific run by ID. It returns an error wrapping
+	// ErrRunNotFound if the run does not exist.
 	GetRun(ctx context.Context, runID string) (*RunRecord, error)
 
 	// GetStats returns overall statistics
 	GetStats(ctx context.Context) (*StatsResponse, error)
+
+	// GetJobStats returns aggregate stats for one job's runs
+	GetJobStats(ctx context.Context, jobID string) (*JobStatsResponse, error)
+
+	// Ping checks that the underlying store can be read.
+	Ping(ctx context.Context) error
+
+	// DeleteRun removes a run record. It returns an error wrapping
+	// ErrRunNotFound if the run does not exist.
+	DeleteRun(ctx context.Context, runID string) error
 }
 
 // Scheduler defines the interface for accessing scheduler state
@@ -30,14 +61,57 @@ type Scheduler interface {
 
 	// GetJob returns a specific job by ID
 	GetJob(ctx context.Context, jobID string) (*JobSummary, error)
+
+	// TriggerJob starts a run of the job immediately and returns its run ID.
+	// It returns an error wrapping ErrJobNotFound if the job does not exist.
+	TriggerJob(ctx context.Context, jobID string) (runID string, err error)
+
+	// NextRuns returns the next count times the job is scheduled to fire.
+	// It returns an error wrapping ErrJobNotFound if the job does not exist.
+	NextRuns(ctx context.Context, jobID string, count int) ([]time.Time, error)
+
+	// JobStats returns the scheduler's own view of a job, independent of
+	// stored run history. It returns an error wrapping ErrJobNotFound if
+	// the job does not exist.
+	JobStats(ctx context.Context, jobID string) (*scheduler.JobStats, error)
+
+	// Running reports whether the scheduler is started and not stopping.
+	Running() bool
 }
 
+// EventSource provides the run events streamed by GET /api/events.
+// *events.Bus implements it.
+type EventSource interface {
+	// Subscribe registers a subscriber, returning the retained events
+	// published after lastID (when non-zero) and a cancel function.
+	Subscribe(lastID uint64) (ch <-chan events.Event, backlog []events.Event, cancel func())
+}
+
+// ErrJobNotFound is returned by Scheduler implementations for unknown job IDs.
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrRunNotFound is returned by Store implementations for unknown run IDs.
+var ErrRunNotFound = errors.New("run not found")
+
+// ErrInvalidFilter is returned by Store implementations for run filters
+// that fail validation.
+var ErrInvalidFilter = errors.New("invalid run filter")
+
 // Server represents the HTTP server for the Jobster dashboard
 type Server struct {
-	addr      string
-	store     Store
-	scheduler Scheduler
-	logger    *slog.Logger
+	addr        string
+	store       Store
+	scheduler   Scheduler
+	historyDir  string
+	events      EventSource
+	metrics     http.Handler
+	authToken   string
+	corsOrigins []string
+	refresh     time.Duration
+	limiters    *ipLimiters // nil = no rate limit
+	tlsCert     string
+	tlsKey      string
+	logger      *slog.Logger
 
 	srv       *http.Server
 	router    *http.ServeMux
@@ -47,19 +121,22 @@ type Server struct {
 	started bool
 }
 
-// New creates a new Server instance
-func New(addr string, store Store, scheduler Scheduler, logger *slog.Logger) *Server {
+// New creates a new Server instance. historyDir is where the runner saves
+// full run logs (<historyDir>/<job>/<run>.stdout.log); empty disables
+// GET /api/runs/{id}/logs.
+func New(addr string, store Store, scheduler Scheduler, historyDir string, logger *slog.Logger) *Server {
 	if logger == nil {
 		logger = slog.Default()
 	}
 
 	s := &Server{
-		addr:      addr,
-		store:     store,
-		scheduler: scheduler,
-		logger:    logger,
-		startTime: time.Now(),
-		router:    http.NewServeMux(),
+		addr:       addr,
+		store:      store,
+		scheduler:  scheduler,
+		historyDir: historyDir,
+		logger:     logger,
+		startTime:  time.Now(),
+		router:     http.NewServeMux(),
 	}
 
 	// Register routes
@@ -72,16 +149,74 @@ func New(addr string, store Store, scheduler Scheduler, logger *slog.Logger) *Se
 func (s *Server) registerRoutes() {
 	// API routes
 	s.router.HandleFunc("GET /api/health", s.handleHealth)
+	s.router.HandleFunc("GET /api/livez", s.handleHealth)
+	s.router.HandleFunc("GET /api/readyz", s.handleReady)
 	s.router.HandleFunc("GET /api/jobs", s.handleListJobs)
 	s.router.HandleFunc("GET /api/jobs/{id}", s.handleGetJob)
 	s.router.HandleFunc("GET /api/jobs/{id}/runs", s.handleGetJobRuns)
+	s.router.HandleFunc("GET /api/jobs/{id}/schedule", s.handleGetJobSchedule)
+	s.router.HandleFunc("GET /api/jobs/{id}/stats", s.handleGetJobStats)
+	s.router.HandleFunc("GET /api/jobs/{id}/scheduler-stats", s.handleGetSchedulerStats)
+	s.router.HandleFunc("POST /api/jobs/{id}/run", s.handleTriggerJob)
 	s.router.HandleFunc("GET /api/runs", s.handleListRuns)
 	s.router.HandleFunc("GET /api/runs/{id}", s.handleGetRun)
+	s.router.HandleFunc("GET /api/runs/{id}/logs", s.handleGetRunLogs)
+	s.router.HandleFunc("DELETE /api/runs/{id}", s.handleDeleteRun)
 	s.router.HandleFunc("GET /api/stats", s.handleGetStats)
+	s.router.HandleFunc("GET /api/events", s.handleEvents)
+	s.router.HandleFunc("GET /api/ws", s.handleWebSocket)
+	s.router.HandleFunc("GET /metrics", s.handleMetrics)
 
 	// UI routes
 	s.router.HandleFunc("GET /", s.handleDashboard)
 	s.router.HandleFunc("GET /jobs/{id}", s.handleJobDetail)
+	s.router.HandleFunc("GET /runs/{id}", s.handleRunDetail)
+}
+
+// SetEventSource enables the live event stream. It must be called before Start.
+func (s *Server) SetEventSource(src EventSource) {
+	s.events = src
+}
+
+// SetDashboardRefresh makes the dashboard page reload itself every d via a
+// <meta http-equiv="refresh"> tag, as a fallback for browsers that lose the
+// live event stream. Zero disables it. It must be called before Start.
+func (s *Server) SetDashboardRefresh(d time.Duration) {
+	s.refresh = d
+}
+
+// SetMetricsHandler enables GET /metrics, served by h. It must be called
+// before Start.
+func (s *Server) SetMetricsHandler(h http.Handler) {
+	s.metrics = h
+}
+
+// SetTLS makes Start serve HTTPS with the given PEM certificate and key
+// files. Both files are read now, so a missing, unreadable or mismatched pair
+// is reported before the server starts. It must be called before Start.
+func (s *Server) SetTLS(certFile, keyFile string) error {
+	if certFile == "" || keyFile == "" {
+		return errors.New("TLS requires both a certificate and a key file")
+	}
+	for _, path := range []string{certFile, keyFile} {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("TLS file not readable: %w", err)
+		}
+		f.Close()
+	}
+	if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		return fmt.Errorf("invalid TLS certificate/key pair: %w", err)
+	}
+
+	s.tlsCert = certFile
+	s.tlsKey = keyFile
+	return nil
+}
+
+// Handler returns the server's HTTP handler with middleware applied.
+func (s *Server) Handler() http.Handler {
+	return s.loggingMiddleware(s.gzipMiddleware(s.corsMiddleware(s.rateLimitMiddleware(s.authMiddleware(s.router)))))
 }
 
 // Start starts the HTTP server with graceful shutdown support
@@ -96,18 +231,25 @@ func (s *Server) Start(ctx context.Context) error {
 
 	s.srv = &http.Server{
 		Addr:         s.addr,
-		Handler:      s.loggingMiddleware(s.router),
+		Handler:      s.Handler(),
 		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
+		WriteTimeout: writeTimeout,
 		IdleTimeout:  60 * time.Second,
 		BaseContext:  func(_ net.Listener) context.Context { return ctx },
 	}
 
-	s.logger.Info("starting HTTP server", "addr", s.addr)
+	tlsEnabled := s.tlsCert != ""
+	s.logger.Info("starting HTTP server", "addr", s.addr, "tls", tlsEnabled)
 
 	errCh := make(chan error, 1)
 	go func() {
-		if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		var err error
+		if tlsEnabled {
+			err = s.srv.ListenAndServeTLS(s.tlsCert, s.tlsKey)
+		} else {
+			err = s.srv.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			errCh <- fmt.Errorf("server failed: %w", err)
 		}
 	}()
@@ -180,6 +322,12 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap exposes the underlying writer to http.ResponseController so
+// streaming handlers can flush and adjust deadlines.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // Uptime returns the server uptime as a string
 func (s *Server) Uptime() string {
 	duration := time.Since(s.startTime)
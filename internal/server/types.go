@@ -4,15 +4,23 @@ import "time"
 
 // JobSummary represents a configured job with its status
 type JobSummary struct {
-	ID           string     `json:"id"`
-	Schedule     string     `json:"schedule"`
-	Command      string     `json:"command"`
-	LastRunID    *string    `json:"last_run_id,omitempty"`
-	LastRunTime  *time.Time `json:"last_run_time,omitempty"`
-	LastStatus   *string    `json:"last_status,omitempty"`
-	NextRunTime  *time.Time `json:"next_run_time,omitempty"`
-	SuccessCount int        `json:"success_count"`
-	FailureCount int        `json:"failure_count"`
+	ID           string            `json:"id"`
+	Schedule     string            `json:"schedule"`
+	Command      string            `json:"command"`
+	Enabled      bool              `json:"enabled"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	LastRunID    *string           `json:"last_run_id,omitempty"`
+	LastRunTime  *time.Time        `json:"last_run_time,omitempty"`
+	LastStatus   *string           `json:"last_status,omitempty"`
+	NextRunTime  *time.Time        `json:"next_run_time,omitempty"`
+	SuccessCount int               `json:"success_count"`
+	FailureCount int               `json:"failure_count"`
+
+	// ConsecutiveFailures counts failed runs since the last success, and
+	// CircuitOpen reports that the job was paused for reaching
+	// defaults.failure_threshold
+	ConsecutiveFailures int  `json:"consecutive_failures,omitempty"`
+	CircuitOpen         bool `json:"circuit_open,omitempty"`
 }
 
 // RunRecord represents a single job execution
@@ -24,9 +32,27 @@ type RunRecord struct {
 	Duration  float64   `json:"duration_ms"`
 	ExitCode  int       `json:"exit_code"`
 	Status    string    `json:"status"`
+	Trigger   string    `json:"trigger,omitempty"` // schedule, manual, dependency, catchup, or restart
 	Stdout    string    `json:"stdout,omitempty"`
 	Stderr    string    `json:"stderr,omitempty"`
 	Error     string    `json:"error,omitempty"`
+
+	// Metadata carries extra context recorded with the run, such as the
+	// attempt count and hook results
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// TriggerResponse represents the response to a manual job trigger
+type TriggerResponse struct {
+	JobID  string `json:"job_id"`
+	RunID  string `json:"run_id"`
+	Status string `json:"status"`
+}
+
+// ScheduleResponse lists a job's upcoming fire times
+type ScheduleResponse struct {
+	JobID    string      `json:"job_id"`
+	NextRuns []time.Time `json:"next_runs"`
 }
 
 // HealthResponse represents the health check response
@@ -36,6 +62,13 @@ type HealthResponse struct {
 	Uptime  string `json:"uptime"`
 }
 
+// ReadyResponse represents the readiness check response. Checks maps each
+// dependency ("store", "scheduler") to "ok" or the reason it is not ready.
+type ReadyResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -45,9 +78,27 @@ type ErrorResponse struct {
 
 // StatsResponse represents overall statistics
 type StatsResponse struct {
-	TotalJobs    int `json:"total_jobs"`
-	TotalRuns    int `json:"total_runs"`
-	SuccessCount int `json:"success_count"`
-	FailureCount int `json:"failure_count"`
-	ActiveJobs   int `json:"active_jobs"`
+	TotalJobs    int     `json:"total_jobs"` // jobs registered with the scheduler
+	TotalRuns    int     `json:"total_runs"`
+	SuccessCount int     `json:"success_count"`
+	FailureCount int     `json:"failure_count"`
+	SuccessRate  float64 `json:"success_rate"` // successes / completed runs, 0 to 1
+	ActiveJobs   int     `json:"active_jobs"`  // jobs with a run in progress
+
+	// InFlightRuns counts the runs this scheduler is executing right now;
+	// MaxConcurrentJobs is its limit on them (0 = unlimited).
+	InFlightRuns      int `json:"in_flight_runs"`
+	MaxConcurrentJobs int `json:"max_concurrent_jobs"`
+}
+
+// JobStatsResponse aggregates one job's run history
+type JobStatsResponse struct {
+	JobID         string     `json:"job_id"`
+	TotalRuns     int        `json:"total_runs"`
+	SuccessCount  int        `json:"success_count"`
+	FailureCount  int        `json:"failure_count"`
+	RunningCount  int        `json:"running_count"`
+	SuccessRate   float64    `json:"success_rate"` // successes / completed runs, 0 to 1
+	AvgDurationMs float64    `json:"avg_duration_ms"`
+	LastRunTime   *time.Time `json:"last_run_time,omitempty"`
 }
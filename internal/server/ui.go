@@ -1,9 +1,11 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"html/template"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -26,7 +28,7 @@ func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if s.store != nil {
-		fetchedRuns, err := s.store.GetRuns(ctx, nil, 20)
+		fetchedRuns, err := s.store.GetRuns(ctx, nil, 20, 0)
 		if err != nil {
 			s.logger.Error("failed to get runs for dashboard", "error", err)
 		} else {
@@ -49,6 +51,8 @@ func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
 		Stats:   stats,
 		Version: version,
 		Uptime:  s.Uptime(),
+
+		RefreshSec: int(s.refresh / time.Second),
 	}
 
 	// Render template
@@ -86,7 +90,7 @@ func (s *Server) handleJobDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if s.store != nil {
-		fetchedRuns, err := s.store.GetRuns(ctx, &jobID, 50)
+		fetchedRuns, err := s.store.GetRuns(ctx, &jobID, 50, 0)
 		if err != nil {
 			s.logger.Error("failed to get runs for job detail", "job_id", jobID, "error", err)
 		} else {
@@ -116,6 +120,49 @@ func (s *Server) handleJobDetail(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleRunDetail serves the run detail page
+func (s *Server) handleRunDetail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	runID := r.PathValue("id")
+
+	if runID == "" {
+		http.Error(w, "Run ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if s.store == nil {
+		http.Error(w, "Store not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	run, err := s.store.GetRun(ctx, runID)
+	if errors.Is(err, ErrRunNotFound) {
+		http.Error(w, "Run not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to get run for detail page", "run_id", runID, "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// Prepare template data
+	data := RunDetailData{
+		Title:   "Run: " + run.RunID,
+		Run:     run,
+		HasLogs: s.historyDir != "",
+	}
+
+	// Render template
+	tmpl := template.Must(template.New("rundetail").Funcs(templateFuncs).Parse(runDetailTemplate))
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if err := tmpl.Execute(w, data); err != nil {
+		s.logger.Error("failed to render run detail template", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
 // DashboardData holds data for the dashboard template
 type DashboardData struct {
 	Title   string
@@ -124,6 +171,8 @@ type DashboardData struct {
 	Stats   *StatsResponse
 	Version string
 	Uptime  string
+
+	RefreshSec int // reload interval for the meta refresh tag; 0 omits it
 }
 
 // JobDetailData holds data for the job detail template
@@ -133,6 +182,13 @@ type JobDetailData struct {
 	Runs  []RunRecord
 }
 
+// RunDetailData holds data for the run detail template
+type RunDetailData struct {
+	Title   string
+	Run     *RunRecord
+	HasLogs bool // whether full logs can be fetched from /api/runs/{id}/logs
+}
+
 // templateFuncs provides custom template functions
 var templateFuncs = template.FuncMap{
 	"formatTime": func(t *time.Time) string {
@@ -169,10 +225,20 @@ var templateFuncs = template.FuncMap{
 			return template.HTML(`<span class="badge badge-danger">failure</span>`)
 		case "running":
 			return template.HTML(`<span class="badge badge-info">running</span>`)
+		case "timeout":
+			return template.HTML(`<span class="badge badge-warning">timeout</span>`)
+		case "command_not_found":
+			return template.HTML(`<span class="badge badge-danger">command not found</span>`)
+		case "workdir_not_found":
+			return template.HTML(`<span class="badge badge-danger">workdir not found</span>`)
 		default:
 			return template.HTML(`<span class="badge badge-secondary">` + template.HTMLEscapeString(s) + `</span>`)
 		}
 	},
+	"circuitBadge": func(failures int) template.HTML {
+		title := fmt.Sprintf("paused after %d consecutive failures", failures)
+		return template.HTML(`<span class="badge badge-danger" title="` + template.HTMLEscapeString(title) + `">circuit open</span>`)
+	},
 	"exitCodeBadge": func(code int) template.HTML {
 		if code == 0 {
 			return template.HTML(`<span class="badge badge-success">0</span>`)
@@ -185,6 +251,27 @@ var templateFuncs = template.FuncMap{
 		}
 		return s[:max] + "..."
 	},
+	"add": func(a, b int) int { return a + b },
+	"percent": func(rate float64) string {
+		return fmt.Sprintf("%.1f%%", rate*100)
+	},
+	// sortTime renders a time as Unix milliseconds for data-* sort keys;
+	// nil renders empty so unset times sort last.
+	"sortTime": func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	},
+	// sortRate renders a job's success rate (0 to 1) for data-* sort keys;
+	// jobs without completed runs render empty.
+	"sortRate": func(j JobSummary) string {
+		total := j.SuccessCount + j.FailureCount
+		if total == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(float64(j.SuccessCount)/float64(total), 'f', 4, 64)
+	},
 }
 
 // dashboardTemplate is the main dashboard HTML template
@@ -193,6 +280,7 @@ const dashboardTemplate = `<!DOCTYPE html>
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    {{if .RefreshSec}}<meta http-equiv="refresh" content="{{.RefreshSec}}">{{end}}
     <title>{{.Title}}</title>
     <style>
         * { margin: 0; padding: 0; box-sizing: border-box; }
@@ -215,11 +303,18 @@ const dashboardTemplate = `<!DOCTYPE html>
         .badge-success { background: #d4edda; color: #155724; }
         .badge-danger { background: #f8d7da; color: #721c24; }
         .badge-info { background: #d1ecf1; color: #0c5460; }
+        .badge-warning { background: #fff3cd; color: #856404; }
         .badge-secondary { background: #e2e3e5; color: #383d41; }
         .empty { text-align: center; padding: 40px; color: #7f8c8d; }
         a { color: #3498db; text-decoration: none; }
         a:hover { text-decoration: underline; }
         code { background: #f8f9fa; padding: 2px 6px; border-radius: 3px; font-family: monospace; font-size: 13px; }
+        .toolbar { display: flex; gap: 15px; align-items: center; margin-bottom: 15px; font-size: 14px; }
+        .toolbar input[type=search] { padding: 6px 10px; border: 1px solid #dee2e6; border-radius: 4px; min-width: 250px; }
+        th[data-sort] { cursor: pointer; user-select: none; }
+        th[data-sort]::after { content: " \2195"; color: #bdc3c7; }
+        th[aria-sort=ascending]::after { content: " \2191"; color: #2c3e50; }
+        th[aria-sort=descending]::after { content: " \2193"; color: #2c3e50; }
     </style>
 </head>
 <body>
@@ -243,7 +338,7 @@ const dashboardTemplate = `<!DOCTYPE html>
             </div>
             <div class="stat-card">
                 <h3>Success Rate</h3>
-                <div class="value">{{if gt .Stats.TotalRuns 0}}{{printf "%.1f%%" (mul (div (float64 .Stats.SuccessCount) (float64 .Stats.TotalRuns)) 100)}}{{else}}N/A{{end}}</div>
+                <div class="value">{{if gt (add .Stats.SuccessCount .Stats.FailureCount) 0}}{{percent .Stats.SuccessRate}}{{else}}N/A{{end}}</div>
             </div>
             <div class="stat-card">
                 <h3>Active Jobs</h3>
@@ -255,25 +350,28 @@ const dashboardTemplate = `<!DOCTYPE html>
         <div class="section">
             <h2>Jobs ({{len .Jobs}})</h2>
             {{if .Jobs}}
-            <table>
+            <div class="toolbar">
+                <input type="search" id="job-filter" placeholder="Filter by job ID" aria-label="Filter jobs by ID">
+            </div>
+            <table id="jobs-table">
                 <thead>
                     <tr>
                         <th>Job ID</th>
                         <th>Schedule</th>
                         <th>Command</th>
                         <th>Last Status</th>
-                        <th>Last Run</th>
-                        <th>Next Run</th>
-                        <th>Success/Fail</th>
+                        <th data-sort="last-run">Last Run</th>
+                        <th data-sort="next-run">Next Run</th>
+                        <th data-sort="success-rate">Success/Fail</th>
                     </tr>
                 </thead>
                 <tbody>
                     {{range .Jobs}}
-                    <tr>
+                    <tr data-job-id="{{.ID}}" data-last-run="{{sortTime .LastRunTime}}" data-next-run="{{sortTime .NextRunTime}}" data-success-rate="{{sortRate .}}">
                         <td><a href="/jobs/{{.ID}}">{{.ID}}</a></td>
                         <td><code>{{.Schedule}}</code></td>
                         <td><code>{{truncate .Command 50}}</code></td>
-                        <td>{{statusBadge .LastStatus}}</td>
+                        <td>{{statusBadge .LastStatus}}{{if .CircuitOpen}} {{circuitBadge .ConsecutiveFailures}}{{end}}</td>
                         <td>{{formatTime .LastRunTime}}</td>
                         <td>{{formatTime .NextRunTime}}</td>
                         <td>{{.SuccessCount}} / {{.FailureCount}}</td>
@@ -289,7 +387,10 @@ const dashboardTemplate = `<!DOCTYPE html>
         <div class="section">
             <h2>Recent Runs ({{len .Runs}})</h2>
             {{if .Runs}}
-            <table>
+            <div class="toolbar">
+                <label><input type="checkbox" id="failures-only"> Show failures only</label>
+            </div>
+            <table id="runs-table">
                 <thead>
                     <tr>
                         <th>Run ID</th>
@@ -298,17 +399,19 @@ const dashboardTemplate = `<!DOCTYPE html>
                         <th>Duration</th>
                         <th>Exit Code</th>
                         <th>Status</th>
+                        <th>Trigger</th>
                     </tr>
                 </thead>
                 <tbody>
                     {{range .Runs}}
-                    <tr>
-                        <td><code>{{truncate .RunID 12}}</code></td>
+                    <tr data-status="{{.Status}}">
+                        <td><a href="/runs/{{.RunID}}"><code>{{truncate .RunID 12}}</code></a></td>
                         <td><a href="/jobs/{{.JobID}}">{{.JobID}}</a></td>
                         <td>{{.StartTime.Format "2006-01-02 15:04:05"}}</td>
                         <td>{{formatDuration .Duration}}</td>
                         <td>{{exitCodeBadge .ExitCode}}</td>
                         <td>{{statusBadge .Status}}</td>
+                        <td>{{if .Trigger}}{{.Trigger}}{{else}}-{{end}}</td>
                     </tr>
                     {{end}}
                 </tbody>
@@ -318,6 +421,85 @@ const dashboardTemplate = `<!DOCTYPE html>
             {{end}}
         </div>
     </div>
+    <script>
+        // Reload when a run starts or finishes; EventSource reconnects on its own.
+        if (window.EventSource) {
+            var pending;
+            var stream = new EventSource("/api/events");
+            var reload = function () {
+                clearTimeout(pending);
+                pending = setTimeout(function () { location.reload(); }, 250);
+            };
+            stream.addEventListener("run_started", reload);
+            stream.addEventListener("run_finished", reload);
+        }
+
+        // Client-side sorting and filtering. State lives in sessionStorage so
+        // it survives the reloads above.
+        (function () {
+            var store = window.sessionStorage || { getItem: function () { return null; }, setItem: function () {} };
+            var jobs = document.getElementById("jobs-table");
+            var filter = document.getElementById("job-filter");
+            var failures = document.getElementById("failures-only");
+
+            var applyFilter = function () {
+                var needle = filter.value.trim().toLowerCase();
+                jobs.querySelectorAll("tbody tr").forEach(function (row) {
+                    row.hidden = needle !== "" && row.dataset.jobId.toLowerCase().indexOf(needle) === -1;
+                });
+                store.setItem("jobster.jobFilter", filter.value);
+            };
+
+            var sortBy = function (key, dir) {
+                var body = jobs.tBodies[0];
+                var rows = Array.prototype.slice.call(body.rows);
+                var attr = key.replace(/-([a-z])/g, function (_, c) { return c.toUpperCase(); });
+                rows.sort(function (a, b) {
+                    var x = a.dataset[attr], y = b.dataset[attr];
+                    // Rows without a value always go last
+                    if (x === "" || y === "") { return (x === "") - (y === ""); }
+                    return (parseFloat(x) - parseFloat(y)) * dir;
+                });
+                rows.forEach(function (row) { body.appendChild(row); });
+                jobs.querySelectorAll("th[data-sort]").forEach(function (th) {
+                    if (th.dataset.sort === key) {
+                        th.setAttribute("aria-sort", dir > 0 ? "ascending" : "descending");
+                    } else {
+                        th.removeAttribute("aria-sort");
+                    }
+                });
+                store.setItem("jobster.jobSort", key + ":" + dir);
+            };
+
+            if (jobs) {
+                jobs.querySelectorAll("th[data-sort]").forEach(function (th) {
+                    th.addEventListener("click", function () {
+                        sortBy(th.dataset.sort, th.getAttribute("aria-sort") === "ascending" ? -1 : 1);
+                    });
+                });
+                filter.value = store.getItem("jobster.jobFilter") || "";
+                filter.addEventListener("input", applyFilter);
+                applyFilter();
+                var saved = (store.getItem("jobster.jobSort") || "").split(":");
+                if (saved.length === 2 && jobs.querySelector('th[data-sort="' + saved[0] + '"]')) {
+                    sortBy(saved[0], parseInt(saved[1], 10) < 0 ? -1 : 1);
+                }
+            }
+
+            if (failures) {
+                var applyFailures = function () {
+                    document.querySelectorAll("#runs-table tbody tr").forEach(function (row) {
+                        var failed = row.dataset.status !== "success" && row.dataset.status !== "running";
+                        row.hidden = failures.checked && !failed;
+                    });
+                    store.setItem("jobster.failuresOnly", failures.checked ? "1" : "");
+                };
+                failures.checked = store.getItem("jobster.failuresOnly") === "1";
+                failures.addEventListener("change", applyFailures);
+                applyFailures();
+            }
+        })();
+    </script>
 </body>
 </html>`
 
@@ -352,6 +534,7 @@ const jobDetailTemplate = `<!DOCTYPE html>
         .badge-success { background: #d4edda; color: #155724; }
         .badge-danger { background: #f8d7da; color: #721c24; }
         .badge-info { background: #d1ecf1; color: #0c5460; }
+        .badge-warning { background: #fff3cd; color: #856404; }
         .badge-secondary { background: #e2e3e5; color: #383d41; }
         .empty { text-align: center; padding: 40px; color: #7f8c8d; }
         code { background: #f8f9fa; padding: 2px 6px; border-radius: 3px; font-family: monospace; font-size: 13px; }
@@ -383,7 +566,7 @@ const jobDetailTemplate = `<!DOCTYPE html>
                 </div>
                 <div class="info-item">
                     <label>Last Status</label>
-                    <div class="value">{{statusBadge .Job.LastStatus}}</div>
+                    <div class="value">{{statusBadge .Job.LastStatus}}{{if .Job.CircuitOpen}} {{circuitBadge .Job.ConsecutiveFailures}}{{end}}</div>
                 </div>
                 <div class="info-item">
                     <label>Last Run</label>
@@ -416,17 +599,19 @@ const jobDetailTemplate = `<!DOCTYPE html>
                         <th>Duration</th>
                         <th>Exit Code</th>
                         <th>Status</th>
+                        <th>Trigger</th>
                     </tr>
                 </thead>
                 <tbody>
                     {{range .Runs}}
                     <tr>
-                        <td><code>{{truncate .RunID 16}}</code></td>
+                        <td><a href="/runs/{{.RunID}}"><code>{{truncate .RunID 16}}</code></a></td>
                         <td>{{.StartTime.Format "2006-01-02 15:04:05"}}</td>
                         <td>{{.EndTime.Format "2006-01-02 15:04:05"}}</td>
                         <td>{{formatDuration .Duration}}</td>
                         <td>{{exitCodeBadge .ExitCode}}</td>
                         <td>{{statusBadge .Status}}</td>
+                        <td>{{if .Trigger}}{{.Trigger}}{{else}}-{{end}}</td>
                     </tr>
                     {{end}}
                 </tbody>
@@ -436,5 +621,128 @@ const jobDetailTemplate = `<!DOCTYPE html>
             {{end}}
         </div>
     </div>
+    <script>
+        // Reload when a run starts or finishes; EventSource reconnects on its own.
+        if (window.EventSource) {
+            var pending;
+            var stream = new EventSource("/api/events");
+            var reload = function () {
+                clearTimeout(pending);
+                pending = setTimeout(function () { location.reload(); }, 250);
+            };
+            stream.addEventListener("run_started", reload);
+            stream.addEventListener("run_finished", reload);
+        }
+    </script>
+</body>
+</html>`
+
+// runDetailTemplate is the run detail HTML template
+const runDetailTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+    <style>
+        * { margin: 0; padding: 0; box-sizing: border-box; }
+        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; background: #f5f5f5; color: #333; line-height: 1.6; }
+        .container { max-width: 1200px; margin: 0 auto; padding: 20px; }
+        header { background: #2c3e50; color: white; padding: 20px 0; margin-bottom: 30px; box-shadow: 0 2px 4px rgba(0,0,0,0.1); }
+        header h1 { font-size: 28px; margin-bottom: 5px; word-break: break-all; }
+        header a { color: white; opacity: 0.8; text-decoration: none; }
+        header a:hover { opacity: 1; text-decoration: underline; }
+        .section { background: white; padding: 25px; border-radius: 8px; margin-bottom: 30px; box-shadow: 0 2px 4px rgba(0,0,0,0.1); }
+        .section h2 { font-size: 20px; margin-bottom: 20px; color: #2c3e50; border-bottom: 2px solid #3498db; padding-bottom: 10px; }
+        .section h2 a { font-size: 14px; font-weight: normal; margin-left: 10px; }
+        .info-grid { display: grid; grid-template-columns: repeat(auto-fit, minmax(250px, 1fr)); gap: 15px; }
+        .info-item { padding: 10px 0; }
+        .info-item label { display: block; font-size: 12px; color: #7f8c8d; text-transform: uppercase; margin-bottom: 5px; }
+        .info-item .value { font-size: 16px; font-weight: 500; }
+        table { width: 100%; border-collapse: collapse; }
+        th { background: #f8f9fa; text-align: left; padding: 12px; font-weight: 600; border-bottom: 2px solid #dee2e6; }
+        td { padding: 12px; border-bottom: 1px solid #dee2e6; }
+        .badge { display: inline-block; padding: 4px 8px; border-radius: 4px; font-size: 12px; font-weight: 600; text-transform: uppercase; }
+        .badge-success { background: #d4edda; color: #155724; }
+        .badge-danger { background: #f8d7da; color: #721c24; }
+        .badge-info { background: #d1ecf1; color: #0c5460; }
+        .badge-warning { background: #fff3cd; color: #856404; }
+        .badge-secondary { background: #e2e3e5; color: #383d41; }
+        .empty { text-align: center; padding: 40px; color: #7f8c8d; }
+        a { color: #3498db; text-decoration: none; }
+        a:hover { text-decoration: underline; }
+        code { background: #f8f9fa; padding: 2px 6px; border-radius: 3px; font-family: monospace; font-size: 13px; }
+        pre { background: #2c3e50; color: #ecf0f1; padding: 15px; border-radius: 4px; overflow-x: auto; font-size: 13px; max-height: 500px; }
+    </style>
+</head>
+<body>
+    <header>
+        <div class="container">
+            <div><a href="/jobs/{{.Run.JobID}}">&larr; Back to {{.Run.JobID}}</a></div>
+            <h1>{{.Title}}</h1>
+        </div>
+    </header>
+
+    <div class="container">
+        <div class="section">
+            <h2>Run Details</h2>
+            <div class="info-grid">
+                <div class="info-item">
+                    <label>Job ID</label>
+                    <div class="value"><a href="/jobs/{{.Run.JobID}}"><code>{{.Run.JobID}}</code></a></div>
+                </div>
+                <div class="info-item">
+                    <label>Status</label>
+                    <div class="value">{{statusBadge .Run.Status}}</div>
+                </div>
+                <div class="info-item">
+                    <label>Exit Code</label>
+                    <div class="value">{{exitCodeBadge .Run.ExitCode}}</div>
+                </div>
+                <div class="info-item">
+                    <label>Trigger</label>
+                    <div class="value">{{if .Run.Trigger}}{{.Run.Trigger}}{{else}}-{{end}}</div>
+                </div>
+                <div class="info-item">
+                    <label>Start Time</label>
+                    <div class="value">{{.Run.StartTime.Format "2006-01-02 15:04:05"}}</div>
+                </div>
+                <div class="info-item">
+                    <label>End Time</label>
+                    <div class="value">{{if .Run.EndTime.IsZero}}-{{else}}{{.Run.EndTime.Format "2006-01-02 15:04:05"}}{{end}}</div>
+                </div>
+                <div class="info-item">
+                    <label>Duration</label>
+                    <div class="value">{{formatDuration .Run.Duration}}</div>
+                </div>
+            </div>
+        </div>
+
+        {{if .Run.Metadata}}
+        <div class="section">
+            <h2>Metadata</h2>
+            <table>
+                <tbody>
+                    {{range $key, $value := .Run.Metadata}}
+                    <tr>
+                        <th>{{$key}}</th>
+                        <td><code>{{printf "%v" $value}}</code></td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+
+        <div class="section">
+            <h2>Stdout{{if .HasLogs}}<a href="/api/runs/{{.Run.RunID}}/logs?stream=stdout">Full log</a>{{end}}</h2>
+            {{if .Run.Stdout}}<pre>{{.Run.Stdout}}</pre>{{else}}<div class="empty">No output</div>{{end}}
+        </div>
+
+        <div class="section">
+            <h2>Stderr{{if .HasLogs}}<a href="/api/runs/{{.Run.RunID}}/logs?stream=stderr">Full log</a>{{end}}</h2>
+            {{if .Run.Stderr}}<pre>{{.Run.Stderr}}</pre>{{else}}<div class="empty">No output</div>{{end}}
+        </div>
+    </div>
 </body>
 </html>`
@@ -0,0 +1,223 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/caevv/jobster/internal/events"
+	"github.com/coder/websocket"
+)
+
+const (
+	// wsPingInterval is how often an idle WebSocket is pinged; a client
+	// that does not answer within wsWriteTimeout is disconnected.
+	wsPingInterval = 15 * time.Second
+
+	// wsWriteTimeout bounds each message write and ping round trip.
+	wsWriteTimeout = 10 * time.Second
+)
+
+// WSMessage is a message sent by the server on GET /api/ws.
+type WSMessage struct {
+	// Type is "jobs" (the current job states, sent on connect), "event" (a
+	// run event), "triggered" (a trigger request was accepted) or "error".
+	Type  string        `json:"type"`
+	Jobs  []JobSummary  `json:"jobs,omitempty"`
+	Event *events.Event `json:"event,omitempty"`
+	JobID string        `json:"job_id,omitempty"`
+	RunID string        `json:"run_id,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// WSCommand is a message sent by the client on GET /api/ws.
+type WSCommand struct {
+	// Action is "trigger" (run Job now) or "subscribe" (only send events
+	// for Jobs; an empty list means all jobs).
+	Action string   `json:"action"`
+	Job    string   `json:"job,omitempty"`
+	Jobs   []string `json:"jobs,omitempty"`
+}
+
+// handleWebSocket serves a WebSocket carrying the same run events as
+// GET /api/events, preceded by the current job states, and accepts trigger
+// and subscribe commands from the client.
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	if s.events == nil {
+		s.writeError(w, http.StatusServiceUnavailable, "event stream not available", nil)
+		return
+	}
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		OriginPatterns: wsOriginPatterns(s.corsOrigins),
+	})
+	if err != nil {
+		// Accept has already written the error response
+		s.logger.Debug("websocket upgrade failed", "error", err)
+		return
+	}
+	defer conn.CloseNow()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// Subscribe before sending the snapshot so no event falls in between
+	ch, _, unsubscribe := s.events.Subscribe(0)
+	defer unsubscribe()
+
+	snapshot := WSMessage{Type: "jobs", Jobs: []JobSummary{}}
+	if s.scheduler != nil {
+		jobs, err := s.scheduler.GetJobs(ctx)
+		if err != nil {
+			s.logger.Error("failed to list jobs for websocket", "error", err)
+			conn.Close(websocket.StatusInternalError, "failed to list jobs")
+			return
+		}
+		snapshot.Jobs = jobs
+	}
+	if err := writeWS(ctx, conn, snapshot); err != nil {
+		return
+	}
+
+	// Reading also answers the client's pings and receives its pongs, so it
+	// runs for the life of the connection; it ends when the client goes away.
+	commands := make(chan WSCommand)
+	go func() {
+		defer cancel()
+		for {
+			_, data, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+			var cmd WSCommand
+			if err := json.Unmarshal(data, &cmd); err != nil {
+				// Conn allows concurrent writes, so reply from here
+				if err := writeWS(ctx, conn, WSMessage{Type: "error", Error: "invalid command: " + err.Error()}); err != nil {
+					return
+				}
+				continue
+			}
+			select {
+			case commands <- cmd:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(wsPingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pingCtx, cancelPing := context.WithTimeout(ctx, wsWriteTimeout)
+				err := conn.Ping(pingCtx)
+				cancelPing()
+				if err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	var jobFilter map[string]bool
+	for {
+		select {
+		case <-ctx.Done():
+			if r.Context().Err() != nil {
+				conn.Close(websocket.StatusGoingAway, "server shutting down")
+			} else {
+				conn.Close(websocket.StatusNormalClosure, "")
+			}
+			return
+		case e, ok := <-ch:
+			if !ok {
+				// Dropped for falling behind; the client reconnects
+				conn.Close(websocket.StatusTryAgainLater, "client fell behind")
+				return
+			}
+			if jobFilter != nil && !jobFilter[e.JobID] {
+				continue
+			}
+			if err := writeWS(ctx, conn, WSMessage{Type: "event", Event: &e}); err != nil {
+				return
+			}
+		case cmd := <-commands:
+			var reply WSMessage
+			switch cmd.Action {
+			case "trigger":
+				reply = s.triggerFromWS(ctx, cmd.Job)
+			case "subscribe":
+				jobFilter = nil
+				if len(cmd.Jobs) > 0 {
+					jobFilter = make(map[string]bool, len(cmd.Jobs))
+					for _, id := range cmd.Jobs {
+						jobFilter[id] = true
+					}
+				}
+				continue
+			default:
+				reply = WSMessage{Type: "error", Error: fmt.Sprintf("unknown action %q (must be trigger or subscribe)", cmd.Action)}
+			}
+			if err := writeWS(ctx, conn, reply); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// triggerFromWS runs a job for a WebSocket trigger command and returns the
+// reply to send.
+func (s *Server) triggerFromWS(ctx context.Context, jobID string) WSMessage {
+	if jobID == "" {
+		return WSMessage{Type: "error", Error: "job is required"}
+	}
+	if s.scheduler == nil {
+		return WSMessage{Type: "error", JobID: jobID, Error: "scheduler not available"}
+	}
+
+	runID, err := s.scheduler.TriggerJob(ctx, jobID)
+	if errors.Is(err, ErrJobNotFound) {
+		return WSMessage{Type: "error", JobID: jobID, Error: "job not found"}
+	}
+	if err != nil {
+		s.logger.Error("failed to trigger job", "job_id", jobID, "error", err)
+		return WSMessage{Type: "error", JobID: jobID, Error: "failed to trigger job"}
+	}
+	return WSMessage{Type: "triggered", JobID: jobID, RunID: runID}
+}
+
+// writeWS sends msg as a JSON text message.
+func writeWS(ctx context.Context, conn *websocket.Conn, msg WSMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, wsWriteTimeout)
+	defer cancel()
+	return conn.Write(ctx, websocket.MessageText, data)
+}
+
+// wsOriginPatterns turns the configured CORS origins into the host patterns
+// WebSocket upgrades are checked against. Same-origin connections are always
+// allowed.
+func wsOriginPatterns(origins []string) []string {
+	var patterns []string
+	for _, origin := range origins {
+		if origin == "*" {
+			return []string{"*"}
+		}
+		if u, err := url.Parse(origin); err == nil && u.Host != "" {
+			patterns = append(patterns, u.Host)
+		}
+	}
+	return patterns
+}
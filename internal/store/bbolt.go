@@ -1,9 +1,10 @@
 package store
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"sort"
 	"time"
 
 	bolt "go.etcd.io/bbolt"
@@ -14,6 +15,26 @@ const (
 	runsBucket = "runs"
 	// runIndexBucket stores run metadata indexed by run_id for fast lookups.
 	runIndexBucket = "run_index"
+	// runTimeBucket indexes every run by start time: keys are
+	// timeIndexKey(start, run_id) and values the job_id, so the newest runs
+	// are read from the end with a cursor instead of loading them all.
+	runTimeBucket = "runs_by_time"
+	// jobRunTimeBucket holds the same index per job: a sub-bucket for each
+	// job_id, mapping timeIndexKey(start, run_id) to the run_id.
+	jobRunTimeBucket = "job_runs_by_time"
+	// statsBucket holds aggregate run counters, updated in the same
+	// transaction as every write so GetStats never scans runs.
+	statsBucket = "stats"
+	// statsTotalsKey is the statsBucket key for the store-wide counters.
+	statsTotalsKey = "totals"
+	// jobStatsBucket is the statsBucket sub-bucket holding each job's
+	// jobCounters, keyed by job_id.
+	jobStatsBucket = "jobs"
+
+	// timeIndexLayout is RFC 3339 with a fixed-width fraction. Formatted in
+	// UTC every key has the same length, so byte order is time order
+	// (time.RFC3339Nano trims trailing zeros and would not sort).
+	timeIndexLayout = "2006-01-02T15:04:05.000000000Z07:00"
 )
 
 // BoltStore implements the Store interface using BoltDB.
@@ -36,6 +57,28 @@ func NewBoltStore(path string) (Store, error) {
 		if _, err := tx.CreateBucketIfNotExists([]byte(runIndexBucket)); err != nil {
 			return fmt.Errorf("create run_index bucket: %w", err)
 		}
+		// Likewise the time indexes for databases created before them
+		if tx.Bucket([]byte(runTimeBucket)) == nil || tx.Bucket([]byte(jobRunTimeBucket)) == nil {
+			if _, err := tx.CreateBucketIfNotExists([]byte(runTimeBucket)); err != nil {
+				return fmt.Errorf("create runs_by_time bucket: %w", err)
+			}
+			if _, err := tx.CreateBucketIfNotExists([]byte(jobRunTimeBucket)); err != nil {
+				return fmt.Errorf("create job_runs_by_time bucket: %w", err)
+			}
+			if err := rebuildTimeIndex(tx); err != nil {
+				return fmt.Errorf("rebuild time index: %w", err)
+			}
+		}
+		// Databases created before counters, or before per-job counters,
+		// existed get them computed once
+		if stats := tx.Bucket([]byte(statsBucket)); stats == nil || stats.Bucket([]byte(jobStatsBucket)) == nil {
+			if _, err := tx.CreateBucketIfNotExists([]byte(statsBucket)); err != nil {
+				return fmt.Errorf("create stats bucket: %w", err)
+			}
+			if err := rebuildStats(tx); err != nil {
+				return fmt.Errorf("rebuild stats: %w", err)
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -47,7 +90,11 @@ func NewBoltStore(path string) (Store, error) {
 }
 
 // SaveRun persists a job run record.
-func (s *BoltStore) SaveRun(run *JobRun) error {
+func (s *BoltStore) SaveRun(ctx context.Context, run *JobRun) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if run.RunID == "" {
 		return fmt.Errorf("run_id is required")
 	}
@@ -70,21 +117,59 @@ func (s *BoltStore) SaveRun(run *JobRun) error {
 			return fmt.Errorf("create job bucket %s: %w", run.JobID, err)
 		}
 
+		// Decode any previous version of this run so the counters move it
+		// between states (e.g. running -> success) instead of double-counting.
+		var prev *JobRun
+		if prevData := jobBucket.Get([]byte(run.RunID)); prevData != nil {
+			prev = &JobRun{}
+			if err := json.Unmarshal(prevData, prev); err != nil {
+				return fmt.Errorf("unmarshal previous run: %w", err)
+			}
+		}
+
 		if err := jobBucket.Put([]byte(run.RunID), data); err != nil {
 			return fmt.Errorf("put run in job bucket: %w", err)
 		}
 
+		if err := updateStats(tx, func(st *StoreStats) {
+			if prev != nil {
+				st.add(prev, -1)
+			}
+			st.add(run, 1)
+		}); err != nil {
+			return err
+		}
+		if err := updateJobStats(tx, run.JobID, func(c *jobCounters) {
+			if prev != nil {
+				c.add(prev, -1)
+			}
+			c.add(run, 1)
+		}); err != nil {
+			return err
+		}
+
 		// Also index by run_id for fast lookup
 		if err := index.Put([]byte(run.RunID), []byte(run.JobID)); err != nil {
 			return fmt.Errorf("put run index: %w", err)
 		}
 
-		return nil
+		// And by start time, which normally stays the same across saves of
+		// a run; if it moved, drop the old entry so the run is listed once
+		if prev != nil && !prev.StartTime.Equal(run.StartTime) {
+			if err := deleteTimeIndex(tx, prev); err != nil {
+				return err
+			}
+		}
+		return putTimeIndex(tx, run)
 	})
 }
 
 // GetRun retrieves a specific run by its ID.
-func (s *BoltStore) GetRun(runID string) (*JobRun, error) {
+func (s *BoltStore) GetRun(ctx context.Context, runID string) (*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if runID == "" {
 		return nil, fmt.Errorf("run_id is required")
 	}
@@ -98,7 +183,7 @@ func (s *BoltStore) GetRun(runID string) (*JobRun, error) {
 		// Look up job_id from index
 		jobID := index.Get([]byte(runID))
 		if jobID == nil {
-			return fmt.Errorf("run not found: %s", runID)
+			return fmt.Errorf("%w: %s", ErrRunNotFound, runID)
 		}
 
 		// Get the run from the job bucket
@@ -126,59 +211,197 @@ func (s *BoltStore) GetRun(runID string) (*JobRun, error) {
 	return run, nil
 }
 
-// GetJobRuns retrieves the most recent runs for a specific job.
-func (s *BoltStore) GetJobRuns(jobID string, limit int) ([]*JobRun, error) {
+// GetJobRuns retrieves the most recent runs for a specific job, reading
+// only those runs from the job's time index.
+func (s *BoltStore) GetJobRuns(ctx context.Context, jobID string, limit int) ([]*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if jobID == "" {
 		return nil, fmt.Errorf("job_id is required")
 	}
-	if limit <= 0 {
-		limit = 100 // default limit
+
+	return s.newestRuns(ctx, RunFilter{JobID: jobID, Limit: limit})
+}
+
+// GetAllRuns retrieves the most recent runs across all jobs, reading only
+// those runs from the time index.
+func (s *BoltStore) GetAllRuns(ctx context.Context, limit int) ([]*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
+	return s.newestRuns(ctx, RunFilter{Limit: limit})
+}
+
+// GetRunsFiltered retrieves the runs matching filter, newest first, walking
+// the time index (the job's own with a JobID) from Until down to Since and
+// stopping once the page is full.
+func (s *BoltStore) GetRunsFiltered(ctx context.Context, filter RunFilter) ([]*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.newestRuns(ctx, filter)
+}
+
+// newestRuns returns the page of runs selected by filter by scanning a time
+// index backwards. Runs older than the page are never read.
+func (s *BoltStore) newestRuns(ctx context.Context, filter RunFilter) ([]*JobRun, error) {
 	var runs []*JobRun
 
 	err := s.db.View(func(tx *bolt.Tx) error {
 		runsBucket := tx.Bucket([]byte(runsBucket))
-		jobBucket := runsBucket.Bucket([]byte(jobID))
 
-		if jobBucket == nil {
+		index := timeIndex(tx, filter.JobID)
+		if index == nil {
 			// No runs for this job yet
 			return nil
 		}
 
-		// Collect all runs for this job
-		err := jobBucket.ForEach(func(k, v []byte) error {
+		// Keys sort by start time, so Until and Since bound the scan: start
+		// just before the first key at or after Until and stop below Since
+		c := index.Cursor()
+		k, v := c.Last()
+		if !filter.Until.IsZero() {
+			if k, v = c.Seek(timeIndexPrefix(filter.Until)); k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+		}
+		var since []byte
+		if !filter.Since.IsZero() {
+			since = timeIndexPrefix(filter.Since)
+		}
+
+		skip := filter.Offset
+		for ; k != nil && len(runs) < filter.limit(); k, v = c.Prev() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if since != nil && bytes.Compare(k, since) < 0 {
+				break
+			}
+
+			// The global index stores the job_id, a job's index the run_id
+			jobID, runID := v, v
+			if filter.JobID != "" {
+				jobID = []byte(filter.JobID)
+			} else {
+				var ok bool
+				if runID, ok = runIDFromTimeKey(k); !ok {
+					return fmt.Errorf("malformed time index key %q", k)
+				}
+			}
+
+			jobBucket := runsBucket.Bucket(jobID)
+			if jobBucket == nil {
+				return fmt.Errorf("job bucket not found: %s", string(jobID))
+			}
+			data := jobBucket.Get(runID)
+			if data == nil {
+				return fmt.Errorf("run not found in job bucket: %s", string(runID))
+			}
+
 			run := &JobRun{}
-			if err := json.Unmarshal(v, run); err != nil {
-				return fmt.Errorf("unmarshal run %s: %w", string(k), err)
+			if err := json.Unmarshal(data, run); err != nil {
+				return fmt.Errorf("unmarshal run %s: %w", string(runID), err)
+			}
+			if !filter.Matches(run) {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
 			}
 			runs = append(runs, run)
-			return nil
-		})
-
-		return err
+		}
+		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Sort by start time descending (newest first)
-	sort.Slice(runs, func(i, j int) bool {
-		return runs[i].StartTime.After(runs[j].StartTime)
-	})
+	return runs, nil
+}
 
-	// Apply limit
-	if len(runs) > limit {
-		runs = runs[:limit]
+// CountRuns returns how many runs match filter, ignoring its Limit and Offset.
+// Without time bounds the count comes from the stored counters, and time
+// bounds alone are counted from the time index keys; only a status combined
+// with time bounds decodes runs.
+func (s *BoltStore) CountRuns(ctx context.Context, filter RunFilter) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if err := filter.Validate(); err != nil {
+		return 0, err
 	}
 
-	return runs, nil
+	timeBounded := !filter.Since.IsZero() || !filter.Until.IsZero()
+	if timeBounded && filter.Status != "" {
+		runs, err := s.matchingRuns(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+		return len(runs), nil
+	}
+
+	var n int
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if timeBounded {
+			n = countTimeIndex(timeIndex(tx, filter.JobID), filter.Since, filter.Until)
+			return nil
+		}
+
+		var stats StoreStats
+		if filter.JobID == "" {
+			totals, err := getStats(tx)
+			if err != nil {
+				return err
+			}
+			stats = totals
+		} else {
+			counters, err := getJobStats(tx, []byte(filter.JobID))
+			if err != nil {
+				return err
+			}
+			stats = counters.StoreStats
+		}
+
+		switch filter.Status {
+		case StatusSuccess:
+			n = stats.SuccessCount
+		case StatusFailure:
+			n = stats.FailureCount
+		case StatusRunning:
+			n = stats.RunningCount
+		default:
+			n = stats.TotalRuns
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
 }
 
-// GetAllRuns retrieves the most recent runs across all jobs.
-func (s *BoltStore) GetAllRuns(limit int) ([]*JobRun, error) {
-	if limit <= 0 {
-		limit = 100 // default limit
+// matchingRuns returns every run matching filter, unsorted and without
+// paging, for the callers that need them all. With a JobID only that job's
+// bucket is read.
+func (s *BoltStore) matchingRuns(ctx context.Context, filter RunFilter) ([]*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
 	}
 
 	var runs []*JobRun
@@ -186,16 +409,157 @@ func (s *BoltStore) GetAllRuns(limit int) ([]*JobRun, error) {
 	err := s.db.View(func(tx *bolt.Tx) error {
 		runsBucket := tx.Bucket([]byte(runsBucket))
 
-		// Iterate through all job buckets
-		return runsBucket.ForEach(func(jobID, v []byte) error {
-			// Skip if this is not a bucket (shouldn't happen)
-			jobBucket := runsBucket.Bucket(jobID)
+		collect := func(jobBucket *bolt.Bucket) error {
+			return jobBucket.ForEach(func(k, v []byte) error {
+				run := &JobRun{}
+				if err := json.Unmarshal(v, run); err != nil {
+					return fmt.Errorf("unmarshal run %s: %w", string(k), err)
+				}
+				if filter.Matches(run) {
+					runs = append(runs, run)
+				}
+				return nil
+			})
+		}
+
+		if filter.JobID != "" {
+			jobBucket := runsBucket.Bucket([]byte(filter.JobID))
 			if jobBucket == nil {
+				// No runs for this job yet
 				return nil
 			}
+			return collect(jobBucket)
+		}
 
-			// Collect all runs from this job
-			return jobBucket.ForEach(func(k, v []byte) error {
+		return runsBucket.ForEachBucket(func(jobID []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return collect(runsBucket.Bucket(jobID))
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// GetJobStats returns one job's stats from its stored counters. Only the
+// job's newest run is decoded, for its start time.
+func (s *BoltStore) GetJobStats(ctx context.Context, jobID string) (*JobRunStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := &JobRunStats{JobID: jobID}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		counters, err := getJobStats(tx, []byte(jobID))
+		if err != nil {
+			return err
+		}
+		stats.StoreStats = counters.StoreStats
+		if completed := stats.SuccessCount + stats.FailureCount; completed > 0 {
+			stats.AvgDuration = counters.CompletedDuration / time.Duration(completed)
+		}
+		stats.SuccessRate = stats.StoreStats.SuccessRate()
+
+		jobIndex := tx.Bucket([]byte(jobRunTimeBucket)).Bucket([]byte(jobID))
+		if jobIndex == nil {
+			return nil
+		}
+		_, runID := jobIndex.Cursor().Last()
+		jobBucket := tx.Bucket([]byte(runsBucket)).Bucket([]byte(jobID))
+		if runID == nil || jobBucket == nil {
+			return nil
+		}
+		data := jobBucket.Get(runID)
+		if data == nil {
+			return nil
+		}
+		last := &JobRun{}
+		if err := json.Unmarshal(data, last); err != nil {
+			return fmt.Errorf("unmarshal run %s: %w", string(runID), err)
+		}
+		stats.LastRunTime = last.StartTime
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// DeleteRun removes a single run record from its job bucket and the index.
+func (s *BoltStore) DeleteRun(ctx context.Context, runID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if runID == "" {
+		return fmt.Errorf("run_id is required")
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		index := tx.Bucket([]byte(runIndexBucket))
+		runs := tx.Bucket([]byte(runsBucket))
+
+		jobID := index.Get([]byte(runID))
+		if jobID == nil {
+			return fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+		}
+
+		if jobBucket := runs.Bucket(jobID); jobBucket != nil {
+			if data := jobBucket.Get([]byte(runID)); data != nil {
+				run := &JobRun{}
+				if err := json.Unmarshal(data, run); err != nil {
+					return fmt.Errorf("unmarshal run: %w", err)
+				}
+				if err := updateStats(tx, func(st *StoreStats) { st.add(run, -1) }); err != nil {
+					return err
+				}
+				if err := updateJobStats(tx, run.JobID, func(c *jobCounters) { c.add(run, -1) }); err != nil {
+					return err
+				}
+				if err := deleteTimeIndex(tx, run); err != nil {
+					return err
+				}
+			}
+			if err := jobBucket.Delete([]byte(runID)); err != nil {
+				return fmt.Errorf("delete run from job bucket: %w", err)
+			}
+		}
+
+		if err := index.Delete([]byte(runID)); err != nil {
+			return fmt.Errorf("delete run index: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// PruneRuns deletes old run records according to the retention rules.
+func (s *BoltStore) PruneRuns(ctx context.Context, olderThan time.Time, keepPerJob int) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var deleted []*JobRun
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		runsBucket := tx.Bucket([]byte(runsBucket))
+		index := tx.Bucket([]byte(runIndexBucket))
+
+		// Retention rules are per job, so prune one job bucket at a time
+		err := runsBucket.ForEachBucket(func(jobID []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			jobBucket := runsBucket.Bucket(jobID)
+			var runs []*JobRun
+			err := jobBucket.ForEach(func(k, v []byte) error {
 				run := &JobRun{}
 				if err := json.Unmarshal(v, run); err != nil {
 					return fmt.Errorf("unmarshal run %s: %w", string(k), err)
@@ -203,23 +567,312 @@ func (s *BoltStore) GetAllRuns(limit int) ([]*JobRun, error) {
 				runs = append(runs, run)
 				return nil
 			})
+			if err != nil {
+				return err
+			}
+
+			prunable := selectPrunable(runs, olderThan, keepPerJob)
+			for _, run := range prunable {
+				if err := jobBucket.Delete([]byte(run.RunID)); err != nil {
+					return fmt.Errorf("delete run %s: %w", run.RunID, err)
+				}
+				if err := index.Delete([]byte(run.RunID)); err != nil {
+					return fmt.Errorf("delete run index %s: %w", run.RunID, err)
+				}
+				if err := deleteTimeIndex(tx, run); err != nil {
+					return err
+				}
+				deleted = append(deleted, run)
+			}
+			if len(prunable) == 0 {
+				return nil
+			}
+
+			return updateJobStats(tx, string(jobID), func(c *jobCounters) {
+				for _, run := range prunable {
+					c.add(run, -1)
+				}
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		return updateStats(tx, func(st *StoreStats) {
+			for _, run := range deleted {
+				st.add(run, -1)
+			}
 		})
 	})
 	if err != nil {
+		return 0, err
+	}
+
+	return len(deleted), nil
+}
+
+// GetStats returns the aggregate run counters.
+func (s *BoltStore) GetStats(ctx context.Context) (*StoreStats, error) {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	// Sort by start time descending (newest first)
-	sort.Slice(runs, func(i, j int) bool {
-		return runs[i].StartTime.After(runs[j].StartTime)
+	var stats StoreStats
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		stats, err = getStats(tx)
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
 
-	// Apply limit
-	if len(runs) > limit {
-		runs = runs[:limit]
+// getStats returns the store-wide counters within tx.
+func getStats(tx *bolt.Tx) (StoreStats, error) {
+	var stats StoreStats
+	data := tx.Bucket([]byte(statsBucket)).Get([]byte(statsTotalsKey))
+	if data == nil {
+		return stats, nil
 	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return stats, fmt.Errorf("unmarshal stats: %w", err)
+	}
+	return stats, nil
+}
 
-	return runs, nil
+// updateStats applies fn to the stored counters within tx.
+func updateStats(tx *bolt.Tx, fn func(*StoreStats)) error {
+	bucket := tx.Bucket([]byte(statsBucket))
+
+	var stats StoreStats
+	if data := bucket.Get([]byte(statsTotalsKey)); data != nil {
+		if err := json.Unmarshal(data, &stats); err != nil {
+			return fmt.Errorf("unmarshal stats: %w", err)
+		}
+	}
+
+	fn(&stats)
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("marshal stats: %w", err)
+	}
+	if err := bucket.Put([]byte(statsTotalsKey), data); err != nil {
+		return fmt.Errorf("put stats: %w", err)
+	}
+	return nil
+}
+
+// jobCounters is the record jobStatsBucket keeps for each job: its run
+// counts plus what GetJobStats needs to average durations.
+type jobCounters struct {
+	StoreStats
+
+	// CompletedDuration is the summed duration of the job's completed runs.
+	CompletedDuration time.Duration `json:"completed_duration"`
+}
+
+// add applies run's contribution to the counters, like StoreStats.add.
+func (c *jobCounters) add(run *JobRun, sign int) {
+	c.StoreStats.add(run, sign)
+	if !run.IsRunning() {
+		c.CompletedDuration += time.Duration(sign) * run.Duration()
+	}
+}
+
+// getJobStats returns jobID's stored counters within tx; a job with no runs
+// has zero counters.
+func getJobStats(tx *bolt.Tx, jobID []byte) (jobCounters, error) {
+	var counters jobCounters
+	data := tx.Bucket([]byte(statsBucket)).Bucket([]byte(jobStatsBucket)).Get(jobID)
+	if data == nil {
+		return counters, nil
+	}
+	if err := json.Unmarshal(data, &counters); err != nil {
+		return counters, fmt.Errorf("unmarshal job stats %s: %w", string(jobID), err)
+	}
+	return counters, nil
+}
+
+// updateJobStats applies fn to jobID's stored counters within tx, dropping
+// them once the job has no runs left.
+func updateJobStats(tx *bolt.Tx, jobID string, fn func(*jobCounters)) error {
+	counters, err := getJobStats(tx, []byte(jobID))
+	if err != nil {
+		return err
+	}
+
+	fn(&counters)
+
+	bucket := tx.Bucket([]byte(statsBucket)).Bucket([]byte(jobStatsBucket))
+	if counters.TotalRuns <= 0 {
+		if err := bucket.Delete([]byte(jobID)); err != nil {
+			return fmt.Errorf("delete job stats %s: %w", jobID, err)
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(counters)
+	if err != nil {
+		return fmt.Errorf("marshal job stats: %w", err)
+	}
+	if err := bucket.Put([]byte(jobID), data); err != nil {
+		return fmt.Errorf("put job stats %s: %w", jobID, err)
+	}
+	return nil
+}
+
+// rebuildStats recomputes the store-wide and per-job counters from every
+// stored run.
+func rebuildStats(tx *bolt.Tx) error {
+	var stats StoreStats
+	jobs := make(map[string]*jobCounters)
+
+	err := forEachRun(tx, func(run *JobRun) error {
+		stats.add(run, 1)
+		counters, ok := jobs[run.JobID]
+		if !ok {
+			counters = &jobCounters{}
+			jobs[run.JobID] = counters
+		}
+		counters.add(run, 1)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := updateStats(tx, func(st *StoreStats) { *st = stats }); err != nil {
+		return err
+	}
+
+	// Start the per-job counters afresh
+	statsBkt := tx.Bucket([]byte(statsBucket))
+	if statsBkt.Bucket([]byte(jobStatsBucket)) != nil {
+		if err := statsBkt.DeleteBucket([]byte(jobStatsBucket)); err != nil {
+			return fmt.Errorf("reset job stats: %w", err)
+		}
+	}
+	if _, err := statsBkt.CreateBucket([]byte(jobStatsBucket)); err != nil {
+		return fmt.Errorf("create job stats bucket: %w", err)
+	}
+	for jobID, counters := range jobs {
+		if err := updateJobStats(tx, jobID, func(c *jobCounters) { *c = *counters }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// timeIndexKey returns the time index key for a run: its start time in
+// timeIndexLayout, then "|" and the run ID to keep keys unique.
+func timeIndexKey(start time.Time, runID string) []byte {
+	return []byte(start.UTC().Format(timeIndexLayout) + "|" + runID)
+}
+
+// timeIndexPrefix returns the key prefix for t. It sorts before the keys of
+// every run started at t and after those of every earlier run.
+func timeIndexPrefix(t time.Time) []byte {
+	return []byte(t.UTC().Format(timeIndexLayout))
+}
+
+// runIDFromTimeKey returns the run ID part of a timeIndexKey.
+func runIDFromTimeKey(key []byte) ([]byte, bool) {
+	_, runID, ok := bytes.Cut(key, []byte("|"))
+	return runID, ok
+}
+
+// timeIndex returns the time index of jobID's runs within tx, or the global
+// one when jobID is empty. It is nil for a job that has never run.
+func timeIndex(tx *bolt.Tx, jobID string) *bolt.Bucket {
+	if jobID == "" {
+		return tx.Bucket([]byte(runTimeBucket))
+	}
+	return tx.Bucket([]byte(jobRunTimeBucket)).Bucket([]byte(jobID))
+}
+
+// countTimeIndex counts the keys of index for runs started in [since,
+// until), without reading the runs. A zero bound is open.
+func countTimeIndex(index *bolt.Bucket, since, until time.Time) int {
+	if index == nil {
+		return 0
+	}
+
+	c := index.Cursor()
+	k, _ := c.First()
+	if !since.IsZero() {
+		k, _ = c.Seek(timeIndexPrefix(since))
+	}
+	var end []byte
+	if !until.IsZero() {
+		end = timeIndexPrefix(until)
+	}
+
+	n := 0
+	for ; k != nil && (end == nil || bytes.Compare(k, end) < 0); k, _ = c.Next() {
+		n++
+	}
+	return n
+}
+
+// putTimeIndex adds run to the global and per-job time indexes within tx.
+func putTimeIndex(tx *bolt.Tx, run *JobRun) error {
+	key := timeIndexKey(run.StartTime, run.RunID)
+	if err := tx.Bucket([]byte(runTimeBucket)).Put(key, []byte(run.JobID)); err != nil {
+		return fmt.Errorf("put time index: %w", err)
+	}
+
+	jobIndex, err := tx.Bucket([]byte(jobRunTimeBucket)).CreateBucketIfNotExists([]byte(run.JobID))
+	if err != nil {
+		return fmt.Errorf("create job time index %s: %w", run.JobID, err)
+	}
+	if err := jobIndex.Put(key, []byte(run.RunID)); err != nil {
+		return fmt.Errorf("put job time index: %w", err)
+	}
+	return nil
+}
+
+// deleteTimeIndex removes run from the global and per-job time indexes
+// within tx.
+func deleteTimeIndex(tx *bolt.Tx, run *JobRun) error {
+	key := timeIndexKey(run.StartTime, run.RunID)
+	if err := tx.Bucket([]byte(runTimeBucket)).Delete(key); err != nil {
+		return fmt.Errorf("delete time index %s: %w", run.RunID, err)
+	}
+
+	if jobIndex := tx.Bucket([]byte(jobRunTimeBucket)).Bucket([]byte(run.JobID)); jobIndex != nil {
+		if err := jobIndex.Delete(key); err != nil {
+			return fmt.Errorf("delete job time index %s: %w", run.RunID, err)
+		}
+	}
+	return nil
+}
+
+// rebuildTimeIndex indexes every stored run by start time, globally and per
+// job. Entries already present are rewritten unchanged.
+func rebuildTimeIndex(tx *bolt.Tx) error {
+	return forEachRun(tx, func(run *JobRun) error {
+		return putTimeIndex(tx, run)
+	})
+}
+
+// forEachRun decodes every stored run and calls fn with it. Only nested job
+// buckets are visited; any plain key in the runs bucket is ignored.
+func forEachRun(tx *bolt.Tx, fn func(*JobRun) error) error {
+	runs := tx.Bucket([]byte(runsBucket))
+	return runs.ForEachBucket(func(jobID []byte) error {
+		return runs.Bucket(jobID).ForEach(func(k, v []byte) error {
+			run := &JobRun{}
+			if err := json.Unmarshal(v, run); err != nil {
+				return fmt.Errorf("unmarshal run %s: %w", string(k), err)
+			}
+			return fn(run)
+		})
+	})
 }
 
 // Close releases resources held by the store.
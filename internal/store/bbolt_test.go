@@ -1,10 +1,14 @@
 package store
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
+
+	bolt "go.etcd.io/bbolt"
 )
 
 func TestNewBoltStore(t *testing.T) {
@@ -51,13 +55,13 @@ func TestBoltStore_SaveAndGetRun(t *testing.T) {
 	}
 
 	// Save run
-	err = store.SaveRun(run)
+	err = store.SaveRun(context.Background(), run)
 	if err != nil {
 		t.Fatalf("SaveRun() error = %v", err)
 	}
 
 	// Get run
-	got, err := store.GetRun("test-run-1")
+	got, err := store.GetRun(context.Background(), "test-run-1")
 	if err != nil {
 		t.Fatalf("GetRun() error = %v", err)
 	}
@@ -117,7 +121,7 @@ func TestBoltStore_SaveRun_ValidationErrors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := store.SaveRun(tt.run)
+			err := store.SaveRun(context.Background(), tt.run)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SaveRun() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -163,13 +167,13 @@ func TestBoltStore_GetJobRuns(t *testing.T) {
 
 	// Save all runs
 	for _, run := range runs {
-		if err := store.SaveRun(run); err != nil {
+		if err := store.SaveRun(context.Background(), run); err != nil {
 			t.Fatalf("SaveRun() error = %v", err)
 		}
 	}
 
 	// Get job runs
-	got, err := store.GetJobRuns(jobID, 10)
+	got, err := store.GetJobRuns(context.Background(), jobID, 10)
 	if err != nil {
 		t.Fatalf("GetJobRuns() error = %v", err)
 	}
@@ -184,7 +188,7 @@ func TestBoltStore_GetJobRuns(t *testing.T) {
 	}
 
 	// Test with limit
-	got, err = store.GetJobRuns(jobID, 2)
+	got, err = store.GetJobRuns(context.Background(), jobID, 2)
 	if err != nil {
 		t.Fatalf("GetJobRuns() with limit error = %v", err)
 	}
@@ -194,7 +198,7 @@ func TestBoltStore_GetJobRuns(t *testing.T) {
 	}
 
 	// Test non-existent job
-	got, err = store.GetJobRuns("non-existent", 10)
+	got, err = store.GetJobRuns(context.Background(), "non-existent", 10)
 	if err != nil {
 		t.Fatalf("GetJobRuns() for non-existent job error = %v", err)
 	}
@@ -241,13 +245,13 @@ func TestBoltStore_GetAllRuns(t *testing.T) {
 
 	// Save all runs
 	for _, run := range runs {
-		if err := store.SaveRun(run); err != nil {
+		if err := store.SaveRun(context.Background(), run); err != nil {
 			t.Fatalf("SaveRun() error = %v", err)
 		}
 	}
 
 	// Get all runs
-	got, err := store.GetAllRuns(10)
+	got, err := store.GetAllRuns(context.Background(), 10)
 	if err != nil {
 		t.Fatalf("GetAllRuns() error = %v", err)
 	}
@@ -262,7 +266,7 @@ func TestBoltStore_GetAllRuns(t *testing.T) {
 	}
 
 	// Test with limit
-	got, err = store.GetAllRuns(2)
+	got, err = store.GetAllRuns(context.Background(), 2)
 	if err != nil {
 		t.Fatalf("GetAllRuns() with limit error = %v", err)
 	}
@@ -291,7 +295,7 @@ func TestBoltStore_UpdateRun(t *testing.T) {
 		Success:   false, // Will be updated
 	}
 
-	err = store.SaveRun(run)
+	err = store.SaveRun(context.Background(), run)
 	if err != nil {
 		t.Fatalf("SaveRun() error = %v", err)
 	}
@@ -301,13 +305,13 @@ func TestBoltStore_UpdateRun(t *testing.T) {
 	run.EndTime = time.Now()
 	run.StdoutTail = "completed successfully"
 
-	err = store.SaveRun(run)
+	err = store.SaveRun(context.Background(), run)
 	if err != nil {
 		t.Fatalf("SaveRun() update error = %v", err)
 	}
 
 	// Verify update
-	got, err := store.GetRun("update-test")
+	got, err := store.GetRun(context.Background(), "update-test")
 	if err != nil {
 		t.Fatalf("GetRun() error = %v", err)
 	}
@@ -383,3 +387,386 @@ func TestJobRun_IsRunning(t *testing.T) {
 		t.Error("IsRunning() = true, want false for zero StartTime")
 	}
 }
+
+func TestBoltStore_RebuildsStatsForLegacyDatabase(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	store, err := NewBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewBoltStore() error = %v", err)
+	}
+	for i, success := range []bool{true, true, false} {
+		run := &JobRun{
+			RunID:     fmt.Sprintf("run-%d", i),
+			JobID:     "legacy-job",
+			StartTime: time.Now(),
+			EndTime:   time.Now(),
+			Success:   success,
+		}
+		if err := store.SaveRun(context.Background(), run); err != nil {
+			t.Fatalf("SaveRun() error = %v", err)
+		}
+	}
+
+	// Simulate a database written before counters existed
+	db := store.(*BoltStore).db
+	if err := db.Update(func(tx *bolt.Tx) error {
+		return tx.DeleteBucket([]byte(statsBucket))
+	}); err != nil {
+		t.Fatalf("delete stats bucket: %v", err)
+	}
+	store.Close()
+
+	store, err = NewBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewBoltStore() reopen error = %v", err)
+	}
+	defer store.Close()
+
+	stats, err := store.GetStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+	want := StoreStats{TotalRuns: 3, SuccessCount: 2, FailureCount: 1}
+	if *stats != want {
+		t.Errorf("GetStats() = %+v, want %+v", *stats, want)
+	}
+}
+
+func TestBoltStore_JobStatsCounters(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	store, err := NewBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewBoltStore() error = %v", err)
+	}
+	defer func() { store.Close() }()
+
+	// check compares the stored counters with stats computed from every run
+	check := func(step string) {
+		t.Helper()
+		runs, err := store.GetAllRuns(ctx, 0)
+		if err != nil {
+			t.Fatalf("%s: GetAllRuns() error = %v", step, err)
+		}
+		for _, jobID := range []string{"job-a", "job-b"} {
+			got, err := store.GetJobStats(ctx, jobID)
+			if err != nil {
+				t.Fatalf("%s: GetJobStats(%s) error = %v", step, jobID, err)
+			}
+			want := jobStatsOf(jobID, runs)
+			if got.StoreStats != want.StoreStats || got.AvgDuration != want.AvgDuration ||
+				got.SuccessRate != want.SuccessRate || !got.LastRunTime.Equal(want.LastRunTime) {
+				t.Errorf("%s: GetJobStats(%s) = %+v, want %+v", step, jobID, *got, *want)
+			}
+		}
+	}
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i := range 6 {
+		jobID := "job-a"
+		if i%3 == 2 {
+			jobID = "job-b"
+		}
+		run := &JobRun{
+			RunID:     fmt.Sprintf("run-%d", i),
+			JobID:     jobID,
+			StartTime: start.Add(time.Duration(i) * time.Minute),
+		}
+		if err := store.SaveRun(ctx, run); err != nil {
+			t.Fatalf("SaveRun() error = %v", err)
+		}
+		check("running " + run.RunID)
+
+		// Finishing a run moves it between counters rather than adding one
+		run.EndTime = run.StartTime.Add(time.Duration(i+1) * time.Second)
+		run.Success = i%2 == 0
+		if err := store.SaveRun(ctx, run); err != nil {
+			t.Fatalf("SaveRun() error = %v", err)
+		}
+		check("finished " + run.RunID)
+	}
+
+	if err := store.DeleteRun(ctx, "run-5"); err != nil {
+		t.Fatalf("DeleteRun() error = %v", err)
+	}
+	check("delete")
+
+	if n, err := store.PruneRuns(ctx, time.Time{}, 1); err != nil || n == 0 {
+		t.Fatalf("PruneRuns() = %d, %v; want some runs pruned", n, err)
+	}
+	check("prune")
+
+	// Simulate a database written before per-job counters existed
+	if err := store.(*BoltStore).db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(statsBucket)).DeleteBucket([]byte(jobStatsBucket))
+	}); err != nil {
+		t.Fatalf("delete job stats bucket: %v", err)
+	}
+	store.Close()
+
+	store, err = NewBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewBoltStore() reopen error = %v", err)
+	}
+	check("reopen")
+}
+
+// timeIndexEntries returns the run IDs in a time index of store, oldest
+// first: the global index, or jobID's when it is set.
+func timeIndexEntries(t *testing.T, store Store, jobID string) []string {
+	t.Helper()
+	var ids []string
+	err := store.(*BoltStore).db.View(func(tx *bolt.Tx) error {
+		index := tx.Bucket([]byte(runTimeBucket))
+		if jobID != "" {
+			if index = tx.Bucket([]byte(jobRunTimeBucket)).Bucket([]byte(jobID)); index == nil {
+				return nil
+			}
+		}
+		return index.ForEach(func(k, v []byte) error {
+			runID, ok := runIDFromTimeKey(k)
+			if !ok {
+				return fmt.Errorf("malformed key %q", k)
+			}
+			if jobID != "" && string(v) != string(runID) {
+				return fmt.Errorf("job index key %q maps to %q", k, v)
+			}
+			ids = append(ids, string(runID))
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("read time index: %v", err)
+	}
+	return ids
+}
+
+func TestBoltStore_GetAllRunsTimeOrder(t *testing.T) {
+	store, err := NewBoltStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore() error = %v", err)
+	}
+	defer store.Close()
+
+	// Fractions that sort wrongly as RFC3339Nano text, a time zone other
+	// than UTC, and a tie broken by run ID
+	base := time.Date(2025, 10, 8, 2, 0, 0, 0, time.UTC)
+	plus2 := time.FixedZone("UTC+2", 2*60*60)
+	runs := []*JobRun{
+		{RunID: "a", JobID: "job-1", StartTime: base.Add(100 * time.Millisecond)},
+		{RunID: "b", JobID: "job-2", StartTime: base.Add(150 * time.Millisecond)},
+		{RunID: "c", JobID: "job-1", StartTime: base.Add(time.Second).In(plus2)},
+		{RunID: "d", JobID: "job-2", StartTime: base.Add(2 * time.Second)},
+		{RunID: "e", JobID: "job-1", StartTime: base.Add(2 * time.Second)},
+		{RunID: "f", JobID: "job-3", StartTime: base.Add(-time.Hour).In(plus2)},
+	}
+	for _, run := range runs {
+		if err := store.SaveRun(context.Background(), run); err != nil {
+			t.Fatalf("SaveRun() error = %v", err)
+		}
+	}
+
+	got, err := store.GetAllRuns(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("GetAllRuns() error = %v", err)
+	}
+	want := []string{"e", "d", "c", "b", "a", "f"}
+	if fmt.Sprint(runIDs(got)) != fmt.Sprint(want) {
+		t.Errorf("GetAllRuns() = %v, want %v", runIDs(got), want)
+	}
+
+	got, err = store.GetAllRuns(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetAllRuns() error = %v", err)
+	}
+	if len(got) != 1 || got[0].RunID != "e" || got[0].JobID != "job-1" {
+		t.Errorf("GetAllRuns(1) = %v, want [e]", runIDs(got))
+	}
+}
+
+func TestBoltStore_TimeIndexConsistency(t *testing.T) {
+	store, err := NewBoltStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	start := time.Now().Add(-time.Hour)
+	for i := range 4 {
+		run := &JobRun{RunID: fmt.Sprintf("run-%d", i), JobID: "job", StartTime: start.Add(time.Duration(i) * time.Minute)}
+		if err := store.SaveRun(ctx, run); err != nil {
+			t.Fatalf("SaveRun() error = %v", err)
+		}
+	}
+
+	// Finishing a run keeps its single entry
+	finished := &JobRun{RunID: "run-1", JobID: "job", StartTime: start.Add(time.Minute), EndTime: start.Add(2 * time.Minute), Success: true}
+	if err := store.SaveRun(ctx, finished); err != nil {
+		t.Fatalf("SaveRun() update error = %v", err)
+	}
+	if got := timeIndexEntries(t, store, ""); fmt.Sprint(got) != "[run-0 run-1 run-2 run-3]" {
+		t.Errorf("time index after update = %v", got)
+	}
+	if got := timeIndexEntries(t, store, "job"); fmt.Sprint(got) != "[run-0 run-1 run-2 run-3]" {
+		t.Errorf("job time index after update = %v", got)
+	}
+
+	// A run whose start time changes moves rather than gaining a second entry
+	moved := &JobRun{RunID: "run-0", JobID: "job", StartTime: start.Add(10 * time.Minute)}
+	if err := store.SaveRun(ctx, moved); err != nil {
+		t.Fatalf("SaveRun() move error = %v", err)
+	}
+	if got := timeIndexEntries(t, store, ""); fmt.Sprint(got) != "[run-1 run-2 run-3 run-0]" {
+		t.Errorf("time index after moving run-0 = %v", got)
+	}
+	if got := timeIndexEntries(t, store, "job"); fmt.Sprint(got) != "[run-1 run-2 run-3 run-0]" {
+		t.Errorf("job time index after moving run-0 = %v", got)
+	}
+	all, err := store.GetAllRuns(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetAllRuns() error = %v", err)
+	}
+	if len(all) != 1 || all[0].RunID != "run-0" {
+		t.Errorf("GetAllRuns(1) = %v, want [run-0]", runIDs(all))
+	}
+	jobRuns, err := store.GetJobRuns(ctx, "job", 10)
+	if err != nil {
+		t.Fatalf("GetJobRuns() error = %v", err)
+	}
+	if fmt.Sprint(runIDs(jobRuns)) != "[run-0 run-3 run-2 run-1]" {
+		t.Errorf("GetJobRuns() = %v, want [run-0 run-3 run-2 run-1]", runIDs(jobRuns))
+	}
+
+	if err := store.DeleteRun(ctx, "run-2"); err != nil {
+		t.Fatalf("DeleteRun() error = %v", err)
+	}
+	if got := timeIndexEntries(t, store, ""); fmt.Sprint(got) != "[run-1 run-3 run-0]" {
+		t.Errorf("time index after delete = %v", got)
+	}
+	if got := timeIndexEntries(t, store, "job"); fmt.Sprint(got) != "[run-1 run-3 run-0]" {
+		t.Errorf("job time index after delete = %v", got)
+	}
+
+	if _, err := store.PruneRuns(ctx, time.Now(), 1); err != nil {
+		t.Fatalf("PruneRuns() error = %v", err)
+	}
+	// run-1 is the only finished run; running runs are never pruned
+	if got := timeIndexEntries(t, store, ""); fmt.Sprint(got) != "[run-3 run-0]" {
+		t.Errorf("time index after prune = %v", got)
+	}
+	if got := timeIndexEntries(t, store, "job"); fmt.Sprint(got) != "[run-3 run-0]" {
+		t.Errorf("job time index after prune = %v", got)
+	}
+}
+
+func TestBoltStore_GetJobRunsTimeOrder(t *testing.T) {
+	store, err := NewBoltStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore() error = %v", err)
+	}
+	defer store.Close()
+
+	// Interleave two jobs, saving out of order and re-saving each run
+	start := time.Date(2025, 10, 8, 2, 0, 0, 0, time.UTC)
+	for _, i := range []int{3, 0, 5, 1, 4, 2} {
+		run := &JobRun{RunID: fmt.Sprintf("run-%d", i), JobID: fmt.Sprintf("job-%d", i%2), StartTime: start.Add(time.Duration(i) * time.Millisecond)}
+		for range 2 {
+			if err := store.SaveRun(context.Background(), run); err != nil {
+				t.Fatalf("SaveRun() error = %v", err)
+			}
+			run.EndTime = run.StartTime.Add(time.Second)
+		}
+	}
+
+	for jobID, want := range map[string]string{"job-0": "[run-4 run-2 run-0]", "job-1": "[run-5 run-3 run-1]"} {
+		got, err := store.GetJobRuns(context.Background(), jobID, 10)
+		if err != nil {
+			t.Fatalf("GetJobRuns(%s) error = %v", jobID, err)
+		}
+		if fmt.Sprint(runIDs(got)) != want {
+			t.Errorf("GetJobRuns(%s) = %v, want %s", jobID, runIDs(got), want)
+		}
+		if got := timeIndexEntries(t, store, jobID); len(got) != 3 {
+			t.Errorf("job time index for %s = %v, want 3 entries", jobID, got)
+		}
+	}
+
+	got, err := store.GetJobRuns(context.Background(), "job-1", 2)
+	if err != nil {
+		t.Fatalf("GetJobRuns() error = %v", err)
+	}
+	if fmt.Sprint(runIDs(got)) != "[run-5 run-3]" {
+		t.Errorf("GetJobRuns(job-1, 2) = %v, want [run-5 run-3]", runIDs(got))
+	}
+	if got := timeIndexEntries(t, store, ""); len(got) != 6 {
+		t.Errorf("time index = %v, want 6 entries", got)
+	}
+}
+
+func TestBoltStore_RebuildsTimeIndexForLegacyDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	store, err := NewBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewBoltStore() error = %v", err)
+	}
+	start := time.Now().Add(-time.Hour)
+	for i := range 3 {
+		run := &JobRun{RunID: fmt.Sprintf("run-%d", i), JobID: fmt.Sprintf("job-%d", i%2), StartTime: start.Add(time.Duration(i) * time.Minute)}
+		if err := store.SaveRun(context.Background(), run); err != nil {
+			t.Fatalf("SaveRun() error = %v", err)
+		}
+	}
+
+	// Simulate a database written before the time index existed, with a
+	// stray non-bucket key in the runs bucket
+	db := store.(*BoltStore).db
+	if err := db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket([]byte(runsBucket)).Put([]byte("stray"), []byte("value")); err != nil {
+			return err
+		}
+		if err := tx.DeleteBucket([]byte(jobRunTimeBucket)); err != nil {
+			return err
+		}
+		return tx.DeleteBucket([]byte(runTimeBucket))
+	}); err != nil {
+		t.Fatalf("prepare legacy database: %v", err)
+	}
+	store.Close()
+
+	store, err = NewBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewBoltStore() reopen error = %v", err)
+	}
+	defer store.Close()
+
+	got, err := store.GetAllRuns(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("GetAllRuns() error = %v", err)
+	}
+	if fmt.Sprint(runIDs(got)) != "[run-2 run-1 run-0]" {
+		t.Errorf("GetAllRuns() = %v, want [run-2 run-1 run-0]", runIDs(got))
+	}
+
+	jobRuns, err := store.GetJobRuns(context.Background(), "job-0", 10)
+	if err != nil {
+		t.Fatalf("GetJobRuns() error = %v", err)
+	}
+	if fmt.Sprint(runIDs(jobRuns)) != "[run-2 run-0]" {
+		t.Errorf("GetJobRuns() = %v, want [run-2 run-0]", runIDs(jobRuns))
+	}
+
+	filtered, err := store.GetRunsFiltered(context.Background(), RunFilter{})
+	if err != nil {
+		t.Fatalf("GetRunsFiltered() error = %v", err)
+	}
+	if len(filtered) != 3 {
+		t.Errorf("GetRunsFiltered() returned %d runs, want 3", len(filtered))
+	}
+	if _, err := store.PruneRuns(context.Background(), time.Now(), 0); err != nil {
+		t.Fatalf("PruneRuns() error = %v", err)
+	}
+}
@@ -3,20 +3,54 @@ package store
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // SupportedDrivers lists all available store drivers.
-var SupportedDrivers = []string{"bbolt", "json"}
+var SupportedDrivers = []string{"bbolt", "sqlite", "json", "memory"}
+
+// Option configures driver-specific store behavior.
+type Option func(*options)
+
+// options holds optional store configuration accumulated from Option values.
+type options struct {
+	jsonFlushInterval time.Duration
+}
+
+// WithJSONFlushInterval makes the JSON driver coalesce writes made within d
+// into a single file rewrite instead of rewriting the file on every save.
+// A non-positive d keeps the default write-through behavior. Other drivers
+// ignore this option.
+func WithJSONFlushInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.jsonFlushInterval = d
+	}
+}
+
+// applyOptions folds opts into an options value.
+func applyOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
 
 // NewStore creates a new Store instance based on the specified driver.
 // Supported drivers:
 //   - "bbolt": BoltDB-backed persistent storage (recommended for production)
+//   - "sqlite": SQLite-backed persistent storage (queryable with standard SQL tooling)
 //   - "json": JSON file-backed storage (suitable for testing and small deployments)
+//   - "memory": in-memory storage that is lost on exit (for tests and ephemeral use)
 //
-// The path parameter specifies where the store data will be persisted.
-func NewStore(driver, path string) (Store, error) {
+// The path parameter specifies where the store data will be persisted. The
+// memory driver ignores it, so it may be empty.
+func NewStore(driver, path string, opts ...Option) (Store, error) {
 	driver = strings.ToLower(strings.TrimSpace(driver))
 
+	if driver == "memory" {
+		return NewMemoryStore(), nil
+	}
 	if path == "" {
 		return nil, fmt.Errorf("store path is required")
 	}
@@ -24,8 +58,10 @@ func NewStore(driver, path string) (Store, error) {
 	switch driver {
 	case "bbolt":
 		return NewBoltStore(path)
+	case "sqlite":
+		return NewSQLiteStore(path)
 	case "json":
-		return NewJSONStore(path)
+		return NewJSONStore(path, opts...)
 	default:
 		return nil, fmt.Errorf("unsupported store driver: %s (supported: %v)", driver, SupportedDrivers)
 	}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Run statuses, as matched by RunFilter.Status.
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+	StatusRunning = "running"
+
+	// StatusTimeout labels failed runs whose command was killed at its
+	// timeout, for display. Filters still count those runs as failures.
+	StatusTimeout = "timeout"
+
+	// StatusCommandNotFound and StatusWorkdirNotFound label failed runs
+	// whose command never started because its executable or working
+	// directory did not exist, for display. Filters count them as failures.
+	StatusCommandNotFound = "command_not_found"
+	StatusWorkdirNotFound = "workdir_not_found"
+)
+
+// RunFilter selects runs for GetRunsFiltered. Zero-valued fields do not
+// filter.
+type RunFilter struct {
+	// JobID restricts results to one job.
+	JobID string
+
+	// Status is StatusSuccess, StatusFailure, or StatusRunning.
+	Status string
+
+	// Since and Until bound the run's StartTime: Since is inclusive and
+	// Until exclusive.
+	Since time.Time
+	Until time.Time
+
+	// Limit caps the number of runs returned (default 100). Offset skips
+	// that many of the newest matching runs, for pagination.
+	Limit  int
+	Offset int
+}
+
+// Validate reports an unknown status or a negative limit or offset.
+func (f RunFilter) Validate() error {
+	switch f.Status {
+	case "", StatusSuccess, StatusFailure, StatusRunning:
+	default:
+		return fmt.Errorf("invalid status %q (must be %s, %s, or %s)", f.Status, StatusSuccess, StatusFailure, StatusRunning)
+	}
+	if f.Limit < 0 {
+		return fmt.Errorf("limit must be non-negative")
+	}
+	if f.Offset < 0 {
+		return fmt.Errorf("offset must be non-negative")
+	}
+	return nil
+}
+
+// limit returns the effective limit, applying the default.
+func (f RunFilter) limit() int {
+	if f.Limit <= 0 {
+		return 100 // default limit
+	}
+	return f.Limit
+}
+
+// Matches reports whether run satisfies every condition of f except the
+// limit and offset.
+func (f RunFilter) Matches(run *JobRun) bool {
+	if f.JobID != "" && run.JobID != f.JobID {
+		return false
+	}
+	if f.Status != "" && run.Status() != f.Status {
+		return false
+	}
+	if !f.Since.IsZero() && run.StartTime.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !run.StartTime.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Status returns StatusRunning, StatusSuccess, or StatusFailure.
+func (r *JobRun) Status() string {
+	switch {
+	case r.IsRunning():
+		return StatusRunning
+	case r.Success:
+		return StatusSuccess
+	default:
+		return StatusFailure
+	}
+}
+
+// filterRuns returns the page of runs selected by f, newest first. It is
+// used by the drivers that scan runs in memory.
+func filterRuns(runs []*JobRun, f RunFilter) []*JobRun {
+	var matched []*JobRun
+	for _, run := range runs {
+		if f.Matches(run) {
+			matched = append(matched, run)
+		}
+	}
+
+	// Sort by start time descending (newest first)
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].StartTime.After(matched[j].StartTime)
+	})
+
+	if f.Offset >= len(matched) {
+		return nil
+	}
+	matched = matched[f.Offset:]
+	if len(matched) > f.limit() {
+		matched = matched[:f.limit()]
+	}
+	return matched
+}
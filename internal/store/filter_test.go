@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_GetRunsFiltered(t *testing.T) {
+	for _, driver := range SupportedDrivers {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			s, err := NewStore(driver, filepath.Join(t.TempDir(), "runs."+driver))
+			if err != nil {
+				t.Fatalf("NewStore(%s) error = %v", driver, err)
+			}
+			defer s.Close()
+
+			// 30 runs a minute apart across 2 jobs: every third fails and
+			// the last 2 are still running.
+			start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			for i := 0; i < 30; i++ {
+				run := &JobRun{
+					RunID:     fmt.Sprintf("run-%02d", i),
+					JobID:     fmt.Sprintf("job-%d", i%2),
+					StartTime: start.Add(time.Duration(i) * time.Minute),
+				}
+				if i < 28 {
+					run.EndTime = run.StartTime.Add(time.Second)
+					run.Success = i%3 != 0
+				}
+				if err := s.SaveRun(ctx, run); err != nil {
+					t.Fatalf("SaveRun() error = %v", err)
+				}
+			}
+
+			tests := []struct {
+				name   string
+				filter RunFilter
+				want   []string
+				total  int
+			}{
+				{
+					name:   "status failure",
+					filter: RunFilter{Status: StatusFailure},
+					want:   []string{"run-27", "run-24", "run-21", "run-18", "run-15", "run-12", "run-09", "run-06", "run-03", "run-00"},
+					total:  10,
+				},
+				{
+					name:   "status running",
+					filter: RunFilter{Status: StatusRunning},
+					want:   []string{"run-29", "run-28"},
+					total:  2,
+				},
+				{
+					name:   "since inclusive until exclusive",
+					filter: RunFilter{Since: start.Add(10 * time.Minute), Until: start.Add(13 * time.Minute)},
+					want:   []string{"run-12", "run-11", "run-10"},
+					total:  3,
+				},
+				{
+					name:   "job only",
+					filter: RunFilter{JobID: "job-0", Limit: 2},
+					want:   []string{"run-28", "run-26"},
+					total:  15,
+				},
+				{
+					name:   "job without runs",
+					filter: RunFilter{JobID: "job-9"},
+					want:   nil,
+					total:  0,
+				},
+				{
+					name:   "status and bounds",
+					filter: RunFilter{Status: StatusSuccess, Since: start.Add(10 * time.Minute), Until: start.Add(13 * time.Minute)},
+					want:   []string{"run-11", "run-10"},
+					total:  2,
+				},
+				{
+					name:   "job and status",
+					filter: RunFilter{JobID: "job-1", Status: StatusFailure},
+					want:   []string{"run-27", "run-21", "run-15", "run-09", "run-03"},
+					total:  5,
+				},
+				{
+					name:   "job with bounds between runs and offset",
+					filter: RunFilter{JobID: "job-0", Since: start.Add(9*time.Minute + 30*time.Second), Until: start.Add(20 * time.Minute), Offset: 1},
+					want:   []string{"run-16", "run-14", "run-12", "run-10"},
+					total:  5,
+				},
+				{
+					name:   "until after the newest run",
+					filter: RunFilter{Until: start.Add(time.Hour), Limit: 2},
+					want:   []string{"run-29", "run-28"},
+					total:  30,
+				},
+				{
+					name:   "limit and offset",
+					filter: RunFilter{Limit: 3, Offset: 4},
+					want:   []string{"run-25", "run-24", "run-23"},
+					total:  30,
+				},
+				{
+					name:   "offset past the end",
+					filter: RunFilter{Offset: 30},
+					want:   nil,
+					total:  30,
+				},
+			}
+
+			for _, tt := range tests {
+				runs, err := s.GetRunsFiltered(ctx, tt.filter)
+				if err != nil {
+					t.Fatalf("%s: GetRunsFiltered() error = %v", tt.name, err)
+				}
+				var got []string
+				for _, run := range runs {
+					got = append(got, run.RunID)
+				}
+				if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+					t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				}
+
+				total, err := s.CountRuns(ctx, tt.filter)
+				if err != nil {
+					t.Fatalf("%s: CountRuns() error = %v", tt.name, err)
+				}
+				if total != tt.total {
+					t.Errorf("%s: CountRuns() = %d, want %d", tt.name, total, tt.total)
+				}
+			}
+		})
+	}
+}
+
+func TestRunFilter_Validate(t *testing.T) {
+	if err := (RunFilter{Status: StatusSuccess, Limit: 10, Offset: 5}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	for _, f := range []RunFilter{{Status: "ok"}, {Limit: -1}, {Offset: -1}} {
+		if err := f.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected error", f)
+		}
+	}
+}
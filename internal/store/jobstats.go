@@ -0,0 +1,54 @@
+package store
+
+import "time"
+
+// JobRunStats aggregates the run history of one job.
+type JobRunStats struct {
+	JobID string `json:"job_id"`
+	StoreStats
+
+	// SuccessRate is the fraction of completed runs that succeeded, from 0
+	// to 1; it is 0 when no run has completed.
+	SuccessRate float64 `json:"success_rate"`
+
+	// AvgDuration is the mean duration of completed runs.
+	AvgDuration time.Duration `json:"avg_duration"`
+
+	// LastRunTime is the start time of the most recent run (zero if none).
+	LastRunTime time.Time `json:"last_run_time,omitempty"`
+}
+
+// SuccessRate returns the fraction of completed runs that succeeded, or 0
+// when no run has completed.
+func (st StoreStats) SuccessRate() float64 {
+	completed := st.SuccessCount + st.FailureCount
+	if completed == 0 {
+		return 0
+	}
+	return float64(st.SuccessCount) / float64(completed)
+}
+
+// jobStatsOf computes jobID's stats from runs, skipping runs of other jobs.
+// It is used by the drivers that scan runs in memory.
+func jobStatsOf(jobID string, runs []*JobRun) *JobRunStats {
+	stats := &JobRunStats{JobID: jobID}
+	var total time.Duration
+	for _, run := range runs {
+		if run.JobID != jobID {
+			continue
+		}
+		stats.add(run, 1)
+		if !run.IsRunning() {
+			total += run.Duration()
+		}
+		if run.StartTime.After(stats.LastRunTime) {
+			stats.LastRunTime = run.StartTime
+		}
+	}
+
+	if completed := stats.SuccessCount + stats.FailureCount; completed > 0 {
+		stats.AvgDuration = total / time.Duration(completed)
+	}
+	stats.SuccessRate = stats.StoreStats.SuccessRate()
+	return stats
+}
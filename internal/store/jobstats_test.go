@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_GetJobStats(t *testing.T) {
+	for _, driver := range SupportedDrivers {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			s, err := NewStore(driver, filepath.Join(t.TempDir(), "runs."+driver))
+			if err != nil {
+				t.Fatalf("NewStore(%s) error = %v", driver, err)
+			}
+			defer s.Close()
+
+			// job-a: 3 successes (1s, 2s, 3s), 1 failure (6s), 1 running.
+			// job-b: one success that must not leak into job-a's stats.
+			start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+			runs := []struct {
+				id, job  string
+				duration time.Duration // 0 leaves the run in progress
+				success  bool
+			}{
+				{"a1", "job-a", time.Second, true},
+				{"a2", "job-a", 2 * time.Second, true},
+				{"a3", "job-a", 6 * time.Second, false},
+				{"a4", "job-a", 3 * time.Second, true},
+				{"a5", "job-a", 0, false},
+				{"b1", "job-b", time.Hour, true},
+			}
+			for i, r := range runs {
+				run := &JobRun{
+					RunID:     r.id,
+					JobID:     r.job,
+					StartTime: start.Add(time.Duration(i) * time.Minute),
+					Success:   r.success,
+				}
+				if r.duration > 0 {
+					run.EndTime = run.StartTime.Add(r.duration)
+				}
+				if err := s.SaveRun(ctx, run); err != nil {
+					t.Fatalf("SaveRun() error = %v", err)
+				}
+			}
+
+			stats, err := s.GetJobStats(ctx, "job-a")
+			if err != nil {
+				t.Fatalf("GetJobStats() error = %v", err)
+			}
+			want := StoreStats{TotalRuns: 5, SuccessCount: 3, FailureCount: 1, RunningCount: 1}
+			if stats.StoreStats != want {
+				t.Errorf("counts = %+v, want %+v", stats.StoreStats, want)
+			}
+			if math.Abs(stats.SuccessRate-0.75) > 1e-9 {
+				t.Errorf("SuccessRate = %v, want 0.75", stats.SuccessRate)
+			}
+			if stats.AvgDuration != 3*time.Second {
+				t.Errorf("AvgDuration = %v, want 3s", stats.AvgDuration)
+			}
+			if want := start.Add(4 * time.Minute); !stats.LastRunTime.Equal(want) {
+				t.Errorf("LastRunTime = %v, want %v", stats.LastRunTime, want)
+			}
+
+			empty, err := s.GetJobStats(ctx, "never-ran")
+			if err != nil {
+				t.Fatalf("GetJobStats() error = %v", err)
+			}
+			if fmt.Sprint(*empty) != fmt.Sprint(JobRunStats{JobID: "never-ran"}) {
+				t.Errorf("stats for a job without runs = %+v, want zero", *empty)
+			}
+		})
+	}
+}
@@ -1,20 +1,31 @@
 package store
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
 	"sync"
+	"time"
 )
 
 // JSONStore implements the Store interface using a simple JSON file.
-// All runs are kept in memory and persisted to disk on each write.
+// All runs are kept in memory and the whole file is rewritten on persist.
+// By default every write is persisted immediately; with a flush interval
+// (see WithJSONFlushInterval), writes within the interval are coalesced into
+// a single rewrite and any pending write is flushed on Close.
 // This implementation is suitable for small-scale deployments and testing.
 type JSONStore struct {
-	path string
-	runs map[string]*JobRun // indexed by run_id
-	mu   sync.RWMutex
+	path  string
+	runs  map[string]*JobRun // indexed by run_id
+	stats StoreStats         // kept in step with runs
+	mu    sync.RWMutex
+
+	flushInterval time.Duration
+	flushTimer    *time.Timer // pending flush, nil when none is scheduled
+	dirty         bool        // in-memory state has changes not yet on disk
+	flushErr      error       // error from the last background flush, reported on the next write
 }
 
 // jsonPersistence is the on-disk format for the JSON store.
@@ -23,10 +34,13 @@ type jsonPersistence struct {
 }
 
 // NewJSONStore creates a new JSON file-backed store at the given path.
-func NewJSONStore(path string) (Store, error) {
+func NewJSONStore(path string, opts ...Option) (Store, error) {
+	o := applyOptions(opts)
+
 	s := &JSONStore{
-		path: path,
-		runs: make(map[string]*JobRun),
+		path:          path,
+		runs:          make(map[string]*JobRun),
+		flushInterval: o.jsonFlushInterval,
 	}
 
 	// Load existing data if file exists
@@ -54,8 +68,13 @@ func (s *JSONStore) load() error {
 	}
 
 	s.runs = make(map[string]*JobRun, len(persist.Runs))
+	s.stats = StoreStats{}
 	for _, run := range persist.Runs {
+		if prev, ok := s.runs[run.RunID]; ok {
+			s.stats.add(prev, -1)
+		}
 		s.runs[run.RunID] = run
+		s.stats.add(run, 1)
 	}
 
 	return nil
@@ -88,8 +107,49 @@ func (s *JSONStore) save() error {
 	return nil
 }
 
+// persist writes the in-memory state to disk, or schedules a batched write
+// when a flush interval is configured. The caller must hold s.mu.
+func (s *JSONStore) persist() error {
+	if s.flushInterval <= 0 {
+		return s.save()
+	}
+
+	s.dirty = true
+	if s.flushTimer == nil {
+		s.flushTimer = time.AfterFunc(s.flushInterval, s.flush)
+	}
+
+	// Surface a failed background flush to the next writer. The data is
+	// still dirty, so the scheduled flush retries it.
+	if err := s.flushErr; err != nil {
+		s.flushErr = nil
+		return fmt.Errorf("flush json store: %w", err)
+	}
+	return nil
+}
+
+// flush writes pending changes to disk. It runs on the flush timer.
+func (s *JSONStore) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.flushTimer = nil
+	if !s.dirty {
+		return
+	}
+	if err := s.save(); err != nil {
+		s.flushErr = err
+		return
+	}
+	s.dirty = false
+}
+
 // SaveRun persists a job run record.
-func (s *JSONStore) SaveRun(run *JobRun) error {
+func (s *JSONStore) SaveRun(ctx context.Context, run *JobRun) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if run.RunID == "" {
 		return fmt.Errorf("run_id is required")
 	}
@@ -97,15 +157,33 @@ func (s *JSONStore) SaveRun(run *JobRun) error {
 		return fmt.Errorf("job_id is required")
 	}
 
+	// Keep a private copy: with batched writes the record is marshaled
+	// later, while the caller may still be updating its own copy.
+	stored := *run
+	if run.Metadata != nil {
+		stored.Metadata = make(map[string]interface{}, len(run.Metadata))
+		for k, v := range run.Metadata {
+			stored.Metadata[k] = v
+		}
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.runs[run.RunID] = run
-	return s.save()
+	if prev, ok := s.runs[run.RunID]; ok {
+		s.stats.add(prev, -1)
+	}
+	s.runs[run.RunID] = &stored
+	s.stats.add(&stored, 1)
+	return s.persist()
 }
 
 // GetRun retrieves a specific run by its ID.
-func (s *JSONStore) GetRun(runID string) (*JobRun, error) {
+func (s *JSONStore) GetRun(ctx context.Context, runID string) (*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if runID == "" {
 		return nil, fmt.Errorf("run_id is required")
 	}
@@ -115,14 +193,18 @@ func (s *JSONStore) GetRun(runID string) (*JobRun, error) {
 
 	run, ok := s.runs[runID]
 	if !ok {
-		return nil, fmt.Errorf("run not found: %s", runID)
+		return nil, fmt.Errorf("%w: %s", ErrRunNotFound, runID)
 	}
 
 	return run, nil
 }
 
 // GetJobRuns retrieves the most recent runs for a specific job.
-func (s *JSONStore) GetJobRuns(jobID string, limit int) ([]*JobRun, error) {
+func (s *JSONStore) GetJobRuns(ctx context.Context, jobID string, limit int) ([]*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if jobID == "" {
 		return nil, fmt.Errorf("job_id is required")
 	}
@@ -155,7 +237,11 @@ func (s *JSONStore) GetJobRuns(jobID string, limit int) ([]*JobRun, error) {
 }
 
 // GetAllRuns retrieves the most recent runs across all jobs.
-func (s *JSONStore) GetAllRuns(limit int) ([]*JobRun, error) {
+func (s *JSONStore) GetAllRuns(ctx context.Context, limit int) ([]*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if limit <= 0 {
 		limit = 100 // default limit
 	}
@@ -182,8 +268,146 @@ func (s *JSONStore) GetAllRuns(limit int) ([]*JobRun, error) {
 	return runs, nil
 }
 
-// Close releases resources held by the store.
-// For JSON store, this is a no-op since we don't hold open file handles.
+// GetRunsFiltered retrieves the runs matching filter, newest first.
+func (s *JSONStore) GetRunsFiltered(ctx context.Context, filter RunFilter) ([]*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := make([]*JobRun, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, run)
+	}
+
+	return filterRuns(runs, filter), nil
+}
+
+// CountRuns returns how many runs match filter, ignoring its Limit and Offset.
+func (s *JSONStore) CountRuns(ctx context.Context, filter RunFilter) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for _, run := range s.runs {
+		if filter.Matches(run) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetStats returns the aggregate run counts kept in memory.
+func (s *JSONStore) GetStats(ctx context.Context) (*StoreStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := s.stats
+	return &stats, nil
+}
+
+// GetJobStats aggregates one job's runs.
+func (s *JSONStore) GetJobStats(ctx context.Context, jobID string) (*JobRunStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := make([]*JobRun, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, run)
+	}
+	return jobStatsOf(jobID, runs), nil
+}
+
+// DeleteRun removes a single run record.
+func (s *JSONStore) DeleteRun(ctx context.Context, runID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if runID == "" {
+		return fmt.Errorf("run_id is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, ok := s.runs[runID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+	}
+
+	delete(s.runs, runID)
+	s.stats.add(run, -1)
+	return s.persist()
+}
+
+// PruneRuns deletes old run records according to the retention rules.
+func (s *JSONStore) PruneRuns(ctx context.Context, olderThan time.Time, keepPerJob int) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runs := make([]*JobRun, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, run)
+	}
+
+	prunable := selectPrunable(runs, olderThan, keepPerJob)
+	if len(prunable) == 0 {
+		return 0, nil
+	}
+
+	for _, run := range prunable {
+		delete(s.runs, run.RunID)
+		s.stats.add(run, -1)
+	}
+
+	if err := s.persist(); err != nil {
+		return 0, err
+	}
+
+	return len(prunable), nil
+}
+
+// Close flushes any batched writes to disk. The JSON store holds no open
+// file handles, so it stays usable and Close may be called more than once.
 func (s *JSONStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.flushTimer != nil {
+		s.flushTimer.Stop()
+		s.flushTimer = nil
+	}
+	if !s.dirty {
+		return nil
+	}
+	if err := s.save(); err != nil {
+		return fmt.Errorf("flush json store: %w", err)
+	}
+	s.dirty = false
+	s.flushErr = nil
 	return nil
 }
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -47,7 +48,7 @@ func TestJSONStore_SaveAndGetRun(t *testing.T) {
 	}
 
 	// Save run
-	err = store.SaveRun(run)
+	err = store.SaveRun(context.Background(), run)
 	if err != nil {
 		t.Fatalf("SaveRun() error = %v", err)
 	}
@@ -58,7 +59,7 @@ func TestJSONStore_SaveAndGetRun(t *testing.T) {
 	}
 
 	// Get run
-	got, err := store.GetRun("test-run-1")
+	got, err := store.GetRun(context.Background(), "test-run-1")
 	if err != nil {
 		t.Fatalf("GetRun() error = %v", err)
 	}
@@ -99,7 +100,7 @@ func TestJSONStore_Persistence(t *testing.T) {
 		Success:   true,
 	}
 
-	err = store1.SaveRun(run)
+	err = store1.SaveRun(context.Background(), run)
 	if err != nil {
 		t.Fatalf("SaveRun() error = %v", err)
 	}
@@ -113,7 +114,7 @@ func TestJSONStore_Persistence(t *testing.T) {
 	}
 	defer store2.Close()
 
-	got, err := store2.GetRun("persist-test")
+	got, err := store2.GetRun(context.Background(), "persist-test")
 	if err != nil {
 		t.Fatalf("GetRun() after reload error = %v", err)
 	}
@@ -160,7 +161,7 @@ func TestJSONStore_SaveRun_ValidationErrors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := store.SaveRun(tt.run)
+			err := store.SaveRun(context.Background(), tt.run)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SaveRun() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -206,13 +207,13 @@ func TestJSONStore_GetJobRuns(t *testing.T) {
 
 	// Save all runs
 	for _, run := range runs {
-		if err := store.SaveRun(run); err != nil {
+		if err := store.SaveRun(context.Background(), run); err != nil {
 			t.Fatalf("SaveRun() error = %v", err)
 		}
 	}
 
 	// Get job runs
-	got, err := store.GetJobRuns(jobID, 10)
+	got, err := store.GetJobRuns(context.Background(), jobID, 10)
 	if err != nil {
 		t.Fatalf("GetJobRuns() error = %v", err)
 	}
@@ -227,7 +228,7 @@ func TestJSONStore_GetJobRuns(t *testing.T) {
 	}
 
 	// Test with limit
-	got, err = store.GetJobRuns(jobID, 2)
+	got, err = store.GetJobRuns(context.Background(), jobID, 2)
 	if err != nil {
 		t.Fatalf("GetJobRuns() with limit error = %v", err)
 	}
@@ -237,7 +238,7 @@ func TestJSONStore_GetJobRuns(t *testing.T) {
 	}
 
 	// Test non-existent job
-	got, err = store.GetJobRuns("non-existent", 10)
+	got, err = store.GetJobRuns(context.Background(), "non-existent", 10)
 	if err != nil {
 		t.Fatalf("GetJobRuns() for non-existent job error = %v", err)
 	}
@@ -284,13 +285,13 @@ func TestJSONStore_GetAllRuns(t *testing.T) {
 
 	// Save all runs
 	for _, run := range runs {
-		if err := store.SaveRun(run); err != nil {
+		if err := store.SaveRun(context.Background(), run); err != nil {
 			t.Fatalf("SaveRun() error = %v", err)
 		}
 	}
 
 	// Get all runs
-	got, err := store.GetAllRuns(10)
+	got, err := store.GetAllRuns(context.Background(), 10)
 	if err != nil {
 		t.Fatalf("GetAllRuns() error = %v", err)
 	}
@@ -305,7 +306,7 @@ func TestJSONStore_GetAllRuns(t *testing.T) {
 	}
 
 	// Test with limit
-	got, err = store.GetAllRuns(2)
+	got, err = store.GetAllRuns(context.Background(), 2)
 	if err != nil {
 		t.Fatalf("GetAllRuns() with limit error = %v", err)
 	}
@@ -334,7 +335,7 @@ func TestJSONStore_UpdateRun(t *testing.T) {
 		Success:   false, // Will be updated
 	}
 
-	err = store.SaveRun(run)
+	err = store.SaveRun(context.Background(), run)
 	if err != nil {
 		t.Fatalf("SaveRun() error = %v", err)
 	}
@@ -344,13 +345,13 @@ func TestJSONStore_UpdateRun(t *testing.T) {
 	run.EndTime = time.Now()
 	run.StdoutTail = "completed successfully"
 
-	err = store.SaveRun(run)
+	err = store.SaveRun(context.Background(), run)
 	if err != nil {
 		t.Fatalf("SaveRun() update error = %v", err)
 	}
 
 	// Verify update
-	got, err := store.GetRun("update-test")
+	got, err := store.GetRun(context.Background(), "update-test")
 	if err != nil {
 		t.Fatalf("GetRun() error = %v", err)
 	}
@@ -384,7 +385,7 @@ func TestJSONStore_ConcurrentAccess(t *testing.T) {
 				ExitCode:  0,
 				Success:   true,
 			}
-			if err := store.SaveRun(run); err != nil {
+			if err := store.SaveRun(context.Background(), run); err != nil {
 				t.Errorf("SaveRun() concurrent error = %v", err)
 			}
 			done <- true
@@ -397,7 +398,7 @@ func TestJSONStore_ConcurrentAccess(t *testing.T) {
 	}
 
 	// Verify all runs were saved
-	runs, err := store.GetJobRuns("test-job", 100)
+	runs, err := store.GetJobRuns(context.Background(), "test-job", 100)
 	if err != nil {
 		t.Fatalf("GetJobRuns() error = %v", err)
 	}
@@ -436,7 +437,7 @@ func TestNewJSONStore_LoadExisting(t *testing.T) {
 	}
 	defer store.Close()
 
-	run, err := store.GetRun("existing-run")
+	run, err := store.GetRun(context.Background(), "existing-run")
 	if err != nil {
 		t.Fatalf("GetRun() error = %v", err)
 	}
@@ -445,3 +446,79 @@ func TestNewJSONStore_LoadExisting(t *testing.T) {
 		t.Errorf("Loaded JobID = %v, want 'existing-job'", run.JobID)
 	}
 }
+
+func TestJSONStore_BatchedWrites(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.json")
+
+	store, err := NewJSONStore(dbPath, WithJSONFlushInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("NewJSONStore() error = %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		run := &JobRun{
+			RunID:     fmt.Sprintf("run-%d", i),
+			JobID:     "batched-job",
+			StartTime: time.Now(),
+		}
+		if err := store.SaveRun(context.Background(), run); err != nil {
+			t.Fatalf("SaveRun() error = %v", err)
+		}
+	}
+
+	// Nothing is written until the flush interval elapses or the store closes
+	if _, err := os.Stat(dbPath); !os.IsNotExist(err) {
+		t.Errorf("file written before flush: stat error = %v", err)
+	}
+
+	// Reads see unflushed state
+	if _, err := store.GetRun(context.Background(), "run-9"); err != nil {
+		t.Errorf("GetRun() on unflushed run error = %v", err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reopened, err := NewJSONStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewJSONStore() reopen error = %v", err)
+	}
+	defer reopened.Close()
+
+	runs, err := reopened.GetJobRuns(context.Background(), "batched-job", 100)
+	if err != nil {
+		t.Fatalf("GetJobRuns() error = %v", err)
+	}
+	if len(runs) != 10 {
+		t.Errorf("after Close, %d runs on disk, want 10", len(runs))
+	}
+}
+
+func TestJSONStore_BatchedWritesFlushOnInterval(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.json")
+
+	store, err := NewJSONStore(dbPath, WithJSONFlushInterval(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewJSONStore() error = %v", err)
+	}
+	defer store.Close()
+
+	run := &JobRun{RunID: "interval-run", JobID: "interval-job", StartTime: time.Now()}
+	if err := store.SaveRun(context.Background(), run); err != nil {
+		t.Fatalf("SaveRun() error = %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(dbPath); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("batched write was not flushed after the interval")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
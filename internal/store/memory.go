@@ -0,0 +1,260 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore implements the Store interface entirely in memory. Nothing is
+// persisted, so history is lost when the process exits. It is intended for
+// tests and ephemeral runs.
+type MemoryStore struct {
+	runs  map[string]*JobRun // indexed by run_id
+	stats StoreStats         // kept in step with runs
+	mu    sync.RWMutex
+}
+
+// NewMemoryStore creates a new, empty in-memory store.
+func NewMemoryStore() Store {
+	return &MemoryStore{runs: make(map[string]*JobRun)}
+}
+
+// copyRun returns a copy of run with its own Metadata map, so neither the
+// caller nor the store sees the other's later changes, as with the
+// persistent drivers.
+func copyRun(run *JobRun) *JobRun {
+	c := *run
+	if run.Metadata != nil {
+		c.Metadata = maps.Clone(run.Metadata)
+	}
+	return &c
+}
+
+// SaveRun stores a copy of a job run record.
+func (s *MemoryStore) SaveRun(ctx context.Context, run *JobRun) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if run.RunID == "" {
+		return fmt.Errorf("run_id is required")
+	}
+	if run.JobID == "" {
+		return fmt.Errorf("job_id is required")
+	}
+
+	stored := copyRun(run)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if prev, ok := s.runs[run.RunID]; ok {
+		s.stats.add(prev, -1)
+	}
+	s.runs[run.RunID] = stored
+	s.stats.add(stored, 1)
+	return nil
+}
+
+// GetRun retrieves a specific run by its ID.
+func (s *MemoryStore) GetRun(ctx context.Context, runID string) (*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if runID == "" {
+		return nil, fmt.Errorf("run_id is required")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	run, ok := s.runs[runID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+	}
+
+	return copyRun(run), nil
+}
+
+// GetJobRuns retrieves the most recent runs for a specific job.
+func (s *MemoryStore) GetJobRuns(ctx context.Context, jobID string, limit int) ([]*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if jobID == "" {
+		return nil, fmt.Errorf("job_id is required")
+	}
+
+	return s.newest(limit, func(run *JobRun) bool { return run.JobID == jobID }), nil
+}
+
+// GetAllRuns retrieves the most recent runs across all jobs.
+func (s *MemoryStore) GetAllRuns(ctx context.Context, limit int) ([]*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.newest(limit, func(*JobRun) bool { return true }), nil
+}
+
+// newest returns copies of the up to limit newest runs that match keep.
+func (s *MemoryStore) newest(limit int, keep func(*JobRun) bool) []*JobRun {
+	if limit <= 0 {
+		limit = 100 // default limit
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var runs []*JobRun
+	for _, run := range s.runs {
+		if keep(run) {
+			runs = append(runs, run)
+		}
+	}
+
+	// Sort by start time descending (newest first)
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].StartTime.After(runs[j].StartTime)
+	})
+
+	if len(runs) > limit {
+		runs = runs[:limit]
+	}
+	for i, run := range runs {
+		runs[i] = copyRun(run)
+	}
+
+	return runs
+}
+
+// GetRunsFiltered retrieves copies of the runs matching filter, newest first.
+func (s *MemoryStore) GetRunsFiltered(ctx context.Context, filter RunFilter) ([]*JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := make([]*JobRun, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, run)
+	}
+
+	runs = filterRuns(runs, filter)
+	for i, run := range runs {
+		runs[i] = copyRun(run)
+	}
+	return runs, nil
+}
+
+// CountRuns returns how many runs match filter, ignoring its Limit and Offset.
+func (s *MemoryStore) CountRuns(ctx context.Context, filter RunFilter) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for _, run := range s.runs {
+		if filter.Matches(run) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetStats returns the aggregate run counts.
+func (s *MemoryStore) GetStats(ctx context.Context) (*StoreStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := s.stats
+	return &stats, nil
+}
+
+// GetJobStats aggregates one job's runs.
+func (s *MemoryStore) GetJobStats(ctx context.Context, jobID string) (*JobRunStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := make([]*JobRun, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, run)
+	}
+	return jobStatsOf(jobID, runs), nil
+}
+
+// DeleteRun removes a single run record.
+func (s *MemoryStore) DeleteRun(ctx context.Context, runID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if runID == "" {
+		return fmt.Errorf("run_id is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, ok := s.runs[runID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+	}
+
+	delete(s.runs, runID)
+	s.stats.add(run, -1)
+	return nil
+}
+
+// PruneRuns deletes old run records according to the retention rules.
+func (s *MemoryStore) PruneRuns(ctx context.Context, olderThan time.Time, keepPerJob int) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runs := make([]*JobRun, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, run)
+	}
+
+	prunable := selectPrunable(runs, olderThan, keepPerJob)
+	for _, run := range prunable {
+		delete(s.runs, run.RunID)
+		s.stats.add(run, -1)
+	}
+
+	return len(prunable), nil
+}
+
+// Close is a no-op: the memory store holds no resources, stays usable, and
+// keeps its runs until it is garbage collected.
+func (s *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,237 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNewStore_MemoryWithoutPath(t *testing.T) {
+	store, err := NewStore("memory", "")
+	if err != nil {
+		t.Fatalf("NewStore(memory) error = %v", err)
+	}
+	defer store.Close()
+
+	if _, ok := store.(*MemoryStore); !ok {
+		t.Fatalf("NewStore(memory) returned %T, want *MemoryStore", store)
+	}
+}
+
+func TestMemoryStore_SaveAndGetRun(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+
+	run := &JobRun{
+		RunID:      "test-run-1",
+		JobID:      "test-job",
+		StartTime:  time.Now(),
+		EndTime:    time.Now().Add(5 * time.Second),
+		ExitCode:   0,
+		Success:    true,
+		StdoutTail: "test output",
+		Metadata:   map[string]interface{}{"test": "value"},
+	}
+
+	if err := store.SaveRun(context.Background(), run); err != nil {
+		t.Fatalf("SaveRun() error = %v", err)
+	}
+
+	got, err := store.GetRun(context.Background(), "test-run-1")
+	if err != nil {
+		t.Fatalf("GetRun() error = %v", err)
+	}
+
+	if got.RunID != run.RunID {
+		t.Errorf("RunID = %v, want %v", got.RunID, run.RunID)
+	}
+	if got.JobID != run.JobID {
+		t.Errorf("JobID = %v, want %v", got.JobID, run.JobID)
+	}
+	if got.Success != run.Success {
+		t.Errorf("Success = %v, want %v", got.Success, run.Success)
+	}
+	if got.StdoutTail != run.StdoutTail {
+		t.Errorf("StdoutTail = %v, want %v", got.StdoutTail, run.StdoutTail)
+	}
+	if got.Metadata["test"] != "value" {
+		t.Errorf("Metadata = %v, want test=value", got.Metadata)
+	}
+
+	_, err = store.GetRun(context.Background(), "missing")
+	if !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("GetRun(missing) error = %v, want ErrRunNotFound", err)
+	}
+}
+
+func TestMemoryStore_CopiesRuns(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+
+	run := &JobRun{
+		RunID:     "copy-test",
+		JobID:     "test-job",
+		StartTime: time.Now(),
+		Metadata:  map[string]interface{}{"status": "running"},
+	}
+	if err := store.SaveRun(context.Background(), run); err != nil {
+		t.Fatalf("SaveRun() error = %v", err)
+	}
+
+	// Changes to the caller's copy are not visible until saved again
+	run.Success = true
+	run.Metadata["status"] = "success"
+
+	got, err := store.GetRun(context.Background(), "copy-test")
+	if err != nil {
+		t.Fatalf("GetRun() error = %v", err)
+	}
+	if got.Success || got.Metadata["status"] != "running" {
+		t.Errorf("stored run changed without SaveRun: %+v", got)
+	}
+
+	// Nor are changes to a returned run
+	got.Metadata["status"] = "tampered"
+	again, err := store.GetRun(context.Background(), "copy-test")
+	if err != nil {
+		t.Fatalf("GetRun() error = %v", err)
+	}
+	if again.Metadata["status"] != "running" {
+		t.Errorf("stored run changed through a returned copy: %v", again.Metadata)
+	}
+}
+
+func TestMemoryStore_SaveRun_ValidationErrors(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+
+	tests := []struct {
+		name string
+		run  *JobRun
+	}{
+		{name: "empty RunID", run: &JobRun{JobID: "test-job", StartTime: time.Now()}},
+		{name: "empty JobID", run: &JobRun{RunID: "test-run", StartTime: time.Now()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := store.SaveRun(context.Background(), tt.run); err == nil {
+				t.Error("SaveRun() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestMemoryStore_GetJobRunsAndAllRuns(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+
+	runs := []*JobRun{
+		{RunID: "run-1", JobID: "job-1", StartTime: time.Now().Add(-3 * time.Hour), Success: true},
+		{RunID: "run-2", JobID: "job-2", StartTime: time.Now().Add(-2 * time.Hour)},
+		{RunID: "run-3", JobID: "job-1", StartTime: time.Now().Add(-1 * time.Hour), Success: true},
+	}
+	for _, run := range runs {
+		if err := store.SaveRun(context.Background(), run); err != nil {
+			t.Fatalf("SaveRun() error = %v", err)
+		}
+	}
+
+	got, err := store.GetJobRuns(context.Background(), "job-1", 10)
+	if err != nil {
+		t.Fatalf("GetJobRuns() error = %v", err)
+	}
+	if len(got) != 2 || got[0].RunID != "run-3" || got[1].RunID != "run-1" {
+		t.Errorf("GetJobRuns() = %v, want run-3, run-1", runIDs(got))
+	}
+
+	got, err = store.GetJobRuns(context.Background(), "non-existent", 10)
+	if err != nil {
+		t.Fatalf("GetJobRuns() for non-existent job error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("GetJobRuns() for non-existent job returned %d runs, want 0", len(got))
+	}
+
+	got, err = store.GetAllRuns(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("GetAllRuns() error = %v", err)
+	}
+	if len(got) != 3 || got[0].RunID != "run-3" || got[2].RunID != "run-1" {
+		t.Errorf("GetAllRuns() = %v, want newest first", runIDs(got))
+	}
+
+	got, err = store.GetAllRuns(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetAllRuns() with limit error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("GetAllRuns() with limit=2 returned %d runs, want 2", len(got))
+	}
+}
+
+func TestMemoryStore_ConcurrentAccess(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+
+	done := make(chan bool)
+	for i := 0; i < 10; i++ {
+		go func(id int) {
+			run := &JobRun{
+				RunID:     fmt.Sprintf("concurrent-run-%d", id),
+				JobID:     "test-job",
+				StartTime: time.Now(),
+				Success:   true,
+			}
+			if err := store.SaveRun(context.Background(), run); err != nil {
+				t.Errorf("SaveRun() concurrent error = %v", err)
+			}
+			done <- true
+		}(i)
+	}
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+
+	runs, err := store.GetJobRuns(context.Background(), "test-job", 100)
+	if err != nil {
+		t.Fatalf("GetJobRuns() error = %v", err)
+	}
+	if len(runs) != 10 {
+		t.Errorf("Expected 10 concurrent runs, got %d", len(runs))
+	}
+}
+
+func TestMemoryStore_CloseIsNoop(t *testing.T) {
+	store := NewMemoryStore()
+	run := &JobRun{RunID: "run-1", JobID: "job-1", StartTime: time.Now()}
+	if err := store.SaveRun(context.Background(), run); err != nil {
+		t.Fatalf("SaveRun() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := store.Close(); err != nil {
+			t.Fatalf("Close() #%d error = %v", i+1, err)
+		}
+	}
+
+	// Still usable, with its runs intact
+	if _, err := store.GetRun(context.Background(), "run-1"); err != nil {
+		t.Errorf("GetRun() after Close error = %v", err)
+	}
+	run2 := &JobRun{RunID: "run-2", JobID: "job-1", StartTime: time.Now()}
+	if err := store.SaveRun(context.Background(), run2); err != nil {
+		t.Errorf("SaveRun() after Close error = %v", err)
+	}
+}
+
+// runIDs returns the run IDs of runs, for test failure messages.
+func runIDs(runs []*JobRun) []string {
+	ids := make([]string, len(runs))
+	for i, run := range runs {
+		ids[i] = run.RunID
+	}
+	return ids
+}
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"sort"
+	"time"
+)
+
+// selectPrunable returns the runs that PruneRuns should delete from runs,
+// which may span several jobs. See Store.PruneRuns for the rules.
+func selectPrunable(runs []*JobRun, olderThan time.Time, keepPerJob int) []*JobRun {
+	byJob := make(map[string][]*JobRun)
+	for _, run := range runs {
+		byJob[run.JobID] = append(byJob[run.JobID], run)
+	}
+
+	var prunable []*JobRun
+	for _, jobRuns := range byJob {
+		// Newest first, so index i is the run's rank within its job
+		sort.Slice(jobRuns, func(i, j int) bool {
+			return jobRuns[i].StartTime.After(jobRuns[j].StartTime)
+		})
+
+		for i, run := range jobRuns {
+			if run.IsRunning() {
+				continue
+			}
+			tooOld := !olderThan.IsZero() && run.StartTime.Before(olderThan)
+			overLimit := keepPerJob > 0 && i >= keepPerJob
+			if tooOld || overLimit {
+				prunable = append(prunable, run)
+			}
+		}
+	}
+
+	return prunable
+}
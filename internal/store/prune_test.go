@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+func seedPruneRuns(t *testing.T, s Store, now time.Time) {
+	t.Helper()
+
+	runs := []*JobRun{
+		// job-a: two old runs, three recent ones
+		{RunID: "a-old-1", JobID: "job-a", StartTime: now.Add(-72 * time.Hour), EndTime: now.Add(-72 * time.Hour)},
+		{RunID: "a-old-2", JobID: "job-a", StartTime: now.Add(-48 * time.Hour), EndTime: now.Add(-48 * time.Hour)},
+		{RunID: "a-new-1", JobID: "job-a", StartTime: now.Add(-3 * time.Hour), EndTime: now.Add(-3 * time.Hour)},
+		{RunID: "a-new-2", JobID: "job-a", StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-2 * time.Hour)},
+		{RunID: "a-new-3", JobID: "job-a", StartTime: now.Add(-1 * time.Hour), EndTime: now.Add(-1 * time.Hour)},
+		// job-b: one old run that is still in progress, one recent run
+		{RunID: "b-running", JobID: "job-b", StartTime: now.Add(-96 * time.Hour)},
+		{RunID: "b-new-1", JobID: "job-b", StartTime: now.Add(-1 * time.Hour), EndTime: now.Add(-1 * time.Hour)},
+	}
+
+	for _, run := range runs {
+		if err := s.SaveRun(context.Background(), run); err != nil {
+			t.Fatalf("SaveRun(%s) error = %v", run.RunID, err)
+		}
+	}
+}
+
+func remainingRunIDs(t *testing.T, s Store) []string {
+	t.Helper()
+
+	runs, err := s.GetAllRuns(context.Background(), 1000)
+	if err != nil {
+		t.Fatalf("GetAllRuns() error = %v", err)
+	}
+
+	ids := make([]string, len(runs))
+	for i, run := range runs {
+		ids[i] = run.RunID
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestPruneRuns(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAge      time.Duration
+		keepPerJob  int
+		wantDeleted int
+		wantIDs     []string
+	}{
+		{
+			name:        "by age",
+			maxAge:      24 * time.Hour,
+			wantDeleted: 2,
+			wantIDs:     []string{"a-new-1", "a-new-2", "a-new-3", "b-new-1", "b-running"},
+		},
+		{
+			name:        "by count per job",
+			keepPerJob:  2,
+			wantDeleted: 3,
+			wantIDs:     []string{"a-new-2", "a-new-3", "b-new-1", "b-running"},
+		},
+		{
+			name:        "age and count combined",
+			maxAge:      24 * time.Hour,
+			keepPerJob:  1,
+			wantDeleted: 4,
+			wantIDs:     []string{"a-new-3", "b-new-1", "b-running"},
+		},
+		{
+			name:        "disabled",
+			wantDeleted: 0,
+			wantIDs:     []string{"a-new-1", "a-new-2", "a-new-3", "a-old-1", "a-old-2", "b-new-1", "b-running"},
+		},
+	}
+
+	for _, driver := range SupportedDrivers {
+		for _, tt := range tests {
+			t.Run(driver+"/"+tt.name, func(t *testing.T) {
+				s, err := NewStore(driver, filepath.Join(t.TempDir(), "runs."+driver))
+				if err != nil {
+					t.Fatalf("NewStore(%s) error = %v", driver, err)
+				}
+				defer s.Close()
+
+				now := time.Now()
+				seedPruneRuns(t, s, now)
+
+				var olderThan time.Time
+				if tt.maxAge > 0 {
+					olderThan = now.Add(-tt.maxAge)
+				}
+
+				deleted, err := s.PruneRuns(context.Background(), olderThan, tt.keepPerJob)
+				if err != nil {
+					t.Fatalf("PruneRuns() error = %v", err)
+				}
+				if deleted != tt.wantDeleted {
+					t.Errorf("PruneRuns() deleted = %d, want %d", deleted, tt.wantDeleted)
+				}
+
+				got := remainingRunIDs(t, s)
+				if len(got) != len(tt.wantIDs) {
+					t.Fatalf("remaining runs = %v, want %v", got, tt.wantIDs)
+				}
+				for i := range got {
+					if got[i] != tt.wantIDs[i] {
+						t.Fatalf("remaining runs = %v, want %v", got, tt.wantIDs)
+					}
+				}
+
+				// Pruned runs must also be gone from point lookups
+				if _, err := s.GetRun(context.Background(), "a-old-1"); tt.maxAge > 0 && err == nil {
+					t.Error("GetRun() found a pruned run")
+				}
+			})
+		}
+	}
+}
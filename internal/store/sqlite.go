@@ -0,0 +1,441 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the pure-Go "sqlite" database/sql driver
+)
+
+// sqliteSchema creates the runs table and the indexes used by the query methods.
+// Times are stored as Unix nanoseconds so ordering happens on an integer column;
+// a NULL end_time marks a run that has not completed yet.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS runs (
+	run_id      TEXT PRIMARY KEY,
+	job_id      TEXT NOT NULL,
+	start_time  INTEGER NOT NULL,
+	end_time    INTEGER,
+	exit_code   INTEGER NOT NULL DEFAULT 0,
+	success     INTEGER NOT NULL DEFAULT 0,
+	stdout_tail TEXT NOT NULL DEFAULT '',
+	stderr_tail TEXT NOT NULL DEFAULT '',
+	metadata    TEXT,
+	"trigger"   TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_runs_job_id ON runs (job_id, start_time DESC);
+CREATE INDEX IF NOT EXISTS idx_runs_start_time ON runs (start_time DESC);
+`
+
+// sqliteColumns is the column list shared by every SELECT so scanRun stays in sync.
+const sqliteColumns = `run_id, job_id, start_time, end_time, exit_code, success, stdout_tail, stderr_tail, metadata, "trigger"`
+
+// SQLiteStore implements the Store interface using an SQLite database.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore creates a new SQLite-backed store at the given path.
+func NewSQLiteStore(path string) (Store, error) {
+	// Build the URI so a path containing '?', '#' or '%' is escaped rather
+	// than read as the start of the query or fragment
+	dsn := (&url.URL{
+		Scheme:   "file",
+		Path:     path,
+		RawQuery: "_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)",
+	}).String()
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite at %s: %w", path, err)
+	}
+
+	// SQLite allows a single writer; serializing connections avoids
+	// "database is locked" errors from concurrent job goroutines.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initialize sqlite schema: %w", err)
+	}
+	if err := migrateSQLite(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate sqlite schema: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// migrateSQLite adds columns introduced after a database was created.
+func migrateSQLite(db *sql.DB) error {
+	var n int
+	err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('runs') WHERE name = 'trigger'`).Scan(&n)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		_, err = db.Exec(`ALTER TABLE runs ADD COLUMN "trigger" TEXT NOT NULL DEFAULT ''`)
+	}
+	return err
+}
+
+// SaveRun persists a job run record, replacing any existing record with the same run_id.
+func (s *SQLiteStore) SaveRun(ctx context.Context, run *JobRun) error {
+	if run.RunID == "" {
+		return fmt.Errorf("run_id is required")
+	}
+	if run.JobID == "" {
+		return fmt.Errorf("job_id is required")
+	}
+
+	var metadata []byte
+	if run.Metadata != nil {
+		var err error
+		metadata, err = json.Marshal(run.Metadata)
+		if err != nil {
+			return fmt.Errorf("marshal metadata: %w", err)
+		}
+	}
+
+	var endTime sql.NullInt64
+	if !run.EndTime.IsZero() {
+		endTime = sql.NullInt64{Int64: run.EndTime.UnixNano(), Valid: true}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO runs (`+sqliteColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (run_id) DO UPDATE SET
+			job_id      = excluded.job_id,
+			start_time  = excluded.start_time,
+			end_time    = excluded.end_time,
+			exit_code   = excluded.exit_code,
+			success     = excluded.success,
+			stdout_tail = excluded.stdout_tail,
+			stderr_tail = excluded.stderr_tail,
+			metadata    = excluded.metadata,
+			"trigger"   = excluded."trigger"`,
+		run.RunID,
+		run.JobID,
+		run.StartTime.UnixNano(),
+		endTime,
+		run.ExitCode,
+		run.Success,
+		run.StdoutTail,
+		run.StderrTail,
+		metadata,
+		run.Trigger,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert run: %w", err)
+	}
+
+	return nil
+}
+
+// GetRun retrieves a specific run by its ID.
+func (s *SQLiteStore) GetRun(ctx context.Context, runID string) (*JobRun, error) {
+	if runID == "" {
+		return nil, fmt.Errorf("run_id is required")
+	}
+
+	row := s.db.QueryRowContext(ctx, `SELECT `+sqliteColumns+` FROM runs WHERE run_id = ?`, runID)
+	run, err := scanRun(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// GetJobRuns retrieves the most recent runs for a specific job.
+func (s *SQLiteStore) GetJobRuns(ctx context.Context, jobID string, limit int) ([]*JobRun, error) {
+	if jobID == "" {
+		return nil, fmt.Errorf("job_id is required")
+	}
+	if limit <= 0 {
+		limit = 100 // default limit
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT `+sqliteColumns+` FROM runs WHERE job_id = ? ORDER BY start_time DESC LIMIT ?`,
+		jobID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query job runs: %w", err)
+	}
+
+	return collectRuns(rows)
+}
+
+// GetAllRuns retrieves the most recent runs across all jobs.
+func (s *SQLiteStore) GetAllRuns(ctx context.Context, limit int) ([]*JobRun, error) {
+	if limit <= 0 {
+		limit = 100 // default limit
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT `+sqliteColumns+` FROM runs ORDER BY start_time DESC LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query runs: %w", err)
+	}
+
+	return collectRuns(rows)
+}
+
+// GetRunsFiltered retrieves the runs matching filter, newest first, with
+// the conditions and paging applied in SQL.
+func (s *SQLiteStore) GetRunsFiltered(ctx context.Context, filter RunFilter) ([]*JobRun, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	where, args := sqliteFilterWhere(filter)
+	query := `SELECT ` + sqliteColumns + ` FROM runs WHERE ` + where + ` ORDER BY start_time DESC LIMIT ? OFFSET ?`
+	args = append(args, filter.limit(), filter.Offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query filtered runs: %w", err)
+	}
+
+	return collectRuns(rows)
+}
+
+// CountRuns returns how many runs match filter, ignoring its Limit and Offset.
+func (s *SQLiteStore) CountRuns(ctx context.Context, filter RunFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	where, args := sqliteFilterWhere(filter)
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM runs WHERE `+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count filtered runs: %w", err)
+	}
+
+	return count, nil
+}
+
+// sqliteFilterWhere returns the WHERE clause and arguments for filter's
+// conditions, without its limit and offset.
+func sqliteFilterWhere(filter RunFilter) (string, []any) {
+	where := `1 = 1`
+	var args []any
+	if filter.JobID != "" {
+		where += ` AND job_id = ?`
+		args = append(args, filter.JobID)
+	}
+	switch filter.Status {
+	case StatusRunning:
+		where += ` AND end_time IS NULL`
+	case StatusSuccess:
+		where += ` AND end_time IS NOT NULL AND success = 1`
+	case StatusFailure:
+		where += ` AND end_time IS NOT NULL AND success = 0`
+	}
+	if !filter.Since.IsZero() {
+		where += ` AND start_time >= ?`
+		args = append(args, filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		where += ` AND start_time < ?`
+		args = append(args, filter.Until.UnixNano())
+	}
+	return where, args
+}
+
+// GetStats aggregates run counts in a single query.
+func (s *SQLiteStore) GetStats(ctx context.Context) (*StoreStats, error) {
+	var stats StoreStats
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN end_time IS NULL THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN end_time IS NOT NULL AND success THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN end_time IS NOT NULL AND NOT success THEN 1 ELSE 0 END), 0)
+		FROM runs`,
+	).Scan(&stats.TotalRuns, &stats.RunningCount, &stats.SuccessCount, &stats.FailureCount)
+	if err != nil {
+		return nil, fmt.Errorf("query stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// GetJobStats aggregates one job's runs in a single query.
+func (s *SQLiteStore) GetJobStats(ctx context.Context, jobID string) (*JobRunStats, error) {
+	stats := &JobRunStats{JobID: jobID}
+	var avgNanos sql.NullFloat64
+	var lastStart sql.NullInt64
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN end_time IS NULL THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN end_time IS NOT NULL AND success THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN end_time IS NOT NULL AND NOT success THEN 1 ELSE 0 END), 0),
+			AVG(end_time - start_time),
+			MAX(start_time)
+		FROM runs WHERE job_id = ?`, jobID,
+	).Scan(&stats.TotalRuns, &stats.RunningCount, &stats.SuccessCount, &stats.FailureCount, &avgNanos, &lastStart)
+	if err != nil {
+		return nil, fmt.Errorf("query job stats: %w", err)
+	}
+
+	if avgNanos.Valid {
+		stats.AvgDuration = time.Duration(avgNanos.Float64)
+	}
+	if lastStart.Valid {
+		stats.LastRunTime = time.Unix(0, lastStart.Int64)
+	}
+	stats.SuccessRate = stats.StoreStats.SuccessRate()
+	return stats, nil
+}
+
+// DeleteRun removes a single run record.
+func (s *SQLiteStore) DeleteRun(ctx context.Context, runID string) error {
+	if runID == "" {
+		return fmt.Errorf("run_id is required")
+	}
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM runs WHERE run_id = ?`, runID)
+	if err != nil {
+		return fmt.Errorf("delete run: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+	}
+
+	return nil
+}
+
+// PruneRuns deletes old run records according to the retention rules.
+func (s *SQLiteStore) PruneRuns(ctx context.Context, olderThan time.Time, keepPerJob int) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin prune: %w", err)
+	}
+	defer tx.Rollback()
+
+	var deleted int64
+
+	if !olderThan.IsZero() {
+		res, err := tx.ExecContext(
+			ctx,
+			`DELETE FROM runs WHERE end_time IS NOT NULL AND start_time < ?`,
+			olderThan.UnixNano(),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("prune runs by age: %w", err)
+		}
+		n, _ := res.RowsAffected()
+		deleted += n
+	}
+
+	if keepPerJob > 0 {
+		res, err := tx.ExecContext(ctx, `
+			DELETE FROM runs WHERE run_id IN (
+				SELECT run_id FROM (
+					SELECT run_id, end_time,
+						ROW_NUMBER() OVER (PARTITION BY job_id ORDER BY start_time DESC) AS rank
+					FROM runs
+				) WHERE rank > ? AND end_time IS NOT NULL
+			)`,
+			keepPerJob,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("prune runs by count: %w", err)
+		}
+		n, _ := res.RowsAffected()
+		deleted += n
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit prune: %w", err)
+	}
+
+	return int(deleted), nil
+}
+
+// Close releases resources held by the store.
+func (s *SQLiteStore) Close() error {
+	if s.db != nil {
+		return s.db.Close()
+	}
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanRun reads a single runs row (selected with sqliteColumns) into a JobRun.
+func scanRun(row rowScanner) (*JobRun, error) {
+	var (
+		run       JobRun
+		startTime int64
+		endTime   sql.NullInt64
+		metadata  sql.NullString
+	)
+
+	err := row.Scan(
+		&run.RunID,
+		&run.JobID,
+		&startTime,
+		&endTime,
+		&run.ExitCode,
+		&run.Success,
+		&run.StdoutTail,
+		&run.StderrTail,
+		&metadata,
+		&run.Trigger,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	run.StartTime = time.Unix(0, startTime)
+	if endTime.Valid {
+		run.EndTime = time.Unix(0, endTime.Int64)
+	}
+	if metadata.Valid && metadata.String != "" {
+		if err := json.Unmarshal([]byte(metadata.String), &run.Metadata); err != nil {
+			return nil, fmt.Errorf("unmarshal metadata for run %s: %w", run.RunID, err)
+		}
+	}
+
+	return &run, nil
+}
+
+// collectRuns scans every row of a runs query and closes the result set.
+func collectRuns(rows *sql.Rows) ([]*JobRun, error) {
+	defer rows.Close()
+
+	var runs []*JobRun
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate runs: %w", err)
+	}
+
+	return runs, nil
+}
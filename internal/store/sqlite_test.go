@@ -0,0 +1,486 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewSQLiteStore(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.sqlite")
+
+	store, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	defer store.Close()
+
+	if store == nil {
+		t.Fatal("NewSQLiteStore() returned nil store")
+	}
+
+	// Verify file was created
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		t.Error("SQLite file was not created")
+	}
+
+	// The DSN's pragmas are applied
+	var mode string
+	if err := store.(*SQLiteStore).db.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil {
+		t.Fatal(err)
+	}
+	if mode != "wal" {
+		t.Errorf("journal_mode = %q, want wal", mode)
+	}
+}
+
+func TestNewSQLiteStore_PathWithURICharacters(t *testing.T) {
+	// '?', '#' and '%' would otherwise be read as part of the file: URI
+	dir := filepath.Join(t.TempDir(), "runs?mode=ro#50%")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	dbPath := filepath.Join(dir, "test.sqlite")
+
+	store, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	defer store.Close()
+
+	run := &JobRun{RunID: "run-1", JobID: "job", StartTime: time.Now()}
+	if err := store.SaveRun(context.Background(), run); err != nil {
+		t.Fatalf("SaveRun() error = %v", err)
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		t.Errorf("SQLite file not created at %s: %v", dbPath, err)
+	}
+}
+
+func TestSQLiteStore_SaveAndGetRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.sqlite")
+
+	store, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	defer store.Close()
+
+	// Create test run
+	run := &JobRun{
+		RunID:      "test-run-1",
+		JobID:      "test-job",
+		StartTime:  time.Now(),
+		EndTime:    time.Now().Add(5 * time.Second),
+		ExitCode:   0,
+		Success:    true,
+		StdoutTail: "test output",
+		StderrTail: "",
+		Metadata:   map[string]interface{}{"test": "value"},
+	}
+
+	// Save run
+	err = store.SaveRun(context.Background(), run)
+	if err != nil {
+		t.Fatalf("SaveRun() error = %v", err)
+	}
+
+	// Get run
+	got, err := store.GetRun(context.Background(), "test-run-1")
+	if err != nil {
+		t.Fatalf("GetRun() error = %v", err)
+	}
+
+	// Verify
+	if got.RunID != run.RunID {
+		t.Errorf("RunID = %v, want %v", got.RunID, run.RunID)
+	}
+	if got.JobID != run.JobID {
+		t.Errorf("JobID = %v, want %v", got.JobID, run.JobID)
+	}
+	if got.ExitCode != run.ExitCode {
+		t.Errorf("ExitCode = %v, want %v", got.ExitCode, run.ExitCode)
+	}
+	if got.Success != run.Success {
+		t.Errorf("Success = %v, want %v", got.Success, run.Success)
+	}
+	if got.StdoutTail != run.StdoutTail {
+		t.Errorf("StdoutTail = %v, want %v", got.StdoutTail, run.StdoutTail)
+	}
+}
+
+func TestSQLiteStore_SaveRun_ValidationErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.sqlite")
+
+	store, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	defer store.Close()
+
+	tests := []struct {
+		name    string
+		run     *JobRun
+		wantErr bool
+	}{
+		{
+			name: "empty RunID",
+			run: &JobRun{
+				RunID:     "",
+				JobID:     "test-job",
+				StartTime: time.Now(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty JobID",
+			run: &JobRun{
+				RunID:     "test-run",
+				JobID:     "",
+				StartTime: time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := store.SaveRun(context.Background(), tt.run)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SaveRun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSQLiteStore_GetJobRuns(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.sqlite")
+
+	store, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	defer store.Close()
+
+	// Create test runs for the same job
+	jobID := "test-job"
+	runs := []*JobRun{
+		{
+			RunID:     "run-1",
+			JobID:     jobID,
+			StartTime: time.Now().Add(-3 * time.Hour),
+			ExitCode:  0,
+			Success:   true,
+		},
+		{
+			RunID:     "run-2",
+			JobID:     jobID,
+			StartTime: time.Now().Add(-2 * time.Hour),
+			ExitCode:  1,
+			Success:   false,
+		},
+		{
+			RunID:     "run-3",
+			JobID:     jobID,
+			StartTime: time.Now().Add(-1 * time.Hour),
+			ExitCode:  0,
+			Success:   true,
+		},
+	}
+
+	// Save all runs
+	for _, run := range runs {
+		if err := store.SaveRun(context.Background(), run); err != nil {
+			t.Fatalf("SaveRun() error = %v", err)
+		}
+	}
+
+	// Get job runs
+	got, err := store.GetJobRuns(context.Background(), jobID, 10)
+	if err != nil {
+		t.Fatalf("GetJobRuns() error = %v", err)
+	}
+
+	if len(got) != len(runs) {
+		t.Errorf("GetJobRuns() returned %d runs, want %d", len(got), len(runs))
+	}
+
+	// Verify ordering (newest first)
+	if len(got) >= 2 && got[0].StartTime.Before(got[1].StartTime) {
+		t.Error("GetJobRuns() not ordered by StartTime descending")
+	}
+
+	// Test with limit
+	got, err = store.GetJobRuns(context.Background(), jobID, 2)
+	if err != nil {
+		t.Fatalf("GetJobRuns() with limit error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Errorf("GetJobRuns() with limit=2 returned %d runs, want 2", len(got))
+	}
+
+	// Test non-existent job
+	got, err = store.GetJobRuns(context.Background(), "non-existent", 10)
+	if err != nil {
+		t.Fatalf("GetJobRuns() for non-existent job error = %v", err)
+	}
+
+	if len(got) != 0 {
+		t.Errorf("GetJobRuns() for non-existent job returned %d runs, want 0", len(got))
+	}
+}
+
+func TestSQLiteStore_GetAllRuns(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.sqlite")
+
+	store, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	defer store.Close()
+
+	// Create test runs for different jobs
+	runs := []*JobRun{
+		{
+			RunID:     "run-1",
+			JobID:     "job-1",
+			StartTime: time.Now().Add(-3 * time.Hour),
+			ExitCode:  0,
+			Success:   true,
+		},
+		{
+			RunID:     "run-2",
+			JobID:     "job-2",
+			StartTime: time.Now().Add(-2 * time.Hour),
+			ExitCode:  1,
+			Success:   false,
+		},
+		{
+			RunID:     "run-3",
+			JobID:     "job-1",
+			StartTime: time.Now().Add(-1 * time.Hour),
+			ExitCode:  0,
+			Success:   true,
+		},
+	}
+
+	// Save all runs
+	for _, run := range runs {
+		if err := store.SaveRun(context.Background(), run); err != nil {
+			t.Fatalf("SaveRun() error = %v", err)
+		}
+	}
+
+	// Get all runs
+	got, err := store.GetAllRuns(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("GetAllRuns() error = %v", err)
+	}
+
+	if len(got) != len(runs) {
+		t.Errorf("GetAllRuns() returned %d runs, want %d", len(got), len(runs))
+	}
+
+	// Verify ordering (newest first)
+	if len(got) >= 2 && got[0].StartTime.Before(got[1].StartTime) {
+		t.Error("GetAllRuns() not ordered by StartTime descending")
+	}
+
+	// Test with limit
+	got, err = store.GetAllRuns(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetAllRuns() with limit error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Errorf("GetAllRuns() with limit=2 returned %d runs, want 2", len(got))
+	}
+}
+
+func TestSQLiteStore_UpdateRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.sqlite")
+
+	store, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	defer store.Close()
+
+	// Create and save initial run
+	run := &JobRun{
+		RunID:     "update-test",
+		JobID:     "test-job",
+		StartTime: time.Now(),
+		ExitCode:  0,
+		Success:   false, // Will be updated
+	}
+
+	err = store.SaveRun(context.Background(), run)
+	if err != nil {
+		t.Fatalf("SaveRun() error = %v", err)
+	}
+
+	// Update run
+	run.Success = true
+	run.EndTime = time.Now()
+	run.StdoutTail = "completed successfully"
+
+	err = store.SaveRun(context.Background(), run)
+	if err != nil {
+		t.Fatalf("SaveRun() update error = %v", err)
+	}
+
+	// Verify update
+	got, err := store.GetRun(context.Background(), "update-test")
+	if err != nil {
+		t.Fatalf("GetRun() error = %v", err)
+	}
+
+	if !got.Success {
+		t.Error("Run Success not updated")
+	}
+	if got.StdoutTail != "completed successfully" {
+		t.Errorf("StdoutTail = %v, want 'completed successfully'", got.StdoutTail)
+	}
+}
+
+func TestSQLiteStore_Close(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.sqlite")
+
+	store, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+
+	err = store.Close()
+	if err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+
+	// Multiple closes should not error
+	err = store.Close()
+	if err != nil {
+		t.Errorf("Second Close() error = %v", err)
+	}
+}
+
+func TestSQLiteStore_Persistence(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.sqlite")
+
+	store1, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+
+	run := &JobRun{
+		RunID:     "persist-test",
+		JobID:     "test-job",
+		StartTime: time.Now(),
+		Metadata:  map[string]interface{}{"status": "running"},
+	}
+
+	if err := store1.SaveRun(context.Background(), run); err != nil {
+		t.Fatalf("SaveRun() error = %v", err)
+	}
+	store1.Close()
+
+	store2, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() second open error = %v", err)
+	}
+	defer store2.Close()
+
+	got, err := store2.GetRun(context.Background(), "persist-test")
+	if err != nil {
+		t.Fatalf("GetRun() after reload error = %v", err)
+	}
+
+	if !got.StartTime.Equal(run.StartTime) {
+		t.Errorf("StartTime = %v, want %v", got.StartTime, run.StartTime)
+	}
+	if !got.IsRunning() {
+		t.Error("IsRunning() = false, want true for run without EndTime")
+	}
+	if got.Metadata["status"] != "running" {
+		t.Errorf("Metadata[status] = %v, want running", got.Metadata["status"])
+	}
+}
+
+func TestNewStore_SQLiteDriver(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	store, err := NewStore("sqlite", filepath.Join(tmpDir, "factory.sqlite"))
+	if err != nil {
+		t.Fatalf("NewStore(sqlite) error = %v", err)
+	}
+	defer store.Close()
+
+	if _, ok := store.(*SQLiteStore); !ok {
+		t.Errorf("NewStore(sqlite) returned %T, want *SQLiteStore", store)
+	}
+}
+
+func TestSQLiteStore_MigratesTriggerColumn(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "old.sqlite")
+
+	// A database created before runs had a trigger column
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`
+CREATE TABLE runs (
+	run_id      TEXT PRIMARY KEY,
+	job_id      TEXT NOT NULL,
+	start_time  INTEGER NOT NULL,
+	end_time    INTEGER,
+	exit_code   INTEGER NOT NULL DEFAULT 0,
+	success     INTEGER NOT NULL DEFAULT 0,
+	stdout_tail TEXT NOT NULL DEFAULT '',
+	stderr_tail TEXT NOT NULL DEFAULT '',
+	metadata    TEXT
+);
+INSERT INTO runs (run_id, job_id, start_time) VALUES ('old-run', 'job', 1);`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	defer store.Close()
+
+	old, err := store.GetRun(context.Background(), "old-run")
+	if err != nil {
+		t.Fatalf("GetRun() error = %v", err)
+	}
+	if old.Trigger != "" {
+		t.Errorf("old run Trigger = %q, want empty", old.Trigger)
+	}
+
+	run := &JobRun{RunID: "new-run", JobID: "job", StartTime: time.Now(), Trigger: "manual"}
+	if err := store.SaveRun(context.Background(), run); err != nil {
+		t.Fatalf("SaveRun() error = %v", err)
+	}
+	got, err := store.GetRun(context.Background(), "new-run")
+	if err != nil {
+		t.Fatalf("GetRun() error = %v", err)
+	}
+	if got.Trigger != "manual" {
+		t.Errorf("Trigger = %q, want manual", got.Trigger)
+	}
+}
@@ -2,29 +2,86 @@
 package store
 
 import (
+	"context"
+	"errors"
 	"time"
 )
 
+// ErrRunNotFound is returned (wrapped) when a run ID is not in the store.
+var ErrRunNotFound = errors.New("run not found")
+
 // Store defines the interface for persisting and retrieving job run history.
+// Every method except Close takes a context; implementations return the
+// context's error if it is cancelled before or during the operation.
 type Store interface {
 	// SaveRun persists a job run record.
-	SaveRun(run *JobRun) error
+	SaveRun(ctx context.Context, run *JobRun) error
 
 	// GetRun retrieves a specific run by its ID.
-	GetRun(runID string) (*JobRun, error)
+	GetRun(ctx context.Context, runID string) (*JobRun, error)
 
 	// GetJobRuns retrieves the most recent runs for a specific job.
 	// Returns up to 'limit' runs, ordered by StartTime descending (newest first).
-	GetJobRuns(jobID string, limit int) ([]*JobRun, error)
+	GetJobRuns(ctx context.Context, jobID string, limit int) ([]*JobRun, error)
 
 	// GetAllRuns retrieves the most recent runs across all jobs.
 	// Returns up to 'limit' runs, ordered by StartTime descending (newest first).
-	GetAllRuns(limit int) ([]*JobRun, error)
+	GetAllRuns(ctx context.Context, limit int) ([]*JobRun, error)
+
+	// GetRunsFiltered retrieves the runs matching filter, ordered by
+	// StartTime descending (newest first). See RunFilter.
+	GetRunsFiltered(ctx context.Context, filter RunFilter) ([]*JobRun, error)
+
+	// CountRuns returns how many runs match filter, ignoring its Limit and
+	// Offset, so callers can paginate through GetRunsFiltered.
+	CountRuns(ctx context.Context, filter RunFilter) (int, error)
+
+	// GetJobStats returns aggregate counts, success rate, average duration
+	// and last run time for one job. A job with no runs has zero stats.
+	GetJobStats(ctx context.Context, jobID string) (*JobRunStats, error)
+
+	// DeleteRun removes a single run record. It returns an error wrapping
+	// ErrRunNotFound if no run has the given ID.
+	DeleteRun(ctx context.Context, runID string) error
+
+	// GetStats returns aggregate counts across every run in the store. It is
+	// maintained incrementally by the backends, so it is cheap to call and not
+	// limited to a window of recent runs.
+	GetStats(ctx context.Context) (*StoreStats, error)
+
+	// PruneRuns deletes completed runs that started before olderThan, and
+	// completed runs beyond the newest keepPerJob runs of each job. A zero
+	// olderThan or a non-positive keepPerJob disables that rule. Runs that are
+	// still in progress are never deleted. Returns the number of runs deleted.
+	PruneRuns(ctx context.Context, olderThan time.Time, keepPerJob int) (deleted int, err error)
 
 	// Close releases any resources held by the store.
 	Close() error
 }
 
+// StoreStats holds aggregate run counts. Every run is counted in exactly one
+// of SuccessCount, FailureCount, or RunningCount.
+type StoreStats struct {
+	TotalRuns    int `json:"total_runs"`
+	SuccessCount int `json:"success_count"`
+	FailureCount int `json:"failure_count"`
+	RunningCount int `json:"running_count"` // runs started but not yet finished
+}
+
+// add applies run's contribution to the counts: sign is +1 when a run is
+// stored and -1 when it is removed or replaced.
+func (st *StoreStats) add(run *JobRun, sign int) {
+	st.TotalRuns += sign
+	switch {
+	case run.IsRunning():
+		st.RunningCount += sign
+	case run.Success:
+		st.SuccessCount += sign
+	default:
+		st.FailureCount += sign
+	}
+}
+
 // JobRun represents a single execution of a job.
 type JobRun struct {
 	// RunID is a unique identifier for this run (typically UUID).
@@ -53,6 +110,11 @@ type JobRun struct {
 
 	// Metadata contains additional context (attempt number, hook results, etc.).
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// Trigger records why the run was started: "schedule", "manual",
+	// "dependency", "catchup", or "restart". Empty for runs recorded before
+	// it existed.
+	Trigger string `json:"trigger,omitempty"`
 }
 
 // Duration returns the time taken for this run.
@@ -68,3 +130,23 @@ func (r *JobRun) Duration() time.Duration {
 func (r *JobRun) IsRunning() bool {
 	return !r.StartTime.IsZero() && r.EndTime.IsZero()
 }
+
+// TimedOut reports whether the run's command was killed for exceeding its
+// timeout, as recorded in Metadata["timed_out"].
+func (r *JobRun) TimedOut() bool {
+	timedOut, _ := r.Metadata["timed_out"].(bool)
+	return timedOut
+}
+
+// MissingPath reports why a run's command never started, as recorded in
+// Metadata["status"] and Metadata["missing_path"]: StatusCommandNotFound or
+// StatusWorkdirNotFound, and the path that did not exist. Status is empty
+// for every other run.
+func (r *JobRun) MissingPath() (status, path string) {
+	status, _ = r.Metadata["status"].(string)
+	if status != StatusCommandNotFound && status != StatusWorkdirNotFound {
+		return "", ""
+	}
+	path, _ = r.Metadata["missing_path"].(string)
+	return status, path
+}
@@ -0,0 +1,249 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_CancelledContext(t *testing.T) {
+	for _, driver := range SupportedDrivers {
+		t.Run(driver, func(t *testing.T) {
+			s, err := NewStore(driver, filepath.Join(t.TempDir(), "runs."+driver))
+			if err != nil {
+				t.Fatalf("NewStore(%s) error = %v", driver, err)
+			}
+			defer s.Close()
+
+			run := &JobRun{RunID: "run-1", JobID: "job-1", StartTime: time.Now()}
+			if err := s.SaveRun(context.Background(), run); err != nil {
+				t.Fatalf("SaveRun() error = %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			if err := s.SaveRun(ctx, run); !errors.Is(err, context.Canceled) {
+				t.Errorf("SaveRun() error = %v, want context.Canceled", err)
+			}
+			if _, err := s.GetRun(ctx, "run-1"); !errors.Is(err, context.Canceled) {
+				t.Errorf("GetRun() error = %v, want context.Canceled", err)
+			}
+			if _, err := s.GetJobRuns(ctx, "job-1", 10); !errors.Is(err, context.Canceled) {
+				t.Errorf("GetJobRuns() error = %v, want context.Canceled", err)
+			}
+			if _, err := s.GetAllRuns(ctx, 10); !errors.Is(err, context.Canceled) {
+				t.Errorf("GetAllRuns() error = %v, want context.Canceled", err)
+			}
+			if _, err := s.PruneRuns(ctx, time.Now(), 0); !errors.Is(err, context.Canceled) {
+				t.Errorf("PruneRuns() error = %v, want context.Canceled", err)
+			}
+		})
+	}
+}
+
+func TestStore_DeleteRun(t *testing.T) {
+	for _, driver := range SupportedDrivers {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			s, err := NewStore(driver, filepath.Join(t.TempDir(), "runs."+driver))
+			if err != nil {
+				t.Fatalf("NewStore(%s) error = %v", driver, err)
+			}
+			defer s.Close()
+
+			now := time.Now()
+			for _, run := range []*JobRun{
+				{RunID: "keep", JobID: "job-1", StartTime: now.Add(-time.Minute), EndTime: now},
+				{RunID: "delete-me", JobID: "job-1", StartTime: now, EndTime: now},
+			} {
+				if err := s.SaveRun(ctx, run); err != nil {
+					t.Fatalf("SaveRun() error = %v", err)
+				}
+			}
+
+			if err := s.DeleteRun(ctx, "delete-me"); err != nil {
+				t.Fatalf("DeleteRun() error = %v", err)
+			}
+
+			if _, err := s.GetRun(ctx, "delete-me"); !errors.Is(err, ErrRunNotFound) {
+				t.Errorf("GetRun() after delete error = %v, want ErrRunNotFound", err)
+			}
+
+			jobRuns, err := s.GetJobRuns(ctx, "job-1", 10)
+			if err != nil {
+				t.Fatalf("GetJobRuns() error = %v", err)
+			}
+			if len(jobRuns) != 1 || jobRuns[0].RunID != "keep" {
+				t.Errorf("GetJobRuns() after delete = %d runs, want only \"keep\"", len(jobRuns))
+			}
+
+			allRuns, err := s.GetAllRuns(ctx, 10)
+			if err != nil {
+				t.Fatalf("GetAllRuns() error = %v", err)
+			}
+			if len(allRuns) != 1 || allRuns[0].RunID != "keep" {
+				t.Errorf("GetAllRuns() after delete = %d runs, want only \"keep\"", len(allRuns))
+			}
+
+			if err := s.DeleteRun(ctx, "delete-me"); !errors.Is(err, ErrRunNotFound) {
+				t.Errorf("second DeleteRun() error = %v, want ErrRunNotFound", err)
+			}
+		})
+	}
+}
+
+func TestStore_GetStats(t *testing.T) {
+	for _, driver := range SupportedDrivers {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "runs."+driver)
+			s, err := NewStore(driver, path)
+			if err != nil {
+				t.Fatalf("NewStore(%s) error = %v", driver, err)
+			}
+
+			// 60 runs across 3 jobs: each is saved as running, then finished.
+			// Every third run fails; the last 5 are left running.
+			start := time.Now().Add(-time.Hour)
+			for i := 0; i < 60; i++ {
+				run := &JobRun{
+					RunID:     fmt.Sprintf("run-%02d", i),
+					JobID:     fmt.Sprintf("job-%d", i%3),
+					StartTime: start.Add(time.Duration(i) * time.Second),
+				}
+				if err := s.SaveRun(ctx, run); err != nil {
+					t.Fatalf("SaveRun() error = %v", err)
+				}
+				if i >= 55 {
+					continue
+				}
+				run.EndTime = run.StartTime.Add(time.Second)
+				run.Success = i%3 != 0
+				if err := s.SaveRun(ctx, run); err != nil {
+					t.Fatalf("SaveRun() update error = %v", err)
+				}
+			}
+
+			want := StoreStats{TotalRuns: 60, SuccessCount: 36, FailureCount: 19, RunningCount: 5}
+			assertStats(t, s, want)
+
+			// Deleting a successful run and a failed run moves both counters
+			if err := s.DeleteRun(ctx, "run-01"); err != nil {
+				t.Fatalf("DeleteRun() error = %v", err)
+			}
+			if err := s.DeleteRun(ctx, "run-03"); err != nil {
+				t.Fatalf("DeleteRun() error = %v", err)
+			}
+			want = StoreStats{TotalRuns: 58, SuccessCount: 35, FailureCount: 18, RunningCount: 5}
+			assertStats(t, s, want)
+
+			// Counters survive a reopen of the persistent drivers
+			if driver != "memory" {
+				if err := s.Close(); err != nil {
+					t.Fatalf("Close() error = %v", err)
+				}
+				s, err = NewStore(driver, path)
+				if err != nil {
+					t.Fatalf("NewStore(%s) reopen error = %v", driver, err)
+				}
+				assertStats(t, s, want)
+			}
+			defer s.Close()
+
+			// Pruning completed runs of job-0 beyond the newest one
+			deleted, err := s.PruneRuns(ctx, time.Time{}, 1)
+			if err != nil {
+				t.Fatalf("PruneRuns() error = %v", err)
+			}
+			want.TotalRuns -= deleted
+			got, err := s.GetStats(ctx)
+			if err != nil {
+				t.Fatalf("GetStats() error = %v", err)
+			}
+			if got.TotalRuns != want.TotalRuns || got.RunningCount != 5 {
+				t.Errorf("after prune GetStats() = %+v, want total %d and 5 running", *got, want.TotalRuns)
+			}
+			if got.SuccessCount+got.FailureCount+got.RunningCount != got.TotalRuns {
+				t.Errorf("after prune counts don't add up: %+v", *got)
+			}
+		})
+	}
+}
+
+func assertStats(t *testing.T, s Store, want StoreStats) {
+	t.Helper()
+
+	got, err := s.GetStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+	if *got != want {
+		t.Errorf("GetStats() = %+v, want %+v", *got, want)
+	}
+}
+
+func TestStore_Trigger(t *testing.T) {
+	for _, driver := range SupportedDrivers {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			s, err := NewStore(driver, filepath.Join(t.TempDir(), "runs."+driver))
+			if err != nil {
+				t.Fatalf("NewStore(%s) error = %v", driver, err)
+			}
+			defer s.Close()
+
+			now := time.Now()
+			run := &JobRun{RunID: "r1", JobID: "job-1", StartTime: now, EndTime: now, Trigger: "catchup"}
+			if err := s.SaveRun(ctx, run); err != nil {
+				t.Fatalf("SaveRun() error = %v", err)
+			}
+			got, err := s.GetRun(ctx, "r1")
+			if err != nil {
+				t.Fatalf("GetRun() error = %v", err)
+			}
+			if got.Trigger != "catchup" {
+				t.Errorf("Trigger = %q, want catchup", got.Trigger)
+			}
+		})
+	}
+}
+
+func TestStore_TimedOut(t *testing.T) {
+	for _, driver := range SupportedDrivers {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			s, err := NewStore(driver, filepath.Join(t.TempDir(), "runs."+driver))
+			if err != nil {
+				t.Fatalf("NewStore(%s) error = %v", driver, err)
+			}
+			defer s.Close()
+
+			now := time.Now()
+			run := &JobRun{RunID: "r1", JobID: "job-1", StartTime: now, EndTime: now, ExitCode: -1,
+				Metadata: map[string]interface{}{"timed_out": true}}
+			if err := s.SaveRun(ctx, run); err != nil {
+				t.Fatalf("SaveRun() error = %v", err)
+			}
+			got, err := s.GetRun(ctx, "r1")
+			if err != nil {
+				t.Fatalf("GetRun() error = %v", err)
+			}
+			if !got.TimedOut() {
+				t.Error("TimedOut() = false after round trip, want true")
+			}
+
+			// Timed-out runs are still failures to filters
+			runs, err := s.GetRunsFiltered(ctx, RunFilter{Status: StatusFailure})
+			if err != nil {
+				t.Fatalf("GetRunsFiltered() error = %v", err)
+			}
+			if len(runs) != 1 {
+				t.Errorf("failure filter returned %d runs, want 1", len(runs))
+			}
+		})
+	}
+}
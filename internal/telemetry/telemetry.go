@@ -0,0 +1,80 @@
+// Package telemetry sets up optional OpenTelemetry tracing of job runs.
+//
+// Spans are created through the global tracer provider, which is a no-op
+// until Setup installs an exporting one, so instrumented code costs next to
+// nothing when telemetry.otlp_endpoint is not configured.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/caevv/jobster/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies jobster's instrumentation in exported spans.
+const TracerName = "github.com/caevv/jobster"
+
+// Tracer returns the tracer jobster creates its spans with.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Setup installs a global tracer provider that exports spans over OTLP/HTTP
+// to cfg.OTLPEndpoint. With no endpoint configured it does nothing, leaving
+// the no-op provider in place. The returned shutdown function flushes
+// pending spans and must be called before the process exits.
+func Setup(ctx context.Context, cfg config.Telemetry, version string) (shutdown func(context.Context) error, err error) {
+	if cfg.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "jobster"
+	}
+	res := resource.NewWithAttributes("",
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", version),
+	)
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tp.Shutdown, nil
+}
+
+// EnvCarrier returns the W3C trace context of the span in ctx as environment
+// variables (TRACEPARENT, and TRACESTATE when set), so a child process can
+// continue the trace. It returns nil when ctx carries no sampled span.
+func EnvCarrier(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+
+	env := make(map[string]string, len(carrier))
+	if v := carrier.Get("traceparent"); v != "" {
+		env["TRACEPARENT"] = v
+	}
+	if v := carrier.Get("tracestate"); v != "" {
+		env["TRACESTATE"] = v
+	}
+	return env
+}
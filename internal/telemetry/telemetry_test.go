@@ -0,0 +1,52 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/caevv/jobster/internal/config"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSetup_DisabledKeepsNoopProvider(t *testing.T) {
+	prev := otel.GetTracerProvider()
+
+	shutdown, err := Setup(context.Background(), config.Telemetry{}, "test")
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown: %v", err)
+	}
+	if otel.GetTracerProvider() != prev {
+		t.Error("Setup without an endpoint replaced the tracer provider")
+	}
+
+	ctx, span := Tracer().Start(context.Background(), "job.run")
+	defer span.End()
+	if span.SpanContext().IsValid() {
+		t.Error("no-op tracer produced a real span")
+	}
+	if env := EnvCarrier(ctx); env != nil {
+		t.Errorf("EnvCarrier without a span = %v, want nil", env)
+	}
+}
+
+func TestEnvCarrier(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	defer tp.Shutdown(context.Background())
+
+	ctx, span := tp.Tracer(TracerName).Start(context.Background(), "job.run")
+	defer span.End()
+
+	env := EnvCarrier(ctx)
+	traceparent := env["TRACEPARENT"]
+	if !strings.HasPrefix(traceparent, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()) {
+		t.Errorf("TRACEPARENT = %q, want the span's trace and span IDs", traceparent)
+	}
+	if _, ok := env["TRACESTATE"]; ok {
+		t.Error("TRACESTATE set without trace state")
+	}
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
@@ -25,18 +26,31 @@ type Model struct {
 	store     store.Store
 	scheduler *scheduler.Scheduler
 	logger    *slog.Logger
+	styles    theme
 
 	// UI state
-	viewMode     ViewMode
-	jobs         []JobState
-	recentRuns   []*store.JobRun
-	selectedJob  int
-	detailRuns   []*store.JobRun // runs for the selected job in detail view
-	width        int
-	height       int
-	lastUpdate   time.Time
-	quitting     bool
-	errorMessage string
+	viewMode           ViewMode
+	jobs               []JobState
+	recentRuns         []*store.JobRun
+	selectedJob        int
+	detailRuns         []*store.JobRun // runs for the selected job in detail view
+	detailScrollOffset int             // index of the first run shown in detail view
+	historyRuns        int             // how many runs loadDetailRuns requests
+	width              int
+	height             int
+	lastUpdate         time.Time
+	quitting           bool
+	errorMessage       string
+	showHelp           bool // full-screen keybinding help is displayed
+
+	// Quitting takes a second q within quitConfirmWindow
+	confirmQuit bool
+	quitExpiry  time.Time
+
+	// Manual runs
+	confirmTrigger string    // job ID awaiting y/n confirmation to run now
+	statusMessage  string    // transient notice shown in the help bar
+	statusExpiry   time.Time // when statusMessage stops being shown
 
 	// Stats
 	totalJobs   int
@@ -54,6 +68,11 @@ type JobState struct {
 	NextRun    time.Time
 	LastRun    *store.JobRun
 	IsSelected bool
+
+	// CircuitOpen marks a job paused by the circuit breaker after
+	// Failures consecutive failed runs
+	CircuitOpen bool
+	Failures    int
 }
 
 // JobStatus represents the execution status of a job.
@@ -64,18 +83,27 @@ const (
 	JobStatusRunning
 	JobStatusSuccess
 	JobStatusError
+	JobStatusTimeout
+	JobStatusPaused
+	JobStatusDisabled
 )
 
 // New creates a new TUI model.
 func New(cfg *config.Config, st store.Store, sched *scheduler.Scheduler, logger *slog.Logger) Model {
+	historyRuns := cfg.TUI.HistoryRuns
+	if historyRuns <= 0 {
+		historyRuns = defaultHistoryRuns
+	}
 	return Model{
-		config:     cfg,
-		store:      st,
-		scheduler:  sched,
-		logger:     logger,
-		jobs:       []JobState{},
-		recentRuns: []*store.JobRun{},
-		lastUpdate: time.Now(),
+		config:      cfg,
+		store:       st,
+		scheduler:   sched,
+		logger:      logger,
+		styles:      newTheme(cfg.TUI.Theme),
+		jobs:        []JobState{},
+		recentRuns:  []*store.JobRun{},
+		historyRuns: historyRuns,
+		lastUpdate:  time.Now(),
 	}
 }
 
@@ -97,8 +125,66 @@ func tickCmd() tea.Cmd {
 	})
 }
 
+// defaultHistoryRuns is how many of a job's most recent runs the detail
+// view loads for scrolling when tui.history_runs is unset.
+const defaultHistoryRuns = 100
+
+// statusMessageDuration is how long a transient status notice stays visible.
+const statusMessageDuration = 5 * time.Second
+
+// quitConfirmWindow is how long after a first q a second one quits.
+const quitConfirmWindow = 3 * time.Second
+
+// jobTriggeredMsg reports the result of starting a job with RunJobNow.
+type jobTriggeredMsg struct {
+	jobID string
+	runID string
+	err   error
+}
+
+// runPendingMsg reports that a triggered run has not finished yet.
+type runPendingMsg struct {
+	jobID string
+	runID string
+}
+
+// runFinishedMsg reports that a triggered run has completed.
+type runFinishedMsg struct {
+	jobID string
+	run   *store.JobRun
+}
+
+// triggerJobCmd starts jobID immediately through the scheduler.
+func triggerJobCmd(sched *scheduler.Scheduler, jobID string) tea.Cmd {
+	return func() tea.Msg {
+		runID, err := sched.RunJobNow(jobID)
+		return jobTriggeredMsg{jobID: jobID, runID: runID, err: err}
+	}
+}
+
+// watchRunCmd checks the store once a second until the run is recorded as
+// finished.
+func watchRunCmd(st store.Store, jobID, runID string) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		run, err := st.GetRun(context.Background(), runID)
+		if err != nil || run.IsRunning() {
+			// The run may not be saved yet; keep waiting
+			return runPendingMsg{jobID: jobID, runID: runID}
+		}
+		return runFinishedMsg{jobID: jobID, run: run}
+	})
+}
+
+// setStatus shows msg in the help bar for statusMessageDuration.
+func (m *Model) setStatus(msg string) {
+	m.statusMessage = msg
+	m.statusExpiry = time.Now().Add(statusMessageDuration)
+}
+
 // refreshData loads the latest data from the store and scheduler.
 func (m *Model) refreshData() {
+	ctx := context.Background()
+
 	// Update job states
 	m.totalJobs = len(m.config.Jobs)
 	m.runningJobs = 0
@@ -106,7 +192,7 @@ func (m *Model) refreshData() {
 
 	for i, job := range m.config.Jobs {
 		// Get last run for this job
-		lastRuns, err := m.store.GetJobRuns(job.ID, 1)
+		lastRuns, err := m.store.GetJobRuns(ctx, job.ID, 1)
 		var lastRun *store.JobRun
 		if err == nil && len(lastRuns) > 0 {
 			lastRun = lastRuns[0]
@@ -120,6 +206,8 @@ func (m *Model) refreshData() {
 				m.runningJobs++
 			} else if lastRun.Success {
 				status = JobStatusSuccess
+			} else if lastRun.TimedOut() {
+				status = JobStatusTimeout
 			} else {
 				status = JobStatusError
 			}
@@ -127,8 +215,19 @@ func (m *Model) refreshData() {
 
 		// Get next run time from scheduler
 		nextRun := time.Now().Add(time.Hour) // default fallback
+		var circuitOpen bool
+		var failures int
 		if stats, ok := m.scheduler.GetJobStats(job.ID); ok {
 			nextRun = stats.NextRun
+			circuitOpen, failures = stats.CircuitOpen, stats.ConsecutiveFailures
+			// A paused job shows as paused unless a run is still in flight
+			if stats.Paused && status != JobStatusRunning {
+				status = JobStatusPaused
+			}
+			// Likewise a disabled job, which can only run when triggered
+			if stats.Disabled && status != JobStatusRunning {
+				status = JobStatusDisabled
+			}
 		}
 
 		m.jobs[i] = JobState{
@@ -138,11 +237,14 @@ func (m *Model) refreshData() {
 			NextRun:    nextRun,
 			LastRun:    lastRun,
 			IsSelected: i == m.selectedJob,
+
+			CircuitOpen: circuitOpen,
+			Failures:    failures,
 		}
 	}
 
 	// Get recent runs across all jobs
-	recentRuns, err := m.store.GetAllRuns(10)
+	recentRuns, err := m.store.GetAllRuns(ctx, 10)
 	if err == nil {
 		m.recentRuns = recentRuns
 		m.totalRuns = len(recentRuns)
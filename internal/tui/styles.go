@@ -2,131 +2,197 @@
 package tui
 
 import (
+	"github.com/caevv/jobster/internal/config"
 	"github.com/charmbracelet/lipgloss"
 )
 
-var (
-	// Color palette
-	colorPrimary   = lipgloss.Color("#7C3AED") // Purple
-	colorSuccess   = lipgloss.Color("#10B981") // Green
-	colorError     = lipgloss.Color("#EF4444") // Red
-	colorWarning   = lipgloss.Color("#F59E0B") // Orange
-	colorInfo      = lipgloss.Color("#3B82F6") // Blue
-	colorMuted     = lipgloss.Color("#6B7280") // Gray
-	colorBorder    = lipgloss.Color("#374151") // Dark gray
-	colorHighlight = lipgloss.Color("#8B5CF6") // Light purple
-
-	// Base styles
-	baseStyle = lipgloss.NewStyle().
-			Padding(0, 1)
-
-	// Header style
-	headerStyle = lipgloss.NewStyle().
+// palette is the set of colors a theme's styles are built from.
+type palette struct {
+	primary     lipgloss.Color
+	success     lipgloss.Color
+	error       lipgloss.Color
+	warning     lipgloss.Color
+	info        lipgloss.Color
+	muted       lipgloss.Color
+	border      lipgloss.Color
+	highlight   lipgloss.Color
+	statusBarBg lipgloss.Color
+	statusBarFg lipgloss.Color
+}
+
+// palettes maps each tui.theme name to its colors.
+var palettes = map[string]palette{
+	config.ThemeDark: {
+		primary:     lipgloss.Color("#7C3AED"), // Purple
+		success:     lipgloss.Color("#10B981"), // Green
+		error:       lipgloss.Color("#EF4444"), // Red
+		warning:     lipgloss.Color("#F59E0B"), // Orange
+		info:        lipgloss.Color("#3B82F6"), // Blue
+		muted:       lipgloss.Color("#6B7280"), // Gray
+		border:      lipgloss.Color("#374151"), // Dark gray
+		highlight:   lipgloss.Color("#8B5CF6"), // Light purple
+		statusBarBg: lipgloss.Color("#1F2937"),
+		statusBarFg: lipgloss.Color("#6B7280"),
+	},
+	// Darker shades that keep their contrast on a white background
+	config.ThemeLight: {
+		primary:     lipgloss.Color("#5B21B6"), // Deep purple
+		success:     lipgloss.Color("#047857"), // Dark green
+		error:       lipgloss.Color("#B91C1C"), // Dark red
+		warning:     lipgloss.Color("#B45309"), // Dark orange
+		info:        lipgloss.Color("#1D4ED8"), // Dark blue
+		muted:       lipgloss.Color("#4B5563"), // Slate
+		border:      lipgloss.Color("#D1D5DB"), // Light gray
+		highlight:   lipgloss.Color("#6D28D9"), // Purple
+		statusBarBg: lipgloss.Color("#E5E7EB"),
+		statusBarFg: lipgloss.Color("#374151"),
+	},
+	// The 16 basic ANSI colors, so the terminal's own (accessible) palette
+	// decides how they look
+	config.ThemeHighContrast: {
+		primary:     lipgloss.Color("15"), // Bright white
+		success:     lipgloss.Color("10"), // Bright green
+		error:       lipgloss.Color("9"),  // Bright red
+		warning:     lipgloss.Color("11"), // Bright yellow
+		info:        lipgloss.Color("14"), // Bright cyan
+		muted:       lipgloss.Color("7"),  // White
+		border:      lipgloss.Color("15"), // Bright white
+		highlight:   lipgloss.Color("11"), // Bright yellow
+		statusBarBg: lipgloss.Color("0"),  // Black
+		statusBarFg: lipgloss.Color("15"), // Bright white
+	},
+}
+
+// theme holds the styles the views render with.
+type theme struct {
+	header          lipgloss.Style
+	statusBar       lipgloss.Style
+	jobList         lipgloss.Style
+	jobItem         lipgloss.Style
+	jobItemSelected lipgloss.Style
+
+	// Status indicators
+	statusRunning lipgloss.Style
+	statusSuccess lipgloss.Style
+	statusError   lipgloss.Style
+	statusTimeout lipgloss.Style
+	statusPaused  lipgloss.Style
+	statusIdle    lipgloss.Style
+
+	stats         lipgloss.Style
+	recentRuns    lipgloss.Style
+	detailHistory lipgloss.Style // run history in the detail view (no fixed height)
+	runItem       lipgloss.Style
+
+	help    lipgloss.Style
+	helpBox lipgloss.Style
+	helpKey lipgloss.Style
+
+	title    lipgloss.Style
+	subtitle lipgloss.Style
+
+	// Key-value pairs
+	key   lipgloss.Style
+	value lipgloss.Style
+
+	duration lipgloss.Style
+}
+
+// newTheme builds the styles of the named tui.theme. An empty or unknown
+// name gives the dark theme.
+func newTheme(name string) theme {
+	p, ok := palettes[name]
+	if !ok {
+		p = palettes[config.ThemeDark]
+	}
+
+	panel := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(p.border)
+	status := func(c lipgloss.Color) lipgloss.Style {
+		return lipgloss.NewStyle().Foreground(c).Bold(true)
+	}
+
+	return theme{
+		header: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(colorPrimary).
+			Foreground(p.primary).
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderBottom(true).
-			BorderForeground(colorBorder).
+			BorderForeground(p.border).
 			Padding(0, 1).
-			MarginBottom(1)
-
-	// Status bar style
-	statusBarStyle = lipgloss.NewStyle().
-			Foreground(colorMuted).
-			Background(lipgloss.Color("#1F2937")).
+			MarginBottom(1),
+		statusBar: lipgloss.NewStyle().
+			Foreground(p.statusBarFg).
+			Background(p.statusBarBg).
 			Padding(0, 1).
-			MarginTop(1)
-
-	// Job list styles
-	jobListStyle = lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(colorBorder).
+			MarginTop(1),
+		jobList: panel.
 			Padding(1, 2).
-			MarginBottom(1)
-
-	jobItemStyle = lipgloss.NewStyle().
-			Padding(0, 1)
-
-	jobItemSelectedStyle = lipgloss.NewStyle().
-				Foreground(colorHighlight).
-				Bold(true).
-				Padding(0, 1)
-
-	// Status indicator styles
-	statusRunningStyle = lipgloss.NewStyle().
-				Foreground(colorInfo).
-				Bold(true)
-
-	statusSuccessStyle = lipgloss.NewStyle().
-				Foreground(colorSuccess).
-				Bold(true)
-
-	statusErrorStyle = lipgloss.NewStyle().
-				Foreground(colorError).
-				Bold(true)
+			MarginBottom(1),
+		jobItem: lipgloss.NewStyle().
+			Padding(0, 1),
+		jobItemSelected: lipgloss.NewStyle().
+			Foreground(p.highlight).
+			Bold(true).
+			Padding(0, 1),
 
-	statusIdleStyle = lipgloss.NewStyle().
-			Foreground(colorMuted)
+		statusRunning: status(p.info),
+		statusSuccess: status(p.success),
+		statusError:   status(p.error),
+		statusTimeout: status(p.warning),
+		statusPaused:  status(p.warning),
+		statusIdle:    lipgloss.NewStyle().Foreground(p.muted),
 
-	// Stats panel style
-	statsStyle = lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(colorBorder).
+		stats: panel.
 			Padding(0, 2).
-			MarginBottom(1)
-
-	statsItemStyle = lipgloss.NewStyle().
-			Padding(0, 1)
-
-	// Recent runs panel style
-	recentRunsStyle = lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(colorBorder).
+			MarginBottom(1),
+		recentRuns: panel.
 			Padding(1, 2).
-			Height(10)
-
-	// Detail view run history style (no fixed height)
-	detailHistoryStyle = lipgloss.NewStyle().
-				BorderStyle(lipgloss.RoundedBorder()).
-				BorderForeground(colorBorder).
-				Padding(1, 2)
-
-	runItemStyle = lipgloss.NewStyle().
-			Padding(0, 1)
-
-	// Help text style
-	helpStyle = lipgloss.NewStyle().
-			Foreground(colorMuted).
-			Padding(0, 1)
-
-	// Title styles
-	titleStyle = lipgloss.NewStyle().
+			Height(10),
+		detailHistory: panel.
+			Padding(1, 2),
+		runItem: lipgloss.NewStyle().
+			Padding(0, 1),
+
+		help: lipgloss.NewStyle().
+			Foreground(p.muted).
+			Padding(0, 1),
+		helpBox: lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(p.primary).
+			Padding(1, 3),
+		helpKey: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(colorPrimary).
-			Padding(0, 1)
+			Foreground(p.highlight),
 
-	subtitleStyle = lipgloss.NewStyle().
-			Foreground(colorMuted).
-			Padding(0, 1)
-
-	// Value styles for key-value pairs
-	keyStyle = lipgloss.NewStyle().
-			Foreground(colorMuted)
-
-	valueStyle = lipgloss.NewStyle().
-			Bold(true)
-
-	// Duration styles
-	durationStyle = lipgloss.NewStyle().
-			Foreground(colorInfo)
-)
+		title: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(p.primary).
+			Padding(0, 1),
+		subtitle: lipgloss.NewStyle().
+			Foreground(p.muted).
+			Padding(0, 1),
+
+		key: lipgloss.NewStyle().
+			Foreground(p.muted),
+		value: lipgloss.NewStyle().
+			Bold(true),
+
+		duration: lipgloss.NewStyle().
+			Foreground(p.info),
+	}
+}
 
 // Status icons
 const (
 	iconRunning = "⟳"
 	iconSuccess = "✓"
 	iconError   = "✗"
+	iconTimeout = "⧗"
 	iconIdle    = "⏸"
+	iconPaused  = "‖"
+	iconOff     = "○"
 	iconPending = "◌"
 	iconArrow   = ">"
 	iconBullet  = "•"
@@ -1,6 +1,10 @@
 package tui
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -21,6 +25,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Schedule next tick
 		return m, tickCmd()
 
+	case jobTriggeredMsg:
+		if msg.err != nil {
+			m.errorMessage = msg.err.Error()
+			return m, nil
+		}
+		m.errorMessage = ""
+		m.setStatus(fmt.Sprintf("Triggered %s (run %s)", msg.jobID, shortRunID(msg.runID)))
+		m.refreshData()
+		return m, watchRunCmd(m.store, msg.jobID, msg.runID)
+
+	case runPendingMsg:
+		return m, watchRunCmd(m.store, msg.jobID, msg.runID)
+
+	case runFinishedMsg:
+		result := "succeeded"
+		if msg.run.TimedOut() {
+			result = "timed out"
+		} else if !msg.run.Success {
+			result = fmt.Sprintf("failed (exit %d)", msg.run.ExitCode)
+		}
+		m.setStatus(fmt.Sprintf("Run of %s %s", msg.jobID, result))
+		m.refreshData()
+		m.loadDetailRuns()
+		return m, nil
+
 	case error:
 		m.errorMessage = msg.Error()
 		return m, nil
@@ -31,16 +60,60 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKeyPress processes keyboard input.
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// A pending "run now?" prompt consumes the next key: y confirms, anything
+	// else (except ctrl+c, which still quits) cancels.
+	if jobID := m.confirmTrigger; jobID != "" {
+		m.confirmTrigger = ""
+		if msg.String() == "y" {
+			return m, triggerJobCmd(m.scheduler, jobID)
+		}
+		if msg.String() != "ctrl+c" {
+			return m, nil
+		}
+	}
+
+	// A pending quit prompt is answered by a second q in time; any other key
+	// dismisses it and is handled as usual.
+	if m.confirmQuit {
+		m.confirmQuit = false
+		if msg.String() == "q" && time.Now().Before(m.quitExpiry) {
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+
+	// The help screen only responds to closing it or quitting
+	if m.showHelp {
+		switch msg.String() {
+		case "?", "h", "esc":
+			m.showHelp = false
+			return m, nil
+		case "ctrl+c":
+		case "q":
+			// Close it so the quit prompt is visible
+			m.showHelp = false
+		default:
+			return m, nil
+		}
+	}
+
 	switch msg.String() {
-	case "ctrl+c", "q":
+	case "ctrl+c":
 		m.quitting = true
 		return m, tea.Quit
 
+	case "q":
+		// Ask first, so a stray keypress doesn't stop the scheduler
+		m.confirmQuit = true
+		m.quitExpiry = time.Now().Add(quitConfirmWindow)
+		return m, nil
+
 	case "esc":
 		// Go back to list view if in detail view
 		if m.viewMode == ViewModeDetail {
 			m.viewMode = ViewModeList
 			m.detailRuns = nil
+			m.detailScrollOffset = 0
 		}
 		return m, nil
 
@@ -48,60 +121,129 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Show detail view for selected job
 		if m.viewMode == ViewModeList && len(m.jobs) > 0 {
 			m.viewMode = ViewModeDetail
-			// Load runs for the selected job
-			if m.selectedJob < len(m.jobs) {
-				jobID := m.jobs[m.selectedJob].ID
-				runs, err := m.store.GetJobRuns(jobID, 5) // Get last 5 runs (fits on screen with config and errors)
-				if err == nil {
-					m.detailRuns = runs
-				}
-			}
+			m.detailScrollOffset = 0
+			m.loadDetailRuns()
 		}
 		return m, nil
 
 	case "up", "k":
-		if m.viewMode == ViewModeList && m.selectedJob > 0 {
+		switch {
+		case m.viewMode == ViewModeList && m.selectedJob > 0:
 			m.selectedJob--
+		case m.viewMode == ViewModeDetail && m.detailScrollOffset > 0:
+			m.detailScrollOffset--
 		}
 		return m, nil
 
 	case "down", "j":
-		if m.viewMode == ViewModeList && m.selectedJob < len(m.jobs)-1 {
-			m.selectedJob++
+		switch m.viewMode {
+		case ViewModeList:
+			if m.selectedJob < len(m.jobs)-1 {
+				m.selectedJob++
+			}
+		case ViewModeDetail:
+			// Stop once the last run is on screen
+			if _, end := m.detailWindow(); end < len(m.detailRuns) {
+				m.detailScrollOffset++
+			}
 		}
 		return m, nil
 
 	case "g":
 		// Go to top
-		if m.viewMode == ViewModeList {
+		switch m.viewMode {
+		case ViewModeList:
 			m.selectedJob = 0
+		case ViewModeDetail:
+			m.detailScrollOffset = 0
 		}
 		return m, nil
 
 	case "G":
 		// Go to bottom
-		if m.viewMode == ViewModeList && len(m.jobs) > 0 {
-			m.selectedJob = len(m.jobs) - 1
+		switch m.viewMode {
+		case ViewModeList:
+			if len(m.jobs) > 0 {
+				m.selectedJob = len(m.jobs) - 1
+			}
+		case ViewModeDetail:
+			m.scrollDetailToEnd()
 		}
 		return m, nil
 
 	case "r":
 		// Manual refresh
 		m.refreshData()
-		// Reload detail runs if in detail view
-		if m.viewMode == ViewModeDetail && m.selectedJob < len(m.jobs) {
-			jobID := m.jobs[m.selectedJob].ID
-			runs, err := m.store.GetJobRuns(jobID, 5)
-			if err == nil {
-				m.detailRuns = runs
+		m.loadDetailRuns()
+		return m, nil
+
+	case "x":
+		// Run the selected job now, after confirmation
+		if m.selectedJob < len(m.jobs) {
+			m.confirmTrigger = m.jobs[m.selectedJob].ID
+			m.errorMessage = ""
+		}
+		return m, nil
+
+	case "p":
+		// Pause or resume the selected job. Ask the scheduler rather than
+		// going by the displayed status, which shows a paused job with a run
+		// in flight as running.
+		if m.selectedJob < len(m.jobs) {
+			job := m.jobs[m.selectedJob]
+			var err error
+			if stats, ok := m.scheduler.GetJobStats(job.ID); ok && stats.Paused {
+				err = m.scheduler.ResumeJob(job.ID)
+			} else {
+				err = m.scheduler.PauseJob(job.ID)
 			}
+			if err != nil {
+				m.errorMessage = err.Error()
+			} else {
+				m.errorMessage = ""
+			}
+			m.refreshData()
 		}
 		return m, nil
 
 	case "?", "h":
-		// Toggle help (TODO: implement help view)
+		m.showHelp = true
 		return m, nil
 	}
 
 	return m, nil
 }
+
+// loadDetailRuns loads the selected job's latest historyRuns runs for the
+// detail view. Opening the view and refreshing it both go through here.
+func (m *Model) loadDetailRuns() {
+	if m.viewMode == ViewModeDetail && m.selectedJob < len(m.jobs) {
+		jobID := m.jobs[m.selectedJob].ID
+		runs, err := m.store.GetJobRuns(context.Background(), jobID, m.historyRuns)
+		if err == nil {
+			m.detailRuns = runs
+			m.detailScrollOffset = min(m.detailScrollOffset, max(len(runs)-1, 0))
+		}
+	}
+}
+
+// scrollDetailToEnd scrolls the detail view so the oldest run is at the
+// bottom of a full screen.
+func (m *Model) scrollDetailToEnd() {
+	m.detailScrollOffset = max(len(m.detailRuns)-1, 0)
+	for m.detailScrollOffset > 0 {
+		m.detailScrollOffset--
+		if _, end := m.detailWindow(); end < len(m.detailRuns) {
+			m.detailScrollOffset++
+			return
+		}
+	}
+}
+
+// shortRunID abbreviates a run ID for the status bar.
+func shortRunID(runID string) string {
+	if len(runID) > 8 {
+		return runID[:8]
+	}
+	return runID
+}
@@ -15,6 +15,10 @@ func (m Model) View() string {
 		return "Shutting down...\n"
 	}
 
+	if m.showHelp {
+		return m.renderHelp()
+	}
+
 	// Switch between list and detail view
 	if m.viewMode == ViewModeDetail {
 		return m.renderDetailView()
@@ -42,25 +46,25 @@ func (m Model) View() string {
 
 // renderHeader renders the dashboard header.
 func (m Model) renderHeader() string {
-	title := titleStyle.Render("⚡ Jobster Dashboard")
-	subtitle := subtitleStyle.Render(fmt.Sprintf("Last updated: %s", m.lastUpdate.Format("15:04:05")))
+	title := m.styles.title.Render("⚡ Jobster Dashboard")
+	subtitle := m.styles.subtitle.Render(fmt.Sprintf("Last updated: %s", m.lastUpdate.Format("15:04:05")))
 
 	header := lipgloss.JoinHorizontal(lipgloss.Top, title, "  ", subtitle)
-	return headerStyle.Render(header)
+	return m.styles.header.Render(header)
 }
 
 // renderStats renders the statistics bar.
 func (m Model) renderStats() string {
 	stats := []string{
-		fmt.Sprintf("%s %d", keyStyle.Render("Jobs:"), m.totalJobs),
-		fmt.Sprintf("%s %d", keyStyle.Render("Running:"), m.runningJobs),
+		fmt.Sprintf("%s %d", m.styles.key.Render("Jobs:"), m.totalJobs),
+		fmt.Sprintf("%s %d", m.styles.key.Render("Running:"), m.runningJobs),
 	}
 
 	if m.totalRuns > 0 {
 		successRate := float64(m.successRuns) / float64(m.totalRuns) * 100
 		stats = append(stats, fmt.Sprintf(
 			"%s %d/%d (%.0f%%)",
-			keyStyle.Render("Success:"),
+			m.styles.key.Render("Success:"),
 			m.successRuns,
 			m.totalRuns,
 			successRate,
@@ -68,26 +72,26 @@ func (m Model) renderStats() string {
 	}
 
 	content := strings.Join(stats, "  │  ")
-	return statsStyle.Render(content)
+	return m.styles.stats.Render(content)
 }
 
 // renderJobList renders the list of jobs.
 func (m Model) renderJobList() string {
 	if len(m.jobs) == 0 {
-		return jobListStyle.Render(subtitleStyle.Render("No jobs configured"))
+		return m.styles.jobList.Render(m.styles.subtitle.Render("No jobs configured"))
 	}
 
 	var rows []string
 
 	// Title
-	rows = append(rows, titleStyle.Render("Jobs"))
+	rows = append(rows, m.styles.title.Render("Jobs"))
 	rows = append(rows, "")
 
 	// Header row
 	header := fmt.Sprintf("   %-22s  %-10s  %-6s  %s",
 		"Job ID", "Status", "Last", "Next Run")
-	rows = append(rows, keyStyle.Render(header))
-	rows = append(rows, keyStyle.Render(strings.Repeat("─", 70)))
+	rows = append(rows, m.styles.key.Render(header))
+	rows = append(rows, m.styles.key.Render(strings.Repeat("─", 70)))
 
 	// Job rows
 	for i, job := range m.jobs {
@@ -95,7 +99,7 @@ func (m Model) renderJobList() string {
 	}
 
 	content := strings.Join(rows, "\n")
-	return jobListStyle.Render(content)
+	return m.styles.jobList.Render(content)
 }
 
 // renderJobRow renders a single job row.
@@ -118,19 +122,35 @@ func (m Model) renderJobRow(job JobState, selected bool) string {
 	case JobStatusRunning:
 		statusIcon = iconRunning
 		statusText = "Running"
-		statusStyle = statusRunningStyle
+		statusStyle = m.styles.statusRunning
 	case JobStatusSuccess:
 		statusIcon = iconSuccess
 		statusText = "Success"
-		statusStyle = statusSuccessStyle
+		statusStyle = m.styles.statusSuccess
 	case JobStatusError:
 		statusIcon = iconError
 		statusText = "Failed "
-		statusStyle = statusErrorStyle
+		statusStyle = m.styles.statusError
+	case JobStatusTimeout:
+		statusIcon = iconTimeout
+		statusText = "Timeout"
+		statusStyle = m.styles.statusTimeout
+	case JobStatusPaused:
+		statusIcon = iconPaused
+		statusText = "Paused "
+		statusStyle = m.styles.statusPaused
+		if job.CircuitOpen {
+			statusText = "Tripped"
+			statusStyle = m.styles.statusError
+		}
+	case JobStatusDisabled:
+		statusIcon = iconOff
+		statusText = "Off    "
+		statusStyle = m.styles.statusIdle
 	default:
 		statusIcon = iconIdle
 		statusText = "Idle   "
-		statusStyle = statusIdleStyle
+		statusStyle = m.styles.statusIdle
 	}
 
 	statusDisplay := statusStyle.Render(fmt.Sprintf("%s %s", statusIcon, statusText))
@@ -141,11 +161,11 @@ func (m Model) renderJobRow(job JobState, selected bool) string {
 		duration := job.LastRun.Duration()
 		lastRunStr = padRight(formatDuration(duration), 6)
 	}
-	lastRunDisplay := durationStyle.Render(lastRunStr)
+	lastRunDisplay := m.styles.duration.Render(lastRunStr)
 
 	// Next run time
 	nextRunStr := formatTimeFromNow(job.NextRun)
-	nextRunDisplay := keyStyle.Render(nextRunStr)
+	nextRunDisplay := m.styles.key.Render(nextRunStr)
 
 	// Build row with fixed spacing
 	row := fmt.Sprintf(
@@ -158,9 +178,9 @@ func (m Model) renderJobRow(job JobState, selected bool) string {
 	)
 
 	if selected {
-		return jobItemSelectedStyle.Render(row)
+		return m.styles.jobItemSelected.Render(row)
 	}
-	return jobItemStyle.Render(row)
+	return m.styles.jobItem.Render(row)
 }
 
 // renderRecentRuns renders the recent runs panel.
@@ -169,16 +189,16 @@ func (m Model) renderRecentRuns() string {
 
 	// Title with count
 	titleText := fmt.Sprintf("Recent Runs (%d)", len(m.recentRuns))
-	rows = append(rows, titleStyle.Render(titleText))
+	rows = append(rows, m.styles.title.Render(titleText))
 	rows = append(rows, "")
 
 	if len(m.recentRuns) == 0 {
-		rows = append(rows, subtitleStyle.Render("No runs yet"))
+		rows = append(rows, m.styles.subtitle.Render("No runs yet"))
 	} else {
 		// Column headers
 		header := fmt.Sprintf("   %-10s  %-22s  %-6s  %s", "Time", "Job", "Status", "Duration")
-		rows = append(rows, keyStyle.Render(header))
-		rows = append(rows, keyStyle.Render("   "+strings.Repeat("─", 60)))
+		rows = append(rows, m.styles.key.Render(header))
+		rows = append(rows, m.styles.key.Render("   "+strings.Repeat("─", 60)))
 
 		// Runs
 		for _, run := range m.recentRuns {
@@ -187,7 +207,7 @@ func (m Model) renderRecentRuns() string {
 	}
 
 	content := strings.Join(rows, "\n")
-	return recentRunsStyle.Render(content)
+	return m.styles.recentRuns.Render(content)
 }
 
 // renderRunItem renders a single run item.
@@ -195,12 +215,16 @@ func (m Model) renderRunItem(run *store.JobRun) string {
 	// Status icon
 	var statusIcon string
 	var statusStyleFunc lipgloss.Style
-	if run.Success {
+	switch {
+	case run.Success:
 		statusIcon = iconSuccess
-		statusStyleFunc = statusSuccessStyle
-	} else {
+		statusStyleFunc = m.styles.statusSuccess
+	case run.TimedOut():
+		statusIcon = iconTimeout
+		statusStyleFunc = m.styles.statusTimeout
+	default:
 		statusIcon = iconError
-		statusStyleFunc = statusErrorStyle
+		statusStyleFunc = m.styles.statusError
 	}
 
 	// Format time (HH:MM:SS = 8 chars)
@@ -224,45 +248,140 @@ func (m Model) renderRunItem(run *store.JobRun) string {
 		timeStr,
 		jobIDStr,
 		statusStyleFunc.Render(statusIcon),
-		durationStyle.Render(durationStr),
+		m.styles.duration.Render(durationStr),
 	)
 
-	return runItemStyle.Render(row)
+	return m.styles.runItem.Render(row)
 }
 
 // renderHelpBar renders the help/status bar at the bottom.
 func (m Model) renderHelpBar() string {
-	if m.errorMessage != "" {
-		return statusBarStyle.Render(statusErrorStyle.Render("Error: " + m.errorMessage))
+	return m.renderStatusBar("q: quit  │  ↑/↓: navigate  │  enter: details  │  x: run now  │  p: pause/resume  │  r: refresh  │  ?: help")
+}
+
+// renderStatusBar renders help, unless a confirmation prompt, error, or
+// recent status notice takes its place.
+func (m Model) renderStatusBar(help string) string {
+	switch {
+	case m.confirmQuit && time.Now().Before(m.quitExpiry):
+		return m.styles.statusBar.Render(m.styles.statusRunning.Render("Press q again to quit"))
+	case m.confirmTrigger != "":
+		return m.styles.statusBar.Render(m.styles.statusRunning.Render(fmt.Sprintf("Run %s now? (y/n)", m.confirmTrigger)))
+	case m.errorMessage != "":
+		return m.styles.statusBar.Render(m.styles.statusError.Render("Error: " + m.errorMessage))
+	case m.statusMessage != "" && time.Now().Before(m.statusExpiry):
+		return m.styles.statusBar.Render(m.styles.statusSuccess.Render(m.statusMessage))
 	}
+	return m.styles.statusBar.Render(help)
+}
 
-	help := "q: quit  │  ↑/↓: navigate  │  enter: details  │  r: refresh"
-	return statusBarStyle.Render(help)
+// helpBinding is one row of the help screen.
+type helpBinding struct {
+	keys string
+	desc string
 }
 
-// renderDetailView renders the detailed view for a selected job.
+// helpSections lists every keybinding, grouped as shown on the help screen.
+var helpSections = []struct {
+	title    string
+	bindings []helpBinding
+}{
+	{"Navigation", []helpBinding{
+		{"↑ / k", "Previous job, or scroll run history up"},
+		{"↓ / j", "Next job, or scroll run history down"},
+		{"g", "Jump to the first job or newest run"},
+		{"G", "Jump to the last job or oldest run"},
+		{"enter", "Show job details"},
+		{"esc", "Back to the job list"},
+	}},
+	{"Jobs", []helpBinding{
+		{"x", "Run the selected job now (confirm with y)"},
+		{"p", "Pause or resume the selected job"},
+		{"r", "Refresh data"},
+	}},
+	{"General", []helpBinding{
+		{"?", "Toggle this help"},
+		{"q q", "Quit (press q twice)"},
+		{"ctrl+c", "Quit immediately"},
+	}},
+}
+
+// renderHelp renders the full-screen keybinding help, centered in the
+// terminal when its size is known.
+func (m Model) renderHelp() string {
+	lines := []string{m.styles.title.Render("Keyboard Shortcuts"), ""}
+	for i, section := range helpSections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, m.styles.value.Render(section.title))
+		for _, b := range section.bindings {
+			lines = append(lines, fmt.Sprintf("  %s  %s",
+				m.styles.helpKey.Render(fmt.Sprintf("%-10s", b.keys)),
+				b.desc))
+		}
+	}
+	lines = append(lines, "", m.styles.help.Render("Press ? or esc to close"))
+
+	box := m.styles.helpBox.Render(strings.Join(lines, "\n"))
+	if m.width == 0 || m.height == 0 {
+		return box
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// renderDetailView renders the detailed view for a selected job. The header
+// and configuration panel stay pinned; the run history below them shows only
+// as many runs as fit the terminal, starting at detailScrollOffset.
 func (m Model) renderDetailView() string {
 	if m.selectedJob >= len(m.jobs) {
 		return "Invalid job selection"
 	}
 
+	header, config, statusBar := m.renderDetailChrome()
+	start, end := m.detailWindow()
+
+	// Run history
+	var historyInfo []string
+	historyInfo = append(historyInfo, m.styles.title.Render(fmt.Sprintf("Run History (%d runs)", len(m.detailRuns))))
+	historyInfo = append(historyInfo, "")
+
+	if len(m.detailRuns) == 0 {
+		historyInfo = append(historyInfo, m.styles.subtitle.Render("No runs yet"))
+	} else {
+		historyInfo = append(historyInfo, m.detailHistoryHeader()...)
+		for _, run := range m.detailRuns[start:end] {
+			historyInfo = append(historyInfo, m.detailRunLines(run)...)
+		}
+		if start > 0 || end < len(m.detailRuns) {
+			historyInfo = append(historyInfo, "", m.styles.key.Render(fmt.Sprintf(
+				"  Runs %d-%d of %d  (↑/↓ to scroll)", start+1, end, len(m.detailRuns))))
+		}
+	}
+
+	history := m.styles.detailHistory.Render(strings.Join(historyInfo, "\n"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, config, history, statusBar)
+}
+
+// renderDetailChrome renders the fixed parts of the detail view: the header,
+// the configuration panel, and the status bar.
+func (m Model) renderDetailChrome() (header, config, statusBar string) {
 	job := m.jobs[m.selectedJob]
-	var sections []string
 
 	// Header with job name - make it prominent
 	jobTitle := fmt.Sprintf("⚡ Jobster Dashboard - %s", job.ID)
 	lastUpdate := fmt.Sprintf("Last updated: %s", m.lastUpdate.Format("15:04:05"))
-	header := lipgloss.JoinHorizontal(
+	header = m.styles.header.Render(lipgloss.JoinHorizontal(
 		lipgloss.Top,
-		titleStyle.Render(jobTitle),
+		m.styles.title.Render(jobTitle),
 		"  ",
-		subtitleStyle.Render(lastUpdate),
-	)
-	sections = append(sections, headerStyle.Render(header))
+		m.styles.subtitle.Render(lastUpdate),
+	))
 
 	// Job info panel
 	var jobInfo []string
-	jobInfo = append(jobInfo, titleStyle.Render("Configuration"))
+	jobInfo = append(jobInfo, m.styles.title.Render("Configuration"))
 	jobInfo = append(jobInfo, "")
 
 	// Get the full job config from the config
@@ -275,95 +394,134 @@ func (m Model) renderDetailView() string {
 	}
 
 	if jobCommand != "" {
-		jobInfo = append(jobInfo, fmt.Sprintf("%s %s", keyStyle.Render("Command:"), valueStyle.Render(truncate(jobCommand, 60))))
+		jobInfo = append(jobInfo, fmt.Sprintf("%s %s", m.styles.key.Render("Command:"), m.styles.value.Render(truncate(jobCommand, 60))))
 	}
-	jobInfo = append(jobInfo, fmt.Sprintf("%s %s", keyStyle.Render("Schedule:"), valueStyle.Render(job.Schedule)))
+	jobInfo = append(jobInfo, fmt.Sprintf("%s %s", m.styles.key.Render("Schedule:"), m.styles.value.Render(job.Schedule)))
 
 	// Status
 	var statusDisplay string
 	switch job.Status {
 	case JobStatusRunning:
-		statusDisplay = statusRunningStyle.Render(iconRunning + " Running")
+		statusDisplay = m.styles.statusRunning.Render(iconRunning + " Running")
 	case JobStatusSuccess:
-		statusDisplay = statusSuccessStyle.Render(iconSuccess + " Success")
+		statusDisplay = m.styles.statusSuccess.Render(iconSuccess + " Success")
 	case JobStatusError:
-		statusDisplay = statusErrorStyle.Render(iconError + " Failed")
+		statusDisplay = m.styles.statusError.Render(iconError + " Failed")
+	case JobStatusTimeout:
+		statusDisplay = m.styles.statusTimeout.Render(iconTimeout + " Timed out")
+	case JobStatusPaused:
+		if job.CircuitOpen {
+			statusDisplay = m.styles.statusError.Render(fmt.Sprintf("%s Tripped (paused after %d failures in a row)", iconPaused, job.Failures))
+		} else {
+			statusDisplay = m.styles.statusPaused.Render(iconPaused + " Paused")
+		}
+	case JobStatusDisabled:
+		statusDisplay = m.styles.statusIdle.Render(iconOff + " Disabled")
 	default:
-		statusDisplay = statusIdleStyle.Render(iconIdle + " Idle")
+		statusDisplay = m.styles.statusIdle.Render(iconIdle + " Idle")
 	}
-	jobInfo = append(jobInfo, fmt.Sprintf("%s %s", keyStyle.Render("Status:"), statusDisplay))
+	jobInfo = append(jobInfo, fmt.Sprintf("%s %s", m.styles.key.Render("Status:"), statusDisplay))
 
 	// Next run
 	nextRunStr := formatTimeFromNow(job.NextRun)
-	jobInfo = append(jobInfo, fmt.Sprintf("%s %s", keyStyle.Render("Next Run:"), valueStyle.Render(nextRunStr)))
+	jobInfo = append(jobInfo, fmt.Sprintf("%s %s", m.styles.key.Render("Next Run:"), m.styles.value.Render(nextRunStr)))
 
 	// Last run
 	if job.LastRun != nil {
 		lastRunTime := job.LastRun.StartTime.Format("2006-01-02 15:04:05")
 		duration := formatDuration(job.LastRun.Duration())
-		jobInfo = append(jobInfo, fmt.Sprintf("%s %s (%s)", keyStyle.Render("Last Run:"), valueStyle.Render(lastRunTime), durationStyle.Render(duration)))
+		jobInfo = append(jobInfo, fmt.Sprintf("%s %s (%s)", m.styles.key.Render("Last Run:"), m.styles.value.Render(lastRunTime), m.styles.duration.Render(duration)))
 	}
 
-	sections = append(sections, jobListStyle.Render(strings.Join(jobInfo, "\n")))
+	config = m.styles.jobList.Render(strings.Join(jobInfo, "\n"))
 
-	// Run history
-	var historyInfo []string
-	historyInfo = append(historyInfo, titleStyle.Render(fmt.Sprintf("Run History (%d runs)", len(m.detailRuns))))
-	historyInfo = append(historyInfo, "")
+	statusBar = m.renderStatusBar("esc: back  │  q: quit  │  ↑/↓: scroll  │  x: run now  │  p: pause/resume  │  r: refresh  │  ?: help")
+	return header, config, statusBar
+}
 
-	if len(m.detailRuns) == 0 {
-		historyInfo = append(historyInfo, subtitleStyle.Render("No runs yet"))
-	} else {
-		// Header
-		header := fmt.Sprintf("  %-20s  %-8s  %-12s  %s", "Start Time", "Status", "Duration", "Exit Code")
-		historyInfo = append(historyInfo, keyStyle.Render(header))
-		historyInfo = append(historyInfo, keyStyle.Render("  "+strings.Repeat("─", 65)))
+// detailHistoryHeader returns the column header lines of the run history.
+func (m Model) detailHistoryHeader() []string {
+	header := fmt.Sprintf("  %-20s  %-8s  %-12s  %s", "Start Time", "Status", "Duration", "Exit Code")
+	return []string{
+		m.styles.key.Render(header),
+		m.styles.key.Render("  " + strings.Repeat("─", 65)),
+	}
+}
 
-		// Runs
-		for _, run := range m.detailRuns {
-			// Status icon
-			statusIcon := iconSuccess
-			statusStyleFunc := statusSuccessStyle
-			if !run.Success {
-				statusIcon = iconError
-				statusStyleFunc = statusErrorStyle
-			}
-
-			// Format fields
-			timeStr := run.StartTime.Format("2006-01-02 15:04:05")
-			statusDisplay := statusStyleFunc.Render(statusIcon)
-
-			durationStr := formatDuration(run.Duration())
-			if run.IsRunning() {
-				durationStr = "running..."
-			}
-			durationDisplay := durationStyle.Render(padRight(durationStr, 12))
-
-			// Build row with proper spacing
-			row := fmt.Sprintf(
-				"  %-20s  %s        %-12s  %d",
-				timeStr,
-				statusDisplay,
-				durationDisplay,
-				run.ExitCode,
-			)
-			historyInfo = append(historyInfo, row)
-
-			// Show stderr if failed
-			if !run.Success && run.StderrTail != "" {
-				errorPreview := truncate(strings.TrimSpace(run.StderrTail), 75)
-				historyInfo = append(historyInfo, "    "+keyStyle.Render("Error: ")+statusErrorStyle.Render(errorPreview))
-			}
-		}
+// detailRunLines renders one run of the history: its row, plus a stderr
+// preview line if it failed.
+func (m Model) detailRunLines(run *store.JobRun) []string {
+	// Status icon
+	statusIcon := iconSuccess
+	statusStyleFunc := m.styles.statusSuccess
+	if run.TimedOut() {
+		statusIcon = iconTimeout
+		statusStyleFunc = m.styles.statusTimeout
+	} else if !run.Success {
+		statusIcon = iconError
+		statusStyleFunc = m.styles.statusError
 	}
 
-	sections = append(sections, detailHistoryStyle.Render(strings.Join(historyInfo, "\n")))
+	// Format fields
+	timeStr := run.StartTime.Format("2006-01-02 15:04:05")
+	statusDisplay := statusStyleFunc.Render(statusIcon)
 
-	// Help bar
-	helpText := "esc: back  │  q: quit  │  r: refresh"
-	sections = append(sections, statusBarStyle.Render(helpText))
+	durationStr := formatDuration(run.Duration())
+	if run.IsRunning() {
+		durationStr = "running..."
+	}
+	durationDisplay := m.styles.duration.Render(padRight(durationStr, 12))
 
-	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+	// Build row with proper spacing
+	lines := []string{fmt.Sprintf(
+		"  %-20s  %s        %-12s  %d",
+		timeStr,
+		statusDisplay,
+		durationDisplay,
+		run.ExitCode,
+	)}
+
+	// Show why the command never started, or stderr if it failed
+	if status, path := run.MissingPath(); status != "" {
+		reason := "command not found: "
+		if status == store.StatusWorkdirNotFound {
+			reason = "working directory not found: "
+		}
+		lines = append(lines, "    "+m.styles.key.Render("Error: ")+m.styles.statusError.Render(truncate(reason+path, 75)))
+	} else if !run.Success && run.StderrTail != "" {
+		errorPreview := truncate(strings.TrimSpace(run.StderrTail), 75)
+		lines = append(lines, "    "+m.styles.key.Render("Error: ")+m.styles.statusError.Render(errorPreview))
+	}
+	return lines
+}
+
+// detailWindow returns the range of detailRuns, [start, end), that fits the
+// terminal below the pinned panels when scrolled to detailScrollOffset. With
+// an unknown terminal height every run is shown.
+func (m Model) detailWindow() (start, end int) {
+	start = min(max(m.detailScrollOffset, 0), max(len(m.detailRuns)-1, 0))
+	if m.height == 0 || m.selectedJob >= len(m.jobs) {
+		return start, len(m.detailRuns)
+	}
+
+	header, config, statusBar := m.renderDetailChrome()
+	used := lipgloss.Height(header) + lipgloss.Height(config) + lipgloss.Height(statusBar)
+	// Panel border and padding, title, blank line, column header, separator,
+	// and the blank line plus position indicator
+	used += lipgloss.Height(m.styles.detailHistory.Render("")) - 1 + 4 + 2
+
+	avail := m.height - used
+	end = start
+	for end < len(m.detailRuns) {
+		n := len(m.detailRunLines(m.detailRuns[end]))
+		// Always show at least one run, even on a tiny terminal
+		if avail < n && end > start {
+			break
+		}
+		avail -= n
+		end++
+	}
+	return start, end
 }
 
 // Helper functions
@@ -384,6 +542,11 @@ func formatDuration(d time.Duration) string {
 
 // formatTimeFromNow formats a time relative to now.
 func formatTimeFromNow(t time.Time) string {
+	// Paused and disabled jobs have no next run
+	if t.IsZero() {
+		return "-"
+	}
+
 	duration := time.Until(t)
 
 	if duration < 0 {